@@ -19,14 +19,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/generate-secret-token": {
-            "post": {
+        "/.well-known/paseto-keys": {
+            "get": {
+                "description": "Publish the Ed25519 keys that verify v4.public tokens. Services match a token's footer kid against these keys to verify it offline. The response is a plain key set, not wrapped in the usual envelope.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "well-known"
+                ],
+                "summary": "List PASETO public keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/hcrypto.PublicKeySet"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/blocklist": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a company secret token.",
+                "description": "List blocklist entries of every company, newest first, with how many payment intents each refused. Filter by company, by type, or to the entries that apply to every company.",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,9 +54,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "company"
+                    "admin"
+                ],
+                "summary": "List blocklist entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only this company's entries",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only entries that apply to every company",
+                        "name": "platform_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PHONE, EMAIL_DOMAIN or IP",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get secret token",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -49,16 +101,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.CompanyCredentialResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.BlocklistEntry"
+                                            }
                                         },
-                                        "meta_data": {}
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
                                     }
                                 }
                             ]
                         }
                     },
                     "400": {
-                        "description": "Bad request due to invalid input",
+                        "description": "Bad request due to invalid filter",
                         "schema": {
                             "$ref": "#/definitions/doc.ErrorResponse"
                         }
@@ -76,11 +133,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/login": {
+            },
             "post": {
-                "description": "This endpoint allows a company to log in to the application.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refuse new payment intents of customers with the phone number, an email address at the domain or its subdomains, or a customer_ip in the network. Without company_id the entry applies to every company.",
                 "consumes": [
                     "application/json"
                 ],
@@ -88,23 +148,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "company"
+                    "admin"
                 ],
-                "summary": "Authenticate a company",
+                "summary": "Add a blocklist entry",
                 "parameters": [
                     {
-                        "description": "Login credentials of the company",
-                        "name": "company_login_request_body",
+                        "description": "Entry",
+                        "name": "blocklist_request_body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
+                            "$ref": "#/definitions/dto.OperatorBlocklistRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -114,7 +174,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SignInResponse"
+                                            "$ref": "#/definitions/dto.BlocklistEntry"
                                         },
                                         "meta_data": {}
                                     }
@@ -134,6 +194,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/doc.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -143,14 +209,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-intents": {
-            "post": {
+        "/admin/blocklist-hits": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Initiate onetme paymentIntent",
+                "description": "List the payment intents the blocklist refused, newest first, of every company, of one company or of one entry.",
                 "consumes": [
                     "application/json"
                 ],
@@ -158,23 +224,120 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "admin"
                 ],
-                "summary": "InitPaymentIntent",
+                "summary": "List payment intents refused by the blocklist",
                 "parameters": [
                     {
-                        "description": "payment-intent details",
-                        "name": "create_payment_intent_request_body",
+                        "type": "string",
+                        "description": "Only this company's hits",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only hits of this entry",
+                        "name": "entry_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hits per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.BlocklistHit"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/blocklist/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the type, value and reason of any blocklist entry. The company it applies to does not change.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update a blocklist entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blocklist entry id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entry",
+                        "name": "blocklist_request_body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.InitPaymentIntent"
+                            "$ref": "#/definitions/dto.BlocklistRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -184,7 +347,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.PaymentIntent"
+                                            "$ref": "#/definitions/dto.BlocklistEntry"
                                         },
                                         "meta_data": {}
                                     }
@@ -204,6 +367,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/doc.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -211,30 +380,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/payment-intents/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get payment details by id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove any blocklist entry. Its hits are kept.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "admin"
                 ],
-                "summary": "Get PaymentIntent By ID",
+                "summary": "Remove a blocklist entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "payment intent id",
+                        "description": "Blocklist entry id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -252,7 +416,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.PaymentIntentDetail"
+                                            "$ref": "#/definitions/dto.MessageResponse"
                                         },
                                         "meta_data": {}
                                     }
@@ -272,6 +436,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/doc.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -281,14 +451,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/signup-company-owner": {
-            "post": {
+        "/admin/companies": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint registers a new company in the Activity Rewards application.",
+                "description": "List every merchant company, newest first. Search matches the name, email or registration number.",
                 "consumes": [
                     "application/json"
                 ],
@@ -296,23 +466,111 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "company"
+                    "admin"
                 ],
-                "summary": "Create a new company",
+                "summary": "List companies",
                 "parameters": [
                     {
-                        "description": "Company details for registration",
-                        "name": "create_company_request_body",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Name, email or registration number",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ACTIVE, SUSPENDED, INACTIVE, ONBOARDING, UNDER_REVIEW or REJECTED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Companies per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateCompany"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.Company"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/admin/companies/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return any merchant company by id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -331,7 +589,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request due to invalid input",
+                        "description": "Bad request due to invalid id",
                         "schema": {
                             "$ref": "#/definitions/doc.ErrorResponse"
                         }
@@ -350,188 +608,8717 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "constant.Currency": {
-            "type": "string",
-            "enum": [
-                "ETB",
-                "EUR",
-                "USD",
-                "GBP"
-            ],
-            "x-enum-varnames": [
-                "CurrencyETB",
-                "CurrencyEUR",
-                "CurrencyUSD",
+        },
+        "/admin/companies/{id}/currencies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the currencies of the registry and whether the company can create payment intents in each.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List a company's currencies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.Currency"
+                                            }
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid company id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/currencies/{code}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Override the registry default of a currency for one company, for example to onboard a EUR or GBP merchant.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Enable or disable a currency for a company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Whether the currency is enabled",
+                        "name": "set_company_currency_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCompanyCurrency"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Currency"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company or currency not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/fx-settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return the currency the company is paid out in and the spread taken on the mid rate when a payment intent is converted to it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a company's settlement settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CompanyFXSettings"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid company id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the currency the company is paid out in and the spread in basis points. Payment intents created afterwards snapshot the rate they are converted at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Set a company's settlement settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Settlement currency and spread",
+                        "name": "set_fx_settings_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCompanyFXSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CompanyFXSettings"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/kyc": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return a company's verification status and the documents it uploaded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a company's verification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.KYCStatus"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/kyc/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve or reject a company under review. Approval lets the company accept live payments; a rejection needs a note, which is emailed to the merchant.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Review a company's verification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decision and note",
+                        "name": "kyc_review_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.KYCReview"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.KYCStatus"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or status",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/limits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the company's limits per currency: per transaction amount range, daily and monthly totals, and customer velocity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List a company's transaction limits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CompanyLimit"
+                                            }
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid company id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/limits/{currency}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the company's limits in a currency. Null limits are not enforced. Payment intents that break a limit are refused with status 422.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Set a company's transaction limits in a currency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Limits",
+                        "name": "set_limit_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCompanyLimit"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CompanyLimit"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the company's limits in a currency. Only the currency registry's amount range applies afterwards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove a company's transaction limits in a currency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid company id or currency",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company or limits not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/reactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accept a suspended company's API credentials again and restore the status it was suspended from, such as ONBOARDING or ACTIVE. The reason is kept in the company's audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reactivate a company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the reactivation",
+                        "name": "company_status_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CompanyStatusChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Company"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or status",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/risk-rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the rules new payment intents of the company are scored with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List a company's risk rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RiskRule"
+                                            }
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid company id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a rule whose score is added to new payment intents its expression holds for. Expressions are conditions over the signals amount, currency, livemode, merchant_avg_amount, merchant_payments_30d, amount_ratio, new_customer, customer_payments_1h, customer_payments_24h, phone_country, company_country, phone_country_mismatch and blocklist_hits, for example \"new_customer and amount_ratio \u003e= 5\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Add a risk rule to a company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Risk rule",
+                        "name": "set_risk_rule_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetRiskRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RiskRule"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/risk-rules/{rule_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the name, expression, score and enabled flag of one of the company's risk rules.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Replace a company's risk rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Risk rule id",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Risk rule",
+                        "name": "set_risk_rule_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetRiskRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RiskRule"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company or risk rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove one of the company's risk rules. Intents already scored keep their score.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove a company's risk rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Risk rule id",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid company or risk rule id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company or risk rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/risk-settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the scores at which the company's payment intents are held for review and blocked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a company's risk thresholds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RiskSettings"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid company id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the scores at which the company's payment intents are held for review and blocked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Set a company's risk thresholds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Risk thresholds",
+                        "name": "set_risk_settings_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetRiskSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RiskSettings"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{id}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refuse the company's API credentials until it is reactivated. A company can be suspended in any status, including while it is onboarding or under review. The reason is kept in the company's audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Suspend a company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the suspension",
+                        "name": "company_status_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CompanyStatusChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Company"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or status",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/disputes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List disputes of every company or of one company, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List disputes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only this company's disputes",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "NEEDS_RESPONSE, UNDER_REVIEW, WON or LOST",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Disputes per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.Dispute"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a dispute the processor reported against a successful payment intent, for its whole amount. The merchant has until evidence_due_by, seven days by default, to respond. The company receives a dispute.created webhook event.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Open a dispute",
+                "parameters": [
+                    {
+                        "description": "Dispute",
+                        "name": "open_dispute_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OpenDispute"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Dispute"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input, or the payment intent is not successful or already disputed",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment intent not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/disputes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a dispute with its evidence files and, once lost, the ledger entries reversing the payment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a dispute",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dispute id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Dispute"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Dispute not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/disputes/{id}/evidence/{file_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return the content of a file a merchant attached to a dispute.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Download a dispute evidence file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dispute id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Evidence file id",
+                        "name": "file_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Evidence file not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/disputes/{id}/resolve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close a dispute under review, or one the merchant did not answer before evidence_due_by, as WON or LOST. A lost dispute reverses the payment in the company's ledger. The company receives a dispute.won or dispute.lost webhook event.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Resolve a dispute",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dispute id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome and note",
+                        "name": "resolve_dispute_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResolveDispute"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Dispute"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or status",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Dispute not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/kyc-documents/{id}/file": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return the content of a document a company uploaded for verification.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Download a verification document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Document not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/login": {
+            "post": {
+                "description": "Sign in a platform operator. The access token is only accepted by the /admin routes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Operator sign in",
+                "parameters": [
+                    {
+                        "description": "Operator credentials",
+                        "name": "operator_login_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OperatorLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OperatorSignInResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/operators": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create another platform operator account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Add an operator",
+                "parameters": [
+                    {
+                        "description": "Operator details",
+                        "name": "create_operator_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOperator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Operator"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/payment-intents/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return any payment intent by id, with its customer and company.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a payment intent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment intent id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaymentIntentDetail"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/payment-intents/{id}/risk-review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a held payment intent, sending it on to be processed or, when it was created with require_confirmation, to REQUIRES_CONFIRMATION, where the merchant resends the customer a code; or reject it, blocking it. The decision is kept in the merchant's audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Decide on a payment intent held for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment intent id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decision",
+                        "name": "risk_review_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RiskReviewDecision"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaymentIntentDetail"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or an intent not held for review",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/payment-intents/{id}/status": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Force a payment intent into another status and book the difference in the company's ledger: a CORRECTION entry credits the settlement when the intent becomes SUCCESS and takes it back when it leaves SUCCESS. Disputed payment intents cannot be corrected, and a correction is refused if the status changed since it was read. A reason is required and is kept with the old and new status in the merchant's audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Correct a payment intent status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment intent id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status and reason",
+                        "name": "status_correction_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentIntentStatusCorrection"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaymentIntentDetail"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/pii/data-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the keys that seal customer personal data, with how many customers each one still seals.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List personal data keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PIIDataKey"
+                                            }
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/pii/data-keys/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make a new key seal customer personal data. Customers sealed with the previous key are resealed in the background.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Rotate the personal data key",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PIIDataKey"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/risk-reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the payment intents the risk rules held for review, oldest first, with the rules that matched and the signals they saw.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List payment intents held for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only this company's intents",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Intents per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RiskReview"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit-events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the audit log of the caller's company, newest first. Filter by action, actor and time range.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "List audit events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. auth.login_succeeded",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Actor id",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest event time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest event time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Events per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.AuditEvent"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to view the audit log",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit-events/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the filtered audit log of the caller's company as CSV, newest first. Pagination is ignored and the export is capped by the server.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Export audit events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. auth.login_succeeded",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Actor id",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest event time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest event time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to view the audit log",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/blocklist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the company's blocklist entries, newest first, with how many payment intents each refused.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocklist"
+                ],
+                "summary": "List the blocklist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "PHONE, EMAIL_DOMAIN or IP",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.BlocklistEntry"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refuse new payment intents of customers with the phone number, an email address at the domain or its subdomains, or a customer_ip in the network. Refused intents fail with 403 and a blocked detail naming the entry type.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocklist"
+                ],
+                "summary": "Add a blocklist entry",
+                "parameters": [
+                    {
+                        "description": "Entry",
+                        "name": "blocklist_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BlocklistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.BlocklistEntry"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/blocklist-hits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the company's payment intents the blocklist refused, newest first, including those refused by entries the platform keeps for every company.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocklist"
+                ],
+                "summary": "List payment intents refused by the blocklist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only hits of this entry",
+                        "name": "entry_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hits per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.BlocklistHit"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to view payments",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/blocklist/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the type, value and reason of one of the company's blocklist entries.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocklist"
+                ],
+                "summary": "Update a blocklist entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blocklist entry id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entry",
+                        "name": "blocklist_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BlocklistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.BlocklistEntry"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove one of the company's blocklist entries. Its hits are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocklist"
+                ],
+                "summary": "Remove a blocklist entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blocklist entry id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/company": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return the profile of the caller's company.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Get the company profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Company"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the address, phones, website, callback url or return url of the caller's company. Omitted fields keep their value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Update the company profile",
+                "parameters": [
+                    {
+                        "description": "Profile fields to change",
+                        "name": "update_company_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCompany"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Company"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/company/mfa": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Let the company owner require MFA for every member. Members without MFA are refused by permission checks until they enroll.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Require MFA for the company",
+                "parameters": [
+                    {
+                        "description": "MFA policy",
+                        "name": "update_company_mfa_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCompanyMFA"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Company"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the owner can change the MFA policy",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/currencies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the currencies of the registry with their minor units and amount limits, and whether the company can create payment intents in each.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "List currencies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.Currency"
+                                            }
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/customers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the company's customers in the mode of the key, newest first. Customer details are stored encrypted, so the only search is by exact phone number.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "List customers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone number",
+                        "name": "phone_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Customers per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.Customer"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a customer in the mode of the key. The phone number must be unique among the company's customers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Create a customer",
+                "parameters": [
+                    {
+                        "description": "Customer details",
+                        "name": "create_customer_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCustomer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Customer"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or duplicate phone number",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/customers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return one of the company's customers in the mode of the key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get a customer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Customer"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a customer from the API. Its payment intents are kept. With erase=true the customer's personal data is also erased, even if it was deleted before, and the erasure certificate is returned and written to the audit trail.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Delete a customer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Erase the customer's personal data",
+                        "name": "erase",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CustomerErasure"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the fields that are sent and keep the others.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Update a customer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "update_customer_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCustomer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Customer"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or duplicate phone number",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/customers/{id}/payment-intents": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the customer's payment intents, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "List a customer's payments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Payment intents per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PaymentIntent"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id or page",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/disputes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the disputes against the company's payment intents, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dispute"
+                ],
+                "summary": "List disputes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "NEEDS_RESPONSE, UNDER_REVIEW, WON or LOST",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Disputes per page, at most 200",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.Dispute"
+                                            }
+                                        },
+                                        "meta_data": {
+                                            "$ref": "#/definitions/response.MetaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to view payments",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/disputes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a dispute with its evidence files and, once lost, the ledger entries reversing the payment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dispute"
+                ],
+                "summary": "Get a dispute",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Dispute"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to view payments",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Dispute not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/disputes/{id}/evidence": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the written evidence of a dispute that needs a response, before its evidence_due_by.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dispute"
+                ],
+                "summary": "Update dispute evidence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Evidence",
+                        "name": "dispute_evidence_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DisputeEvidence"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Dispute"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input, status or deadline",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage disputes",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Dispute not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/disputes/{id}/evidence/files": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach a PDF, JPEG or PNG file of at most DISPUTE_MAX_EVIDENCE_BYTES (10 MiB by default) to a dispute that needs a response, before its evidence_due_by.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dispute"
+                ],
+                "summary": "Upload a dispute evidence file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Evidence file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.DisputeEvidenceFile"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid file, status or deadline",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage disputes",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Dispute not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/disputes/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the evidence for review, which moves the dispute to UNDER_REVIEW. The dispute needs written evidence or at least one file and can no longer be changed afterwards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dispute"
+                ],
+                "summary": "Submit dispute evidence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Dispute"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to missing evidence, status or deadline",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage disputes",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Dispute not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/generate-secret-token": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a company secret token. Send ` + "`" + `{\"mode\": \"test\"}` + "`" + ` for a test key, which only sees test data and is served by a simulated processor; keys are live otherwise. A new token replaces the previous token of the same mode.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Get secret token",
+                "parameters": [
+                    {
+                        "description": "Key mode",
+                        "name": "api_key_request_body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CompanyCredentialResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to generate secret tokens or account is pending verification",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/hmac-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the company's PG-HMAC keys, including revoked ones. Secrets are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "List request signing keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.HMACKey"
+                                            }
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage credentials",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a PG-HMAC key for signing server-to-server requests instead of sending the secret token. Sign ` + "`" + `METHOD\\nREQUEST_URI\\nTIMESTAMP\\nNONCE\\nhex(sha256(body))` + "`" + ` with HMAC-SHA256 and send ` + "`" + `Authorization: PG-HMAC keyId=\u003ckey_id\u003e,signature=\u003chex\u003e` + "`" + ` with the ` + "`" + `X-PG-Timestamp` + "`" + ` (unix seconds) and ` + "`" + `X-PG-Nonce` + "`" + ` headers. The secret is only returned once. Send ` + "`" + `{\"mode\": \"test\"}` + "`" + ` for a test key. The newest active key of each mode also signs the webhook events posted to callback_url: ` + "`" + `X-PG-Signature: keyId=\u003ckey_id\u003e,signature=\u003chex\u003e` + "`" + ` is the HMAC-SHA256 of ` + "`" + `TIMESTAMP\\nEVENT_ID\\nhex(sha256(body))` + "`" + `, with the ` + "`" + `X-PG-Timestamp` + "`" + ` and ` + "`" + `X-PG-Event-ID` + "`" + ` headers; refuse timestamps more than a few minutes old. Events are held until the company has an active key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Create a request signing key",
+                "parameters": [
+                    {
+                        "description": "Key mode",
+                        "name": "api_key_request_body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreatedHMACKey"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage credentials",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/hmac-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a PG-HMAC key. Requests signed with it are rejected immediately.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Revoke a request signing key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HMAC key id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.HMACKey"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage credentials",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Active key not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ip-allowlist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the networks allowed to call the merchant API with the company's credentials. An empty list allows every address.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "List the IP allowlist",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.IPAllowlistEntry"
+                                            }
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allow a CIDR or single address to call the merchant API. Once the list has an entry, secret-token and signed requests from any other address are refused.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Add a network to the IP allowlist",
+                "parameters": [
+                    {
+                        "description": "Network to allow",
+                        "name": "ip_allowlist_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.IPAllowlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.IPAllowlistEntry"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ip-allowlist/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the network or description of an allowlist entry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Update an IP allowlist entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Allowlist entry id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Network to allow",
+                        "name": "ip_allowlist_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.IPAllowlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.IPAllowlistEntry"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a network from the allowlist. Removing the last entry allows requests from every address again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Remove an IP allowlist entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Allowlist entry id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kyc": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return the caller's company verification status, its uploaded documents and the required documents still missing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Get verification status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.KYCStatus"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kyc/documents": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a PDF, JPEG or PNG document of at most KYC_MAX_DOCUMENT_BYTES (10 MiB by default). Documents can only be changed before submission or after a rejection.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Upload a verification document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TRADE_LICENSE, TIN_CERTIFICATE, MEMORANDUM_OF_ASSOCIATION, OWNER_IDENTIFICATION or OTHER",
+                        "name": "document_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Document file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.KYCDocument"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or file",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kyc/documents/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an uploaded document before submission or after a rejection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Delete a verification document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {},
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid id or status",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Document not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kyc/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the company's documents for review. Every required document must be uploaded first. Live payments are accepted once an operator approves the company.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Submit for verification",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.KYCStatus"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing documents or company already submitted",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the company",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "This endpoint allows a company to log in to the application. Users with MFA enabled receive a 202 with an MFA challenge token to exchange at /login/mfa instead of a session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Authenticate a company",
+                "parameters": [
+                    {
+                        "description": "Login credentials of the company",
+                        "name": "company_login_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SignInResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MFAChallenge"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid phone, email or password",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account is inactive or pending verification",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts for the account or client address",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/mfa": {
+            "post": {
+                "description": "Exchange the MFA challenge token returned by /login and a TOTP or recovery code for a session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Complete an MFA login",
+                "parameters": [
+                    {
+                        "description": "MFA challenge token and code",
+                        "name": "mfa_login_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFALoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SignInResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or code",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired MFA token",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/unlock": {
+            "post": {
+                "description": "Clear the failed sign-in attempts of an account using the unlock token emailed when it was locked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Unlock an account",
+                "parameters": [
+                    {
+                        "description": "Unlock token",
+                        "name": "unlock_account_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UnlockAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired unlock token",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn MFA off for the caller using a TOTP or recovery code. Not allowed while the company requires MFA.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Disable MFA",
+                "parameters": [
+                    {
+                        "description": "TOTP or recovery code",
+                        "name": "mfa_code_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or code",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Company requires MFA",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mfa/enable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm the pending enrollment with a TOTP code. The response holds one-time recovery codes that are not shown again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Confirm MFA enrollment",
+                "parameters": [
+                    {
+                        "description": "Current TOTP code",
+                        "name": "mfa_code_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RecoveryCodes"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or code",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a TOTP secret for the caller and return its provisioning URI for a QR code. MFA is enabled once a code is confirmed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Start MFA enrollment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MFAEnrollment"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "MFA is already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mfa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace every recovery code of the caller. Requires a current TOTP code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "Regenerate recovery codes",
+                "parameters": [
+                    {
+                        "description": "Current TOTP code",
+                        "name": "mfa_code_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RecoveryCodes"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or code",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/forgot": {
+            "post": {
+                "description": "Send a single-use password reset link to the user. The response is the same whether or not the account exists.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Phone or email of the account",
+                        "name": "forgot_password_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/reset": {
+            "post": {
+                "description": "Set a new password using a reset token. All existing sessions of the user are revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "reset_password_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, used or expired reset token",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-intents": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Initiate onetme paymentIntent. The intent takes the mode of the key: test intents are settled by a simulated processor, where amounts ending in .13 fail, amounts ending in .14 succeed and are then disputed, and all others succeed. Live intents require a verified company. Send customer_id to charge a customer created through the customers API, or customer to create one by phone number. Customers whose phone number, email domain or customer_ip is blocklisted are refused. With require_confirmation the intent starts in REQUIRES_CONFIRMATION and the customer is sent an SMS code to confirm it with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "InitPaymentIntent",
+                "parameters": [
+                    {
+                        "description": "payment-intent details",
+                        "name": "create_payment_intent_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InitPaymentIntent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaymentIntent"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Live key used before the company is verified, or the customer is blocklisted; the blocked detail names the entry type",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "A transaction limit would be exceeded; the limit detail names it",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-intents/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get payment details by id. Only intents of the caller's company in the key's mode are found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get PaymentIntent By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "payment intent id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaymentIntentDetail"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment intent not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-intents/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm a payment intent created with require_confirmation using the code sent to the customer by SMS. The confirmed intent is PENDING and queued to be processed. A code expires after a few minutes or a few wrong attempts, after which a new one has to be requested.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Confirm PaymentIntent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "payment intent id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "confirmation code",
+                        "name": "confirm_payment_intent_request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmPaymentIntent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaymentIntent"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid, incorrect or expired code, or the intent does not require confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment intent not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-intents/{id}/resend-confirmation": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the customer a new confirmation code for a payment intent in REQUIRES_CONFIRMATION. The code sent before stops working. An intent is sent a limited number of codes, with a cooldown between two of them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Resend PaymentIntent Confirmation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "payment intent id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "The intent does not require confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment intent not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many codes sent, or sent too recently",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/signup-company-owner": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint registers a new company in the Activity Rewards application. The owner account stays PENDING_VERIFICATION until the email link and SMS code sent on registration are confirmed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Create a new company",
+                "parameters": [
+                    {
+                        "description": "Company details for registration",
+                        "name": "create_company_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCompany"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Company"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/invitations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invite a user into the caller's company. The invitee receives an email with an invitation token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Invite a team member",
+                "parameters": [
+                    {
+                        "description": "Invitee email and role",
+                        "name": "invite_user_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InviteUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserInvitation"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the team",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/invitations/accept": {
+            "post": {
+                "description": "Create the invitee's account from an invitation token and set their password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Accept a team invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation token and account details",
+                        "name": "accept_invitation_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AcceptInvitation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.User"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired invitation token",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every user of the caller's company.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "List team members",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.User"
+                                            }
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to view the team",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/members/{id}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deactivate a member of the caller's company and end their sessions. The owner cannot be deactivated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Deactivate a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "member user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.User"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the team",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/members/{id}/role": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the role of a member of the caller's company. The owner's role cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Change a member's role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "member user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "update_member_role_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateMemberRole"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.User"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized request",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role is not allowed to manage the team",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify/email": {
+            "post": {
+                "description": "Verify the email address of a newly registered owner using the link token sent by email. The account becomes active once both email and phone are verified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Verify email address",
+                "parameters": [
+                    {
+                        "description": "Verification token",
+                        "name": "verify_email_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.User"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, used or expired verification token",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify/phone": {
+            "post": {
+                "description": "Verify the phone number of a newly registered owner using the code sent by SMS. The account becomes active once both email and phone are verified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Verify phone number",
+                "parameters": [
+                    {
+                        "description": "Phone number and verification code",
+                        "name": "verify_phone_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerifyPhoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.User"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or code",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify/resend": {
+            "post": {
+                "description": "Send a new verification link and code for every unverified contact of the account. The response is the same whether or not the account exists.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "Resend verification",
+                "parameters": [
+                    {
+                        "description": "Phone or email of the account",
+                        "name": "resend_verification_request_body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/doc.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        },
+                                        "meta_data": {}
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/doc.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "constant.AuditAction": {
+            "type": "string",
+            "enum": [
+                "auth.login_succeeded",
+                "auth.login_failed",
+                "auth.account_locked",
+                "auth.account_unlocked",
+                "auth.password_reset",
+                "mfa.enabled",
+                "mfa.disabled",
+                "mfa.recovery_codes_regenerated",
+                "credentials.secret_token_generated",
+                "credentials.hmac_key_created",
+                "credentials.hmac_key_revoked",
+                "company.mfa_policy_updated",
+                "company.profile_updated",
+                "ip_allowlist.request_blocked",
+                "ip_allowlist.entry_created",
+                "ip_allowlist.entry_updated",
+                "ip_allowlist.entry_deleted",
+                "team.invitation_created",
+                "team.invitation_accepted",
+                "team.member_role_changed",
+                "team.member_status_changed",
+                "operator.login_succeeded",
+                "operator.login_failed",
+                "operator.created",
+                "company.suspended",
+                "company.reactivated",
+                "payment_intent.status_corrected",
+                "kyc.document_uploaded",
+                "kyc.document_deleted",
+                "kyc.submitted",
+                "kyc.approved",
+                "kyc.rejected",
+                "pii.data_key_rotated",
+                "customer.erased",
+                "company.currency_updated",
+                "company.fx_settings_updated",
+                "company.limit_updated",
+                "company.limit_deleted",
+                "risk.rule_created",
+                "risk.rule_updated",
+                "risk.rule_deleted",
+                "risk.settings_updated",
+                "risk.review_approved",
+                "risk.review_rejected",
+                "blocklist.entry_created",
+                "blocklist.entry_updated",
+                "blocklist.entry_deleted",
+                "dispute.created",
+                "dispute.evidence_updated",
+                "dispute.evidence_uploaded",
+                "dispute.submitted",
+                "dispute.resolved"
+            ],
+            "x-enum-varnames": [
+                "AuditLoginSucceeded",
+                "AuditLoginFailed",
+                "AuditAccountLocked",
+                "AuditAccountUnlocked",
+                "AuditPasswordReset",
+                "AuditMFAEnabled",
+                "AuditMFADisabled",
+                "AuditRecoveryCodesRegenerated",
+                "AuditSecretTokenGenerated",
+                "AuditHMACKeyCreated",
+                "AuditHMACKeyRevoked",
+                "AuditCompanyMFAPolicyUpdated",
+                "AuditCompanyProfileUpdated",
+                "AuditIPAllowlistBlocked",
+                "AuditIPAllowlistCreated",
+                "AuditIPAllowlistUpdated",
+                "AuditIPAllowlistDeleted",
+                "AuditTeamInvitationCreated",
+                "AuditTeamInvitationAccepted",
+                "AuditTeamMemberRoleChanged",
+                "AuditTeamMemberStatusChanged",
+                "AuditOperatorLoginSucceeded",
+                "AuditOperatorLoginFailed",
+                "AuditOperatorCreated",
+                "AuditCompanySuspended",
+                "AuditCompanyReactivated",
+                "AuditPaymentIntentCorrected",
+                "AuditKYCDocumentUploaded",
+                "AuditKYCDocumentDeleted",
+                "AuditKYCSubmitted",
+                "AuditKYCApproved",
+                "AuditKYCRejected",
+                "AuditPIIDataKeyRotated",
+                "AuditCustomerErased",
+                "AuditCompanyCurrencyUpdated",
+                "AuditCompanyFXSettingsUpdated",
+                "AuditCompanyLimitUpdated",
+                "AuditCompanyLimitDeleted",
+                "AuditRiskRuleCreated",
+                "AuditRiskRuleUpdated",
+                "AuditRiskRuleDeleted",
+                "AuditRiskSettingsUpdated",
+                "AuditRiskReviewApproved",
+                "AuditRiskReviewRejected",
+                "AuditBlocklistEntryCreated",
+                "AuditBlocklistEntryUpdated",
+                "AuditBlocklistEntryDeleted",
+                "AuditDisputeCreated",
+                "AuditDisputeEvidenceUpdated",
+                "AuditDisputeEvidenceUploaded",
+                "AuditDisputeSubmitted",
+                "AuditDisputeResolved"
+            ]
+        },
+        "constant.AuditActorType": {
+            "type": "string",
+            "enum": [
+                "USER",
+                "COMPANY",
+                "ANONYMOUS",
+                "OPERATOR",
+                "SYSTEM"
+            ],
+            "x-enum-varnames": [
+                "AuditActorUser",
+                "AuditActorCompany",
+                "AuditActorAnonymous",
+                "AuditActorOperator",
+                "AuditActorSystem"
+            ]
+        },
+        "constant.BlocklistType": {
+            "type": "string",
+            "enum": [
+                "PHONE",
+                "EMAIL_DOMAIN",
+                "IP"
+            ],
+            "x-enum-varnames": [
+                "BlocklistPhone",
+                "BlocklistEmailDomain",
+                "BlocklistIP"
+            ]
+        },
+        "constant.Currency": {
+            "type": "string",
+            "enum": [
+                "ETB",
+                "EUR",
+                "USD",
+                "GBP"
+            ],
+            "x-enum-varnames": [
+                "CurrencyETB",
+                "CurrencyEUR",
+                "CurrencyUSD",
                 "CurrencyGBP"
             ]
         },
-        "constant.Status": {
-            "type": "string",
-            "enum": [
-                "ACTIVE",
-                "INACTIVE",
-                "PENDING",
-                "DELETED",
-                "YES",
-                "NO",
-                "SUCCESS",
-                "FAILED"
-            ],
-            "x-enum-varnames": [
-                "Active",
-                "Inactive",
-                "Pending",
-                "Deleted",
-                "Yes",
-                "No",
-                "Success",
-                "Failed"
-            ]
+        "constant.DisputeReason": {
+            "type": "string",
+            "enum": [
+                "FRAUDULENT",
+                "DUPLICATE",
+                "PRODUCT_NOT_RECEIVED",
+                "PRODUCT_UNACCEPTABLE",
+                "UNRECOGNIZED",
+                "GENERAL"
+            ],
+            "x-enum-varnames": [
+                "DisputeFraudulent",
+                "DisputeDuplicate",
+                "DisputeProductNotReceived",
+                "DisputeProductUnacceptable",
+                "DisputeUnrecognized",
+                "DisputeGeneral"
+            ]
+        },
+        "constant.ErasureReason": {
+            "type": "string",
+            "enum": [
+                "REQUESTED",
+                "RETENTION"
+            ],
+            "x-enum-varnames": [
+                "ErasureRequested",
+                "ErasureRetention"
+            ]
+        },
+        "constant.KYCDocumentType": {
+            "type": "string",
+            "enum": [
+                "TRADE_LICENSE",
+                "TIN_CERTIFICATE",
+                "MEMORANDUM_OF_ASSOCIATION",
+                "OWNER_IDENTIFICATION",
+                "OTHER"
+            ],
+            "x-enum-varnames": [
+                "KYCTradeLicense",
+                "KYCTINCertificate",
+                "KYCMemorandumOfAssociation",
+                "KYCOwnerIdentification",
+                "KYCOther"
+            ]
+        },
+        "constant.LedgerEntryType": {
+            "type": "string",
+            "enum": [
+                "PAYMENT",
+                "DISPUTE_REVERSAL",
+                "CORRECTION"
+            ],
+            "x-enum-varnames": [
+                "LedgerPayment",
+                "LedgerDisputeReversal",
+                "LedgerCorrection"
+            ]
+        },
+        "constant.Mode": {
+            "type": "string",
+            "enum": [
+                "live",
+                "test"
+            ],
+            "x-enum-varnames": [
+                "ModeLive",
+                "ModeTest"
+            ]
+        },
+        "constant.RiskDecision": {
+            "type": "string",
+            "enum": [
+                "APPROVE",
+                "REJECT"
+            ],
+            "x-enum-varnames": [
+                "RiskApprove",
+                "RiskReject"
+            ]
+        },
+        "constant.RiskOutcome": {
+            "type": "string",
+            "enum": [
+                "ALLOW",
+                "REVIEW",
+                "BLOCK"
+            ],
+            "x-enum-varnames": [
+                "RiskAllow",
+                "RiskReview",
+                "RiskBlock"
+            ]
+        },
+        "constant.Role": {
+            "type": "string",
+            "enum": [
+                "OWNER",
+                "ADMIN",
+                "DEVELOPER",
+                "FINANCE",
+                "SUPPORT"
+            ],
+            "x-enum-varnames": [
+                "Owner",
+                "Admin",
+                "Developer",
+                "Finance",
+                "Support"
+            ]
+        },
+        "constant.Status": {
+            "type": "string",
+            "enum": [
+                "ACTIVE",
+                "INACTIVE",
+                "PENDING",
+                "DELETED",
+                "YES",
+                "NO",
+                "SUCCESS",
+                "FAILED",
+                "ACCEPTED",
+                "REVOKED",
+                "VERIFIED",
+                "SUSPENDED",
+                "ONBOARDING",
+                "UNDER_REVIEW",
+                "REJECTED",
+                "REVIEW",
+                "BLOCKED",
+                "REQUIRES_CONFIRMATION",
+                "NEEDS_RESPONSE",
+                "WON",
+                "LOST",
+                "PENDING_VERIFICATION"
+            ],
+            "x-enum-varnames": [
+                "Active",
+                "Inactive",
+                "Pending",
+                "Deleted",
+                "Yes",
+                "No",
+                "Success",
+                "Failed",
+                "Accepted",
+                "Revoked",
+                "Verified",
+                "Suspended",
+                "Onboarding",
+                "UnderReview",
+                "Rejected",
+                "Review",
+                "Blocked",
+                "RequiresConfirmation",
+                "NeedsResponse",
+                "Won",
+                "Lost",
+                "PendingVerification"
+            ]
+        },
+        "doc.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error contains the error detail if the request was not successful.",
+                    "type": "object",
+                    "properties": {
+                        "code": {
+                            "type": "integer"
+                        },
+                        "description": {
+                            "description": "Description is the error description.",
+                            "type": "string"
+                        },
+                        "field_error": {
+                            "description": "FieldError is the error detail for each field, if available that is.",
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/doc.FieldError"
+                            }
+                        },
+                        "message": {
+                            "description": "Message is the error message.",
+                            "type": "string"
+                        },
+                        "stack_trace": {
+                            "description": "StackTrace is the stack trace of the error.\nIt is only returned for debugging",
+                            "type": "string"
+                        }
+                    }
+                },
+                "success": {
+                    "description": "Success is only true if the request was successful.",
+                    "type": "boolean",
+                    "default": false
+                }
+            }
+        },
+        "doc.FieldError": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description is the error description for this field.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name is the name of the field that caused the error.",
+                    "type": "string"
+                }
+            }
+        },
+        "doc.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Total number of items",
+                    "type": "integer"
+                },
+                "data": {
+                    "description": "Data contains the actual data of the response."
+                },
+                "next": {
+                    "description": "URL for the next page, null if no next page",
+                    "type": "integer"
+                },
+                "previous": {
+                    "description": "URL for the previous page, null if no previous page",
+                    "type": "integer"
+                },
+                "success": {
+                    "description": "Success is only true if the request was successful.",
+                    "type": "boolean",
+                    "default": false
+                }
+            }
+        },
+        "dto.APIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Mode"
+                        }
+                    ],
+                    "example": "test"
+                }
+            }
+        },
+        "dto.AcceptInvitation": {
+            "type": "object",
+            "properties": {
+                "confirm_password": {
+                    "type": "string",
+                    "example": "StrongPass@123"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "Hana"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "StrongPass@123"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+251911234567"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "v2.local.invite-token"
+                }
+            }
+        },
+        "dto.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.AuditAction"
+                        }
+                    ],
+                    "example": "team.member_role_changed"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.AuditActorType"
+                        }
+                    ],
+                    "example": "USER"
+                },
+                "after": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "before": {
+                    "description": "Before and After hold the changed fields for actions that modify\nstate.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "5f0c9b7e-0d7e-4d4c-9a43-2b1f0f7d6c11"
+                }
+            }
+        },
+        "dto.BlocklistEntry": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "description": "CompanyID is empty for entries operators set for every company.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "hit_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_hit_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "chargeback fraud"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.BlocklistType"
+                        }
+                    ],
+                    "example": "PHONE"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value is the normalized phone number, the lower-case domain or the\nnetwork.",
+                    "type": "string",
+                    "example": "+251911234567"
+                }
+            }
+        },
+        "dto.BlocklistHit": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500.75
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "company_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Currency"
+                        }
+                    ],
+                    "example": "ETB"
+                },
+                "entry_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "livemode": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.BlocklistType"
+                        }
+                    ],
+                    "example": "PHONE"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "+251*******67"
+                }
+            }
+        },
+        "dto.BlocklistRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "chargeback fraud"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.BlocklistType"
+                        }
+                    ],
+                    "example": "PHONE"
+                },
+                "value": {
+                    "description": "Value is a phone number, an email domain such as example.com, or an\nIP address or CIDR network.",
+                    "type": "string",
+                    "example": "+251911234567"
+                }
+            }
+        },
+        "dto.Company": {
+            "type": "object",
+            "properties": {
+                "address_city": {
+                    "type": "string"
+                },
+                "address_country": {
+                    "type": "string"
+                },
+                "address_postal_code": {
+                    "type": "string"
+                },
+                "address_state": {
+                    "type": "string"
+                },
+                "address_street": {
+                    "type": "string"
+                },
+                "callback_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mfa_required": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "primary_phone": {
+                    "type": "string"
+                },
+                "registration_number": {
+                    "type": "string"
+                },
+                "return_url": {
+                    "type": "string"
+                },
+                "secondary_phone": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CompanyCredentialResponse": {
+            "type": "object",
+            "properties": {
+                "livemode": {
+                    "type": "boolean"
+                },
+                "scret_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CompanyFXSettings": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "settlement_currency": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Currency"
+                        }
+                    ],
+                    "example": "ETB"
+                },
+                "spread_bps": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CompanyLimit": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "currency": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Currency"
+                        }
+                    ],
+                    "example": "ETB"
+                },
+                "customer_max_payments": {
+                    "description": "CustomerMaxPayments caps how many payment intents one customer may be\nsent within CustomerWindowMinutes.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "customer_window_minutes": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "daily_total": {
+                    "type": "string",
+                    "example": "500000"
+                },
+                "max_amount": {
+                    "type": "string",
+                    "example": "50000"
+                },
+                "min_amount": {
+                    "type": "string",
+                    "example": "10"
+                },
+                "monthly_total": {
+                    "type": "string",
+                    "example": "10000000"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CompanyStatusChange": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "chargeback ratio above threshold"
+                }
+            }
+        },
+        "dto.ConfirmPaymentIntent": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "dto.CreateCompany": {
+            "type": "object",
+            "properties": {
+                "address_city": {
+                    "type": "string",
+                    "example": "Addis Ababa"
+                },
+                "address_country": {
+                    "type": "string",
+                    "example": "Ethiopia"
+                },
+                "address_postal_code": {
+                    "type": "string",
+                    "example": "1000"
+                },
+                "address_state": {
+                    "type": "string",
+                    "example": "Addis Ababa"
+                },
+                "address_street": {
+                    "type": "string",
+                    "example": "Bole Road"
+                },
+                "admin_email": {
+                    "type": "string",
+                    "example": "admin@acmetech.com"
+                },
+                "admin_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "admin_phone": {
+                    "type": "string",
+                    "example": "+251933456789"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://www.acmetech.com/payment/callback"
+                },
+                "confirm_password": {
+                    "type": "string",
+                    "example": "StrongPass@123"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "info@acmetech.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acme Technologies Ltd"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "StrongPass@123"
+                },
+                "primary_phone": {
+                    "type": "string",
+                    "example": "+251911234567"
+                },
+                "registration_number": {
+                    "type": "string",
+                    "example": "REG-123456"
+                },
+                "return_url": {
+                    "type": "string",
+                    "example": "https://www.acmetech.com/payment/return"
+                },
+                "secondary_phone": {
+                    "type": "string",
+                    "example": "+251922345678"
+                },
+                "website": {
+                    "type": "string",
+                    "example": "https://www.acmetech.com"
+                }
+            }
+        },
+        "dto.CreateCustomer": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@gmail.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "+251911234567"
+                }
+            }
+        },
+        "dto.CreateOperator": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "ops@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Abebe Kebede"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "StrongPass@123"
+                }
+            }
+        },
+        "dto.CreatedHMACKey": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key_id": {
+                    "type": "string",
+                    "example": "hk_live_3f9a1c2b7d4e5f60"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "livemode": {
+                    "type": "boolean"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "8Zq0...base64url-secret"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ACTIVE"
+                }
+            }
+        },
+        "dto.Currency": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Currency"
+                        }
+                    ],
+                    "example": "ETB"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_amount": {
+                    "type": "number",
+                    "example": 10000000
+                },
+                "min_amount": {
+                    "type": "number",
+                    "example": 1
+                },
+                "minor_units": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Ethiopian Birr"
+                },
+                "numeric_code": {
+                    "type": "string",
+                    "example": "230"
+                }
+            }
+        },
+        "dto.Customer": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2023-09-11T14:30:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@gmail.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "livemode": {
+                    "type": "boolean"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "+1234567890"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2023-09-11T14:45:00Z"
+                }
+            }
+        },
+        "dto.CustomerErasure": {
+            "type": "object",
+            "properties": {
+                "certificate_id": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "string"
+                },
+                "erased_at": {
+                    "type": "string"
+                },
+                "erased_fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "full_name",
+                        "phone_number",
+                        "email"
+                    ]
+                },
+                "livemode": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.ErasureReason"
+                        }
+                    ],
+                    "example": "REQUESTED"
+                },
+                "retained_payment_intents": {
+                    "description": "RetainedPaymentIntents still reference the anonymized customer and\nare kept for accounting.",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "dto.Dispute": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500.75
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by_operator": {
+                    "description": "CreatedByOperator is empty for disputes opened by the processor.",
+                    "type": "string"
+                },
+                "currency": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Currency"
+                        }
+                    ],
+                    "example": "ETB"
+                },
+                "evidence_due_by": {
+                    "type": "string"
+                },
+                "evidence_text": {
+                    "type": "string",
+                    "example": "Delivered on 12 March, signed receipt attached."
+                },
+                "files": {
+                    "description": "Files and Reversals are only returned for a single dispute.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DisputeEvidenceFile"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "livemode": {
+                    "type": "boolean"
+                },
+                "payment_intent_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.DisputeReason"
+                        }
+                    ],
+                    "example": "PRODUCT_NOT_RECEIVED"
+                },
+                "resolution_note": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                },
+                "reversals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LedgerEntry"
+                    }
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Status"
+                        }
+                    ],
+                    "example": "NEEDS_RESPONSE"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DisputeEvidence": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "Delivered on 12 March, signed receipt attached."
+                }
+            }
+        },
+        "dto.DisputeEvidenceFile": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dispute_id": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "delivery-receipt.pdf"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 248133
+                },
+                "uploaded_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FXSnapshot": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string"
+                },
+                "mid_rate": {
+                    "type": "number",
+                    "example": 128.4567
+                },
+                "rate": {
+                    "description": "Rate is MidRate less the spread; the settlement amount is the\npresentment amount times Rate, rounded down.",
+                    "type": "number",
+                    "example": 126.5298495
+                },
+                "source": {
+                    "type": "string",
+                    "example": "NBE"
+                },
+                "spread_bps": {
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "dto.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string",
+                    "example": "+251933456789"
+                }
+            }
+        },
+        "dto.HMACKey": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key_id": {
+                    "type": "string",
+                    "example": "hk_live_3f9a1c2b7d4e5f60"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "livemode": {
+                    "type": "boolean"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ACTIVE"
+                }
+            }
+        },
+        "dto.IPAllowlistEntry": {
+            "type": "object",
+            "properties": {
+                "cidr": {
+                    "type": "string",
+                    "example": "203.0.113.0/24"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Production servers"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.IPAllowlistRequest": {
+            "type": "object",
+            "properties": {
+                "cidr": {
+                    "description": "CIDR is a network such as 203.0.113.0/24 or a single address, which\nis stored as a /32 or /128 network.",
+                    "type": "string",
+                    "example": "203.0.113.0/24"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Production servers"
+                }
+            }
+        },
+        "dto.InitPaymentIntent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500.75
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://merchant.example.com/payment/callback"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "ETB"
+                },
+                "customer": {
+                    "$ref": "#/definitions/dto.PaymentCustomer"
+                },
+                "customer_id": {
+                    "description": "CustomerID charges a customer created through the customers API.\nCustomer is used to upsert one by phone number instead.",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "customer_ip": {
+                    "description": "CustomerIP is the address the customer reached the merchant from. It\nis checked against the IP blocklist.",
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Parking subscription payment"
+                },
+                "extra": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "require_confirmation": {
+                    "description": "RequireConfirmation holds the intent until the customer confirms it\nwith the code sent to their phone.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "return_url": {
+                    "type": "string",
+                    "example": "https://merchant.example.com/payment/return"
+                }
+            }
+        },
+        "dto.InviteUser": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "finance@acmetech.com"
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Role"
+                        }
+                    ],
+                    "example": "FINANCE"
+                }
+            }
+        },
+        "dto.KYCDecision": {
+            "type": "string",
+            "enum": [
+                "APPROVE",
+                "REJECT"
+            ],
+            "x-enum-varnames": [
+                "KYCApprove",
+                "KYCReject"
+            ]
+        },
+        "dto.KYCDocument": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "document_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.KYCDocumentType"
+                        }
+                    ],
+                    "example": "TRADE_LICENSE"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "trade-license.pdf"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 248133
+                },
+                "uploaded_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.KYCReview": {
+            "type": "object",
+            "properties": {
+                "decision": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.KYCDecision"
+                        }
+                    ],
+                    "example": "REJECT"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "trade license has expired"
+                }
+            }
+        },
+        "dto.KYCStatus": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.KYCDocument"
+                    }
+                },
+                "missing_documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constant.KYCDocumentType"
+                    }
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Status"
+                        }
+                    ],
+                    "example": "UNDER_REVIEW"
+                },
+                "submitted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -1500.75
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Currency"
+                        }
+                    ],
+                    "example": "ETB"
+                },
+                "dispute_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "livemode": {
+                    "type": "boolean"
+                },
+                "payment_intent_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.LedgerEntryType"
+                        }
+                    ],
+                    "example": "DISPUTE_REVERSAL"
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "StrongPass@123"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+251933456789"
+                }
+            }
+        },
+        "dto.MFAChallenge": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "v2.local.mfa-challenge-token"
+                }
+            }
+        },
+        "dto.MFACodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the current TOTP code, or an unused recovery code where the\nendpoint allows one.",
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "dto.MFAEnrollment": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "description": "ProvisioningURI is the otpauth:// URI to render as a QR code.",
+                    "type": "string",
+                    "example": "otpauth://totp/Payment%20Gateway:owner@example.com?secret=JBSWY3DPEHPK3PXP"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "dto.MFALoginRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is either the current TOTP code or an unused recovery code.",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "v2.local.mfa-challenge-token"
+                }
+            }
+        },
+        "dto.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "request processed"
+                }
+            }
+        },
+        "dto.OpenDispute": {
+            "type": "object",
+            "properties": {
+                "evidence_due_by": {
+                    "description": "EvidenceDueBy defaults to seven days from now.",
+                    "type": "string"
+                },
+                "payment_intent_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "reason": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.DisputeReason"
+                        }
+                    ],
+                    "example": "PRODUCT_NOT_RECEIVED"
+                }
+            }
+        },
+        "dto.Operator": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_login_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OperatorBlocklistRequest": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "chargeback fraud"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.BlocklistType"
+                        }
+                    ],
+                    "example": "PHONE"
+                },
+                "value": {
+                    "description": "Value is a phone number, an email domain such as example.com, or an\nIP address or CIDR network.",
+                    "type": "string",
+                    "example": "+251911234567"
+                }
+            }
+        },
+        "dto.OperatorLoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "ops@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "StrongPass@123"
+                }
+            }
+        },
+        "dto.OperatorSignInResponse": {
+            "type": "object",
+            "properties": {
+                "access": {
+                    "type": "string",
+                    "example": "access-token"
+                }
+            }
+        },
+        "dto.PIIDataKey": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customers": {
+                    "type": "integer",
+                    "example": 1520
+                },
+                "id": {
+                    "type": "string"
+                },
+                "master_key_id": {
+                    "type": "string",
+                    "example": "k1"
+                },
+                "retired_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PaymentCustomer": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "abel.tesfaye@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Abel Tesfaye"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "+251911234567"
+                }
+            }
+        },
+        "dto.PaymentIntent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "bill_ref_no": {
+                    "type": "string"
+                },
+                "callback_url": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "$ref": "#/definitions/constant.Currency"
+                },
+                "customer_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expire_at": {
+                    "type": "string"
+                },
+                "extra": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "id": {
+                    "type": "string"
+                },
+                "livemode": {
+                    "type": "boolean"
+                },
+                "payment_type": {
+                    "type": "string"
+                },
+                "return_url": {
+                    "type": "string"
+                },
+                "risk": {
+                    "description": "Risk is nil for intents of companies without risk rules.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RiskAssessment"
+                        }
+                    ]
+                },
+                "settlement": {
+                    "$ref": "#/definitions/dto.Settlement"
+                },
+                "status": {
+                    "$ref": "#/definitions/constant.Status"
+                },
+                "topay_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
         },
-        "doc.ErrorResponse": {
+        "dto.PaymentIntentDetail": {
             "type": "object",
             "properties": {
-                "error": {
-                    "description": "Error contains the error detail if the request was not successful.",
+                "amount": {
+                    "type": "number"
+                },
+                "bill_ref_no": {
+                    "type": "string"
+                },
+                "callback_url": {
+                    "type": "string"
+                },
+                "company": {
+                    "$ref": "#/definitions/dto.Company"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "$ref": "#/definitions/constant.Currency"
+                },
+                "customer": {
+                    "$ref": "#/definitions/dto.Customer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expire_at": {
+                    "type": "string"
+                },
+                "extra": {
                     "type": "object",
-                    "properties": {
-                        "code": {
-                            "type": "integer"
-                        },
-                        "description": {
-                            "description": "Description is the error description.",
-                            "type": "string"
-                        },
-                        "field_error": {
-                            "description": "FieldError is the error detail for each field, if available that is.",
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/doc.FieldError"
-                            }
-                        },
-                        "message": {
-                            "description": "Message is the error message.",
-                            "type": "string"
-                        },
-                        "stack_trace": {
-                            "description": "StackTrace is the stack trace of the error.\nIt is only returned for debugging",
-                            "type": "string"
+                    "additionalProperties": {}
+                },
+                "id": {
+                    "type": "string"
+                },
+                "livemode": {
+                    "type": "boolean"
+                },
+                "payment_type": {
+                    "type": "string"
+                },
+                "return_url": {
+                    "type": "string"
+                },
+                "risk": {
+                    "description": "Risk is nil for intents of companies without risk rules.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RiskAssessment"
                         }
-                    }
+                    ]
                 },
-                "success": {
-                    "description": "Success is only true if the request was successful.",
-                    "type": "boolean",
-                    "default": false
+                "settlement": {
+                    "$ref": "#/definitions/dto.Settlement"
+                },
+                "status": {
+                    "$ref": "#/definitions/constant.Status"
+                },
+                "topay_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "doc.FieldError": {
+        "dto.PaymentIntentStatusCorrection": {
             "type": "object",
             "properties": {
-                "description": {
-                    "description": "Description is the error description for this field.",
-                    "type": "string"
+                "reason": {
+                    "type": "string",
+                    "example": "provider confirmed settlement out of band"
                 },
-                "name": {
-                    "description": "Name is the name of the field that caused the error.",
-                    "type": "string"
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Status"
+                        }
+                    ],
+                    "example": "SUCCESS"
                 }
             }
         },
-        "doc.SuccessResponse": {
+        "dto.RecoveryCodes": {
             "type": "object",
             "properties": {
-                "count": {
-                    "description": "Total number of items",
-                    "type": "integer"
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k3x9p-2mzq7"
+                    ]
+                }
+            }
+        },
+        "dto.ResendVerificationRequest": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string",
+                    "example": "+251933456789"
+                }
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "confirm_password": {
+                    "type": "string",
+                    "example": "NewStrongPass@123"
                 },
-                "data": {
-                    "description": "Data contains the actual data of the response."
+                "password": {
+                    "type": "string",
+                    "example": "NewStrongPass@123"
                 },
-                "next": {
-                    "description": "URL for the next page, null if no next page",
-                    "type": "integer"
+                "token": {
+                    "type": "string",
+                    "example": "v2.local.reset-token"
+                }
+            }
+        },
+        "dto.ResolveDispute": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "issuer accepted the merchant's delivery proof"
                 },
-                "previous": {
-                    "description": "URL for the previous page, null if no previous page",
-                    "type": "integer"
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Status"
+                        }
+                    ],
+                    "example": "LOST"
+                }
+            }
+        },
+        "dto.RiskAssessment": {
+            "type": "object",
+            "properties": {
+                "outcome": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.RiskOutcome"
+                        }
+                    ],
+                    "example": "REVIEW"
                 },
-                "success": {
-                    "description": "Success is only true if the request was successful.",
-                    "type": "boolean",
-                    "default": false
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "signals": {
+                    "type": "object",
+                    "additionalProperties": {}
                 }
             }
         },
-        "dto.Company": {
+        "dto.RiskReview": {
             "type": "object",
             "properties": {
-                "address_city": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500.75
+                },
+                "company_id": {
                     "type": "string"
                 },
-                "address_country": {
+                "company_name": {
                     "type": "string"
                 },
-                "address_postal_code": {
+                "created_at": {
                     "type": "string"
                 },
-                "address_state": {
+                "currency": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Currency"
+                        }
+                    ],
+                    "example": "ETB"
+                },
+                "customer_id": {
                     "type": "string"
                 },
-                "address_street": {
+                "livemode": {
+                    "type": "boolean"
+                },
+                "payment_intent_id": {
                     "type": "string"
                 },
-                "callback_url": {
+                "risk": {
+                    "$ref": "#/definitions/dto.RiskAssessment"
+                }
+            }
+        },
+        "dto.RiskReviewDecision": {
+            "type": "object",
+            "properties": {
+                "decision": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.RiskDecision"
+                        }
+                    ],
+                    "example": "APPROVE"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "customer confirmed the order by phone"
+                }
+            }
+        },
+        "dto.RiskRule": {
+            "type": "object",
+            "properties": {
+                "company_id": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
+                },
+                "expression": {
+                    "type": "string",
+                    "example": "new_customer and amount_ratio \u003e= 5"
                 },
                 "id": {
                     "type": "string"
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "large first payment"
                 },
-                "primary_phone": {
-                    "type": "string"
+                "score": {
+                    "type": "integer",
+                    "example": 40
                 },
-                "registration_number": {
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.RiskSettings": {
+            "type": "object",
+            "properties": {
+                "block_score": {
+                    "type": "integer",
+                    "example": 100
                 },
-                "return_url": {
+                "company_id": {
                     "type": "string"
                 },
-                "secondary_phone": {
+                "review_score": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.SetCompanyCurrency": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.SetCompanyFXSettings": {
+            "type": "object",
+            "properties": {
+                "settlement_currency": {
+                    "description": "SettlementCurrency is left empty to settle in the presentment currency.",
+                    "type": "string",
+                    "example": "ETB"
+                },
+                "spread_bps": {
+                    "description": "SpreadBps is the markup on the mid rate in basis points, at most 10%.",
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "dto.SetCompanyLimit": {
+            "type": "object",
+            "properties": {
+                "customer_max_payments": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "customer_window_minutes": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "daily_total": {
+                    "type": "string",
+                    "example": "500000"
+                },
+                "max_amount": {
+                    "type": "string",
+                    "example": "50000"
+                },
+                "min_amount": {
+                    "type": "string",
+                    "example": "10"
+                },
+                "monthly_total": {
+                    "type": "string",
+                    "example": "10000000"
+                }
+            }
+        },
+        "dto.SetRiskRule": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Enabled defaults to true.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "expression": {
+                    "type": "string",
+                    "example": "new_customer and amount_ratio \u003e= 5"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "large first payment"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "dto.SetRiskSettings": {
+            "type": "object",
+            "properties": {
+                "block_score": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "review_score": {
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "dto.Settlement": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 2529.33
                 },
-                "status": {
-                    "type": "string"
+                "currency": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Currency"
+                        }
+                    ],
+                    "example": "ETB"
                 },
-                "updated_at": {
-                    "type": "string"
+                "fx": {
+                    "$ref": "#/definitions/dto.FXSnapshot"
+                }
+            }
+        },
+        "dto.SignInResponse": {
+            "type": "object",
+            "properties": {
+                "access": {
+                    "description": "Access token for the user.",
+                    "type": "string",
+                    "example": "access-token"
                 },
-                "website": {
-                    "type": "string"
+                "refresh": {
+                    "description": "Refresh token for the user.",
+                    "type": "string",
+                    "example": "refresh-token"
                 }
             }
         },
-        "dto.CompanyCredentialResponse": {
+        "dto.UnlockAccountRequest": {
             "type": "object",
             "properties": {
-                "scret_token": {
-                    "type": "string"
+                "token": {
+                    "type": "string",
+                    "example": "v2.local.unlock-token"
                 }
             }
         },
-        "dto.CreateCompany": {
+        "dto.UpdateCompany": {
             "type": "object",
             "properties": {
                 "address_city": {
@@ -554,46 +9341,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Bole Road"
                 },
-                "admin_email": {
-                    "type": "string",
-                    "example": "admin@acmetech.com"
-                },
-                "admin_name": {
-                    "type": "string",
-                    "example": "John Doe"
-                },
-                "admin_phone": {
-                    "type": "string",
-                    "example": "+251933456789"
-                },
                 "callback_url": {
                     "type": "string",
                     "example": "https://www.acmetech.com/payment/callback"
                 },
-                "confirm_password": {
-                    "type": "string",
-                    "example": "StrongPass@123"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "info@acmetech.com"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Acme Technologies Ltd"
-                },
-                "password": {
-                    "type": "string",
-                    "example": "StrongPass@123"
-                },
                 "primary_phone": {
                     "type": "string",
                     "example": "+251911234567"
                 },
-                "registration_number": {
-                    "type": "string",
-                    "example": "REG-123456"
-                },
                 "return_url": {
                     "type": "string",
                     "example": "https://www.acmetech.com/payment/return"
@@ -608,221 +9363,200 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.Customer": {
+        "dto.UpdateCompanyMFA": {
             "type": "object",
             "properties": {
-                "company_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440002"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2023-09-11T14:30:00Z"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "john.doe@gmail.com"
-                },
-                "full_name": {
-                    "type": "string",
-                    "example": "John Doe"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440001"
-                },
-                "phone_number": {
-                    "type": "string",
-                    "example": "+1234567890"
-                },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2023-09-11T14:45:00Z"
+                "required": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "dto.InitPaymentIntent": {
+        "dto.UpdateCustomer": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number",
-                    "example": 1500.75
-                },
-                "callback_url": {
-                    "type": "string",
-                    "example": "https://merchant.example.com/payment/callback"
-                },
-                "currency": {
+                "email": {
                     "type": "string",
-                    "example": "ETB"
-                },
-                "customer": {
-                    "$ref": "#/definitions/dto.PaymentCustomer"
+                    "example": "john.doe@gmail.com"
                 },
-                "description": {
+                "full_name": {
                     "type": "string",
-                    "example": "Parking subscription payment"
-                },
-                "extra": {
-                    "type": "object",
-                    "additionalProperties": true
+                    "example": "John Doe"
                 },
-                "return_url": {
+                "phone_number": {
                     "type": "string",
-                    "example": "https://merchant.example.com/payment/return"
+                    "example": "+251911234567"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.UpdateMemberRole": {
             "type": "object",
             "properties": {
-                "password": {
-                    "type": "string",
-                    "example": "StrongPass@123"
-                },
-                "phone": {
-                    "type": "string",
-                    "example": "+251933456789"
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Role"
+                        }
+                    ],
+                    "example": "DEVELOPER"
                 }
             }
         },
-        "dto.PaymentCustomer": {
+        "dto.User": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "abel.tesfaye@example.com"
-                },
-                "full_name": {
-                    "type": "string",
-                    "example": "Abel Tesfaye"
+                "bio": {
+                    "type": "string"
                 },
-                "phone_number": {
-                    "type": "string",
-                    "example": "+251911234567"
-                }
-            }
-        },
-        "dto.PaymentIntent": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "number"
+                "company_id": {
+                    "type": "string"
                 },
-                "bill_ref_no": {
+                "created_at": {
                     "type": "string"
                 },
-                "callback_url": {
+                "email": {
                     "type": "string"
                 },
-                "company_id": {
+                "email_verified_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "first_name": {
                     "type": "string"
                 },
-                "currency": {
-                    "$ref": "#/definitions/constant.Currency"
+                "id": {
+                    "type": "string"
                 },
-                "customer_id": {
+                "last_name": {
                     "type": "string"
                 },
-                "description": {
+                "mfa_enabled_at": {
                     "type": "string"
                 },
-                "expire_at": {
+                "password": {
                     "type": "string"
                 },
-                "extra": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "phone": {
+                    "type": "string"
                 },
-                "id": {
+                "phone_verified_at": {
                     "type": "string"
                 },
-                "payment_type": {
+                "profile_picture_url": {
                     "type": "string"
                 },
-                "return_url": {
+                "role": {
                     "type": "string"
                 },
                 "status": {
-                    "$ref": "#/definitions/constant.Status"
+                    "type": "string"
                 },
-                "topay_url": {
+                "timezone_id": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "dto.PaymentIntentDetail": {
+        "dto.UserInvitation": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "bill_ref_no": {
+                "accepted_at": {
                     "type": "string"
                 },
-                "callback_url": {
+                "company_id": {
                     "type": "string"
                 },
-                "company": {
-                    "$ref": "#/definitions/dto.Company"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "currency": {
-                    "$ref": "#/definitions/constant.Currency"
-                },
-                "customer": {
-                    "$ref": "#/definitions/dto.Customer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "expire_at": {
+                "email": {
                     "type": "string"
                 },
-                "extra": {
-                    "type": "object",
-                    "additionalProperties": {}
-                },
                 "id": {
                     "type": "string"
                 },
-                "payment_type": {
+                "invited_by": {
                     "type": "string"
                 },
-                "return_url": {
-                    "type": "string"
+                "role": {
+                    "$ref": "#/definitions/constant.Role"
                 },
                 "status": {
                     "$ref": "#/definitions/constant.Status"
                 },
-                "topay_url": {
-                    "type": "string"
-                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "dto.SignInResponse": {
+        "dto.VerifyEmailRequest": {
             "type": "object",
             "properties": {
-                "access": {
-                    "description": "Access token for the user.",
+                "token": {
                     "type": "string",
-                    "example": "access-token"
+                    "example": "v2.local.verification-token"
+                }
+            }
+        },
+        "dto.VerifyPhoneRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
                 },
-                "refresh": {
-                    "description": "Refresh token for the user.",
+                "phone": {
                     "type": "string",
-                    "example": "refresh-token"
+                    "example": "+251933456789"
+                }
+            }
+        },
+        "hcrypto.PublicKey": {
+            "type": "object",
+            "properties": {
+                "kid": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "purpose": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "hcrypto.PublicKeySet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hcrypto.PublicKey"
+                    }
+                }
+            }
+        },
+        "response.MetaData": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Total number of items",
+                    "type": "integer"
+                },
+                "next": {
+                    "description": "URL for the next page, null if no next page",
+                    "type": "integer"
+                },
+                "previous": {
+                    "description": "URL for the previous page, null if no previous page",
+                    "type": "integer"
                 }
             }
         }
@@ -0,0 +1,31 @@
+package foundation
+
+import (
+	"context"
+	"net"
+	"pg/platform/hlog"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// InitIPExtractor reads the client address from X-Forwarded-For, walking
+// back from the nearest hop and skipping only proxies we trust. Loopback,
+// link-local and private addresses are trusted as echo does by default;
+// TRUSTED_PROXIES adds further CIDRs, such as a CDN's public ranges. A
+// spoofed header from an untrusted peer is therefore ignored.
+func InitIPExtractor(logger hlog.Logger) echo.IPExtractor {
+	options := []echo.TrustOption{}
+	for _, value := range strings.FieldsFunc(viper.GetString("TRUSTED_PROXIES"), isListSeparator) {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			logger.Fatal(context.Background(), "invalid trusted proxy cidr",
+				zap.Error(err), zap.String("cidr", value))
+		}
+		options = append(options, echo.TrustIPRange(network))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -3,10 +3,12 @@ package foundation
 import (
 	"context"
 	"log"
+	"pg/internal/constant"
 	"pg/internal/constant/errors"
 	"pg/platform/hcrypto"
 	"pg/platform/hlog"
 	"pg/platform/httpclient"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -17,6 +19,19 @@ type State struct {
 	HTTPConfig  httpclient.HTTPTransport
 	TokenConfig hcrypto.TokenKey
 	AMQPURL     string
+	// HMACClockSkew is how far a PG-HMAC request timestamp may be from
+	// the server clock.
+	HMACClockSkew time.Duration
+	// BlobStoreDriver selects where uploaded files are kept; "local" keeps
+	// them under BlobStoreLocalPath.
+	BlobStoreDriver    string
+	BlobStoreLocalPath string
+	// FieldKeys seal customer personal data in the database.
+	FieldKeys hcrypto.FieldKeys
+	// FXRatesFile holds the exchange rates cross-currency payment intents
+	// are settled at. Rates older than FXMaxRateAge are refused.
+	FXRatesFile  string
+	FXMaxRateAge time.Duration
 }
 
 func InitState(logger hlog.Logger) State {
@@ -32,18 +47,54 @@ func InitState(logger hlog.Logger) State {
 		log.Fatal(context.Background(), "all http fields are required", zap.Error(err))
 	}
 
+	previousKeys, err := hcrypto.ParseKeyList(viper.GetString("SECURITY_CREDENTIAL_PREVIOUS_KEYS"))
+	if err != nil {
+		err := errors.ErrInvalidUserInput.Wrap(err, "invalid previous token keys")
+		log.Fatal(context.Background(), "invalid previous token keys", zap.Error(err))
+	}
+	previousPublicKeys, err := hcrypto.ParseKeyList(
+		viper.GetString("SECURITY_CREDENTIAL_PREVIOUS_PUBLIC_KEYS"))
+	if err != nil {
+		err := errors.ErrInvalidUserInput.Wrap(err, "invalid previous public token keys")
+		log.Fatal(context.Background(), "invalid previous public token keys", zap.Error(err))
+	}
+	var publicTokenTypes []constant.TokenType
+	for _, tokenType := range strings.FieldsFunc(
+		viper.GetString("SECURITY_CREDENTIAL_PUBLIC_TOKEN_TYPES"), isListSeparator) {
+		publicTokenTypes = append(publicTokenTypes, constant.TokenType(tokenType))
+	}
 	tokenConfig := hcrypto.TokenKey{
-		SymmetricKey: viper.GetString("SECURITY_CREDENTIAL_SYMMETRIC_KEY"),
-		Issuer:       viper.GetString("SECURITY_CREDENTIAL_ISSUER"),
-		Footer:       viper.GetString("SECURITY_CREDENTIAL_FOOTER"),
-		KeyLength:    viper.GetInt("SECURITY_CREDENTIAL_KEY_LENGTH"),
-		Audience:     viper.GetString("SECURITY_CREDENTIAL_AUDIENCE"),
+		SymmetricKey:       viper.GetString("SECURITY_CREDENTIAL_SYMMETRIC_KEY"),
+		KeyID:              viper.GetString("SECURITY_CREDENTIAL_KEY_ID"),
+		PreviousKeys:       previousKeys,
+		RetiredKeyIDs:      strings.FieldsFunc(viper.GetString("SECURITY_CREDENTIAL_RETIRED_KEY_IDS"), isListSeparator),
+		LegacyKeyID:        viper.GetString("SECURITY_CREDENTIAL_LEGACY_KEY_ID"),
+		PublicSigningKey:   viper.GetString("SECURITY_CREDENTIAL_PUBLIC_SIGNING_KEY"),
+		PublicKeyID:        viper.GetString("SECURITY_CREDENTIAL_PUBLIC_KEY_ID"),
+		PreviousPublicKeys: previousPublicKeys,
+		RetiredPublicKeyIDs: strings.FieldsFunc(
+			viper.GetString("SECURITY_CREDENTIAL_RETIRED_PUBLIC_KEY_IDS"), isListSeparator),
+		PublicTokenTypes: publicTokenTypes,
+		Issuer:           viper.GetString("SECURITY_CREDENTIAL_ISSUER"),
+		Footer:           viper.GetString("SECURITY_CREDENTIAL_FOOTER"),
+		KeyLength:        viper.GetInt("SECURITY_CREDENTIAL_KEY_LENGTH"),
+		Audience:         viper.GetString("SECURITY_CREDENTIAL_AUDIENCE"),
 		AccessExpires: time.Duration(
 			viper.GetInt("SECURITY_CREDENTIAL_ACCESS_EXPIRES")) * time.Minute,
 		RefreshExpires: time.Duration(
 			viper.GetInt("SECURITY_CREDENTIAL_REFRESH_EXPIRES")) * time.Minute,
 		SecretTokenExpires: time.Duration(
 			viper.GetInt("SECURITY_CREDENTIAL_SECRET_EXPIRES")) * time.Minute,
+		InviteExpires: time.Duration(
+			viper.GetInt("SECURITY_CREDENTIAL_INVITE_EXPIRES")) * time.Minute,
+		ResetExpires: time.Duration(
+			viper.GetInt("SECURITY_CREDENTIAL_RESET_EXPIRES")) * time.Minute,
+		VerifyExpires: time.Duration(
+			viper.GetInt("SECURITY_CREDENTIAL_VERIFY_EXPIRES")) * time.Minute,
+		MFAExpires: time.Duration(
+			viper.GetInt("SECURITY_CREDENTIAL_MFA_EXPIRES")) * time.Minute,
+		UnlockExpires: time.Duration(
+			viper.GetInt("SECURITY_CREDENTIAL_UNLOCK_EXPIRES")) * time.Minute,
 	}
 
 	if err := tokenConfig.Validate(); err != nil {
@@ -51,9 +102,40 @@ func InitState(logger hlog.Logger) State {
 		log.Fatal(context.Background(), "all tokenKey fields are required", zap.Error(err))
 	}
 
+	hmacClockSkew := time.Duration(viper.GetInt("HMAC_CLOCK_SKEW_SECONDS")) * time.Second
+	if hmacClockSkew <= 0 {
+		hmacClockSkew = 5 * time.Minute
+	}
+
+	previousMasterKeys, err := hcrypto.ParseKeyList(viper.GetString("PII_PREVIOUS_MASTER_KEYS"))
+	if err != nil {
+		err := errors.ErrInvalidUserInput.Wrap(err, "invalid previous pii master keys")
+		log.Fatal(context.Background(), "invalid previous pii master keys", zap.Error(err))
+	}
+
+	blobStoreLocalPath := viper.GetString("BLOB_STORE_LOCAL_PATH")
+	if blobStoreLocalPath == "" {
+		blobStoreLocalPath = "data/blobs"
+	}
+
 	return State{
-		HTTPConfig:  httpconfig,
-		TokenConfig: tokenConfig,
-		AMQPURL:     viper.GetString("RABBITMQ_URL"),
+		HTTPConfig:         httpconfig,
+		TokenConfig:        tokenConfig,
+		AMQPURL:            viper.GetString("RABBITMQ_URL"),
+		HMACClockSkew:      hmacClockSkew,
+		BlobStoreDriver:    viper.GetString("BLOB_STORE_DRIVER"),
+		BlobStoreLocalPath: blobStoreLocalPath,
+		FieldKeys: hcrypto.FieldKeys{
+			MasterKey:          viper.GetString("PII_MASTER_KEY"),
+			MasterKeyID:        viper.GetString("PII_MASTER_KEY_ID"),
+			PreviousMasterKeys: previousMasterKeys,
+			BlindIndexKey:      viper.GetString("PII_BLIND_INDEX_KEY"),
+		},
+		FXRatesFile:  viper.GetString("FX_RATES_FILE"),
+		FXMaxRateAge: time.Duration(viper.GetInt("FX_MAX_RATE_AGE_HOURS")) * time.Hour,
 	}
 }
+
+func isListSeparator(r rune) bool {
+	return r == ',' || r == ' '
+}
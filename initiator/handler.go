@@ -2,28 +2,102 @@ package initiator
 
 import (
 	"pg/internal/handler/rest"
+	"pg/internal/handler/rest/audit"
+	"pg/internal/handler/rest/blocklist"
 	"pg/internal/handler/rest/company"
+	"pg/internal/handler/rest/currency"
+	"pg/internal/handler/rest/customer"
+	"pg/internal/handler/rest/dispute"
+	"pg/internal/handler/rest/kyc"
+	"pg/internal/handler/rest/operator"
 	paymentintent "pg/internal/handler/rest/payment_intent"
+	"pg/internal/handler/rest/team"
+	"pg/internal/handler/rest/wellknown"
+	"pg/platform/hcrypto"
 	"pg/platform/hlog"
 	"time"
+
+	"github.com/spf13/viper"
 )
 
 type HandlerLayer struct {
+	audit         rest.Audit
+	blocklist     rest.Blocklist
 	company       rest.Company
+	currency      rest.Currency
+	customer      rest.Customer
+	dispute       rest.Dispute
+	kyc           rest.KYC
+	operator      rest.Operator
 	paymentIntent rest.PaymentIntent
+	team          rest.Team
+	wellKnown     rest.WellKnown
 }
 
 func InitHandler(ml ModuleLayer, log hlog.Logger,
-	timeout time.Duration) HandlerLayer {
+	tokenMaker hcrypto.Maker, timeout time.Duration) HandlerLayer {
 	return HandlerLayer{
+		audit: audit.New(
+			log.Named("audit-handler"),
+			ml.Audit,
+			timeout,
+		),
+		blocklist: blocklist.New(
+			log.Named("blocklist-handler"),
+			ml.Blocklist,
+			timeout,
+		),
 		company: company.New(
 			log.Named("company-handler"),
 			ml.Company,
 			timeout),
+		currency: currency.New(
+			log.Named("currency-handler"),
+			ml.Currency,
+			timeout,
+		),
+		customer: customer.New(
+			log.Named("customer-handler"),
+			ml.Customer,
+			timeout,
+		),
+		dispute: dispute.New(
+			log.Named("dispute-handler"),
+			ml.Dispute,
+			timeout,
+			viper.GetInt64("DISPUTE_MAX_EVIDENCE_BYTES"),
+		),
+		kyc: kyc.New(
+			log.Named("kyc-handler"),
+			ml.KYC,
+			timeout,
+			viper.GetInt64("KYC_MAX_DOCUMENT_BYTES"),
+		),
+		operator: operator.New(
+			log.Named("operator-handler"),
+			ml.Operator,
+			ml.KYC,
+			ml.PII,
+			ml.Currency,
+			ml.Limit,
+			ml.Risk,
+			ml.Blocklist,
+			ml.Dispute,
+			timeout,
+		),
 		paymentIntent: paymentintent.New(
 			log.Named("payment-intent-handler"),
 			ml.PaymentIntent,
 			timeout,
 		),
+		team: team.New(
+			log.Named("team-handler"),
+			ml.Team,
+			timeout,
+		),
+		wellKnown: wellknown.New(
+			log.Named("well-known-handler"),
+			tokenMaker,
+		),
 	}
 }
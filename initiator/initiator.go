@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"pg/initiator/foundation"
 	"pg/initiator/platform"
+	"pg/internal/constant/model/dto"
 	persistencedb "pg/internal/constant/persistenceDB"
 	"pg/internal/handler/middleware"
 	"pg/platform/hlog"
@@ -77,7 +78,9 @@ func Initiate() {
 
 	// Initiate Persistence layer
 	log.Info(context.Background(), "initializing persistence layer")
-	persistence := InitPersistence(persistencedb.New(pgxConn, log, persistencedb.Options{}), log)
+	persistence := InitPersistence(persistencedb.New(pgxConn, log, persistencedb.Options{
+		FieldKeys: platformInstance.FieldKeys,
+	}), log)
 	log.Info(context.Background(), "persistence layer initialized")
 
 	// Initiate Module
@@ -85,20 +88,43 @@ func Initiate() {
 	module := InitModule(persistence, log, platformInstance)
 	log.Info(context.Background(), "module initialized")
 
+	// Bootstrap the first back-office operator
+	if email := viper.GetString("OPERATOR_BOOTSTRAP_EMAIL"); email != "" {
+		if err := module.Operator.Bootstrap(context.Background(), dto.CreateOperator{
+			FullName: viper.GetString("OPERATOR_BOOTSTRAP_NAME"),
+			Email:    email,
+			Password: viper.GetString("OPERATOR_BOOTSTRAP_PASSWORD"),
+		}); err != nil {
+			log.Fatal(context.Background(), "could not bootstrap operator", zap.Error(err))
+		}
+	}
+
+	// Seal customers left in plaintext or under a retired key before
+	// serving, so every lookup by blind index sees them.
+	log.Info(context.Background(), "sealing personal data")
+	if err := module.PII.Reseal(context.Background()); err != nil {
+		log.Fatal(context.Background(), "could not seal personal data", zap.Error(err))
+	}
+	go module.PII.StartWorker(context.Background())
+	go module.Customer.StartRetentionWorker(context.Background())
+	log.Info(context.Background(), "personal data sealed")
+
 	// Start Worker
 	log.Info(context.Background(), "initializing worker")
 	go module.PaymentIntent.StartWorker(context.Background())
+	go module.Webhook.StartWorker(context.Background())
 	log.Info(context.Background(), "worker initialized")
 
 	// Initiate Handler
 	log.Info(context.Background(), "initializing handler")
-	handler := InitHandler(module, log, viper.GetDuration("SERVER_TIMEOUT"))
+	handler := InitHandler(module, log, platformInstance.Token, viper.GetDuration("SERVER_TIMEOUT"))
 	log.Info(context.Background(), "handler initialized")
 
 	// Initiate Router
 	log.Info(context.Background(), "initializing server")
 	server := echo.New()
 	server.HideBanner = true
+	server.IPExtractor = foundation.InitIPExtractor(log)
 
 	// Middleware
 	server.Use(echomiddleware.Recover())
@@ -113,7 +139,7 @@ func Initiate() {
 
 	// Setup routes
 	log.Info(context.Background(), "initializing router")
-	InitRouter(server.Group("/api"), handler, log, platformInstance.Token,
+	InitRouter(server.Group("/api"), server.Group("/.well-known"), handler, log, platformInstance.Token,
 		persistence, platformInstance)
 	log.Info(context.Background(), "router initialized")
 
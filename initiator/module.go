@@ -3,30 +3,210 @@ package initiator
 import (
 	"pg/initiator/platform"
 	"pg/internal/module"
+	"pg/internal/module/audit"
+	"pg/internal/module/blocklist"
 	"pg/internal/module/company"
+	"pg/internal/module/currency"
+	"pg/internal/module/customer"
+	"pg/internal/module/dispute"
+	"pg/internal/module/kyc"
+	"pg/internal/module/limit"
+	"pg/internal/module/operator"
 	paymentintent "pg/internal/module/payment_intent"
+	"pg/internal/module/pii"
+	"pg/internal/module/risk"
+	"pg/internal/module/team"
+	"pg/internal/module/webhook"
 	"pg/platform/hlog"
+	"time"
+
+	"github.com/spf13/viper"
 )
 
 type ModuleLayer struct {
+	Audit         module.Audit
+	Blocklist     module.Blocklist
 	Company       module.Company
+	Currency      module.Currency
+	Customer      module.Customer
+	Dispute       module.Dispute
+	KYC           module.KYC
+	Limit         module.Limit
+	Operator      module.Operator
 	PaymentIntent module.PaymentIntent
+	PII           module.PII
+	Risk          module.Risk
+	Team          module.Team
+	Webhook       module.Webhook
 }
 
 func InitModule(pl PersistenceLayer, log hlog.Logger,
 	platform platform.Layer) ModuleLayer {
+	auditLog := audit.New(
+		pl.audit,
+		pl.company,
+		log.Named("audit-module"),
+		audit.Options{
+			MaxExportRows: viper.GetInt("AUDIT_MAX_EXPORT_ROWS"),
+		},
+	)
 	return ModuleLayer{
+		Audit: auditLog,
+		Blocklist: blocklist.New(
+			pl.blocklist,
+			pl.company,
+			pl.operator,
+			auditLog,
+			log.Named("blocklist-module"),
+		),
 		Company: company.New(
 			pl.company,
+			auditLog,
 			log.Named("company-module"),
-			platform.Token),
+			platform.Token,
+			platform.Notifier,
+			company.Options{
+				PasswordResetURL:     viper.GetString("PASSWORD_RESET_URL"),
+				EmailVerificationURL: viper.GetString("EMAIL_VERIFICATION_URL"),
+				VerificationCodeExpires: time.Duration(
+					viper.GetInt("VERIFICATION_CODE_EXPIRES")) * time.Minute,
+				MaxVerificationAttempts: viper.GetInt("VERIFICATION_MAX_ATTEMPTS"),
+				MFAIssuer:               viper.GetString("MFA_ISSUER"),
+				LoginMaxFailures:        viper.GetInt("LOGIN_MAX_FAILURES"),
+				LoginMaxFailuresPerIP:   viper.GetInt("LOGIN_MAX_FAILURES_PER_IP"),
+				LoginLockoutDuration: time.Duration(
+					viper.GetInt("LOGIN_LOCKOUT_DURATION")) * time.Minute,
+				LoginDelayAfter: viper.GetInt("LOGIN_DELAY_AFTER"),
+				LoginDelayBase: time.Duration(
+					viper.GetInt("LOGIN_DELAY_BASE_SECONDS")) * time.Second,
+				UnlockURL: viper.GetString("ACCOUNT_UNLOCK_URL"),
+			}),
+		Currency: currency.New(
+			pl.currency,
+			pl.company,
+			pl.operator,
+			auditLog,
+			log.Named("currency-module"),
+		),
+		Customer: customer.New(
+			pl.customer,
+			auditLog,
+			log.Named("customer-module"),
+			customer.Options{
+				RetentionPeriod: time.Duration(
+					viper.GetInt("CUSTOMER_RETENTION_DAYS")) * 24 * time.Hour,
+			},
+		),
+		Dispute: dispute.New(
+			pl.dispute,
+			pl.paymentIntent,
+			pl.company,
+			pl.operator,
+			platform.BlobStore,
+			auditLog,
+			log.Named("dispute-module"),
+			dispute.Options{
+				MaxEvidenceBytes: viper.GetInt64("DISPUTE_MAX_EVIDENCE_BYTES"),
+			},
+		),
+		KYC: kyc.New(
+			pl.kyc,
+			pl.company,
+			pl.operator,
+			platform.BlobStore,
+			auditLog,
+			log.Named("kyc-module"),
+			platform.Notifier,
+			kyc.Options{
+				MaxDocumentBytes: viper.GetInt64("KYC_MAX_DOCUMENT_BYTES"),
+			},
+		),
+		Limit: limit.New(
+			pl.limit,
+			pl.currency,
+			pl.company,
+			pl.operator,
+			auditLog,
+			log.Named("limit-module"),
+		),
+		Operator: operator.New(
+			pl.operator,
+			pl.company,
+			pl.paymentIntent,
+			auditLog,
+			log.Named("operator-module"),
+			platform.Token,
+			operator.Options{
+				LoginMaxFailures: viper.GetInt("LOGIN_MAX_FAILURES"),
+				LoginLockoutDuration: time.Duration(
+					viper.GetInt("LOGIN_LOCKOUT_DURATION")) * time.Minute,
+			},
+		),
 		PaymentIntent: paymentintent.New(
 			pl.paymentIntent,
 			log.Named("payment-intent-module"),
 			pl.company,
+			pl.customer,
+			pl.currency,
+			pl.blocklist,
+			platform.FX,
 			platform.HTTPClient,
 			platform.AMQP,
+			platform.Notifier,
 			pl.db,
+			paymentintent.Options{
+				ConfirmationCodeExpires: time.Duration(
+					viper.GetInt("PAYMENT_CONFIRMATION_CODE_EXPIRES")) * time.Minute,
+				MaxConfirmationAttempts: viper.GetInt("PAYMENT_CONFIRMATION_MAX_ATTEMPTS"),
+				MaxConfirmationSends:    viper.GetInt("PAYMENT_CONFIRMATION_MAX_SENDS"),
+				ConfirmationResendCooldown: time.Duration(
+					viper.GetInt("PAYMENT_CONFIRMATION_RESEND_COOLDOWN")) * time.Second,
+			},
+		),
+		PII: pii.New(
+			pl.pii,
+			pl.operator,
+			auditLog,
+			log.Named("pii-module"),
+			pii.Options{
+				DataKeyMaxAge: time.Duration(
+					viper.GetInt("PII_DATA_KEY_MAX_AGE_DAYS")) * 24 * time.Hour,
+				ResealInterval: time.Duration(
+					viper.GetInt("PII_RESEAL_INTERVAL_MINUTES")) * time.Minute,
+				BatchSize: viper.GetInt("PII_RESEAL_BATCH_SIZE"),
+			},
+		),
+		Risk: risk.New(
+			pl.risk,
+			pl.paymentIntent,
+			pl.company,
+			pl.operator,
+			auditLog,
+			platform.AMQP,
+			log.Named("risk-module"),
+		),
+		Team: team.New(
+			pl.team,
+			pl.company,
+			auditLog,
+			log.Named("team-module"),
+			platform.Token,
+			platform.Notifier,
+			team.Options{
+				InvitationURL: viper.GetString("TEAM_INVITATION_URL"),
+			},
+		),
+		Webhook: webhook.New(
+			pl.webhook,
+			pl.company,
+			platform.HTTPClient,
+			log.Named("webhook-module"),
+			webhook.Options{
+				Interval: time.Duration(
+					viper.GetInt("WEBHOOK_INTERVAL_SECONDS")) * time.Second,
+				BatchSize:   viper.GetInt("WEBHOOK_BATCH_SIZE"),
+				MaxAttempts: viper.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+			},
 		),
 	}
 }
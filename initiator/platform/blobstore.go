@@ -0,0 +1,26 @@
+package platform
+
+import (
+	"context"
+	"pg/platform/blobstore"
+	"pg/platform/hlog"
+
+	"go.uber.org/zap"
+)
+
+// InitBlobStore opens the store uploaded files are kept in. Local disk is
+// the only driver for now; other drivers plug in behind blobstore.Store.
+func InitBlobStore(driver, localPath string, log hlog.Logger) blobstore.Store {
+	switch driver {
+	case "", "local":
+		store, err := blobstore.NewLocal(localPath)
+		if err != nil {
+			log.Fatal(context.Background(), "unable to open local blob store", zap.Error(err),
+				zap.String("path", localPath))
+		}
+		return store
+	default:
+		log.Fatal(context.Background(), "unknown blob store driver", zap.String("driver", driver))
+		return nil
+	}
+}
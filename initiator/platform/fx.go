@@ -0,0 +1,27 @@
+package platform
+
+import (
+	"context"
+	"pg/platform/fx"
+	"pg/platform/hlog"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// InitFXProvider opens the exchange rates payment intents are settled at.
+// The rates file stands in for a market data feed; other sources plug in
+// behind fx.Provider. Without a file, companies can only settle in the
+// currency they price in.
+func InitFXProvider(ratesFile string, maxRateAge time.Duration, log hlog.Logger) fx.Provider {
+	if ratesFile == "" {
+		log.Warn(context.Background(), "no fx rates file configured, cross-currency settlement is disabled")
+		return nil
+	}
+	provider, err := fx.NewFile(ratesFile, maxRateAge)
+	if err != nil {
+		log.Fatal(context.Background(), "unable to open fx rates file", zap.Error(err),
+			zap.String("path", ratesFile))
+	}
+	return provider
+}
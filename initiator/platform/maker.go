@@ -1,10 +1,23 @@
 package platform
 
 import (
+	"context"
 	"pg/platform/hcrypto"
 	"pg/platform/hlog"
+
+	"go.uber.org/zap"
 )
 
+// InitFieldKeys loads the keys that seal customer personal data. The server
+// cannot read or write customers without them.
+func InitFieldKeys(config hcrypto.FieldKeys, log hlog.Logger) *hcrypto.FieldKeyring {
+	keys, err := hcrypto.NewFieldKeyring(config)
+	if err != nil {
+		log.Fatal(context.Background(), "invalid personal data keys", zap.Error(err))
+	}
+	return keys
+}
+
 func InitToken(tokenconfig hcrypto.TokenKey, log hlog.Logger) hcrypto.Maker {
 	return hcrypto.PasetoInit(tokenconfig, log.Named("token-platform"))
 }
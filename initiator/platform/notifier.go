@@ -0,0 +1,10 @@
+package platform
+
+import (
+	"pg/platform/hlog"
+	"pg/platform/notifier"
+)
+
+func InitNotifier(log hlog.Logger) notifier.Notifier {
+	return notifier.NewLogNotifier(log)
+}
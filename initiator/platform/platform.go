@@ -4,9 +4,13 @@ import (
 	"context"
 	"pg/initiator/foundation"
 	"pg/initiator/platform/amqp"
+	"pg/platform/blobstore"
+	"pg/platform/fx"
 	"pg/platform/hcrypto"
 	"pg/platform/hlog"
 	"pg/platform/httpclient"
+	"pg/platform/notifier"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -15,6 +19,14 @@ type Layer struct {
 	Token      hcrypto.Maker
 	HTTPClient httpclient.HTTPClient
 	AMQP       amqp.Client
+	Notifier   notifier.Notifier
+	// HMACClockSkew is how far a PG-HMAC request timestamp may be from the
+	// server clock.
+	HMACClockSkew time.Duration
+	BlobStore     blobstore.Store
+	FieldKeys     *hcrypto.FieldKeyring
+	// FX is nil when no rates are configured.
+	FX fx.Provider
 }
 
 func InitPlatform(log hlog.Logger, state foundation.State) Layer {
@@ -33,8 +45,14 @@ func InitPlatform(log hlog.Logger, state foundation.State) Layer {
 	}
 
 	return Layer{
-		Token:      InitToken(state.TokenConfig, log.Named("token")),
-		HTTPClient: httpclient.Init(state.HTTPConfig, log.Named("httpclient")),
-		AMQP:       amqpClient,
+		Token:         InitToken(state.TokenConfig, log.Named("token")),
+		HTTPClient:    httpclient.Init(state.HTTPConfig, log.Named("httpclient")),
+		AMQP:          amqpClient,
+		Notifier:      InitNotifier(log.Named("notifier")),
+		HMACClockSkew: state.HMACClockSkew,
+		BlobStore: InitBlobStore(state.BlobStoreDriver, state.BlobStoreLocalPath,
+			log.Named("blobstore")),
+		FieldKeys: InitFieldKeys(state.FieldKeys, log.Named("field-keys")),
+		FX:        InitFXProvider(state.FXRatesFile, state.FXMaxRateAge, log.Named("fx")),
 	}
 }
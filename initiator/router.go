@@ -4,8 +4,17 @@ import (
 	"pg/docs"
 	"pg/initiator/platform"
 	"pg/internal/glue/routing"
+	"pg/internal/glue/routing/audit"
+	"pg/internal/glue/routing/blocklist"
 	"pg/internal/glue/routing/company"
+	"pg/internal/glue/routing/currency"
+	"pg/internal/glue/routing/customer"
+	"pg/internal/glue/routing/dispute"
+	"pg/internal/glue/routing/kyc"
+	"pg/internal/glue/routing/operator"
 	paymentintent "pg/internal/glue/routing/payment_intent"
+	"pg/internal/glue/routing/team"
+	"pg/internal/glue/routing/wellknown"
 	"pg/internal/handler/middleware"
 	"pg/platform/hcrypto"
 	"pg/platform/hlog"
@@ -17,6 +26,7 @@ import (
 
 func InitRouter(
 	group *echo.Group,
+	wellKnownGroup *echo.Group,
 	handler HandlerLayer,
 	log hlog.Logger,
 	tokenMaket hcrypto.Maker,
@@ -26,7 +36,10 @@ func InitRouter(
 	md := middleware.InitAuthMiddleware(
 		log.Named("auth-middleware"),
 		tokenMaket,
-		storage.company)
+		storage.company,
+		storage.audit,
+		storage.operator,
+		platform.HMACClockSkew)
 	docs.SwaggerInfo.Schemes = viper.GetStringSlice("swagger.schemes")
 	docs.SwaggerInfo.Host = viper.GetString("swagger.host")
 	docs.SwaggerInfo.BasePath = "/api"
@@ -34,4 +47,13 @@ func InitRouter(
 	routing.TestRoute(group)
 	company.Route(group, md, handler.company)
 	paymentintent.Route(group, md, handler.paymentIntent)
+	customer.Route(group, md, handler.customer)
+	currency.Route(group, md, handler.currency)
+	team.Route(group, md, handler.team)
+	audit.Route(group, md, handler.audit)
+	blocklist.Route(group, md, handler.blocklist)
+	dispute.Route(group, md, handler.dispute)
+	kyc.Route(group, md, handler.kyc)
+	operator.Route(group, md, handler.operator)
+	wellknown.Route(wellKnownGroup, handler.wellKnown)
 }
@@ -3,8 +3,20 @@ package initiator
 import (
 	persistencedb "pg/internal/constant/persistenceDB"
 	"pg/internal/storage"
+	"pg/internal/storage/audit"
+	"pg/internal/storage/blocklist"
 	"pg/internal/storage/company"
+	"pg/internal/storage/currency"
+	"pg/internal/storage/customer"
+	"pg/internal/storage/dispute"
+	"pg/internal/storage/kyc"
+	"pg/internal/storage/limit"
+	"pg/internal/storage/operator"
 	paymentintent "pg/internal/storage/payment_intent"
+	"pg/internal/storage/pii"
+	"pg/internal/storage/risk"
+	"pg/internal/storage/team"
+	"pg/internal/storage/webhook"
 	"pg/platform/hlog"
 )
 
@@ -12,6 +24,18 @@ type PersistenceLayer struct {
 	db            persistencedb.PersistenceDB
 	company       storage.Company
 	paymentIntent storage.PaymentIntent
+	team          storage.Team
+	audit         storage.Audit
+	blocklist     storage.Blocklist
+	operator      storage.Operator
+	kyc           storage.KYC
+	customer      storage.Customer
+	pii           storage.PII
+	currency      storage.Currency
+	limit         storage.Limit
+	risk          storage.Risk
+	dispute       storage.Dispute
+	webhook       storage.Webhook
 }
 
 func InitPersistence(db persistencedb.PersistenceDB, log hlog.Logger) PersistenceLayer {
@@ -19,5 +43,17 @@ func InitPersistence(db persistencedb.PersistenceDB, log hlog.Logger) Persistenc
 		db:            db,
 		company:       company.NewCompanycePersistance(db, log.Named("company-persistence")),
 		paymentIntent: paymentintent.NewPaymentIntentPersistance(db, log.Named("payment-intent-persistence")),
+		team:          team.NewTeamPersistance(db, log.Named("team-persistence")),
+		audit:         audit.NewAuditPersistance(db, log.Named("audit-persistence")),
+		blocklist:     blocklist.NewBlocklistPersistance(db, log.Named("blocklist-persistence")),
+		operator:      operator.NewOperatorPersistance(db, log.Named("operator-persistence")),
+		kyc:           kyc.NewKYCPersistance(db, log.Named("kyc-persistence")),
+		customer:      customer.NewCustomerPersistance(db, log.Named("customer-persistence")),
+		pii:           pii.NewPIIPersistance(db, log.Named("pii-persistence")),
+		currency:      currency.NewCurrencyPersistance(db, log.Named("currency-persistence")),
+		limit:         limit.NewLimitPersistance(db, log.Named("limit-persistence")),
+		risk:          risk.NewRiskPersistance(db, log.Named("risk-persistence")),
+		dispute:       dispute.NewDisputePersistance(db, log.Named("dispute-persistence")),
+		webhook:       webhook.NewWebhookPersistance(db, log.Named("webhook-persistence")),
 	}
 }
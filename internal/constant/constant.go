@@ -4,11 +4,77 @@ type ContextKey any
 type Role string
 
 const (
-	Admin    Role = "ADMIN"
-	HR       Role = "HR"
-	Employee Role = "EMPLOYEE"
+	Owner     Role = "OWNER"
+	Admin     Role = "ADMIN"
+	Developer Role = "DEVELOPER"
+	Finance   Role = "FINANCE"
+	Support   Role = "SUPPORT"
 )
 
+// DefaultMaxUploadBytes is the largest uploaded file accepted when no
+// limit is configured.
+const DefaultMaxUploadBytes = 10 << 20
+
+type Permission string
+
+const (
+	PermissionGenerateSecretToken Permission = "SECRET_TOKEN_GENERATE"
+	PermissionViewPayments        Permission = "PAYMENT_VIEW"
+	PermissionRefundPayments      Permission = "PAYMENT_REFUND"
+	PermissionViewTeam            Permission = "TEAM_VIEW"
+	PermissionManageTeam          Permission = "TEAM_MANAGE"
+	PermissionManageCompany       Permission = "COMPANY_MANAGE"
+	PermissionViewAuditLog        Permission = "AUDIT_LOG_VIEW"
+	PermissionManageDisputes      Permission = "DISPUTE_MANAGE"
+)
+
+// RolePermissions lists what each company user role is allowed to do.
+// The owner holds every permission except refunds, which only finance may
+// issue.
+var RolePermissions = map[Role][]Permission{
+	Owner: {
+		PermissionGenerateSecretToken,
+		PermissionViewPayments,
+		PermissionViewTeam,
+		PermissionManageTeam,
+		PermissionManageCompany,
+		PermissionViewAuditLog,
+		PermissionManageDisputes,
+	},
+	Admin: {
+		PermissionGenerateSecretToken,
+		PermissionViewPayments,
+		PermissionViewTeam,
+		PermissionManageTeam,
+		PermissionManageCompany,
+		PermissionViewAuditLog,
+		PermissionManageDisputes,
+	},
+	Developer: {
+		PermissionGenerateSecretToken,
+		PermissionViewPayments,
+	},
+	Finance: {
+		PermissionViewPayments,
+		PermissionRefundPayments,
+		PermissionManageDisputes,
+	},
+	Support: {
+		PermissionViewPayments,
+		PermissionViewTeam,
+	},
+}
+
+// HasPermission reports whether the role is granted the given permission.
+func (r Role) HasPermission(permission Permission) bool {
+	for _, p := range RolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
 type Status string
 
 const (
@@ -20,10 +86,43 @@ const (
 	No       Status = "NO"
 	Success  Status = "SUCCESS"
 	Failed   Status = "FAILED"
+	Accepted Status = "ACCEPTED"
+	Revoked  Status = "REVOKED"
+	Verified Status = "VERIFIED"
+	// Suspended companies are refused by the merchant API until an
+	// operator reactivates them.
+	Suspended Status = "SUSPENDED"
+	// A company is ONBOARDING until it submits its KYC documents, then
+	// UNDER_REVIEW until an operator makes it ACTIVE or REJECTED.
+	Onboarding  Status = "ONBOARDING"
+	UnderReview Status = "UNDER_REVIEW"
+	Rejected    Status = "REJECTED"
+	// Payment intents the risk rules hold are in REVIEW until an operator
+	// approves them into PENDING or rejects them into BLOCKED. Intents the
+	// rules block go to BLOCKED directly.
+	Review  Status = "REVIEW"
+	Blocked Status = "BLOCKED"
+	// Payment intents created with require_confirmation wait in
+	// REQUIRES_CONFIRMATION until the payer enters the code sent to them by
+	// SMS, then move to PENDING.
+	RequiresConfirmation Status = "REQUIRES_CONFIRMATION"
+	// Disputes wait in NEEDS_RESPONSE for the merchant's evidence, then in
+	// UNDER_REVIEW until an operator closes them as WON or LOST.
+	NeedsResponse Status = "NEEDS_RESPONSE"
+	Won           Status = "WON"
+	Lost          Status = "LOST"
+
+	PendingVerification Status = "PENDING_VERIFICATION"
 )
 const (
 	AuthorizationHeaderkey  = "Authorization"
 	AuthorizationTypeBearer = "Bearer"
+	AuthorizationTypeHMAC   = "PG-HMAC"
+	HMACTimestampHeader     = "X-PG-Timestamp"
+	HMACNonceHeader         = "X-PG-Nonce"
+	WebhookEventIDHeader    = "X-PG-Event-ID"
+	WebhookEventTypeHeader  = "X-PG-Event-Type"
+	WebhookSignatureHeader  = "X-PG-Signature"
 	AuthorizationPayloadKey = "authorization_payload"
 	REQUESTTIME             = "2006-01-02 15:04:05"
 )
@@ -46,6 +145,18 @@ const (
 	VerificationTokenType TokenType = "VERIFICATION_TOKEN"
 	InviteTokenType       TokenType = "INVITE_TOKEN"
 	SecretToken           TokenType = "SECRET_TOKEN"
+	MFAChallengeToken     TokenType = "MFA_CHALLENGE_TOKEN"
+	UnlockAccountToken    TokenType = "UNLOCK_ACCOUNT_TOKEN"
+	OperatorAccessToken   TokenType = "OPERATOR_ACCESS_TOKEN"
+)
+
+// Mode says whether an API key moves real money. Test keys only see test
+// data and are always served by the simulated processor.
+type Mode string
+
+const (
+	ModeLive Mode = "live"
+	ModeTest Mode = "test"
 )
 
 type PaymentType string
@@ -62,3 +173,165 @@ const (
 	CurrencyUSD Currency = "USD"
 	CurrencyGBP Currency = "GBP"
 )
+
+// LimitRule names the transaction limit a payment intent broke.
+type LimitRule string
+
+const (
+	LimitMinAmount        LimitRule = "MIN_AMOUNT"
+	LimitMaxAmount        LimitRule = "MAX_AMOUNT"
+	LimitDailyTotal       LimitRule = "DAILY_TOTAL"
+	LimitMonthlyTotal     LimitRule = "MONTHLY_TOTAL"
+	LimitCustomerVelocity LimitRule = "CUSTOMER_VELOCITY"
+)
+
+// RiskOutcome is what the risk rules decided for a new payment intent.
+type RiskOutcome string
+
+const (
+	RiskAllow  RiskOutcome = "ALLOW"
+	RiskReview RiskOutcome = "REVIEW"
+	RiskBlock  RiskOutcome = "BLOCK"
+)
+
+// BlocklistType is what a blocklist entry matches a new payment intent's
+// customer on.
+type BlocklistType string
+
+const (
+	BlocklistPhone       BlocklistType = "PHONE"
+	BlocklistEmailDomain BlocklistType = "EMAIL_DOMAIN"
+	BlocklistIP          BlocklistType = "IP"
+)
+
+// RiskDecision is an operator's decision on a payment intent held for
+// review.
+type RiskDecision string
+
+const (
+	RiskApprove RiskDecision = "APPROVE"
+	RiskReject  RiskDecision = "REJECT"
+)
+
+// DisputeReason is why the payer disputed a payment.
+type DisputeReason string
+
+const (
+	DisputeFraudulent          DisputeReason = "FRAUDULENT"
+	DisputeDuplicate           DisputeReason = "DUPLICATE"
+	DisputeProductNotReceived  DisputeReason = "PRODUCT_NOT_RECEIVED"
+	DisputeProductUnacceptable DisputeReason = "PRODUCT_UNACCEPTABLE"
+	DisputeUnrecognized        DisputeReason = "UNRECOGNIZED"
+	DisputeGeneral             DisputeReason = "GENERAL"
+)
+
+// LedgerEntryType is what moved a company's balance.
+type LedgerEntryType string
+
+const (
+	LedgerPayment         LedgerEntryType = "PAYMENT"
+	LedgerDisputeReversal LedgerEntryType = "DISPUTE_REVERSAL"
+	LedgerCorrection      LedgerEntryType = "CORRECTION"
+)
+
+// WebhookEventType names an event delivered to a company's callback_url.
+type WebhookEventType string
+
+const (
+	WebhookDisputeCreated WebhookEventType = "dispute.created"
+	WebhookDisputeWon     WebhookEventType = "dispute.won"
+	WebhookDisputeLost    WebhookEventType = "dispute.lost"
+)
+
+type KYCDocumentType string
+
+const (
+	KYCTradeLicense            KYCDocumentType = "TRADE_LICENSE"
+	KYCTINCertificate          KYCDocumentType = "TIN_CERTIFICATE"
+	KYCMemorandumOfAssociation KYCDocumentType = "MEMORANDUM_OF_ASSOCIATION"
+	KYCOwnerIdentification     KYCDocumentType = "OWNER_IDENTIFICATION"
+	KYCOther                   KYCDocumentType = "OTHER"
+)
+
+// RequiredKYCDocuments must all be uploaded before a company can submit
+// for review.
+var RequiredKYCDocuments = []KYCDocumentType{KYCTradeLicense, KYCTINCertificate}
+
+type AuditActorType string
+
+const (
+	AuditActorUser      AuditActorType = "USER"
+	AuditActorCompany   AuditActorType = "COMPANY"
+	AuditActorAnonymous AuditActorType = "ANONYMOUS"
+	AuditActorOperator  AuditActorType = "OPERATOR"
+	// AuditActorSystem is a scheduled job acting on its own.
+	AuditActorSystem AuditActorType = "SYSTEM"
+)
+
+type AuditAction string
+
+const (
+	AuditLoginSucceeded           AuditAction = "auth.login_succeeded"
+	AuditLoginFailed              AuditAction = "auth.login_failed"
+	AuditAccountLocked            AuditAction = "auth.account_locked"
+	AuditAccountUnlocked          AuditAction = "auth.account_unlocked"
+	AuditPasswordReset            AuditAction = "auth.password_reset"
+	AuditMFAEnabled               AuditAction = "mfa.enabled"
+	AuditMFADisabled              AuditAction = "mfa.disabled"
+	AuditRecoveryCodesRegenerated AuditAction = "mfa.recovery_codes_regenerated"
+	AuditSecretTokenGenerated     AuditAction = "credentials.secret_token_generated"
+	AuditHMACKeyCreated           AuditAction = "credentials.hmac_key_created"
+	AuditHMACKeyRevoked           AuditAction = "credentials.hmac_key_revoked"
+	AuditCompanyMFAPolicyUpdated  AuditAction = "company.mfa_policy_updated"
+	AuditCompanyProfileUpdated    AuditAction = "company.profile_updated"
+	AuditIPAllowlistBlocked       AuditAction = "ip_allowlist.request_blocked"
+	AuditIPAllowlistCreated       AuditAction = "ip_allowlist.entry_created"
+	AuditIPAllowlistUpdated       AuditAction = "ip_allowlist.entry_updated"
+	AuditIPAllowlistDeleted       AuditAction = "ip_allowlist.entry_deleted"
+	AuditTeamInvitationCreated    AuditAction = "team.invitation_created"
+	AuditTeamInvitationAccepted   AuditAction = "team.invitation_accepted"
+	AuditTeamMemberRoleChanged    AuditAction = "team.member_role_changed"
+	AuditTeamMemberStatusChanged  AuditAction = "team.member_status_changed"
+	AuditOperatorLoginSucceeded   AuditAction = "operator.login_succeeded"
+	AuditOperatorLoginFailed      AuditAction = "operator.login_failed"
+	AuditOperatorCreated          AuditAction = "operator.created"
+	AuditCompanySuspended         AuditAction = "company.suspended"
+	AuditCompanyReactivated       AuditAction = "company.reactivated"
+	AuditPaymentIntentCorrected   AuditAction = "payment_intent.status_corrected"
+	AuditKYCDocumentUploaded      AuditAction = "kyc.document_uploaded"
+	AuditKYCDocumentDeleted       AuditAction = "kyc.document_deleted"
+	AuditKYCSubmitted             AuditAction = "kyc.submitted"
+	AuditKYCApproved              AuditAction = "kyc.approved"
+	AuditKYCRejected              AuditAction = "kyc.rejected"
+	AuditPIIDataKeyRotated        AuditAction = "pii.data_key_rotated"
+	AuditCustomerErased           AuditAction = "customer.erased"
+	AuditCompanyCurrencyUpdated   AuditAction = "company.currency_updated"
+	AuditCompanyFXSettingsUpdated AuditAction = "company.fx_settings_updated"
+	AuditCompanyLimitUpdated      AuditAction = "company.limit_updated"
+	AuditCompanyLimitDeleted      AuditAction = "company.limit_deleted"
+	AuditRiskRuleCreated          AuditAction = "risk.rule_created"
+	AuditRiskRuleUpdated          AuditAction = "risk.rule_updated"
+	AuditRiskRuleDeleted          AuditAction = "risk.rule_deleted"
+	AuditRiskSettingsUpdated      AuditAction = "risk.settings_updated"
+	AuditRiskReviewApproved       AuditAction = "risk.review_approved"
+	AuditRiskReviewRejected       AuditAction = "risk.review_rejected"
+	AuditBlocklistEntryCreated    AuditAction = "blocklist.entry_created"
+	AuditBlocklistEntryUpdated    AuditAction = "blocklist.entry_updated"
+	AuditBlocklistEntryDeleted    AuditAction = "blocklist.entry_deleted"
+	AuditDisputeCreated           AuditAction = "dispute.created"
+	AuditDisputeEvidenceUpdated   AuditAction = "dispute.evidence_updated"
+	AuditDisputeEvidenceUploaded  AuditAction = "dispute.evidence_uploaded"
+	AuditDisputeSubmitted         AuditAction = "dispute.submitted"
+	AuditDisputeResolved          AuditAction = "dispute.resolved"
+)
+
+// ErasureReason says why a customer's personal data was erased.
+type ErasureReason string
+
+const (
+	// ErasureRequested is an erasure asked for through the API.
+	ErasureRequested ErasureReason = "REQUESTED"
+	// ErasureRetention is an erasure by the retention job after the
+	// customer was inactive for the retention period.
+	ErasureRetention ErasureReason = "RETENTION"
+)
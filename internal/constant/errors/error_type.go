@@ -19,8 +19,18 @@ var (
 	Unauthenticated  = errorx.NewNamespace("user authentication failed")
 	unauthorized     = errorx.NewNamespace("unauthorized").ApplyModifiers(errorx.TypeModifierOmitStackTrace)
 	redisServerError = errorx.NewNamespace("redis service error")
+	limitExceeded    = errorx.NewNamespace("limit exceeded").ApplyModifiers(errorx.TypeModifierOmitStackTrace)
+	blocked          = errorx.NewNamespace("blocked").ApplyModifiers(errorx.TypeModifierOmitStackTrace)
 )
 
+// PropertyLimit carries the dto.LimitViolation of an ErrLimitExceeded, which
+// is returned to the caller as the error's limit detail.
+var PropertyLimit = errorx.RegisterPrintableProperty("limit")
+
+// PropertyBlocked carries the dto.BlocklistMatch of an ErrBlocked, which is
+// returned to the caller as the error's blocked detail.
+var PropertyBlocked = errorx.RegisterPrintableProperty("blocked")
+
 var (
 	ErrUnableToUploadFile       = errorx.NewType(databaseError, "unable to upload file")
 	ErrDrawFailed               = errorx.NewType(serverError, "draw failed")
@@ -49,6 +59,18 @@ var (
 	ErrReadError                   = errorx.NewType(redisServerError, "redis read message error")
 	ErrRedisPubSubBadEvent         = errorx.NewType(redisServerError, "redis read message error")
 	ErrUnsupportedPublicKeyFormat  = errorx.NewType(invalidInput, "unsupported public key format")
+	ErrTooManyRequests             = errorx.NewType(unauthorized, "too many requests")
+
+	ErrInvalidCredentials = errorx.NewType(Unauthenticated, "invalid credentials").
+				ApplyModifiers(errorx.TypeModifierOmitStackTrace)
+
+	// ErrLimitExceeded refuses a payment intent that would break one of the
+	// company's transaction limits.
+	ErrLimitExceeded = errorx.NewType(limitExceeded, "transaction limit exceeded")
+
+	// ErrBlocked refuses a payment intent whose customer is on the
+	// blocklist.
+	ErrBlocked = errorx.NewType(blocked, "customer is blocklisted")
 )
 
 var ErrorMap = map[*errorx.Type]int{
@@ -77,4 +99,8 @@ var ErrorMap = map[*errorx.Type]int{
 	ErrInvalidAccessToken:          http.StatusUnauthorized,
 	ErrAuthError:                   http.StatusUnauthorized,
 	ErrUnsupportedPublicKeyFormat:  http.StatusBadRequest,
+	ErrInvalidCredentials:          http.StatusUnauthorized,
+	ErrTooManyRequests:             http.StatusTooManyRequests,
+	ErrLimitExceeded:               http.StatusUnprocessableEntity,
+	ErrBlocked:                     http.StatusForbidden,
 }
@@ -1,6 +1,9 @@
 package sqlcerr
 
 import (
+	"errors"
+
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/lib/pq"
 )
@@ -9,8 +12,13 @@ var (
 	ErrNoRows = pgx.ErrNoRows
 )
 
+// Is reports whether err is target. A nil err is never an error of target,
+// so callers may check it before checking err for nil.
 func Is(err, target error) bool {
-	return err.Error() == target.Error()
+	if err == nil || target == nil {
+		return err == target
+	}
+	return errors.Is(err, target) || err.Error() == target.Error()
 }
 
 func IsDuplicate(err error) bool {
@@ -19,6 +27,10 @@ func IsDuplicate(err error) bool {
 		if ok {
 			return duplicateError.Code == "23505"
 		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			return pgErr.Code == "23505"
+		}
 	}
 	return false
 }
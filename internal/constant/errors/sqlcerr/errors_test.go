@@ -0,0 +1,26 @@
+package sqlcerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIs(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"no rows", ErrNoRows, true},
+		{"wrapped no rows", fmt.Errorf("get limit: %w", ErrNoRows), true},
+		{"same message", errors.New(ErrNoRows.Error()), true},
+		{"other error", errors.New("connection reset"), false},
+	}
+	for _, c := range cases {
+		if got := Is(c.err, ErrNoRows); got != c.want {
+			t.Errorf("%s: Is(%v, ErrNoRows) = %v, want %v", c.name, c.err, got, c.want)
+		}
+	}
+}
@@ -13,6 +13,29 @@ import (
 	"github.com/google/uuid"
 )
 
+const countCompanies = `-- name: CountCompanies :one
+SELECT COUNT(*)
+FROM companies
+WHERE deleted_at IS NULL
+  AND ($1::TEXT IS NULL OR status = $1)
+  AND ($2::TEXT IS NULL
+    OR name ILIKE '%' || $2 || '%'
+    OR email ILIKE '%' || $2 || '%'
+    OR registration_number ILIKE '%' || $2 || '%')
+`
+
+type CountCompaniesParams struct {
+	Status sql.NullString
+	Search sql.NullString
+}
+
+func (q *Queries) CountCompanies(ctx context.Context, arg CountCompaniesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countCompanies, arg.Status, arg.Search)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCompany = `-- name: CreateCompany :one
 INSERT INTO companies (
   name,
@@ -31,7 +54,7 @@ INSERT INTO companies (
 ) VALUES (
   $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 )
-RETURNING id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, created_at, updated_at
+RETURNING id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, mfa_required, created_at, updated_at
 `
 
 type CreateCompanyParams struct {
@@ -66,6 +89,7 @@ type CreateCompanyRow struct {
 	Website            sql.NullString
 	CallbackUrl        sql.NullString
 	ReturnUrl          sql.NullString
+	MfaRequired        bool
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
@@ -103,6 +127,7 @@ func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (C
 		&i.Website,
 		&i.CallbackUrl,
 		&i.ReturnUrl,
+		&i.MfaRequired,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -110,18 +135,19 @@ func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (C
 }
 
 const createCompanyToken = `-- name: CreateCompanyToken :one
-INSERT INTO company_tokens (token_id, company_id) 
-VALUES ($1, $2) 
-RETURNING id, token_id, company_id, status, created_at, updated_at, deleted_at
+INSERT INTO company_tokens (token_id, company_id, livemode) 
+VALUES ($1, $2, $3) 
+RETURNING id, token_id, company_id, status, created_at, updated_at, deleted_at, livemode
 `
 
 type CreateCompanyTokenParams struct {
 	TokenID   uuid.UUID
 	CompanyID uuid.UUID
+	Livemode  bool
 }
 
 func (q *Queries) CreateCompanyToken(ctx context.Context, arg CreateCompanyTokenParams) (CompanyToken, error) {
-	row := q.db.QueryRow(ctx, createCompanyToken, arg.TokenID, arg.CompanyID)
+	row := q.db.QueryRow(ctx, createCompanyToken, arg.TokenID, arg.CompanyID, arg.Livemode)
 	var i CompanyToken
 	err := row.Scan(
 		&i.ID,
@@ -131,18 +157,19 @@ func (q *Queries) CreateCompanyToken(ctx context.Context, arg CreateCompanyToken
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Livemode,
 	)
 	return i, err
 }
 
-const getActiveCompanyTokenByID = `-- name: GetActiveCompanyTokenByID :one
-SELECT id, token_id, company_id, status, created_at, updated_at, deleted_at 
+const getActiveCompanyTokenByTokenID = `-- name: GetActiveCompanyTokenByTokenID :one
+SELECT id, token_id, company_id, status, created_at, updated_at, deleted_at, livemode 
 FROM company_tokens
-WHERE company_id = $1 AND status = 'ACTIVE' AND deleted_at IS NULL
+WHERE token_id = $1 AND status = 'ACTIVE' AND deleted_at IS NULL
 `
 
-func (q *Queries) GetActiveCompanyTokenByID(ctx context.Context, companyID uuid.UUID) (CompanyToken, error) {
-	row := q.db.QueryRow(ctx, getActiveCompanyTokenByID, companyID)
+func (q *Queries) GetActiveCompanyTokenByTokenID(ctx context.Context, tokenID uuid.UUID) (CompanyToken, error) {
+	row := q.db.QueryRow(ctx, getActiveCompanyTokenByTokenID, tokenID)
 	var i CompanyToken
 	err := row.Scan(
 		&i.ID,
@@ -152,12 +179,13 @@ func (q *Queries) GetActiveCompanyTokenByID(ctx context.Context, companyID uuid.
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Livemode,
 	)
 	return i, err
 }
 
 const getCompanyByID = `-- name: GetCompanyByID :one
-SELECT id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, created_at, updated_at
+SELECT id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, mfa_required, created_at, updated_at
 FROM companies
 WHERE id = $1 AND deleted_at IS NULL
 `
@@ -178,6 +206,7 @@ type GetCompanyByIDRow struct {
 	Website            sql.NullString
 	CallbackUrl        sql.NullString
 	ReturnUrl          sql.NullString
+	MfaRequired        bool
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
@@ -201,6 +230,7 @@ func (q *Queries) GetCompanyByID(ctx context.Context, id uuid.UUID) (GetCompanyB
 		&i.Website,
 		&i.CallbackUrl,
 		&i.ReturnUrl,
+		&i.MfaRequired,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -211,11 +241,222 @@ const inActiveCompanyToken = `-- name: InActiveCompanyToken :exec
 UPDATE company_tokens ct 
 SET status = 'INACTIVE'
 WHERE ct.company_id = $1 
+AND ct.livemode = $2
 AND ct.deleted_at IS NULL 
 AND ct.status = 'ACTIVE'
 `
 
-func (q *Queries) InActiveCompanyToken(ctx context.Context, companyID uuid.UUID) error {
-	_, err := q.db.Exec(ctx, inActiveCompanyToken, companyID)
+type InActiveCompanyTokenParams struct {
+	CompanyID uuid.UUID
+	Livemode  bool
+}
+
+func (q *Queries) InActiveCompanyToken(ctx context.Context, arg InActiveCompanyTokenParams) error {
+	_, err := q.db.Exec(ctx, inActiveCompanyToken, arg.CompanyID, arg.Livemode)
 	return err
 }
+
+const listCompanies = `-- name: ListCompanies :many
+SELECT id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, mfa_required, created_at, updated_at
+FROM companies
+WHERE deleted_at IS NULL
+  AND ($1::TEXT IS NULL OR status = $1)
+  AND ($2::TEXT IS NULL
+    OR name ILIKE '%' || $2 || '%'
+    OR email ILIKE '%' || $2 || '%'
+    OR registration_number ILIKE '%' || $2 || '%')
+ORDER BY created_at DESC, id
+LIMIT $4 OFFSET $3
+`
+
+type ListCompaniesParams struct {
+	Status     sql.NullString
+	Search     sql.NullString
+	PageOffset int32
+	PageLimit  int32
+}
+
+type ListCompaniesRow struct {
+	ID                 uuid.UUID
+	Name               string
+	RegistrationNumber string
+	AddressStreet      sql.NullString
+	AddressCity        sql.NullString
+	AddressState       sql.NullString
+	AddressPostalCode  sql.NullString
+	AddressCountry     sql.NullString
+	PrimaryPhone       sql.NullString
+	SecondaryPhone     sql.NullString
+	Email              sql.NullString
+	Status             string
+	Website            sql.NullString
+	CallbackUrl        sql.NullString
+	ReturnUrl          sql.NullString
+	MfaRequired        bool
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func (q *Queries) ListCompanies(ctx context.Context, arg ListCompaniesParams) ([]ListCompaniesRow, error) {
+	rows, err := q.db.Query(ctx, listCompanies,
+		arg.Status,
+		arg.Search,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCompaniesRow
+	for rows.Next() {
+		var i ListCompaniesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.RegistrationNumber,
+			&i.AddressStreet,
+			&i.AddressCity,
+			&i.AddressState,
+			&i.AddressPostalCode,
+			&i.AddressCountry,
+			&i.PrimaryPhone,
+			&i.SecondaryPhone,
+			&i.Email,
+			&i.Status,
+			&i.Website,
+			&i.CallbackUrl,
+			&i.ReturnUrl,
+			&i.MfaRequired,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reactivateCompany = `-- name: ReactivateCompany :one
+UPDATE companies
+SET status = COALESCE(suspended_from_status, 'ACTIVE'),
+    suspended_from_status = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'SUSPENDED' AND deleted_at IS NULL
+RETURNING status
+`
+
+func (q *Queries) ReactivateCompany(ctx context.Context, id uuid.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, reactivateCompany, id)
+	var status string
+	err := row.Scan(&status)
+	return status, err
+}
+
+const suspendCompany = `-- name: SuspendCompany :execrows
+UPDATE companies
+SET status = 'SUSPENDED', suspended_from_status = status, updated_at = NOW()
+WHERE id = $1 AND status <> 'SUSPENDED' AND deleted_at IS NULL
+`
+
+func (q *Queries) SuspendCompany(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, suspendCompany, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateCompanyProfile = `-- name: UpdateCompanyProfile :one
+UPDATE companies
+SET address_street = COALESCE($1, address_street),
+    address_city = COALESCE($2, address_city),
+    address_state = COALESCE($3, address_state),
+    address_postal_code = COALESCE($4, address_postal_code),
+    address_country = COALESCE($5, address_country),
+    primary_phone = COALESCE($6, primary_phone),
+    secondary_phone = COALESCE($7, secondary_phone),
+    website = COALESCE($8, website),
+    callback_url = COALESCE($9, callback_url),
+    return_url = COALESCE($10, return_url),
+    updated_at = NOW()
+WHERE id = $11 AND deleted_at IS NULL
+RETURNING id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, mfa_required, created_at, updated_at
+`
+
+type UpdateCompanyProfileParams struct {
+	AddressStreet     sql.NullString
+	AddressCity       sql.NullString
+	AddressState      sql.NullString
+	AddressPostalCode sql.NullString
+	AddressCountry    sql.NullString
+	PrimaryPhone      sql.NullString
+	SecondaryPhone    sql.NullString
+	Website           sql.NullString
+	CallbackUrl       sql.NullString
+	ReturnUrl         sql.NullString
+	ID                uuid.UUID
+}
+
+type UpdateCompanyProfileRow struct {
+	ID                 uuid.UUID
+	Name               string
+	RegistrationNumber string
+	AddressStreet      sql.NullString
+	AddressCity        sql.NullString
+	AddressState       sql.NullString
+	AddressPostalCode  sql.NullString
+	AddressCountry     sql.NullString
+	PrimaryPhone       sql.NullString
+	SecondaryPhone     sql.NullString
+	Email              sql.NullString
+	Status             string
+	Website            sql.NullString
+	CallbackUrl        sql.NullString
+	ReturnUrl          sql.NullString
+	MfaRequired        bool
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func (q *Queries) UpdateCompanyProfile(ctx context.Context, arg UpdateCompanyProfileParams) (UpdateCompanyProfileRow, error) {
+	row := q.db.QueryRow(ctx, updateCompanyProfile,
+		arg.AddressStreet,
+		arg.AddressCity,
+		arg.AddressState,
+		arg.AddressPostalCode,
+		arg.AddressCountry,
+		arg.PrimaryPhone,
+		arg.SecondaryPhone,
+		arg.Website,
+		arg.CallbackUrl,
+		arg.ReturnUrl,
+		arg.ID,
+	)
+	var i UpdateCompanyProfileRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.RegistrationNumber,
+		&i.AddressStreet,
+		&i.AddressCity,
+		&i.AddressState,
+		&i.AddressPostalCode,
+		&i.AddressCountry,
+		&i.PrimaryPhone,
+		&i.SecondaryPhone,
+		&i.Email,
+		&i.Status,
+		&i.Website,
+		&i.CallbackUrl,
+		&i.ReturnUrl,
+		&i.MfaRequired,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
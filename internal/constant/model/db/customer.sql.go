@@ -8,36 +8,711 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const countCustomerPaymentIntents = `-- name: CountCustomerPaymentIntents :one
+SELECT COUNT(*)::INT AS total
+FROM payment_intents
+WHERE customer_id = $1
+  AND company_id = $2
+  AND livemode = $3
+  AND deleted_at IS NULL
+`
+
+type CountCustomerPaymentIntentsParams struct {
+	CustomerID uuid.UUID
+	CompanyID  uuid.UUID
+	Livemode   bool
+}
+
+func (q *Queries) CountCustomerPaymentIntents(ctx context.Context, arg CountCustomerPaymentIntentsParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countCustomerPaymentIntents, arg.CustomerID, arg.CompanyID, arg.Livemode)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countCustomers = `-- name: CountCustomers :one
+SELECT COUNT(*)::INT AS total
+FROM customers
+WHERE company_id = $1
+  AND livemode = $2
+  AND deleted_at IS NULL
+  AND ($3::BYTEA IS NULL
+    OR phone_number_index = $3)
+`
+
+type CountCustomersParams struct {
+	CompanyID        uuid.UUID
+	Livemode         bool
+	PhoneNumberIndex []byte
+}
+
+func (q *Queries) CountCustomers(ctx context.Context, arg CountCustomersParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countCustomers, arg.CompanyID, arg.Livemode, arg.PhoneNumberIndex)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
 const createCustomer = `-- name: CreateCustomer :one
 INSERT INTO customers (
+  id,
   company_id,
-  full_name,
-  phone_number,
-  email
+  livemode,
+  data_key_id,
+  full_name_ciphertext,
+  phone_number_ciphertext,
+  email_ciphertext,
+  phone_number_index
 ) VALUES (
-  $1, $2, $3, $4
-) ON CONFLICT (company_id, phone_number) WHERE deleted_at IS NULL
-DO UPDATE SET email = excluded.email, full_name = excluded.full_name
-RETURNING id, company_id, full_name, phone_number, email, status, created_at, updated_at, deleted_at
+  $1, $2, $3, $4, $5, $6, $7, $8
+) ON CONFLICT (company_id, livemode, phone_number_index) WHERE deleted_at IS NULL
+DO NOTHING
+RETURNING id, company_id, full_name, phone_number, email, status, created_at, updated_at, deleted_at, livemode, data_key_id, full_name_ciphertext, phone_number_ciphertext, email_ciphertext, phone_number_index, erased_at, pii_bound_to_id
 `
 
 type CreateCustomerParams struct {
-	CompanyID   uuid.UUID
-	FullName    sql.NullString
-	PhoneNumber string
-	Email       sql.NullString
+	ID                    uuid.UUID
+	CompanyID             uuid.UUID
+	Livemode              bool
+	DataKeyID             uuid.NullUUID
+	FullNameCiphertext    []byte
+	PhoneNumberCiphertext []byte
+	EmailCiphertext       []byte
+	PhoneNumberIndex      []byte
 }
 
+// Inserts nothing, and returns no row, when the company already has a
+// customer with the phone number.
 func (q *Queries) CreateCustomer(ctx context.Context, arg CreateCustomerParams) (Customer, error) {
 	row := q.db.QueryRow(ctx, createCustomer,
+		arg.ID,
+		arg.CompanyID,
+		arg.Livemode,
+		arg.DataKeyID,
+		arg.FullNameCiphertext,
+		arg.PhoneNumberCiphertext,
+		arg.EmailCiphertext,
+		arg.PhoneNumberIndex,
+	)
+	var i Customer
+	err := row.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.FullName,
+		&i.PhoneNumber,
+		&i.Email,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Livemode,
+		&i.DataKeyID,
+		&i.FullNameCiphertext,
+		&i.PhoneNumberCiphertext,
+		&i.EmailCiphertext,
+		&i.PhoneNumberIndex,
+		&i.ErasedAt,
+		&i.PiiBoundToID,
+	)
+	return i, err
+}
+
+const eraseCustomer = `-- name: EraseCustomer :one
+UPDATE customers
+SET full_name = NULL,
+    phone_number = NULL,
+    email = NULL,
+    data_key_id = NULL,
+    full_name_ciphertext = NULL,
+    phone_number_ciphertext = NULL,
+    email_ciphertext = NULL,
+    phone_number_index = NULL,
+    erased_at = NOW(),
+    deleted_at = COALESCE(deleted_at, NOW()),
+    updated_at = NOW()
+WHERE customers.id = $1
+  AND customers.company_id = $2
+  AND customers.livemode = $3
+  AND customers.erased_at IS NULL
+  AND ($4::TIMESTAMPTZ IS NULL
+    OR (customers.updated_at < $4
+      AND NOT EXISTS (
+        SELECT 1 FROM payment_intents pi
+        WHERE pi.customer_id = customers.id AND pi.created_at >= $4)))
+RETURNING customers.id, customers.company_id, customers.livemode, customers.erased_at,
+    (SELECT COUNT(*) FROM payment_intents pi WHERE pi.customer_id = customers.id)::INT AS payment_intents
+`
+
+type EraseCustomerParams struct {
+	ID             uuid.UUID
+	CompanyID      uuid.UUID
+	Livemode       bool
+	InactiveBefore sql.NullTime
+}
+
+type EraseCustomerRow struct {
+	ID             uuid.UUID
+	CompanyID      uuid.UUID
+	Livemode       bool
+	ErasedAt       sql.NullTime
+	PaymentIntents int32
+}
+
+func (q *Queries) EraseCustomer(ctx context.Context, arg EraseCustomerParams) (EraseCustomerRow, error) {
+	row := q.db.QueryRow(ctx, eraseCustomer,
+		arg.ID,
+		arg.CompanyID,
+		arg.Livemode,
+		arg.InactiveBefore,
+	)
+	var i EraseCustomerRow
+	err := row.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.Livemode,
+		&i.ErasedAt,
+		&i.PaymentIntents,
+	)
+	return i, err
+}
+
+const getCustomerByID = `-- name: GetCustomerByID :one
+SELECT id, company_id, full_name, phone_number, email, status, created_at, updated_at, deleted_at, livemode, data_key_id, full_name_ciphertext, phone_number_ciphertext, email_ciphertext, phone_number_index, erased_at, pii_bound_to_id
+FROM customers
+WHERE id = $1 AND company_id = $2 AND livemode = $3 AND deleted_at IS NULL
+`
+
+type GetCustomerByIDParams struct {
+	ID        uuid.UUID
+	CompanyID uuid.UUID
+	Livemode  bool
+}
+
+func (q *Queries) GetCustomerByID(ctx context.Context, arg GetCustomerByIDParams) (Customer, error) {
+	row := q.db.QueryRow(ctx, getCustomerByID, arg.ID, arg.CompanyID, arg.Livemode)
+	var i Customer
+	err := row.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.FullName,
+		&i.PhoneNumber,
+		&i.Email,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Livemode,
+		&i.DataKeyID,
+		&i.FullNameCiphertext,
+		&i.PhoneNumberCiphertext,
+		&i.EmailCiphertext,
+		&i.PhoneNumberIndex,
+		&i.ErasedAt,
+		&i.PiiBoundToID,
+	)
+	return i, err
+}
+
+const getCustomerByIDForUpdate = `-- name: GetCustomerByIDForUpdate :one
+SELECT id, company_id, full_name, phone_number, email, status, created_at, updated_at, deleted_at, livemode, data_key_id, full_name_ciphertext, phone_number_ciphertext, email_ciphertext, phone_number_index, erased_at, pii_bound_to_id
+FROM customers
+WHERE id = $1 AND company_id = $2 AND livemode = $3 AND deleted_at IS NULL
+FOR UPDATE
+`
+
+type GetCustomerByIDForUpdateParams struct {
+	ID        uuid.UUID
+	CompanyID uuid.UUID
+	Livemode  bool
+}
+
+func (q *Queries) GetCustomerByIDForUpdate(ctx context.Context, arg GetCustomerByIDForUpdateParams) (Customer, error) {
+	row := q.db.QueryRow(ctx, getCustomerByIDForUpdate, arg.ID, arg.CompanyID, arg.Livemode)
+	var i Customer
+	err := row.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.FullName,
+		&i.PhoneNumber,
+		&i.Email,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Livemode,
+		&i.DataKeyID,
+		&i.FullNameCiphertext,
+		&i.PhoneNumberCiphertext,
+		&i.EmailCiphertext,
+		&i.PhoneNumberIndex,
+		&i.ErasedAt,
+		&i.PiiBoundToID,
+	)
+	return i, err
+}
+
+const getCustomerByPhoneNumberIndexForUpdate = `-- name: GetCustomerByPhoneNumberIndexForUpdate :one
+SELECT id, company_id, full_name, phone_number, email, status, created_at, updated_at, deleted_at, livemode, data_key_id, full_name_ciphertext, phone_number_ciphertext, email_ciphertext, phone_number_index, erased_at, pii_bound_to_id
+FROM customers
+WHERE company_id = $1 AND livemode = $2 AND phone_number_index = $3 AND deleted_at IS NULL
+FOR UPDATE
+`
+
+type GetCustomerByPhoneNumberIndexForUpdateParams struct {
+	CompanyID        uuid.UUID
+	Livemode         bool
+	PhoneNumberIndex []byte
+}
+
+func (q *Queries) GetCustomerByPhoneNumberIndexForUpdate(ctx context.Context, arg GetCustomerByPhoneNumberIndexForUpdateParams) (Customer, error) {
+	row := q.db.QueryRow(ctx, getCustomerByPhoneNumberIndexForUpdate, arg.CompanyID, arg.Livemode, arg.PhoneNumberIndex)
+	var i Customer
+	err := row.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.FullName,
+		&i.PhoneNumber,
+		&i.Email,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Livemode,
+		&i.DataKeyID,
+		&i.FullNameCiphertext,
+		&i.PhoneNumberCiphertext,
+		&i.EmailCiphertext,
+		&i.PhoneNumberIndex,
+		&i.ErasedAt,
+		&i.PiiBoundToID,
+	)
+	return i, err
+}
+
+const getCustomerPhoneNumberIndex = `-- name: GetCustomerPhoneNumberIndex :one
+SELECT phone_number_index
+FROM customers
+WHERE id = $1 AND company_id = $2 AND livemode = $3 AND erased_at IS NULL
+FOR UPDATE
+`
+
+type GetCustomerPhoneNumberIndexParams struct {
+	ID        uuid.UUID
+	CompanyID uuid.UUID
+	Livemode  bool
+}
+
+func (q *Queries) GetCustomerPhoneNumberIndex(ctx context.Context, arg GetCustomerPhoneNumberIndexParams) ([]byte, error) {
+	row := q.db.QueryRow(ctx, getCustomerPhoneNumberIndex, arg.ID, arg.CompanyID, arg.Livemode)
+	var phone_number_index []byte
+	err := row.Scan(&phone_number_index)
+	return phone_number_index, err
+}
+
+const insertCustomer = `-- name: InsertCustomer :one
+INSERT INTO customers (
+  id,
+  company_id,
+  livemode,
+  data_key_id,
+  full_name_ciphertext,
+  phone_number_ciphertext,
+  email_ciphertext,
+  phone_number_index
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, company_id, full_name, phone_number, email, status, created_at, updated_at, deleted_at, livemode, data_key_id, full_name_ciphertext, phone_number_ciphertext, email_ciphertext, phone_number_index, erased_at, pii_bound_to_id
+`
+
+type InsertCustomerParams struct {
+	ID                    uuid.UUID
+	CompanyID             uuid.UUID
+	Livemode              bool
+	DataKeyID             uuid.NullUUID
+	FullNameCiphertext    []byte
+	PhoneNumberCiphertext []byte
+	EmailCiphertext       []byte
+	PhoneNumberIndex      []byte
+}
+
+func (q *Queries) InsertCustomer(ctx context.Context, arg InsertCustomerParams) (Customer, error) {
+	row := q.db.QueryRow(ctx, insertCustomer,
+		arg.ID,
+		arg.CompanyID,
+		arg.Livemode,
+		arg.DataKeyID,
+		arg.FullNameCiphertext,
+		arg.PhoneNumberCiphertext,
+		arg.EmailCiphertext,
+		arg.PhoneNumberIndex,
+	)
+	var i Customer
+	err := row.Scan(
+		&i.ID,
+		&i.CompanyID,
+		&i.FullName,
+		&i.PhoneNumber,
+		&i.Email,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Livemode,
+		&i.DataKeyID,
+		&i.FullNameCiphertext,
+		&i.PhoneNumberCiphertext,
+		&i.EmailCiphertext,
+		&i.PhoneNumberIndex,
+		&i.ErasedAt,
+		&i.PiiBoundToID,
+	)
+	return i, err
+}
+
+const listCustomerPaymentIntents = `-- name: ListCustomerPaymentIntents :many
+SELECT id, company_id, customer_id, payment_type, amount, currency, callback_url, return_url, description, extra, status, bill_ref_no, expire_at, created_at, updated_at, deleted_at, livemode, settlement_currency, settlement_amount, fx_mid_rate, fx_spread_bps, fx_rate, fx_rate_source, fx_rate_as_of, risk_score, risk_outcome, risk_rules, risk_signals, reviewed_by, reviewed_at, review_note, require_confirmation
+FROM payment_intents
+WHERE customer_id = $1
+  AND company_id = $2
+  AND livemode = $3
+  AND deleted_at IS NULL
+ORDER BY created_at DESC, id
+LIMIT $5 OFFSET $4
+`
+
+type ListCustomerPaymentIntentsParams struct {
+	CustomerID uuid.UUID
+	CompanyID  uuid.UUID
+	Livemode   bool
+	PageOffset int32
+	PageLimit  int32
+}
+
+func (q *Queries) ListCustomerPaymentIntents(ctx context.Context, arg ListCustomerPaymentIntentsParams) ([]PaymentIntent, error) {
+	rows, err := q.db.Query(ctx, listCustomerPaymentIntents,
+		arg.CustomerID,
+		arg.CompanyID,
+		arg.Livemode,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PaymentIntent
+	for rows.Next() {
+		var i PaymentIntent
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompanyID,
+			&i.CustomerID,
+			&i.PaymentType,
+			&i.Amount,
+			&i.Currency,
+			&i.CallbackUrl,
+			&i.ReturnUrl,
+			&i.Description,
+			&i.Extra,
+			&i.Status,
+			&i.BillRefNo,
+			&i.ExpireAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Livemode,
+			&i.SettlementCurrency,
+			&i.SettlementAmount,
+			&i.FxMidRate,
+			&i.FxSpreadBps,
+			&i.FxRate,
+			&i.FxRateSource,
+			&i.FxRateAsOf,
+			&i.RiskScore,
+			&i.RiskOutcome,
+			&i.RiskRules,
+			&i.RiskSignals,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.ReviewNote,
+			&i.RequireConfirmation,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomers = `-- name: ListCustomers :many
+SELECT id, company_id, full_name, phone_number, email, status, created_at, updated_at, deleted_at, livemode, data_key_id, full_name_ciphertext, phone_number_ciphertext, email_ciphertext, phone_number_index, erased_at, pii_bound_to_id
+FROM customers
+WHERE company_id = $1
+  AND livemode = $2
+  AND deleted_at IS NULL
+  AND ($3::BYTEA IS NULL
+    OR phone_number_index = $3)
+ORDER BY created_at DESC, id
+LIMIT $5 OFFSET $4
+`
+
+type ListCustomersParams struct {
+	CompanyID        uuid.UUID
+	Livemode         bool
+	PhoneNumberIndex []byte
+	PageOffset       int32
+	PageLimit        int32
+}
+
+func (q *Queries) ListCustomers(ctx context.Context, arg ListCustomersParams) ([]Customer, error) {
+	rows, err := q.db.Query(ctx, listCustomers,
+		arg.CompanyID,
+		arg.Livemode,
+		arg.PhoneNumberIndex,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Customer
+	for rows.Next() {
+		var i Customer
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompanyID,
+			&i.FullName,
+			&i.PhoneNumber,
+			&i.Email,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Livemode,
+			&i.DataKeyID,
+			&i.FullNameCiphertext,
+			&i.PhoneNumberCiphertext,
+			&i.EmailCiphertext,
+			&i.PhoneNumberIndex,
+			&i.ErasedAt,
+			&i.PiiBoundToID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomersToReseal = `-- name: ListCustomersToReseal :many
+SELECT id, company_id, full_name, phone_number, email, status, created_at, updated_at, deleted_at, livemode, data_key_id, full_name_ciphertext, phone_number_ciphertext, email_ciphertext, phone_number_index, erased_at, pii_bound_to_id
+FROM customers
+WHERE (data_key_id IS DISTINCT FROM $1 OR NOT pii_bound_to_id)
+  AND erased_at IS NULL
+ORDER BY id
+LIMIT $2
+`
+
+type ListCustomersToResealParams struct {
+	DataKeyID uuid.NullUUID
+	BatchSize int32
+}
+
+func (q *Queries) ListCustomersToReseal(ctx context.Context, arg ListCustomersToResealParams) ([]Customer, error) {
+	rows, err := q.db.Query(ctx, listCustomersToReseal, arg.DataKeyID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Customer
+	for rows.Next() {
+		var i Customer
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompanyID,
+			&i.FullName,
+			&i.PhoneNumber,
+			&i.Email,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Livemode,
+			&i.DataKeyID,
+			&i.FullNameCiphertext,
+			&i.PhoneNumberCiphertext,
+			&i.EmailCiphertext,
+			&i.PhoneNumberIndex,
+			&i.ErasedAt,
+			&i.PiiBoundToID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInactiveCustomers = `-- name: ListInactiveCustomers :many
+SELECT cu.id, cu.company_id, cu.livemode
+FROM customers cu
+WHERE cu.erased_at IS NULL
+  AND cu.updated_at < $1
+  AND NOT EXISTS (
+    SELECT 1 FROM payment_intents pi
+    WHERE pi.customer_id = cu.id AND pi.created_at >= $1)
+ORDER BY cu.updated_at, cu.id
+LIMIT $2
+`
+
+type ListInactiveCustomersParams struct {
+	InactiveBefore time.Time
+	BatchSize      int32
+}
+
+type ListInactiveCustomersRow struct {
+	ID        uuid.UUID
+	CompanyID uuid.UUID
+	Livemode  bool
+}
+
+func (q *Queries) ListInactiveCustomers(ctx context.Context, arg ListInactiveCustomersParams) ([]ListInactiveCustomersRow, error) {
+	rows, err := q.db.Query(ctx, listInactiveCustomers, arg.InactiveBefore, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListInactiveCustomersRow
+	for rows.Next() {
+		var i ListInactiveCustomersRow
+		if err := rows.Scan(&i.ID, &i.CompanyID, &i.Livemode); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resealCustomer = `-- name: ResealCustomer :execrows
+UPDATE customers
+SET data_key_id = $1,
+    full_name_ciphertext = $2,
+    phone_number_ciphertext = $3,
+    email_ciphertext = $4,
+    phone_number_index = $5,
+    pii_bound_to_id = TRUE,
+    full_name = NULL,
+    phone_number = NULL,
+    email = NULL
+WHERE id = $6
+  AND updated_at = $7
+  AND data_key_id IS NOT DISTINCT FROM $8
+`
+
+type ResealCustomerParams struct {
+	DataKeyID             uuid.NullUUID
+	FullNameCiphertext    []byte
+	PhoneNumberCiphertext []byte
+	EmailCiphertext       []byte
+	PhoneNumberIndex      []byte
+	ID                    uuid.UUID
+	UpdatedAt             time.Time
+	PreviousDataKeyID     uuid.NullUUID
+}
+
+func (q *Queries) ResealCustomer(ctx context.Context, arg ResealCustomerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resealCustomer,
+		arg.DataKeyID,
+		arg.FullNameCiphertext,
+		arg.PhoneNumberCiphertext,
+		arg.EmailCiphertext,
+		arg.PhoneNumberIndex,
+		arg.ID,
+		arg.UpdatedAt,
+		arg.PreviousDataKeyID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const softDeleteCustomer = `-- name: SoftDeleteCustomer :execrows
+UPDATE customers
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND company_id = $2 AND livemode = $3 AND deleted_at IS NULL
+`
+
+type SoftDeleteCustomerParams struct {
+	ID        uuid.UUID
+	CompanyID uuid.UUID
+	Livemode  bool
+}
+
+func (q *Queries) SoftDeleteCustomer(ctx context.Context, arg SoftDeleteCustomerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteCustomer, arg.ID, arg.CompanyID, arg.Livemode)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateCustomer = `-- name: UpdateCustomer :one
+UPDATE customers
+SET data_key_id = $1,
+    full_name_ciphertext = $2,
+    phone_number_ciphertext = $3,
+    email_ciphertext = $4,
+    phone_number_index = $5,
+    pii_bound_to_id = TRUE,
+    full_name = NULL,
+    phone_number = NULL,
+    email = NULL,
+    updated_at = NOW()
+WHERE id = $6 AND company_id = $7 AND livemode = $8 AND deleted_at IS NULL
+RETURNING id, company_id, full_name, phone_number, email, status, created_at, updated_at, deleted_at, livemode, data_key_id, full_name_ciphertext, phone_number_ciphertext, email_ciphertext, phone_number_index, erased_at, pii_bound_to_id
+`
+
+type UpdateCustomerParams struct {
+	DataKeyID             uuid.NullUUID
+	FullNameCiphertext    []byte
+	PhoneNumberCiphertext []byte
+	EmailCiphertext       []byte
+	PhoneNumberIndex      []byte
+	ID                    uuid.UUID
+	CompanyID             uuid.UUID
+	Livemode              bool
+}
+
+func (q *Queries) UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) (Customer, error) {
+	row := q.db.QueryRow(ctx, updateCustomer,
+		arg.DataKeyID,
+		arg.FullNameCiphertext,
+		arg.PhoneNumberCiphertext,
+		arg.EmailCiphertext,
+		arg.PhoneNumberIndex,
+		arg.ID,
 		arg.CompanyID,
-		arg.FullName,
-		arg.PhoneNumber,
-		arg.Email,
+		arg.Livemode,
 	)
 	var i Customer
 	err := row.Scan(
@@ -50,6 +725,14 @@ func (q *Queries) CreateCustomer(ctx context.Context, arg CreateCustomerParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Livemode,
+		&i.DataKeyID,
+		&i.FullNameCiphertext,
+		&i.PhoneNumberCiphertext,
+		&i.EmailCiphertext,
+		&i.PhoneNumberIndex,
+		&i.ErasedAt,
+		&i.PiiBoundToID,
 	)
 	return i, err
 }
@@ -13,25 +13,136 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+type AuditEvent struct {
+	ID        uuid.UUID
+	CompanyID uuid.NullUUID
+	ActorType string
+	ActorID   uuid.NullUUID
+	Action    string
+	IpAddress string
+	Metadata  pgtype.JSONB
+	CreatedAt time.Time
+	RequestID string
+	Before    pgtype.JSONB
+	After     pgtype.JSONB
+}
+
+type BlocklistEntry struct {
+	ID                uuid.UUID
+	CompanyID         uuid.NullUUID
+	Type              string
+	Value             string
+	Network           pgtype.CIDR
+	Reason            string
+	CreatedByUser     uuid.NullUUID
+	CreatedByOperator uuid.NullUUID
+	HitCount          int32
+	LastHitAt         sql.NullTime
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type BlocklistHit struct {
+	ID               uuid.UUID
+	EntryID          uuid.NullUUID
+	CompanyID        uuid.UUID
+	Type             string
+	Value            string
+	Amount           decimal.Decimal
+	Currency         string
+	Livemode         bool
+	CreatedAt        time.Time
+	PhoneNumberIndex []byte
+}
+
 type Company struct {
-	ID                 uuid.UUID
-	Name               string
-	RegistrationNumber string
-	AddressStreet      sql.NullString
-	AddressCity        sql.NullString
-	AddressState       sql.NullString
-	AddressPostalCode  sql.NullString
-	AddressCountry     sql.NullString
-	PrimaryPhone       sql.NullString
-	SecondaryPhone     sql.NullString
-	Status             string
-	Email              sql.NullString
-	Website            sql.NullString
-	CallbackUrl        sql.NullString
-	ReturnUrl          sql.NullString
+	ID                  uuid.UUID
+	Name                string
+	RegistrationNumber  string
+	AddressStreet       sql.NullString
+	AddressCity         sql.NullString
+	AddressState        sql.NullString
+	AddressPostalCode   sql.NullString
+	AddressCountry      sql.NullString
+	PrimaryPhone        sql.NullString
+	SecondaryPhone      sql.NullString
+	Status              string
+	Email               sql.NullString
+	Website             sql.NullString
+	CallbackUrl         sql.NullString
+	ReturnUrl           sql.NullString
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           sql.NullTime
+	MfaRequired         bool
+	KycSubmittedAt      sql.NullTime
+	KycReviewedAt       sql.NullTime
+	KycReviewedBy       uuid.NullUUID
+	KycReviewNote       sql.NullString
+	SuspendedFromStatus sql.NullString
+}
+
+type CompanyCurrency struct {
+	CompanyID    uuid.UUID
+	CurrencyCode string
+	Enabled      bool
+	UpdatedBy    uuid.NullUUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type CompanyFxSetting struct {
+	CompanyID          uuid.UUID
+	SettlementCurrency sql.NullString
+	SpreadBps          int32
+	UpdatedBy          uuid.NullUUID
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
-	DeletedAt          sql.NullTime
+}
+
+type CompanyHmacKey struct {
+	ID         uuid.UUID
+	KeyID      string
+	CompanyID  uuid.UUID
+	Secret     string
+	Status     string
+	LastUsedAt sql.NullTime
+	CreatedAt  time.Time
+	RevokedAt  sql.NullTime
+	Livemode   bool
+}
+
+type CompanyIpAllowlist struct {
+	ID          uuid.UUID
+	CompanyID   uuid.UUID
+	Cidr        pgtype.CIDR
+	Description string
+	CreatedBy   uuid.NullUUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CompanyLimit struct {
+	CompanyID             uuid.UUID
+	CurrencyCode          string
+	MinAmount             decimal.NullDecimal
+	MaxAmount             decimal.NullDecimal
+	DailyTotal            decimal.NullDecimal
+	MonthlyTotal          decimal.NullDecimal
+	CustomerMaxPayments   sql.NullInt32
+	CustomerWindowMinutes int32
+	UpdatedBy             uuid.NullUUID
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+type CompanyRiskSetting struct {
+	CompanyID   uuid.UUID
+	ReviewScore int32
+	BlockScore  int32
+	UpdatedBy   uuid.NullUUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 type CompanyToken struct {
@@ -42,56 +153,255 @@ type CompanyToken struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt sql.NullTime
+	Livemode  bool
+}
+
+type Currency struct {
+	Code             string
+	NumericCode      string
+	Name             string
+	MinorUnits       int16
+	MinAmount        decimal.Decimal
+	MaxAmount        decimal.Decimal
+	EnabledByDefault bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 type Customer struct {
+	ID                    uuid.UUID
+	CompanyID             uuid.UUID
+	FullName              sql.NullString
+	PhoneNumber           sql.NullString
+	Email                 sql.NullString
+	Status                string
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	DeletedAt             sql.NullTime
+	Livemode              bool
+	DataKeyID             uuid.NullUUID
+	FullNameCiphertext    []byte
+	PhoneNumberCiphertext []byte
+	EmailCiphertext       []byte
+	PhoneNumberIndex      []byte
+	ErasedAt              sql.NullTime
+	PiiBoundToID          bool
+}
+
+type Dispute struct {
+	ID                uuid.UUID
+	CompanyID         uuid.UUID
+	PaymentIntentID   uuid.UUID
+	Livemode          bool
+	Amount            decimal.Decimal
+	Currency          string
+	Reason            string
+	Status            string
+	EvidenceDueBy     time.Time
+	EvidenceText      sql.NullString
+	SubmittedBy       uuid.NullUUID
+	SubmittedAt       sql.NullTime
+	CreatedByOperator uuid.NullUUID
+	ResolvedBy        uuid.NullUUID
+	ResolvedAt        sql.NullTime
+	ResolutionNote    sql.NullString
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type DisputeEvidenceFile struct {
 	ID          uuid.UUID
-	CompanyID   uuid.UUID
-	FullName    sql.NullString
-	PhoneNumber string
-	Email       sql.NullString
+	DisputeID   uuid.UUID
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	Sha256      string
+	StorageKey  string
+	UploadedBy  uuid.UUID
+	CreatedAt   time.Time
+}
+
+type HmacNonce struct {
+	HmacKeyID uuid.UUID
+	Nonce     string
+	ExpiresAt time.Time
+}
+
+type KycDocument struct {
+	ID           uuid.UUID
+	CompanyID    uuid.UUID
+	DocumentType string
+	FileName     string
+	ContentType  string
+	SizeBytes    int64
+	Sha256       string
+	StorageKey   string
+	UploadedBy   uuid.UUID
+	CreatedAt    time.Time
+}
+
+type LedgerEntry struct {
+	ID              uuid.UUID
+	CompanyID       uuid.UUID
+	PaymentIntentID uuid.UUID
+	DisputeID       uuid.NullUUID
+	Type            string
+	Amount          decimal.Decimal
+	Currency        string
+	Livemode        bool
+	CreatedAt       time.Time
+}
+
+type LoginThrottle struct {
+	ThrottleKey   string
+	Attempts      int32
+	LastAttemptAt time.Time
+}
+
+type Operator struct {
+	ID          uuid.UUID
+	FullName    string
+	Email       string
+	Password    string
 	Status      string
+	CreatedBy   uuid.NullUUID
+	LastLoginAt sql.NullTime
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   sql.NullTime
 }
 
+type PasswordReset struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenID   uuid.UUID
+	Status    string
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type PaymentIntent struct {
+	ID                  uuid.UUID
+	CompanyID           uuid.UUID
+	CustomerID          uuid.UUID
+	PaymentType         string
+	Amount              decimal.Decimal
+	Currency            string
+	CallbackUrl         string
+	ReturnUrl           string
+	Description         sql.NullString
+	Extra               pgtype.JSON
+	Status              string
+	BillRefNo           sql.NullString
+	ExpireAt            sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           sql.NullTime
+	Livemode            bool
+	SettlementCurrency  sql.NullString
+	SettlementAmount    decimal.NullDecimal
+	FxMidRate           decimal.NullDecimal
+	FxSpreadBps         sql.NullInt32
+	FxRate              decimal.NullDecimal
+	FxRateSource        sql.NullString
+	FxRateAsOf          sql.NullTime
+	RiskScore           sql.NullInt32
+	RiskOutcome         sql.NullString
+	RiskRules           pgtype.JSONB
+	RiskSignals         pgtype.JSONB
+	ReviewedBy          uuid.NullUUID
+	ReviewedAt          sql.NullTime
+	ReviewNote          sql.NullString
+	RequireConfirmation bool
+}
+
+type PaymentIntentConfirmation struct {
+	ID              uuid.UUID
+	PaymentIntentID uuid.UUID
+	CodeHash        string
+	Attempts        int32
+	Status          string
+	ExpiresAt       time.Time
+	ConfirmedAt     sql.NullTime
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type PiiDataKey struct {
 	ID          uuid.UUID
-	CompanyID   uuid.UUID
-	CustomerID  uuid.UUID
-	PaymentType string
-	Amount      decimal.Decimal
-	Currency    string
-	CallbackUrl string
-	ReturnUrl   string
-	Description sql.NullString
-	Extra       pgtype.JSON
-	Status      string
-	BillRefNo   sql.NullString
-	ExpireAt    sql.NullTime
+	MasterKeyID string
+	WrappedKey  []byte
+	Active      bool
 	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   sql.NullTime
+	RetiredAt   sql.NullTime
+}
+
+type RiskRule struct {
+	ID         uuid.UUID
+	CompanyID  uuid.UUID
+	Name       string
+	Expression string
+	Score      int32
+	Enabled    bool
+	UpdatedBy  uuid.NullUUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type UsedToken struct {
+	TokenID   uuid.UUID
+	TokenType string
+	ExpiresAt time.Time
+	UsedAt    time.Time
 }
 
 type User struct {
-	ID                uuid.UUID
-	CompanyID         uuid.UUID
-	Username          sql.NullString
-	Email             string
-	Phone             string
-	Password          string
-	FirstName         sql.NullString
-	LastName          sql.NullString
-	Role              sql.NullString
-	Status            string
-	TimezoneID        sql.NullString
-	Bio               sql.NullString
-	ProfilePictureUrl sql.NullString
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
-	DeletedAt         sql.NullTime
+	ID                  uuid.UUID
+	CompanyID           uuid.UUID
+	Username            sql.NullString
+	Email               string
+	Phone               string
+	Password            string
+	FirstName           sql.NullString
+	LastName            sql.NullString
+	Role                string
+	Status              string
+	TimezoneID          sql.NullString
+	Bio                 sql.NullString
+	ProfilePictureUrl   sql.NullString
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           sql.NullTime
+	SessionsRevokedAt   sql.NullTime
+	EmailVerifiedAt     sql.NullTime
+	PhoneVerifiedAt     sql.NullTime
+	MfaSecret           sql.NullString
+	MfaEnabledAt        sql.NullTime
+	MfaLastStep         sql.NullInt64
+	MfaDataKeyID        uuid.NullUUID
+	MfaSecretCiphertext []byte
+}
+
+type UserInvitation struct {
+	ID         uuid.UUID
+	CompanyID  uuid.UUID
+	Email      string
+	Role       string
+	TokenID    uuid.UUID
+	InvitedBy  uuid.UUID
+	Status     string
+	AcceptedAt sql.NullTime
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type UserRecoveryCode struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CodeHash  string
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
 }
 
 type UserToken struct {
@@ -103,3 +413,32 @@ type UserToken struct {
 	UpdatedAt time.Time
 	DeletedAt sql.NullTime
 }
+
+type UserVerification struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Channel    string
+	TokenID    uuid.NullUUID
+	CodeHash   sql.NullString
+	Attempts   int32
+	Status     string
+	ExpiresAt  time.Time
+	VerifiedAt sql.NullTime
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type WebhookEvent struct {
+	ID            uuid.UUID
+	CompanyID     uuid.UUID
+	Type          string
+	Livemode      bool
+	Payload       pgtype.JSONB
+	Status        string
+	Attempts      int32
+	NextAttemptAt time.Time
+	LastError     sql.NullString
+	DeliveredAt   sql.NullTime
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
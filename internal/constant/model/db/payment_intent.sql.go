@@ -27,25 +27,55 @@ INSERT INTO payment_intents (
     description,
     extra,
     status,
-    bill_ref_no
+    bill_ref_no,
+    livemode,
+    settlement_currency,
+    settlement_amount,
+    fx_mid_rate,
+    fx_spread_bps,
+    fx_rate,
+    fx_rate_source,
+    fx_rate_as_of,
+    risk_score,
+    risk_outcome,
+    risk_rules,
+    risk_signals,
+    require_confirmation
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+    $13, $14, $15, $16, $17,
+    $18, $19, $20,
+    $21, $22, $23,
+    $24
 )
-RETURNING id, company_id, customer_id, payment_type, amount, currency, callback_url, return_url, description, extra, status, bill_ref_no, expire_at, created_at, updated_at, deleted_at
+RETURNING id, company_id, customer_id, payment_type, amount, currency, callback_url, return_url, description, extra, status, bill_ref_no, expire_at, created_at, updated_at, deleted_at, livemode, settlement_currency, settlement_amount, fx_mid_rate, fx_spread_bps, fx_rate, fx_rate_source, fx_rate_as_of, risk_score, risk_outcome, risk_rules, risk_signals, reviewed_by, reviewed_at, review_note, require_confirmation
 `
 
 type CreatePaymentIntentParams struct {
-	CompanyID   uuid.UUID
-	CustomerID  uuid.UUID
-	PaymentType string
-	Amount      decimal.Decimal
-	Currency    string
-	CallbackUrl string
-	ReturnUrl   string
-	Description sql.NullString
-	Extra       pgtype.JSON
-	Status      string
-	BillRefNo   sql.NullString
+	CompanyID           uuid.UUID
+	CustomerID          uuid.UUID
+	PaymentType         string
+	Amount              decimal.Decimal
+	Currency            string
+	CallbackUrl         string
+	ReturnUrl           string
+	Description         sql.NullString
+	Extra               pgtype.JSON
+	Status              string
+	BillRefNo           sql.NullString
+	Livemode            bool
+	SettlementCurrency  sql.NullString
+	SettlementAmount    decimal.NullDecimal
+	FxMidRate           decimal.NullDecimal
+	FxSpreadBps         sql.NullInt32
+	FxRate              decimal.NullDecimal
+	FxRateSource        sql.NullString
+	FxRateAsOf          sql.NullTime
+	RiskScore           sql.NullInt32
+	RiskOutcome         sql.NullString
+	RiskRules           pgtype.JSONB
+	RiskSignals         pgtype.JSONB
+	RequireConfirmation bool
 }
 
 func (q *Queries) CreatePaymentIntent(ctx context.Context, arg CreatePaymentIntentParams) (PaymentIntent, error) {
@@ -61,6 +91,19 @@ func (q *Queries) CreatePaymentIntent(ctx context.Context, arg CreatePaymentInte
 		arg.Extra,
 		arg.Status,
 		arg.BillRefNo,
+		arg.Livemode,
+		arg.SettlementCurrency,
+		arg.SettlementAmount,
+		arg.FxMidRate,
+		arg.FxSpreadBps,
+		arg.FxRate,
+		arg.FxRateSource,
+		arg.FxRateAsOf,
+		arg.RiskScore,
+		arg.RiskOutcome,
+		arg.RiskRules,
+		arg.RiskSignals,
+		arg.RequireConfirmation,
 	)
 	var i PaymentIntent
 	err := row.Scan(
@@ -80,6 +123,22 @@ func (q *Queries) CreatePaymentIntent(ctx context.Context, arg CreatePaymentInte
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Livemode,
+		&i.SettlementCurrency,
+		&i.SettlementAmount,
+		&i.FxMidRate,
+		&i.FxSpreadBps,
+		&i.FxRate,
+		&i.FxRateSource,
+		&i.FxRateAsOf,
+		&i.RiskScore,
+		&i.RiskOutcome,
+		&i.RiskRules,
+		&i.RiskSignals,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.ReviewNote,
+		&i.RequireConfirmation,
 	)
 	return i, err
 }
@@ -96,18 +155,37 @@ SELECT
     pi.description,
     pi.extra,
     pi.bill_ref_no,
+    pi.livemode,
+    pi.settlement_currency,
+    pi.settlement_amount,
+    pi.fx_mid_rate,
+    pi.fx_spread_bps,
+    pi.fx_rate,
+    pi.fx_rate_source,
+    pi.fx_rate_as_of,
+    pi.risk_score,
+    pi.risk_outcome,
+    pi.risk_rules,
+    pi.risk_signals,
+    pi.reviewed_by,
+    pi.reviewed_at,
+    pi.review_note,
     pi.expire_at,
     pi.created_at,
     pi.updated_at,
-    json_build_object (
-        'id',cu.id,
-        'company_id',cu.company_id,
-        'full_name',cu.full_name,
-        'phone_number',cu.phone_number,
-        'email',cu.email,
-        'created_at',cu.created_at,
-        'updated_at',cu.updated_at
-    ) AS customer,
+    cu.id AS customer_id,
+    cu.company_id AS customer_company_id,
+    cu.livemode AS customer_livemode,
+    cu.full_name AS customer_full_name,
+    cu.phone_number AS customer_phone_number,
+    cu.email AS customer_email,
+    cu.data_key_id AS customer_data_key_id,
+    cu.full_name_ciphertext AS customer_full_name_ciphertext,
+    cu.phone_number_ciphertext AS customer_phone_number_ciphertext,
+    cu.email_ciphertext AS customer_email_ciphertext,
+    cu.pii_bound_to_id AS customer_pii_bound_to_id,
+    cu.created_at AS customer_created_at,
+    cu.updated_at AS customer_updated_at,
     json_build_object (
         'id',c.id,
         'name',c.name,
@@ -134,25 +212,53 @@ JOIN
 JOIN 
     companies c ON pi.company_id = c.id
 WHERE 
-    pi.id = $1 AND pi.deleted_at IS NULL AND c.deleted_at IS NULL AND cu.deleted_at IS NULL
+    -- A deleted or erased customer does not hide its payment intents.
+    pi.id = $1 AND pi.deleted_at IS NULL AND c.deleted_at IS NULL
 `
 
 type GetPaymentIntentByIDRow struct {
-	ID          uuid.UUID
-	PaymentType string
-	Amount      decimal.Decimal
-	Status      string
-	Currency    string
-	CallbackUrl string
-	ReturnUrl   string
-	Description sql.NullString
-	Extra       pgtype.JSON
-	BillRefNo   sql.NullString
-	ExpireAt    sql.NullTime
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Customer    pgtype.JSON
-	Company     pgtype.JSON
+	ID                            uuid.UUID
+	PaymentType                   string
+	Amount                        decimal.Decimal
+	Status                        string
+	Currency                      string
+	CallbackUrl                   string
+	ReturnUrl                     string
+	Description                   sql.NullString
+	Extra                         pgtype.JSON
+	BillRefNo                     sql.NullString
+	Livemode                      bool
+	SettlementCurrency            sql.NullString
+	SettlementAmount              decimal.NullDecimal
+	FxMidRate                     decimal.NullDecimal
+	FxSpreadBps                   sql.NullInt32
+	FxRate                        decimal.NullDecimal
+	FxRateSource                  sql.NullString
+	FxRateAsOf                    sql.NullTime
+	RiskScore                     sql.NullInt32
+	RiskOutcome                   sql.NullString
+	RiskRules                     pgtype.JSONB
+	RiskSignals                   pgtype.JSONB
+	ReviewedBy                    uuid.NullUUID
+	ReviewedAt                    sql.NullTime
+	ReviewNote                    sql.NullString
+	ExpireAt                      sql.NullTime
+	CreatedAt                     time.Time
+	UpdatedAt                     time.Time
+	CustomerID                    uuid.UUID
+	CustomerCompanyID             uuid.UUID
+	CustomerLivemode              bool
+	CustomerFullName              sql.NullString
+	CustomerPhoneNumber           sql.NullString
+	CustomerEmail                 sql.NullString
+	CustomerDataKeyID             uuid.NullUUID
+	CustomerFullNameCiphertext    []byte
+	CustomerPhoneNumberCiphertext []byte
+	CustomerEmailCiphertext       []byte
+	CustomerPiiBoundToID          bool
+	CustomerCreatedAt             time.Time
+	CustomerUpdatedAt             time.Time
+	Company                       pgtype.JSON
 }
 
 func (q *Queries) GetPaymentIntentByID(ctx context.Context, id uuid.UUID) (GetPaymentIntentByIDRow, error) {
@@ -169,10 +275,37 @@ func (q *Queries) GetPaymentIntentByID(ctx context.Context, id uuid.UUID) (GetPa
 		&i.Description,
 		&i.Extra,
 		&i.BillRefNo,
+		&i.Livemode,
+		&i.SettlementCurrency,
+		&i.SettlementAmount,
+		&i.FxMidRate,
+		&i.FxSpreadBps,
+		&i.FxRate,
+		&i.FxRateSource,
+		&i.FxRateAsOf,
+		&i.RiskScore,
+		&i.RiskOutcome,
+		&i.RiskRules,
+		&i.RiskSignals,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.ReviewNote,
 		&i.ExpireAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.Customer,
+		&i.CustomerID,
+		&i.CustomerCompanyID,
+		&i.CustomerLivemode,
+		&i.CustomerFullName,
+		&i.CustomerPhoneNumber,
+		&i.CustomerEmail,
+		&i.CustomerDataKeyID,
+		&i.CustomerFullNameCiphertext,
+		&i.CustomerPhoneNumberCiphertext,
+		&i.CustomerEmailCiphertext,
+		&i.CustomerPiiBoundToID,
+		&i.CustomerCreatedAt,
+		&i.CustomerUpdatedAt,
 		&i.Company,
 	)
 	return i, err
@@ -12,8 +12,28 @@ import (
 	"github.com/google/uuid"
 )
 
+const correctPaymentIntentStatus = `-- name: CorrectPaymentIntentStatus :one
+UPDATE payment_intents
+SET status = $1, updated_at = NOW()
+WHERE id = $2 AND status = $3
+RETURNING id
+`
+
+type CorrectPaymentIntentStatusParams struct {
+	Status         string
+	ID             uuid.UUID
+	ExpectedStatus string
+}
+
+func (q *Queries) CorrectPaymentIntentStatus(ctx context.Context, arg CorrectPaymentIntentStatusParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, correctPaymentIntentStatus, arg.Status, arg.ID, arg.ExpectedStatus)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getPaymentIntentByIDForUpdate = `-- name: GetPaymentIntentByIDForUpdate :one
-SELECT id, company_id, customer_id, payment_type, amount, currency, callback_url, return_url, description, extra, status, bill_ref_no, expire_at, created_at, updated_at, deleted_at FROM payment_intents WHERE id = $1 FOR UPDATE
+SELECT id, company_id, customer_id, payment_type, amount, currency, callback_url, return_url, description, extra, status, bill_ref_no, expire_at, created_at, updated_at, deleted_at, livemode, settlement_currency, settlement_amount, fx_mid_rate, fx_spread_bps, fx_rate, fx_rate_source, fx_rate_as_of, risk_score, risk_outcome, risk_rules, risk_signals, reviewed_by, reviewed_at, review_note, require_confirmation FROM payment_intents WHERE id = $1 FOR UPDATE
 `
 
 func (q *Queries) GetPaymentIntentByIDForUpdate(ctx context.Context, id uuid.UUID) (PaymentIntent, error) {
@@ -36,6 +56,22 @@ func (q *Queries) GetPaymentIntentByIDForUpdate(ctx context.Context, id uuid.UUI
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Livemode,
+		&i.SettlementCurrency,
+		&i.SettlementAmount,
+		&i.FxMidRate,
+		&i.FxSpreadBps,
+		&i.FxRate,
+		&i.FxRateSource,
+		&i.FxRateAsOf,
+		&i.RiskScore,
+		&i.RiskOutcome,
+		&i.RiskRules,
+		&i.RiskSignals,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.ReviewNote,
+		&i.RequireConfirmation,
 	)
 	return i, err
 }
@@ -18,20 +18,26 @@ INSERT INTO users (
   first_name,
   email,
   phone,
-  password
+  password,
+  role,
+  status,
+  email_verified_at
 )
 VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
-RETURNING id, company_id, username, email, phone, password, first_name, last_name, role, status, timezone_id, bio, profile_picture_url, created_at, updated_at, deleted_at
+RETURNING id, company_id, username, email, phone, password, first_name, last_name, role, status, timezone_id, bio, profile_picture_url, created_at, updated_at, deleted_at, sessions_revoked_at, email_verified_at, phone_verified_at, mfa_secret, mfa_enabled_at, mfa_last_step, mfa_data_key_id, mfa_secret_ciphertext
 `
 
 type CreateUserParams struct {
-	CompanyID uuid.UUID
-	FirstName sql.NullString
-	Email     string
-	Phone     string
-	Password  string
+	CompanyID       uuid.UUID
+	FirstName       sql.NullString
+	Email           string
+	Phone           string
+	Password        string
+	Role            string
+	Status          string
+	EmailVerifiedAt sql.NullTime
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -41,6 +47,9 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.Email,
 		arg.Phone,
 		arg.Password,
+		arg.Role,
+		arg.Status,
+		arg.EmailVerifiedAt,
 	)
 	var i User
 	err := row.Scan(
@@ -60,6 +69,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SessionsRevokedAt,
+		&i.EmailVerifiedAt,
+		&i.PhoneVerifiedAt,
+		&i.MfaSecret,
+		&i.MfaEnabledAt,
+		&i.MfaLastStep,
+		&i.MfaDataKeyID,
+		&i.MfaSecretCiphertext,
 	)
 	return i, err
 }
@@ -117,7 +134,7 @@ func (q *Queries) GetActiveUserTokenByUserID(ctx context.Context, userID uuid.UU
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, company_id, username, email, phone, password, first_name, last_name, role, status, timezone_id, bio, profile_picture_url, created_at, updated_at, deleted_at
+SELECT id, company_id, username, email, phone, password, first_name, last_name, role, status, timezone_id, bio, profile_picture_url, created_at, updated_at, deleted_at, sessions_revoked_at, email_verified_at, phone_verified_at, mfa_secret, mfa_enabled_at, mfa_last_step, mfa_data_key_id, mfa_secret_ciphertext
 FROM users 
 WHERE id = $1 AND deleted_at IS NULL
 `
@@ -142,12 +159,20 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SessionsRevokedAt,
+		&i.EmailVerifiedAt,
+		&i.PhoneVerifiedAt,
+		&i.MfaSecret,
+		&i.MfaEnabledAt,
+		&i.MfaLastStep,
+		&i.MfaDataKeyID,
+		&i.MfaSecretCiphertext,
 	)
 	return i, err
 }
 
 const getUserByPhoneOrEmail = `-- name: GetUserByPhoneOrEmail :one
-SELECT id, company_id, username, email, phone, password, first_name, last_name, role, status, timezone_id, bio, profile_picture_url, created_at, updated_at, deleted_at
+SELECT id, company_id, username, email, phone, password, first_name, last_name, role, status, timezone_id, bio, profile_picture_url, created_at, updated_at, deleted_at, sessions_revoked_at, email_verified_at, phone_verified_at, mfa_secret, mfa_enabled_at, mfa_last_step, mfa_data_key_id, mfa_secret_ciphertext
 FROM users 
 WHERE (email = $1 OR phone = $1) AND deleted_at IS NULL
 `
@@ -172,6 +197,14 @@ func (q *Queries) GetUserByPhoneOrEmail(ctx context.Context, email string) (User
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SessionsRevokedAt,
+		&i.EmailVerifiedAt,
+		&i.PhoneVerifiedAt,
+		&i.MfaSecret,
+		&i.MfaEnabledAt,
+		&i.MfaLastStep,
+		&i.MfaDataKeyID,
+		&i.MfaSecretCiphertext,
 	)
 	return i, err
 }
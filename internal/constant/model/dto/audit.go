@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"pg/internal/constant"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+)
+
+type AuditEvent struct {
+	ID        uuid.UUID               `json:"id"`
+	CompanyID uuid.UUID               `json:"company_id,omitempty"`
+	ActorType constant.AuditActorType `json:"actor_type" example:"USER"`
+	ActorID   uuid.UUID               `json:"actor_id,omitempty"`
+	Action    constant.AuditAction    `json:"action" example:"team.member_role_changed"`
+	IPAddress string                  `json:"ip_address" example:"203.0.113.7"`
+	RequestID string                  `json:"request_id" example:"5f0c9b7e-0d7e-4d4c-9a43-2b1f0f7d6c11"`
+	Metadata  map[string]any          `json:"metadata,omitempty"`
+	// Before and After hold the changed fields for actions that modify
+	// state.
+	Before    map[string]any `json:"before,omitempty"`
+	After     map[string]any `json:"after,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type CreateAuditEvent struct {
+	CompanyID uuid.UUID
+	ActorType constant.AuditActorType
+	ActorID   uuid.UUID
+	Action    constant.AuditAction
+	// IPAddress and RequestID default to the values of the current request.
+	IPAddress string
+	RequestID string
+	// Identifier is the phone or email an anonymous actor submitted. It is
+	// stored in the metadata as a keyed hash, never in the clear.
+	Identifier string
+	Metadata   map[string]any
+	Before     map[string]any
+	After      map[string]any
+}
+
+// UserAuditEvent starts an event for an action taken by a dashboard user.
+func UserAuditEvent(user User, action constant.AuditAction) CreateAuditEvent {
+	return CreateAuditEvent{
+		CompanyID: user.CompanyID,
+		ActorType: constant.AuditActorUser,
+		ActorID:   user.ID,
+		Action:    action,
+	}
+}
+
+// AuditEventFilter narrows the audit log. From and To are RFC 3339
+// timestamps; To is exclusive.
+type AuditEventFilter struct {
+	Action  string `query:"action" example:"auth.login_succeeded"`
+	ActorID string `query:"actor_id" example:"5f0c9b7e-0d7e-4d4c-9a43-2b1f0f7d6c11"`
+	From    string `query:"from" example:"2026-10-01T00:00:00Z"`
+	To      string `query:"to" example:"2026-11-01T00:00:00Z"`
+	Page    int    `query:"page" example:"1"`
+	PerPage int    `query:"per_page" example:"50"`
+}
+
+func (a AuditEventFilter) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.ActorID, is.UUID.Error("actor_id must be a uuid")),
+		validation.Field(&a.From, validation.Date(time.RFC3339).Error("from must be an RFC 3339 timestamp")),
+		validation.Field(&a.To, validation.Date(time.RFC3339).Error("to must be an RFC 3339 timestamp")),
+		validation.Field(&a.Page, validation.Min(0)),
+		validation.Field(&a.PerPage, validation.Min(0), validation.Max(200)),
+	)
+}
+
+type AuditEventQuery struct {
+	CompanyID uuid.UUID
+	Action    string
+	ActorID   uuid.UUID
+	From      time.Time
+	To        time.Time
+	Limit     int
+	Offset    int
+}
@@ -0,0 +1,260 @@
+package dto
+
+import (
+	"fmt"
+	"net"
+	"pg/internal/constant"
+	"pg/platform/utils"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// BlocklistEntry refuses new payment intents of customers with the phone
+// number, an email address at the domain or an address in the network.
+type BlocklistEntry struct {
+	ID uuid.UUID `json:"id"`
+	// CompanyID is empty for entries operators set for every company.
+	CompanyID *uuid.UUID             `json:"company_id,omitempty"`
+	Type      constant.BlocklistType `json:"type" example:"PHONE"`
+	// Value is the normalized phone number, the lower-case domain or the
+	// network.
+	Value     string     `json:"value" example:"+251911234567"`
+	Reason    string     `json:"reason" example:"chargeback fraud"`
+	HitCount  int        `json:"hit_count" example:"3"`
+	LastHitAt *time.Time `json:"last_hit_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+var blocklistTypes = []interface{}{
+	constant.BlocklistPhone, constant.BlocklistEmailDomain, constant.BlocklistIP,
+}
+
+type BlocklistRequest struct {
+	Type constant.BlocklistType `json:"type" example:"PHONE"`
+	// Value is a phone number, an email domain such as example.com, or an
+	// IP address or CIDR network.
+	Value  string `json:"value" example:"+251911234567"`
+	Reason string `json:"reason" example:"chargeback fraud"`
+}
+
+func (b BlocklistRequest) Validate() error {
+	knownType := validation.In(blocklistTypes...).Validate(b.Type) == nil
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.Type, validation.Required.Error("type is required"),
+			validation.In(blocklistTypes...).Error("type must be PHONE, EMAIL_DOMAIN or IP")),
+		validation.Field(&b.Value, validation.By(requiredText("value")), validation.Length(0, 255),
+			validation.When(knownType, validation.By(func(value interface{}) error {
+				_, _, err := b.Normalize()
+				return err
+			}))),
+		validation.Field(&b.Reason, validation.Length(0, 255)),
+	)
+}
+
+// Normalize returns the value as it is stored and matched: phone numbers
+// in international format, domains in lower case without a leading @, and
+// addresses as networks, which are also returned for IP entries.
+func (b BlocklistRequest) Normalize() (string, *net.IPNet, error) {
+	value := strings.TrimSpace(b.Value)
+	switch b.Type {
+	case constant.BlocklistPhone:
+		phone, err := utils.ParsePhoneNumber(value)
+		if err != nil {
+			return "", nil, err
+		}
+		return *phone, nil, nil
+	case constant.BlocklistEmailDomain:
+		domain := strings.ToLower(strings.TrimPrefix(value, "@"))
+		if err := is.Domain.Validate(domain); err != nil || !strings.Contains(domain, ".") {
+			return "", nil, fmt.Errorf("invalid email domain")
+		}
+		return domain, nil, nil
+	case constant.BlocklistIP:
+		network, err := parseNetwork(value)
+		if err != nil {
+			return "", nil, err
+		}
+		return network.String(), network, nil
+	}
+	return "", nil, fmt.Errorf("unknown blocklist type")
+}
+
+// OperatorBlocklistRequest is an entry set by an operator. Without a
+// company it applies to every company.
+type OperatorBlocklistRequest struct {
+	BlocklistRequest
+	CompanyID string `json:"company_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+func (o OperatorBlocklistRequest) Validate() error {
+	if err := o.BlocklistRequest.Validate(); err != nil {
+		return err
+	}
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.CompanyID, is.UUID.Error("company_id must be a uuid")),
+	)
+}
+
+type CreateBlocklistEntry struct {
+	CompanyID         uuid.UUID
+	Type              constant.BlocklistType
+	Value             string
+	Network           *net.IPNet
+	Reason            string
+	CreatedByUser     uuid.UUID
+	CreatedByOperator uuid.UUID
+}
+
+type UpdateBlocklistEntry struct {
+	ID      uuid.UUID
+	Type    constant.BlocklistType
+	Value   string
+	Network *net.IPNet
+	Reason  string
+}
+
+type BlocklistFilter struct {
+	Type constant.BlocklistType `query:"type" example:"PHONE"`
+	// CompanyID and PlatformOnly are only used by operators.
+	CompanyID    string `query:"company_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PlatformOnly bool   `query:"platform_only" example:"false"`
+	Page         int    `query:"page" example:"1"`
+	PerPage      int    `query:"per_page" example:"50"`
+}
+
+func (b BlocklistFilter) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.Type, validation.In(blocklistTypes...).
+			Error("type must be PHONE, EMAIL_DOMAIN or IP")),
+		validation.Field(&b.CompanyID, is.UUID.Error("company_id must be a uuid")),
+		validation.Field(&b.Page, validation.Min(0)),
+		validation.Field(&b.PerPage, validation.Min(0), validation.Max(200)),
+	)
+}
+
+type BlocklistQuery struct {
+	CompanyID    uuid.UUID
+	PlatformOnly bool
+	Type         constant.BlocklistType
+	Limit        int
+	Offset       int
+}
+
+// BlocklistCheck is the customer of a new payment intent, checked against
+// the company's entries and those of every company.
+type BlocklistCheck struct {
+	CompanyID   uuid.UUID
+	PhoneNumber string
+	Email       string
+	IPAddress   string
+}
+
+// EmailDomains are the domain of Email and its parent domains, so that an
+// entry for example.com also refuses mail.example.com.
+func (b BlocklistCheck) EmailDomains() []string {
+	at := strings.LastIndex(b.Email, "@")
+	if at < 0 {
+		return nil
+	}
+	domain := strings.ToLower(strings.TrimSpace(b.Email[at+1:]))
+	domains := []string{}
+	for strings.Contains(domain, ".") {
+		domains = append(domains, domain)
+		domain = domain[strings.Index(domain, ".")+1:]
+	}
+	return domains
+}
+
+// BlocklistMatch is the blocked detail of a refused payment intent.
+type BlocklistMatch struct {
+	Type constant.BlocklistType `json:"type" example:"PHONE"`
+	// PlatformWide is true when the entry was set by the platform rather
+	// than the company.
+	PlatformWide bool `json:"platform_wide"`
+}
+
+// BlocklistHit is a payment intent an entry refused. Value is what
+// matched, masked for phone numbers and addresses, and empty once the
+// customer was erased.
+type BlocklistHit struct {
+	ID          uuid.UUID              `json:"id"`
+	EntryID     *uuid.UUID             `json:"entry_id,omitempty"`
+	CompanyID   uuid.UUID              `json:"company_id"`
+	CompanyName string                 `json:"company_name"`
+	Type        constant.BlocklistType `json:"type" example:"PHONE"`
+	Value       string                 `json:"value" example:"+251*******67"`
+	Amount      decimal.Decimal        `json:"amount" example:"1500.75"`
+	Currency    constant.Currency      `json:"currency" example:"ETB"`
+	Livemode    bool                   `json:"livemode"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+type CreateBlocklistHit struct {
+	EntryID   uuid.UUID
+	CompanyID uuid.UUID
+	Type      constant.BlocklistType
+	// Value is stored as given; see MaskBlocklistValue.
+	Value    string
+	Amount   decimal.Decimal
+	Currency constant.Currency
+	Livemode bool
+	// PhoneNumber is the payer's normalized phone number. Only its blind
+	// index is stored.
+	PhoneNumber string
+}
+
+// MaskBlocklistValue hides what identifies a payer in a matched value: a
+// phone number keeps its first four and last two characters, and an
+// address is reduced to its /24 or /48 network. Email domains are kept.
+func MaskBlocklistValue(entryType constant.BlocklistType, value string) string {
+	switch entryType {
+	case constant.BlocklistPhone:
+		if len(value) <= 6 {
+			return strings.Repeat("*", len(value))
+		}
+		return value[:4] + strings.Repeat("*", len(value)-6) + value[len(value)-2:]
+	case constant.BlocklistIP:
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return ""
+		}
+		mask := net.CIDRMask(48, 128)
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, mask = ip4, net.CIDRMask(24, 32)
+		}
+		network := net.IPNet{IP: ip.Mask(mask), Mask: mask}
+		return network.String()
+	default:
+		return value
+	}
+}
+
+type BlocklistHitFilter struct {
+	EntryID string `query:"entry_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	// CompanyID is only used by operators.
+	CompanyID string `query:"company_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Page      int    `query:"page" example:"1"`
+	PerPage   int    `query:"per_page" example:"50"`
+}
+
+func (b BlocklistHitFilter) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.EntryID, is.UUID.Error("entry_id must be a uuid")),
+		validation.Field(&b.CompanyID, is.UUID.Error("company_id must be a uuid")),
+		validation.Field(&b.Page, validation.Min(0)),
+		validation.Field(&b.PerPage, validation.Min(0), validation.Max(200)),
+	)
+}
+
+type BlocklistHitQuery struct {
+	CompanyID uuid.UUID
+	EntryID   uuid.UUID
+	Limit     int
+	Offset    int
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"pg/internal/constant"
+	"reflect"
+	"testing"
+)
+
+func TestBlocklistCheckEmailDomains(t *testing.T) {
+	cases := []struct {
+		email string
+		want  []string
+	}{
+		{"payer@example.com", []string{"example.com"}},
+		{"payer@Mail.Example.COM", []string{"mail.example.com", "example.com"}},
+		{"a@b@evil.co.uk", []string{"evil.co.uk", "co.uk"}},
+		{"payer@localhost", []string{}},
+		{"not-an-email", nil},
+		{"", nil},
+	}
+	for _, c := range cases {
+		got := BlocklistCheck{Email: c.email}.EmailDomains()
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("EmailDomains(%q) = %q, want %q", c.email, got, c.want)
+		}
+	}
+}
+
+func TestBlocklistNormalize(t *testing.T) {
+	cases := []struct {
+		entryType constant.BlocklistType
+		value     string
+		want      string
+		wantErr   bool
+	}{
+		{constant.BlocklistPhone, "0911234567", "251911234567", false},
+		{constant.BlocklistPhone, " +251911234567 ", "251911234567", false},
+		{constant.BlocklistPhone, "12", "", true},
+		{constant.BlocklistEmailDomain, "@Example.COM", "example.com", false},
+		{constant.BlocklistEmailDomain, "localhost", "", true},
+		{constant.BlocklistEmailDomain, "not a domain.com", "", true},
+		{constant.BlocklistIP, "10.0.0.5", "10.0.0.5/32", false},
+		{constant.BlocklistIP, "10.0.0.5/24", "10.0.0.0/24", false},
+		{constant.BlocklistIP, "2001:db8::1", "2001:db8::1/128", false},
+		{constant.BlocklistIP, "10.0.0.300", "", true},
+	}
+	for _, c := range cases {
+		got, _, err := BlocklistRequest{Type: c.entryType, Value: c.value}.Normalize()
+		if (err != nil) != c.wantErr {
+			t.Errorf("Normalize(%s %q) error = %v, want error %v", c.entryType, c.value, err, c.wantErr)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("Normalize(%s %q) = %q, want %q", c.entryType, c.value, got, c.want)
+		}
+	}
+}
+
+func TestMaskBlocklistValue(t *testing.T) {
+	cases := []struct {
+		entryType constant.BlocklistType
+		value     string
+		want      string
+	}{
+		{constant.BlocklistPhone, "251911234567", "2519******67"},
+		{constant.BlocklistPhone, "+25191", "******"},
+		{constant.BlocklistIP, "196.188.12.34", "196.188.12.0/24"},
+		{constant.BlocklistIP, "2001:db8:1:2::1", "2001:db8:1::/48"},
+		{constant.BlocklistIP, "not an ip", ""},
+		{constant.BlocklistEmailDomain, "example.com", "example.com"},
+	}
+	for _, c := range cases {
+		if got := MaskBlocklistValue(c.entryType, c.value); got != c.want {
+			t.Errorf("MaskBlocklistValue(%s, %q) = %q, want %q", c.entryType, c.value, got, c.want)
+		}
+	}
+}
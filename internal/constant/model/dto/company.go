@@ -2,16 +2,19 @@ package dto
 
 import (
 	"fmt"
+	"pg/internal/constant"
 	"time"
 
 	"github.com/dongri/phonenumber"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/google/uuid"
 )
 
 type CreateCompanyToken struct {
 	TokenID   uuid.UUID `json:"token_id"`
 	CompanyID uuid.UUID `json:"company_id"`
+	Livemode  bool      `json:"livemode"`
 }
 
 type Company struct {
@@ -30,6 +33,7 @@ type Company struct {
 	Website            string    `json:"website"`
 	CallBackURL        string    `json:"callback_url"`
 	ReturnURL          string    `json:"return_url"`
+	MFARequired        bool      `json:"mfa_required"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
 }
@@ -39,6 +43,7 @@ type CompanyToken struct {
 	TokenID   uuid.UUID `json:"token_id"`
 	CompanyID uuid.UUID `json:"company_id"`
 	Status    string    `json:"status"`
+	Livemode  bool      `json:"livemode"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	DeletedAt time.Time `json:"deleted_at"`
@@ -65,13 +70,117 @@ type CreateCompany struct {
 	ReturnURL          string `json:"return_url" example:"https://www.acmetech.com/payment/return"`
 }
 
+// UpdateCompany changes the company profile. Fields left out of the request
+// keep their current value. Values must fit their columns, and phones and
+// URLs must parse, so a typo cannot break payment callbacks.
+type UpdateCompany struct {
+	AddressStreet     *string `json:"address_street,omitempty" example:"Bole Road"`
+	AddressCity       *string `json:"address_city,omitempty" example:"Addis Ababa"`
+	AddressState      *string `json:"address_state,omitempty" example:"Addis Ababa"`
+	AddressPostalCode *string `json:"address_postal_code,omitempty" example:"1000"`
+	AddressCountry    *string `json:"address_country,omitempty" example:"Ethiopia"`
+	PrimaryPhone      *string `json:"primary_phone,omitempty" example:"+251911234567"`
+	SecondaryPhone    *string `json:"secondary_phone,omitempty" example:"+251922345678"`
+	Website           *string `json:"website,omitempty" example:"https://www.acmetech.com"`
+	CallBackURL       *string `json:"callback_url,omitempty" example:"https://www.acmetech.com/payment/callback"`
+	ReturnURL         *string `json:"return_url,omitempty" example:"https://www.acmetech.com/payment/return"`
+}
+
+func (u UpdateCompany) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.AddressStreet, validation.Length(0, 255)),
+		validation.Field(&u.AddressCity, validation.Length(0, 100)),
+		validation.Field(&u.AddressState, validation.Length(0, 100)),
+		validation.Field(&u.AddressPostalCode, validation.Length(0, 20)),
+		validation.Field(&u.AddressCountry, validation.Length(0, 100)),
+		validation.Field(&u.PrimaryPhone, phoneRule("primary phone")),
+		validation.Field(&u.SecondaryPhone, phoneRule("secondary phone")),
+		validation.Field(&u.Website, urlRules("website")...),
+		validation.Field(&u.CallBackURL, urlRules("callback url")...),
+		validation.Field(&u.ReturnURL, urlRules("return url")...),
+	)
+}
+
+// phoneRule accepts an empty value or a phone number ParsePhoneNumber
+// understands.
+func phoneRule(name string) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		phone, _ := value.(string)
+		if p, ok := value.(*string); ok && p != nil {
+			phone = *p
+		}
+		if phone != "" && !IsPhoneNumber(phone) {
+			return fmt.Errorf("invalid %s provided", name)
+		}
+		return nil
+	})
+}
+
+func urlRules(name string) []validation.Rule {
+	return []validation.Rule{
+		validation.Length(0, 255),
+		is.URL.Error("invalid " + name + " provided"),
+	}
+}
+
 type CompanyCredentialResponse struct {
 	ScretToken string `json:"scret_token"`
+	Livemode   bool   `json:"livemode"`
+}
+
+// APIKeyRequest picks the mode of a new secret token or HMAC key. Keys are
+// live unless test is asked for, as they were before test mode existed.
+type APIKeyRequest struct {
+	Mode constant.Mode `json:"mode" example:"test"`
+}
+
+func (a APIKeyRequest) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Mode, validation.In(constant.ModeLive, constant.ModeTest).
+			Error("mode must be live or test")),
+	)
+}
+
+// Livemode reports whether the key should move real money.
+func (a APIKeyRequest) Livemode() bool {
+	return a.Mode != constant.ModeTest
 }
 
 type LoginRequest struct {
 	PhoneOrEmail string `json:"phone" example:"+251933456789"`
 	Password     string `json:"password" example:"StrongPass@123"`
+	// IPAddress is the client address, filled in by the handler.
+	IPAddress string `json:"-"`
+}
+
+// LoginFailureStats summarizes the recent failed sign-in attempts of one
+// phone or email.
+type LoginFailureStats struct {
+	Failures     int
+	LastFailedAt time.Time
+}
+
+// LoginThrottle are the limits a sign-in attempt is counted against.
+type LoginThrottle struct {
+	// Key is the phone or email, or "ip:" and the client address.
+	Key string
+	// Since forgets the attempts of a key idle since then.
+	Since       time.Time
+	MaxAttempts int
+	// DelayAfter is the number of attempts after which every further
+	// attempt has to wait DelayBase, doubled per additional attempt.
+	DelayAfter int
+	DelayBase  time.Duration
+}
+
+type UnlockAccountRequest struct {
+	Token string `json:"token" example:"v2.local.unlock-token"`
+}
+
+func (u UnlockAccountRequest) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Token, validation.Required.Error("token is required")),
+	)
 }
 
 func (l *LoginRequest) Validate() error {
@@ -108,6 +217,44 @@ func ParsePhoneNumber(phone string) (*string, error) {
 	return &str, nil
 }
 
+type ForgotPasswordRequest struct {
+	PhoneOrEmail string `json:"phone" example:"+251933456789"`
+}
+
+func (f *ForgotPasswordRequest) Validate() error {
+	return validation.ValidateStruct(f,
+		validation.Field(&f.PhoneOrEmail, validation.Required.Error("phone or email is required"),
+			validation.When(IsPhoneNumber(f.PhoneOrEmail), validation.By(func(value interface{}) error {
+				normalized, err := ParsePhoneNumber(value.(string))
+				if err != nil {
+					return fmt.Errorf("invalid phone number: %v", err)
+				}
+				f.PhoneOrEmail = *normalized
+				return nil
+			}))),
+	)
+}
+
+type ResetPasswordRequest struct {
+	Token           string `json:"token" example:"v2.local.reset-token"`
+	Password        string `json:"password" example:"NewStrongPass@123"`
+	ConfirmPassword string `json:"confirm_password" example:"NewStrongPass@123"`
+}
+
+func (r ResetPasswordRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Token, validation.Required.Error("token is required")),
+		validation.Field(&r.Password, validation.Required.Error("password is required"),
+			validation.Length(8, 0).Error("password must be at least 8 characters")),
+		validation.Field(&r.ConfirmPassword, validation.Required.Error("confirm password is required"),
+			validation.In(r.Password).Error("password and confirm password does not match")),
+	)
+}
+
+type MessageResponse struct {
+	Message string `json:"message" example:"request processed"`
+}
+
 type SignInResponse struct {
 	// Access token for the user.
 	AccessToken string `json:"access" example:"access-token"`
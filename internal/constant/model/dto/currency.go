@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"fmt"
+	"pg/internal/constant"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Currency is an entry of the currency registry as seen by one company.
+// Enabled is the company's own setting, or the registry default when an
+// operator has not set one.
+type Currency struct {
+	Code        constant.Currency `json:"code" example:"ETB"`
+	NumericCode string            `json:"numeric_code" example:"230"`
+	Name        string            `json:"name" example:"Ethiopian Birr"`
+	MinorUnits  int               `json:"minor_units" example:"2"`
+	MinAmount   decimal.Decimal   `json:"min_amount" example:"1"`
+	MaxAmount   decimal.Decimal   `json:"max_amount" example:"10000000"`
+	Enabled     bool              `json:"enabled"`
+}
+
+// ValidateAmount rejects amounts outside the currency's limits and amounts
+// with more decimals than its minor units. Trailing zeros are allowed, so
+// 10.50 and 10.500 are both accepted for a currency with two minor units.
+func (c Currency) ValidateAmount(amount decimal.Decimal) error {
+	if !amount.Equal(amount.Truncate(int32(c.MinorUnits))) {
+		return fmt.Errorf("%s amounts allow at most %d decimal places", c.Code, c.MinorUnits)
+	}
+	if amount.LessThan(c.MinAmount) {
+		return fmt.Errorf("%s amount must be at least %s", c.Code, c.MinAmount)
+	}
+	if amount.GreaterThan(c.MaxAmount) {
+		return fmt.Errorf("%s amount must be at most %s", c.Code, c.MaxAmount)
+	}
+
+	return nil
+}
+
+// Round rounds an amount half away from zero to the currency's minor units.
+func (c Currency) Round(amount decimal.Decimal) decimal.Decimal {
+	return amount.Round(int32(c.MinorUnits))
+}
+
+// SetCompanyCurrency enables or disables a currency for one company.
+type SetCompanyCurrency struct {
+	Enabled *bool `json:"enabled" example:"true"`
+	// CompanyID, Code and UpdatedBy are set from the request path and the
+	// operator's token.
+	CompanyID uuid.UUID         `json:"-"`
+	Code      constant.Currency `json:"-"`
+	UpdatedBy uuid.UUID         `json:"-"`
+}
+
+func (s SetCompanyCurrency) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Enabled, validation.NotNil.Error("enabled is required")),
+	)
+}
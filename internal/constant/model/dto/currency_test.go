@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	jpy = Currency{Code: "JPY", MinorUnits: 0,
+		MinAmount: decimal.RequireFromString("1"), MaxAmount: decimal.RequireFromString("1000000")}
+	etb = Currency{Code: "ETB", MinorUnits: 2,
+		MinAmount: decimal.RequireFromString("1"), MaxAmount: decimal.RequireFromString("10000000")}
+	kwd = Currency{Code: "KWD", MinorUnits: 3,
+		MinAmount: decimal.RequireFromString("0.100"), MaxAmount: decimal.RequireFromString("100000")}
+)
+
+func TestValidateAmount(t *testing.T) {
+	cases := []struct {
+		currency Currency
+		amount   string
+		valid    bool
+	}{
+		{jpy, "100", true},
+		{jpy, "100.0", true},
+		{jpy, "100.5", false},
+		{jpy, "0", false},
+		{jpy, "1", true},
+		{jpy, "1000000", true},
+		{jpy, "1000001", false},
+		{etb, "10.50", true},
+		{etb, "10.500", true},
+		{etb, "10.505", false},
+		{etb, "0.99", false},
+		{etb, "10000000.00", true},
+		{etb, "10000000.01", false},
+		{etb, "-5", false},
+		{kwd, "0.100", true},
+		{kwd, "0.099", false},
+		{kwd, "1.234", true},
+		{kwd, "1.2345", false},
+		{kwd, "1.2340", true},
+	}
+	for _, c := range cases {
+		err := c.currency.ValidateAmount(decimal.RequireFromString(c.amount))
+		if (err == nil) != c.valid {
+			t.Errorf("%s.ValidateAmount(%s) = %v, want valid %v",
+				c.currency.Code, c.amount, err, c.valid)
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		currency Currency
+		amount   string
+		want     string
+	}{
+		{jpy, "100.4", "100"},
+		{jpy, "100.5", "101"},
+		{jpy, "-100.5", "-101"},
+		{etb, "10.004", "10"},
+		{etb, "10.005", "10.01"},
+		{etb, "10.015", "10.02"},
+		{etb, "-10.005", "-10.01"},
+		{etb, "10.5", "10.5"},
+		{kwd, "1.2344", "1.234"},
+		{kwd, "1.2345", "1.235"},
+		{kwd, "0.0005", "0.001"},
+	}
+	for _, c := range cases {
+		got := c.currency.Round(decimal.RequireFromString(c.amount))
+		if !got.Equal(decimal.RequireFromString(c.want)) {
+			t.Errorf("%s.Round(%s) = %s, want %s", c.currency.Code, c.amount, got, c.want)
+		}
+	}
+}
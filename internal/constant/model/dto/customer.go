@@ -1,8 +1,11 @@
 package dto
 
 import (
+	"pg/internal/constant"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/google/uuid"
 )
 
@@ -12,13 +15,101 @@ type Customer struct {
 	FullName    string    `json:"full_name,omitempty" example:"John Doe"`
 	PhoneNumber string    `json:"phone_number,omitempty" example:"+1234567890"`
 	Email       string    `json:"email,omitempty" example:"john.doe@gmail.com"`
+	Livemode    bool      `json:"livemode"`
 	CreatedAt   time.Time `json:"created_at,omitempty" example:"2023-09-11T14:30:00Z"`
 	UpdatedAt   time.Time `json:"updated_at,omitempty" example:"2023-09-11T14:45:00Z"`
 }
 
 type CreateCustomer struct {
-	CompanyID   uuid.UUID `json:"company_id,omitempty"`
-	FullName    string    `json:"full_name,omitempty"`
-	PhoneNumber string    `json:"phone_number,omitempty"`
-	Email       string    `json:"email,omitempty"`
+	CompanyID   uuid.UUID `json:"-"`
+	FullName    string    `json:"full_name,omitempty" example:"John Doe"`
+	PhoneNumber string    `json:"phone_number,omitempty" example:"+251911234567"`
+	Email       string    `json:"email,omitempty" example:"john.doe@gmail.com"`
+	Livemode    bool      `json:"-"`
+}
+
+func (c CreateCustomer) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.FullName, validation.Length(0, 255)),
+		validation.Field(&c.PhoneNumber, validation.Required.Error("phone number is required"),
+			phoneRule("phone number")),
+		validation.Field(&c.Email, is.EmailFormat.Error("invalid email provided")),
+	)
+}
+
+// UpdateCustomer changes the fields that are set and leaves the others.
+type UpdateCustomer struct {
+	FullName    *string `json:"full_name,omitempty" example:"John Doe"`
+	PhoneNumber *string `json:"phone_number,omitempty" example:"+251911234567"`
+	Email       *string `json:"email,omitempty" example:"john.doe@gmail.com"`
+}
+
+func (u UpdateCustomer) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.FullName, validation.Length(0, 255)),
+		validation.Field(&u.PhoneNumber, validation.NilOrNotEmpty.Error("phone number cannot be empty"),
+			phoneRule("phone number")),
+		validation.Field(&u.Email, is.EmailFormat.Error("invalid email provided")),
+	)
+}
+
+// CustomerFilter narrows the customer listing. Personal data is stored
+// encrypted, so only an exact phone number can be searched for.
+type CustomerFilter struct {
+	PhoneNumber string `query:"phone_number" example:"+251911234567"`
+	Page        int    `query:"page" example:"1"`
+	PerPage     int    `query:"per_page" example:"50"`
+}
+
+func (c CustomerFilter) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.PhoneNumber, validation.Length(0, 32)),
+		validation.Field(&c.Page, validation.Min(0)),
+		validation.Field(&c.PerPage, validation.Min(0), validation.Max(200)),
+	)
+}
+
+type CustomerQuery struct {
+	CompanyID   uuid.UUID
+	Livemode    bool
+	PhoneNumber string
+	Limit       int
+	Offset      int
+}
+
+// Page is a page of a customer's payment history.
+type Page struct {
+	Page    int `query:"page" example:"1"`
+	PerPage int `query:"per_page" example:"50"`
+}
+
+func (p Page) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Page, validation.Min(0)),
+		validation.Field(&p.PerPage, validation.Min(0), validation.Max(200)),
+	)
+}
+
+type EraseCustomer struct {
+	ID        uuid.UUID
+	CompanyID uuid.UUID
+	Livemode  bool
+	// InactiveBefore only erases the customer if it has had no activity
+	// since then. The zero time erases unconditionally.
+	InactiveBefore time.Time
+}
+
+// CustomerErasure is the certificate of an erasure. It is written to the
+// audit trail and names what was removed and what was kept.
+type CustomerErasure struct {
+	CertificateID uuid.UUID              `json:"certificate_id"`
+	CustomerID    uuid.UUID              `json:"customer_id"`
+	CompanyID     uuid.UUID              `json:"company_id"`
+	Livemode      bool                   `json:"livemode"`
+	Reason        constant.ErasureReason `json:"reason" example:"REQUESTED"`
+	ErasedFields  []string               `json:"erased_fields" example:"full_name,phone_number,email"`
+	// RetainedPaymentIntents still reference the anonymized customer and
+	// are kept for accounting.
+	RetainedPaymentIntents int       `json:"retained_payment_intents" example:"12"`
+	ErasedAt               time.Time `json:"erased_at"`
 }
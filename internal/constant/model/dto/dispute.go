@@ -0,0 +1,195 @@
+package dto
+
+import (
+	"io"
+	"pg/internal/constant"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// DefaultDisputeEvidenceWindow is how long a merchant has to answer a
+// dispute when whoever opened it did not set a deadline.
+const DefaultDisputeEvidenceWindow = 7 * 24 * time.Hour
+
+// Dispute is a chargeback against a successful payment intent. It is for
+// the whole amount of the intent.
+type Dispute struct {
+	ID              uuid.UUID              `json:"id"`
+	CompanyID       uuid.UUID              `json:"company_id"`
+	PaymentIntentID uuid.UUID              `json:"payment_intent_id"`
+	Livemode        bool                   `json:"livemode"`
+	Amount          decimal.Decimal        `json:"amount" example:"1500.75"`
+	Currency        constant.Currency      `json:"currency" example:"ETB"`
+	Reason          constant.DisputeReason `json:"reason" example:"PRODUCT_NOT_RECEIVED"`
+	Status          constant.Status        `json:"status" example:"NEEDS_RESPONSE"`
+	EvidenceDueBy   time.Time              `json:"evidence_due_by"`
+	EvidenceText    string                 `json:"evidence_text,omitempty" example:"Delivered on 12 March, signed receipt attached."`
+	SubmittedBy     *uuid.UUID             `json:"submitted_by,omitempty"`
+	SubmittedAt     *time.Time             `json:"submitted_at,omitempty"`
+	// CreatedByOperator is empty for disputes opened by the processor.
+	CreatedByOperator *uuid.UUID `json:"created_by_operator,omitempty"`
+	ResolvedBy        *uuid.UUID `json:"resolved_by,omitempty"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+	ResolutionNote    string     `json:"resolution_note,omitempty"`
+	// Files and Reversals are only returned for a single dispute.
+	Files     []DisputeEvidenceFile `json:"files,omitempty"`
+	Reversals []LedgerEntry         `json:"reversals,omitempty"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+var disputeReasons = []interface{}{
+	constant.DisputeFraudulent, constant.DisputeDuplicate,
+	constant.DisputeProductNotReceived, constant.DisputeProductUnacceptable,
+	constant.DisputeUnrecognized, constant.DisputeGeneral,
+}
+
+// OpenDispute is a dispute an operator opens on behalf of the processor.
+type OpenDispute struct {
+	PaymentIntentID string                 `json:"payment_intent_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Reason          constant.DisputeReason `json:"reason" example:"PRODUCT_NOT_RECEIVED"`
+	// EvidenceDueBy defaults to seven days from now.
+	EvidenceDueBy time.Time `json:"evidence_due_by,omitempty"`
+}
+
+func (o OpenDispute) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.PaymentIntentID, validation.Required.Error("payment_intent_id is required"),
+			is.UUID.Error("payment_intent_id must be a uuid")),
+		validation.Field(&o.Reason, validation.Required.Error("reason is required"),
+			validation.In(disputeReasons...).Error("unknown dispute reason")),
+		validation.Field(&o.EvidenceDueBy, validation.When(!o.EvidenceDueBy.IsZero(),
+			validation.Min(time.Now()).Error("evidence_due_by must be in the future"))),
+	)
+}
+
+type CreateDispute struct {
+	CompanyID         uuid.UUID
+	PaymentIntentID   uuid.UUID
+	Livemode          bool
+	Amount            decimal.Decimal
+	Currency          constant.Currency
+	Reason            constant.DisputeReason
+	EvidenceDueBy     time.Time
+	CreatedByOperator uuid.UUID
+}
+
+type DisputeEvidence struct {
+	Text string `json:"text" example:"Delivered on 12 March, signed receipt attached."`
+}
+
+func (d DisputeEvidence) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Text, validation.By(requiredText("text")), validation.Length(0, 20000)),
+	)
+}
+
+// DisputeEvidenceFile is a file the merchant attached to a dispute.
+type DisputeEvidenceFile struct {
+	ID          uuid.UUID `json:"id"`
+	DisputeID   uuid.UUID `json:"dispute_id"`
+	FileName    string    `json:"file_name" example:"delivery-receipt.pdf"`
+	ContentType string    `json:"content_type" example:"application/pdf"`
+	SizeBytes   int64     `json:"size_bytes" example:"248133"`
+	SHA256      string    `json:"sha256"`
+	StorageKey  string    `json:"-"`
+	UploadedBy  uuid.UUID `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateDisputeEvidenceFile struct {
+	ID          uuid.UUID
+	DisputeID   uuid.UUID
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	SHA256      string
+	StorageKey  string
+	UploadedBy  uuid.UUID
+}
+
+// UploadDisputeEvidence is an evidence file received as multipart form
+// data.
+type UploadDisputeEvidence struct {
+	FileName string
+	Size     int64
+	File     io.Reader
+}
+
+func (u UploadDisputeEvidence) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.FileName, validation.Required.Error("file is required"),
+			validation.Length(0, 255)),
+		validation.Field(&u.File, validation.NotNil.Error("file is required")),
+	)
+}
+
+// ResolveDispute is an operator's decision on a dispute. Only disputes under
+// review, or that the merchant did not answer in time, can be resolved.
+type ResolveDispute struct {
+	Status constant.Status `json:"status" example:"LOST"`
+	Note   string          `json:"note" example:"issuer accepted the merchant's delivery proof"`
+}
+
+func (r ResolveDispute) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Status, validation.Required.Error("status is required"),
+			validation.In(constant.Won, constant.Lost).Error("status must be WON or LOST")),
+		validation.Field(&r.Note, validation.Length(0, 2000)),
+	)
+}
+
+type DisputeFilter struct {
+	Status constant.Status `query:"status" example:"NEEDS_RESPONSE"`
+	// CompanyID is only used by operators.
+	CompanyID string `query:"company_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Page      int    `query:"page" example:"1"`
+	PerPage   int    `query:"per_page" example:"50"`
+}
+
+func (d DisputeFilter) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Status, validation.In(constant.NeedsResponse, constant.UnderReview,
+			constant.Won, constant.Lost).Error("status must be NEEDS_RESPONSE, UNDER_REVIEW, WON or LOST")),
+		validation.Field(&d.CompanyID, is.UUID.Error("company_id must be a uuid")),
+		validation.Field(&d.Page, validation.Min(0)),
+		validation.Field(&d.PerPage, validation.Min(0), validation.Max(200)),
+	)
+}
+
+type DisputeQuery struct {
+	CompanyID uuid.UUID
+	Status    constant.Status
+	Limit     int
+	Offset    int
+}
+
+// LedgerEntry moves a company's balance in its settlement currency. Amount
+// is negative for reversals.
+type LedgerEntry struct {
+	ID              uuid.UUID                `json:"id"`
+	CompanyID       uuid.UUID                `json:"company_id"`
+	PaymentIntentID uuid.UUID                `json:"payment_intent_id"`
+	DisputeID       *uuid.UUID               `json:"dispute_id,omitempty"`
+	Type            constant.LedgerEntryType `json:"type" example:"DISPUTE_REVERSAL"`
+	Amount          decimal.Decimal          `json:"amount" example:"-1500.75"`
+	Currency        constant.Currency        `json:"currency" example:"ETB"`
+	Livemode        bool                     `json:"livemode"`
+	CreatedAt       time.Time                `json:"created_at"`
+}
+
+// WebhookEvent is an event waiting to be, or already, delivered to a
+// company's callback_url.
+type WebhookEvent struct {
+	ID        uuid.UUID                 `json:"id"`
+	CompanyID uuid.UUID                 `json:"company_id"`
+	Type      constant.WebhookEventType `json:"type" example:"dispute.lost"`
+	Livemode  bool                      `json:"livemode"`
+	Payload   []byte                    `json:"-"`
+	Attempts  int                       `json:"attempts"`
+	CreatedAt time.Time                 `json:"created_at"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"pg/internal/constant"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Settlement is what the merchant is paid for a payment intent. FX is set
+// when it is paid in another currency than the intent is presented in.
+type Settlement struct {
+	Currency constant.Currency `json:"currency" example:"ETB"`
+	Amount   decimal.Decimal   `json:"amount" example:"2529.33"`
+	FX       *FXSnapshot       `json:"fx,omitempty"`
+}
+
+// FXSnapshot is the rate a payment intent was converted at, kept so the
+// settlement amount does not move with the market.
+type FXSnapshot struct {
+	MidRate   decimal.Decimal `json:"mid_rate" example:"128.4567"`
+	SpreadBps int             `json:"spread_bps" example:"150"`
+	// Rate is MidRate less the spread; the settlement amount is the
+	// presentment amount times Rate, rounded down.
+	Rate   decimal.Decimal `json:"rate" example:"126.5298495"`
+	Source string          `json:"source,omitempty" example:"NBE"`
+	AsOf   time.Time       `json:"as_of"`
+}
+
+// CompanyFXSettings is how a company is paid out. An empty
+// SettlementCurrency pays it in each intent's own currency.
+type CompanyFXSettings struct {
+	CompanyID          uuid.UUID         `json:"company_id"`
+	SettlementCurrency constant.Currency `json:"settlement_currency,omitempty" example:"ETB"`
+	SpreadBps          int               `json:"spread_bps" example:"150"`
+	UpdatedAt          time.Time         `json:"updated_at,omitempty"`
+}
+
+type SetCompanyFXSettings struct {
+	// SettlementCurrency is left empty to settle in the presentment currency.
+	SettlementCurrency string `json:"settlement_currency" example:"ETB"`
+	// SpreadBps is the markup on the mid rate in basis points, at most 10%.
+	SpreadBps *int `json:"spread_bps" example:"150"`
+	// CompanyID and UpdatedBy are set from the request path and the
+	// operator's token.
+	CompanyID uuid.UUID `json:"-"`
+	UpdatedBy uuid.UUID `json:"-"`
+}
+
+func (s SetCompanyFXSettings) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.SettlementCurrency,
+			validation.Length(3, 3).Error("settlement_currency must be an ISO 4217 code"),
+			is.Alpha.Error("settlement_currency must be an ISO 4217 code")),
+		validation.Field(&s.SpreadBps, validation.NotNil.Error("spread_bps is required"),
+			validation.Min(0).Error("spread_bps must not be negative"),
+			validation.Max(1000).Error("spread_bps must be at most 1000")),
+	)
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HMACKey is a signing key for the PG-HMAC request scheme. The secret is
+// only returned once, when the key is created.
+type HMACKey struct {
+	ID         uuid.UUID `json:"id"`
+	KeyID      string    `json:"key_id" example:"hk_live_3f9a1c2b7d4e5f60"`
+	CompanyID  uuid.UUID `json:"company_id"`
+	Secret     string    `json:"-"`
+	Status     string    `json:"status" example:"ACTIVE"`
+	Livemode   bool      `json:"livemode"`
+	LastUsedAt time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	RevokedAt  time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreatedHMACKey is returned when a key is created. Store the secret
+// securely: it cannot be retrieved again.
+type CreatedHMACKey struct {
+	HMACKey
+	Secret string `json:"secret" example:"8Zq0...base64url-secret"`
+}
+
+type CreateHMACKey struct {
+	KeyID     string
+	CompanyID uuid.UUID
+	Secret    string
+	Livemode  bool
+}
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type IPAllowlistEntry struct {
+	ID          uuid.UUID `json:"id"`
+	CompanyID   uuid.UUID `json:"company_id"`
+	CIDR        string    `json:"cidr" example:"203.0.113.0/24"`
+	Description string    `json:"description" example:"Production servers"`
+	CreatedBy   uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type IPAllowlistRequest struct {
+	// CIDR is a network such as 203.0.113.0/24 or a single address, which
+	// is stored as a /32 or /128 network.
+	CIDR        string `json:"cidr" example:"203.0.113.0/24"`
+	Description string `json:"description" example:"Production servers"`
+}
+
+func (i IPAllowlistRequest) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.CIDR, validation.Required.Error("cidr is required"),
+			validation.By(func(value interface{}) error {
+				_, err := i.Network()
+				return err
+			})),
+		validation.Field(&i.Description, validation.Length(0, 255)),
+	)
+}
+
+// Network parses CIDR, accepting a bare address as a single-host network.
+func (i IPAllowlistRequest) Network() (*net.IPNet, error) {
+	return parseNetwork(i.CIDR)
+}
+
+// parseNetwork parses a CIDR network or a bare address, which is read as a
+// single-host network.
+func parseNetwork(value string) (*net.IPNet, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip address or cidr")
+		}
+		bits := 128
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ip address or cidr")
+	}
+	return network, nil
+}
+
+type CreateIPAllowlistEntry struct {
+	CompanyID   uuid.UUID
+	Network     *net.IPNet
+	Description string
+	CreatedBy   uuid.UUID
+}
+
+type UpdateIPAllowlistEntry struct {
+	ID          uuid.UUID
+	CompanyID   uuid.UUID
+	Network     *net.IPNet
+	Description string
+}
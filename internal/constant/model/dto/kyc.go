@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"io"
+	"pg/internal/constant"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type KYCDocument struct {
+	ID           uuid.UUID                `json:"id"`
+	CompanyID    uuid.UUID                `json:"company_id"`
+	DocumentType constant.KYCDocumentType `json:"document_type" example:"TRADE_LICENSE"`
+	FileName     string                   `json:"file_name" example:"trade-license.pdf"`
+	ContentType  string                   `json:"content_type" example:"application/pdf"`
+	SizeBytes    int64                    `json:"size_bytes" example:"248133"`
+	SHA256       string                   `json:"sha256"`
+	StorageKey   string                   `json:"-"`
+	UploadedBy   uuid.UUID                `json:"uploaded_by"`
+	CreatedAt    time.Time                `json:"created_at"`
+}
+
+type CreateKYCDocument struct {
+	ID           uuid.UUID
+	CompanyID    uuid.UUID
+	DocumentType constant.KYCDocumentType
+	FileName     string
+	ContentType  string
+	SizeBytes    int64
+	SHA256       string
+	StorageKey   string
+	UploadedBy   uuid.UUID
+}
+
+// UploadKYCDocument is a document received as multipart form data.
+type UploadKYCDocument struct {
+	DocumentType constant.KYCDocumentType
+	FileName     string
+	Size         int64
+	File         io.Reader
+}
+
+func (u UploadKYCDocument) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.DocumentType, validation.Required.Error("document type is required"),
+			validation.In(constant.KYCTradeLicense, constant.KYCTINCertificate,
+				constant.KYCMemorandumOfAssociation, constant.KYCOwnerIdentification,
+				constant.KYCOther).Error("unknown document type")),
+		validation.Field(&u.FileName, validation.Required.Error("file is required"),
+			validation.Length(0, 255)),
+		validation.Field(&u.File, validation.NotNil.Error("file is required")),
+	)
+}
+
+// KYCStatus is where a company stands in onboarding, with the documents it
+// has uploaded and the outcome of the last review.
+type KYCStatus struct {
+	CompanyID   uuid.UUID                  `json:"company_id"`
+	Status      constant.Status            `json:"status" example:"UNDER_REVIEW"`
+	SubmittedAt time.Time                  `json:"submitted_at,omitempty"`
+	ReviewedAt  time.Time                  `json:"reviewed_at,omitempty"`
+	ReviewedBy  uuid.UUID                  `json:"reviewed_by,omitempty"`
+	ReviewNote  string                     `json:"review_note,omitempty"`
+	Missing     []constant.KYCDocumentType `json:"missing_documents"`
+	Documents   []KYCDocument              `json:"documents"`
+}
+
+type KYCDecision string
+
+const (
+	KYCApprove KYCDecision = "APPROVE"
+	KYCReject  KYCDecision = "REJECT"
+)
+
+// KYCReview is an operator's decision on a submission. A rejection must say
+// why, since the note is shown to the merchant.
+type KYCReview struct {
+	Decision KYCDecision `json:"decision" example:"REJECT"`
+	Note     string      `json:"note" example:"trade license has expired"`
+}
+
+func (k KYCReview) Validate() error {
+	return validation.ValidateStruct(&k,
+		validation.Field(&k.Decision, validation.Required.Error("decision is required"),
+			validation.In(KYCApprove, KYCReject).Error("decision must be APPROVE or REJECT")),
+		validation.Field(&k.Note, validation.When(k.Decision == KYCReject,
+			validation.By(requiredText("note"))), validation.Length(0, 2000)),
+	)
+}
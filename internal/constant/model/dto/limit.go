@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"errors"
+	"pg/internal/constant"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CompanyLimit holds the transaction limits of a company in one currency.
+// A null limit is not enforced. Totals count payment intents that have not
+// failed, per UTC day and month, separately for live and test mode.
+type CompanyLimit struct {
+	CompanyID    uuid.UUID           `json:"company_id"`
+	Currency     constant.Currency   `json:"currency" example:"ETB"`
+	MinAmount    decimal.NullDecimal `json:"min_amount" swaggertype:"string" example:"10"`
+	MaxAmount    decimal.NullDecimal `json:"max_amount" swaggertype:"string" example:"50000"`
+	DailyTotal   decimal.NullDecimal `json:"daily_total" swaggertype:"string" example:"500000"`
+	MonthlyTotal decimal.NullDecimal `json:"monthly_total" swaggertype:"string" example:"10000000"`
+	// CustomerMaxPayments caps how many payment intents one customer may be
+	// sent within CustomerWindowMinutes.
+	CustomerMaxPayments   *int      `json:"customer_max_payments" example:"5"`
+	CustomerWindowMinutes int       `json:"customer_window_minutes" example:"60"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+type SetCompanyLimit struct {
+	MinAmount             decimal.NullDecimal `json:"min_amount" swaggertype:"string" example:"10"`
+	MaxAmount             decimal.NullDecimal `json:"max_amount" swaggertype:"string" example:"50000"`
+	DailyTotal            decimal.NullDecimal `json:"daily_total" swaggertype:"string" example:"500000"`
+	MonthlyTotal          decimal.NullDecimal `json:"monthly_total" swaggertype:"string" example:"10000000"`
+	CustomerMaxPayments   *int                `json:"customer_max_payments" example:"5"`
+	CustomerWindowMinutes int                 `json:"customer_window_minutes" example:"60"`
+	// CompanyID, Currency and UpdatedBy are set from the request path and
+	// the operator's token.
+	CompanyID uuid.UUID         `json:"-"`
+	Currency  constant.Currency `json:"-"`
+	UpdatedBy uuid.UUID         `json:"-"`
+}
+
+func (s SetCompanyLimit) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.MinAmount, validation.By(positiveAmount)),
+		validation.Field(&s.MaxAmount, validation.By(positiveAmount),
+			validation.By(notBelow(s.MinAmount, "min_amount"))),
+		validation.Field(&s.DailyTotal, validation.By(positiveAmount)),
+		validation.Field(&s.MonthlyTotal, validation.By(positiveAmount),
+			validation.By(notBelow(s.DailyTotal, "daily_total"))),
+		validation.Field(&s.CustomerMaxPayments,
+			validation.When(s.CustomerMaxPayments != nil, validation.Min(1).Error("customer_max_payments must be at least 1"))),
+		validation.Field(&s.CustomerWindowMinutes,
+			validation.Min(0).Error("customer_window_minutes must not be negative"),
+			validation.Max(7*24*60).Error("customer_window_minutes must be at most a week")),
+	)
+}
+
+func positiveAmount(value interface{}) error {
+	amount, _ := value.(decimal.NullDecimal)
+	if amount.Valid && !amount.Decimal.IsPositive() {
+		return errors.New("must be positive")
+	}
+	return nil
+}
+
+// notBelow rejects a limit lower than another one, when both are set.
+func notBelow(other decimal.NullDecimal, name string) validation.RuleFunc {
+	return func(value interface{}) error {
+		amount, _ := value.(decimal.NullDecimal)
+		if amount.Valid && other.Valid && amount.Decimal.LessThan(other.Decimal) {
+			return errors.New("must not be less than " + name)
+		}
+		return nil
+	}
+}
+
+// LimitViolation is the limit detail of a refused payment intent. Used is
+// what the company or customer already used in the current period; a new
+// attempt can succeed once ResetsAt has passed or the amount fits.
+type LimitViolation struct {
+	Rule      constant.LimitRule `json:"rule" example:"DAILY_TOTAL"`
+	Currency  constant.Currency  `json:"currency" example:"ETB"`
+	Limit     decimal.Decimal    `json:"limit" example:"500000"`
+	Used      decimal.Decimal    `json:"used" example:"499000"`
+	Requested decimal.Decimal    `json:"requested" example:"1500"`
+	ResetsAt  *time.Time         `json:"resets_at,omitempty"`
+}
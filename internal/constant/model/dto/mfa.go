@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// MFAChallenge is returned by login instead of a SignInResponse when the
+// user has MFA enabled. The token must be exchanged together with a TOTP or
+// recovery code at /login/mfa.
+type MFAChallenge struct {
+	MFAToken  string    `json:"mfa_token" example:"v2.local.mfa-challenge-token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" example:"v2.local.mfa-challenge-token"`
+	// Code is either the current TOTP code or an unused recovery code.
+	Code string `json:"code" example:"123456"`
+	// IPAddress is the client address, filled in by the handler.
+	IPAddress string `json:"-"`
+}
+
+func (m MFALoginRequest) Validate() error {
+	return validation.ValidateStruct(&m,
+		validation.Field(&m.MFAToken, validation.Required.Error("mfa token is required")),
+		validation.Field(&m.Code, validation.Required.Error("code is required")),
+	)
+}
+
+type MFAEnrollment struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	// ProvisioningURI is the otpauth:// URI to render as a QR code.
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/Payment%20Gateway:owner@example.com?secret=JBSWY3DPEHPK3PXP"`
+}
+
+type MFACodeRequest struct {
+	// Code is the current TOTP code, or an unused recovery code where the
+	// endpoint allows one.
+	Code string `json:"code" example:"123456"`
+}
+
+func (m MFACodeRequest) Validate() error {
+	return validation.ValidateStruct(&m,
+		validation.Field(&m.Code, validation.Required.Error("code is required")),
+	)
+}
+
+// RecoveryCodes are shown once; only their hashes are stored.
+type RecoveryCodes struct {
+	Codes []string `json:"codes" example:"k3x9p-2mzq7"`
+}
+
+type UpdateCompanyMFA struct {
+	Required bool `json:"required" example:"true"`
+}
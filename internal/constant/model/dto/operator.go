@@ -0,0 +1,142 @@
+package dto
+
+import (
+	"pg/internal/constant"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+)
+
+type Operator struct {
+	ID          uuid.UUID `json:"id"`
+	FullName    string    `json:"full_name"`
+	Email       string    `json:"email"`
+	Password    string    `json:"-"`
+	Status      string    `json:"status"`
+	CreatedBy   uuid.UUID `json:"created_by,omitempty"`
+	LastLoginAt time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CreateOperator struct {
+	FullName string `json:"full_name" example:"Abebe Kebede"`
+	Email    string `json:"email" example:"ops@example.com"`
+	Password string `json:"password" example:"StrongPass@123"`
+	// CreatedBy is the operator adding the account, empty when bootstrapping.
+	CreatedBy uuid.UUID `json:"-"`
+}
+
+func (c CreateOperator) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.FullName, validation.Required.Error("full name is required"),
+			validation.Length(0, 255)),
+		validation.Field(&c.Email, validation.Required.Error("email is required"),
+			is.EmailFormat.Error("invalid email provided")),
+		validation.Field(&c.Password, validation.Required.Error("password is required"),
+			validation.Length(12, 0).Error("password must be at least 12 characters")),
+	)
+}
+
+type OperatorLoginRequest struct {
+	Email    string `json:"email" example:"ops@example.com"`
+	Password string `json:"password" example:"StrongPass@123"`
+	// IPAddress is the client address, filled in by the handler.
+	IPAddress string `json:"-"`
+}
+
+func (o OperatorLoginRequest) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Email, validation.Required.Error("email is required")),
+		validation.Field(&o.Password, validation.Required.Error("password is required")),
+	)
+}
+
+type OperatorSignInResponse struct {
+	AccessToken string `json:"access" example:"access-token"`
+}
+
+// CompanyFilter narrows the operator company listing. Search matches the
+// name, email or registration number.
+type CompanyFilter struct {
+	Search  string `query:"search" example:"acme"`
+	Status  string `query:"status" example:"ACTIVE"`
+	Page    int    `query:"page" example:"1"`
+	PerPage int    `query:"per_page" example:"50"`
+}
+
+func (c CompanyFilter) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Search, validation.Length(0, 255)),
+		validation.Field(&c.Status, validation.In(
+			string(constant.Active), string(constant.Suspended), string(constant.Inactive),
+			string(constant.Onboarding), string(constant.UnderReview), string(constant.Rejected),
+		).Error("status must be ACTIVE, SUSPENDED, INACTIVE, ONBOARDING, UNDER_REVIEW or REJECTED")),
+		validation.Field(&c.Page, validation.Min(0)),
+		validation.Field(&c.PerPage, validation.Min(0), validation.Max(200)),
+	)
+}
+
+type CompanyQuery struct {
+	Search string
+	Status string
+	Limit  int
+	Offset int
+}
+
+// CompanyStatusChange suspends or reactivates a company. The reason is kept
+// in the company's audit log.
+type CompanyStatusChange struct {
+	Reason string `json:"reason" example:"chargeback ratio above threshold"`
+}
+
+func (c CompanyStatusChange) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Reason, validation.By(requiredText("reason")),
+			validation.Length(0, 1000)),
+	)
+}
+
+// PaymentIntentStatusCorrection forces a payment intent into another status,
+// for example after the provider settled a payment we recorded as failed.
+type PaymentIntentStatusCorrection struct {
+	Status constant.Status `json:"status" example:"SUCCESS"`
+	Reason string          `json:"reason" example:"provider confirmed settlement out of band"`
+}
+
+func (p PaymentIntentStatusCorrection) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Status, validation.Required.Error("status is required"),
+			validation.In(constant.Pending, constant.Success, constant.Failed).
+				Error("status must be PENDING, SUCCESS or FAILED")),
+		validation.Field(&p.Reason, validation.By(requiredText("reason")),
+			validation.Length(0, 1000)),
+	)
+}
+
+// OperatorAuditEvent starts an event for an action an operator took on a
+// company. Operator sign-ins belong to no company.
+func OperatorAuditEvent(operator Operator, companyID uuid.UUID,
+	action constant.AuditAction) CreateAuditEvent {
+	return CreateAuditEvent{
+		CompanyID: companyID,
+		ActorType: constant.AuditActorOperator,
+		ActorID:   operator.ID,
+		Action:    action,
+	}
+}
+
+// requiredText rejects values made only of whitespace, which
+// validation.Required lets through.
+func requiredText(name string) validation.RuleFunc {
+	return func(value interface{}) error {
+		text, _ := value.(string)
+		if strings.TrimSpace(text) == "" {
+			return validation.NewError("validation_required", name+" is required")
+		}
+		return nil
+	}
+}
@@ -26,10 +26,14 @@ type PaymentIntent struct {
 	Description string               `json:"description,omitempty"`
 	Extra       map[string]any       `json:"extra,omitempty"`
 	BillRefNO   string               `json:"bill_ref_no,omitempty"`
+	Livemode    bool                 `json:"livemode"`
 	TopayURL    string               `json:"topay_url,omitempty"`
-	ExpireAt    time.Time            `json:"expire_at,omitempty"`
-	CreatedAt   time.Time            `json:"created_at,omitempty"`
-	UpdatedAt   time.Time            `json:"updated_at,omitempty"`
+	Settlement  Settlement           `json:"settlement"`
+	// Risk is nil for intents of companies without risk rules.
+	Risk      *RiskAssessment `json:"risk,omitempty"`
+	ExpireAt  time.Time       `json:"expire_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at,omitempty"`
+	UpdatedAt time.Time       `json:"updated_at,omitempty"`
 }
 
 type PaymentIntentDetail struct {
@@ -43,32 +47,48 @@ type PaymentIntentDetail struct {
 	Description string               `json:"description,omitempty"`
 	Extra       map[string]any       `json:"extra,omitempty"`
 	BillRefNO   string               `json:"bill_ref_no,omitempty"`
+	Livemode    bool                 `json:"livemode"`
 	TopayURL    string               `json:"topay_url,omitempty"`
-	ExpireAt    time.Time            `json:"expire_at,omitempty"`
-	CreatedAt   time.Time            `json:"created_at,omitempty"`
-	UpdatedAt   time.Time            `json:"updated_at,omitempty"`
-	Customer    Customer             `json:"customer,omitempty"`
-	Company     Company              `json:"company,omitempty"`
+	Settlement  Settlement           `json:"settlement"`
+	// Risk is nil for intents of companies without risk rules.
+	Risk      *RiskAssessment `json:"risk,omitempty"`
+	ExpireAt  time.Time       `json:"expire_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at,omitempty"`
+	UpdatedAt time.Time       `json:"updated_at,omitempty"`
+	Customer  Customer        `json:"customer,omitempty"`
+	Company   Company         `json:"company,omitempty"`
 }
 
 type InitPaymentIntent struct {
-	Amount      decimal.Decimal        `json:"amount,omitempty" example:"1500.75"`
-	Currency    string                 `json:"currency,omitempty" example:"ETB"`
-	CallBackURL string                 `json:"callback_url,omitempty" example:"https://merchant.example.com/payment/callback"`
-	ReturnURL   string                 `json:"return_url,omitempty" example:"https://merchant.example.com/payment/return"`
-	Description string                 `json:"description,omitempty" example:"Parking subscription payment"`
-	Customer    PaymentCustomer        `json:"customer,omitempty"`
-	Extra       map[string]interface{} `json:"extra,omitempty"`
+	Amount      decimal.Decimal `json:"amount,omitempty" example:"1500.75"`
+	Currency    string          `json:"currency,omitempty" example:"ETB"`
+	CallBackURL string          `json:"callback_url,omitempty" example:"https://merchant.example.com/payment/callback"`
+	ReturnURL   string          `json:"return_url,omitempty" example:"https://merchant.example.com/payment/return"`
+	Description string          `json:"description,omitempty" example:"Parking subscription payment"`
+	// CustomerID charges a customer created through the customers API.
+	// Customer is used to upsert one by phone number instead.
+	CustomerID string          `json:"customer_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Customer   PaymentCustomer `json:"customer,omitempty"`
+	// CustomerIP is the address the customer reached the merchant from. It
+	// is checked against the IP blocklist.
+	CustomerIP string `json:"customer_ip,omitempty" example:"203.0.113.7"`
+	// RequireConfirmation holds the intent until the customer confirms it
+	// with the code sent to their phone.
+	RequireConfirmation bool                   `json:"require_confirmation,omitempty" example:"true"`
+	Extra               map[string]interface{} `json:"extra,omitempty"`
 }
 
 func (c InitPaymentIntent) Validate() error {
 	return validation.ValidateStruct(&c,
-		validation.Field(&c.Amount, validation.Required.Error("Amount is required"),
-			validation.By(ValidateDecimalMin(decimal.NewFromInt(1),
-				fmt.Sprintf("value must be greater than or equal to %v", 1)))),
+		// The currency registry sets the limits and decimals of an amount.
+		validation.Field(&c.Amount, validation.Required.Error("Amount is required")),
 		validation.Field(&c.Currency, validation.Required.Error("Currency id is required"),
-			validation.In(string(constant.CurrencyETB), string(constant.CurrencyUSD)).Error("Currency must be ETB or USD")),
-		validation.Field(&c.Customer, validation.By(func(value interface{}) error {
+			validation.Length(3, 3).Error("currency must be an ISO 4217 code"),
+			is.Alpha.Error("currency must be an ISO 4217 code")),
+		validation.Field(&c.CustomerID, is.UUID.Error("customer_id must be a uuid"),
+			validation.When(c.Customer != PaymentCustomer{},
+				validation.Empty.Error("send either customer_id or customer"))),
+		validation.Field(&c.Customer, validation.When(c.CustomerID == "", validation.By(func(value interface{}) error {
 			customer, ok := value.(PaymentCustomer)
 			if !ok {
 				return errors.New("invalid customer details")
@@ -86,7 +106,8 @@ func (c InitPaymentIntent) Validate() error {
 			}
 
 			return ValidatePhone(customer.PhoneNumber)
-		})),
+		}))),
+		validation.Field(&c.CustomerIP, is.IP.Error("customer_ip must be an ip address")),
 		validation.Field(&c.ReturnURL,
 			validation.When(c.ReturnURL != "", is.URL.Error("invalid return url provided"))),
 		validation.Field(&c.CallBackURL,
@@ -134,7 +155,48 @@ type CreatePaymentIntent struct {
 	CallBackURL string               `json:"callback_url,omitempty"`
 	ReturnURL   string               `json:"return_url,omitempty"`
 	Description string               `json:"description,omitempty"`
-	Customer    PaymentCustomer      `json:"customer,omitempty"`
-	Extra       map[string]any       `json:"extra,omitempty"`
-	BillRefNO   string               `json:"bill_ref_no,omitempty"`
+	// CustomerID is an existing customer; Customer is upserted otherwise.
+	CustomerID uuid.UUID       `json:"customer_id,omitempty"`
+	Customer   PaymentCustomer `json:"customer,omitempty"`
+	Extra      map[string]any  `json:"extra,omitempty"`
+	BillRefNO  string          `json:"bill_ref_no,omitempty"`
+	Livemode   bool            `json:"livemode"`
+	Settlement Settlement      `json:"settlement"`
+	// RequireConfirmation starts an intent that would be processed in
+	// REQUIRES_CONFIRMATION instead.
+	RequireConfirmation bool `json:"require_confirmation,omitempty"`
+}
+
+// PaymentConfirmation is the one-time code sent to the payer of an intent
+// in REQUIRES_CONFIRMATION.
+type PaymentConfirmation struct {
+	ID              uuid.UUID
+	PaymentIntentID uuid.UUID
+	CodeHash        string
+	Attempts        int32
+	Status          string
+	ExpiresAt       time.Time
+	ConfirmedAt     time.Time
+	CreatedAt       time.Time
+}
+
+type CreatePaymentConfirmation struct {
+	PaymentIntentID uuid.UUID
+	CodeHash        string
+	ExpiresAt       time.Time
+	// MaxSends is how many codes the payment intent may be sent in total.
+	MaxSends int
+	// Cooldown is how long to wait after a code before the next is sent.
+	Cooldown time.Duration
+}
+
+type ConfirmPaymentIntent struct {
+	Code string `json:"code" example:"123456"`
+}
+
+func (c ConfirmPaymentIntent) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Code, validation.Required.Error("code is required"),
+			validation.Length(6, 6).Error("code must be 6 digits"), is.Digit),
+	)
 }
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PIIDataKey is a key that seals customer personal data. Only the active key
+// seals new data; Customers counts the rows still sealed with each key.
+type PIIDataKey struct {
+	ID          uuid.UUID `json:"id"`
+	MasterKeyID string    `json:"master_key_id" example:"k1"`
+	Active      bool      `json:"active"`
+	Customers   int       `json:"customers" example:"1520"`
+	CreatedAt   time.Time `json:"created_at"`
+	RetiredAt   time.Time `json:"retired_at,omitempty"`
+}
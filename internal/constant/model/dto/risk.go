@@ -0,0 +1,211 @@
+package dto
+
+import (
+	"pg/internal/constant"
+	"pg/platform/riskexpr"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Scores at which a payment intent is held for review and blocked when an
+// operator has not set the company's own.
+const (
+	DefaultRiskReviewScore = 50
+	DefaultRiskBlockScore  = 100
+)
+
+// RiskSignals are the signals risk rules may use. Averages and counts are
+// per company, currency and mode, and leave out payment intents that failed
+// or were blocked.
+var RiskSignals = riskexpr.Vars{
+	// amount and currency of the new payment intent.
+	"amount":   riskexpr.Number,
+	"currency": riskexpr.String,
+	"livemode": riskexpr.Bool,
+	// merchant_avg_amount is the company's average amount over the last 30
+	// days and amount_ratio the new amount divided by it, 0 without history.
+	"merchant_avg_amount":   riskexpr.Number,
+	"merchant_payments_30d": riskexpr.Number,
+	"amount_ratio":          riskexpr.Number,
+	// new_customer is true for a customer's first payment intent.
+	"new_customer":          riskexpr.Bool,
+	"customer_payments_1h":  riskexpr.Number,
+	"customer_payments_24h": riskexpr.Number,
+	// Countries are ISO 3166 alpha-2 codes, empty when unknown. The mismatch
+	// is only true when both are known.
+	"phone_country":          riskexpr.String,
+	"company_country":        riskexpr.String,
+	"phone_country_mismatch": riskexpr.Bool,
+	// blocklist_hits is how many payment intents of the payer's phone
+	// number the blocklist refused over the last 30 days, for any entry
+	// type: a payer refused for an email domain or address who comes back
+	// with another one keeps the same phone number.
+	"blocklist_hits": riskexpr.Number,
+}
+
+// RiskRule adds Score to the risk score of a new payment intent when its
+// expression holds for the intent's signals.
+type RiskRule struct {
+	ID         uuid.UUID `json:"id"`
+	CompanyID  uuid.UUID `json:"company_id"`
+	Name       string    `json:"name" example:"large first payment"`
+	Expression string    `json:"expression" example:"new_customer and amount_ratio >= 5"`
+	Score      int       `json:"score" example:"40"`
+	Enabled    bool      `json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type SetRiskRule struct {
+	Name       string `json:"name" example:"large first payment"`
+	Expression string `json:"expression" example:"new_customer and amount_ratio >= 5"`
+	Score      *int   `json:"score" example:"40"`
+	// Enabled defaults to true.
+	Enabled *bool `json:"enabled" example:"true"`
+	// ID, CompanyID and UpdatedBy are set from the request path and the
+	// operator's token.
+	ID        uuid.UUID `json:"-"`
+	CompanyID uuid.UUID `json:"-"`
+	UpdatedBy uuid.UUID `json:"-"`
+}
+
+func (s SetRiskRule) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.By(requiredText("name")),
+			validation.Length(0, 100)),
+		validation.Field(&s.Expression, validation.By(requiredText("expression")),
+			validation.Length(0, 1000), validation.By(riskExpression)),
+		validation.Field(&s.Score, validation.NotNil.Error("score is required"),
+			validation.Min(-1000).Error("score must be at least -1000"),
+			validation.Max(1000).Error("score must be at most 1000")),
+	)
+}
+
+func riskExpression(value interface{}) error {
+	expression, _ := value.(string)
+	if _, err := riskexpr.Compile(expression, RiskSignals); err != nil {
+		return validation.NewError("validation_risk_expression", err.Error())
+	}
+	return nil
+}
+
+// RiskSettings are the scores at which a company's payment intents are
+// held for review and blocked.
+type RiskSettings struct {
+	CompanyID   uuid.UUID `json:"company_id"`
+	ReviewScore int       `json:"review_score" example:"50"`
+	BlockScore  int       `json:"block_score" example:"100"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SetRiskSettings struct {
+	ReviewScore int `json:"review_score" example:"50"`
+	BlockScore  int `json:"block_score" example:"100"`
+	// CompanyID and UpdatedBy are set from the request path and the
+	// operator's token.
+	CompanyID uuid.UUID `json:"-"`
+	UpdatedBy uuid.UUID `json:"-"`
+}
+
+func (s SetRiskSettings) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.ReviewScore, validation.Required.Error("review_score is required"),
+			validation.Min(1).Error("review_score must be at least 1")),
+		validation.Field(&s.BlockScore, validation.Required.Error("block_score is required"),
+			validation.Min(s.ReviewScore).Error("block_score must not be below review_score")),
+	)
+}
+
+// Outcome is what a risk score leads to under these settings.
+func (s RiskSettings) Outcome(score int) constant.RiskOutcome {
+	switch {
+	case score >= s.BlockScore:
+		return constant.RiskBlock
+	case score >= s.ReviewScore:
+		return constant.RiskReview
+	default:
+		return constant.RiskAllow
+	}
+}
+
+// RiskAssessment is how a payment intent was scored when it was created.
+// Rules are the names of the rules that matched and Signals what they saw.
+// Merchants only see the score and the outcome.
+type RiskAssessment struct {
+	Score      int                  `json:"score" example:"60"`
+	Outcome    constant.RiskOutcome `json:"outcome" example:"REVIEW"`
+	Rules      []string             `json:"rules,omitempty"`
+	Signals    map[string]any       `json:"signals,omitempty"`
+	ReviewedBy *uuid.UUID           `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time           `json:"reviewed_at,omitempty"`
+	ReviewNote string               `json:"review_note,omitempty"`
+}
+
+// ForMerchant is the part of the assessment a merchant may see. The rules
+// and signals stay with operators so they cannot be probed.
+func (r *RiskAssessment) ForMerchant() *RiskAssessment {
+	if r == nil {
+		return nil
+	}
+	return &RiskAssessment{Score: r.Score, Outcome: r.Outcome}
+}
+
+// RiskReview is a payment intent waiting in the review queue.
+type RiskReview struct {
+	PaymentIntentID uuid.UUID         `json:"payment_intent_id"`
+	CompanyID       uuid.UUID         `json:"company_id"`
+	CompanyName     string            `json:"company_name"`
+	CustomerID      uuid.UUID         `json:"customer_id"`
+	Amount          decimal.Decimal   `json:"amount" example:"1500.75"`
+	Currency        constant.Currency `json:"currency" example:"ETB"`
+	Livemode        bool              `json:"livemode"`
+	Risk            RiskAssessment    `json:"risk"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+type RiskReviewFilter struct {
+	CompanyID string `query:"company_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Page      int    `query:"page" example:"1"`
+	PerPage   int    `query:"per_page" example:"50"`
+}
+
+func (r RiskReviewFilter) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Page, validation.Min(0)),
+		validation.Field(&r.PerPage, validation.Min(0), validation.Max(200)),
+	)
+}
+
+type RiskReviewQuery struct {
+	CompanyID uuid.UUID
+	Limit     int
+	Offset    int
+}
+
+// RiskReviewDecision approves a held payment intent, sending it on to be
+// processed, or rejects it, blocking it.
+type RiskReviewDecision struct {
+	Decision constant.RiskDecision `json:"decision" example:"APPROVE"`
+	Note     string                `json:"note" example:"customer confirmed the order by phone"`
+}
+
+func (r RiskReviewDecision) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Decision, validation.Required.Error("decision is required"),
+			validation.In(constant.RiskApprove, constant.RiskReject).
+				Error("decision must be APPROVE or REJECT")),
+		validation.Field(&r.Note, validation.Length(0, 1000)),
+	)
+}
+
+// ReviewPaymentIntent records an operator's decision on a held payment
+// intent.
+type ReviewPaymentIntent struct {
+	PaymentIntentID uuid.UUID
+	Status          constant.Status
+	ReviewedBy      uuid.UUID
+	Note            string
+}
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"pg/internal/constant"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+)
+
+// InvitableRoles are the roles an owner or admin can hand out. Ownership
+// is only ever assigned through company registration.
+var InvitableRoles = []interface{}{
+	constant.Admin,
+	constant.Developer,
+	constant.Finance,
+	constant.Support,
+}
+
+type InviteUser struct {
+	Email string        `json:"email" example:"finance@acmetech.com"`
+	Role  constant.Role `json:"role" example:"FINANCE"`
+}
+
+func (i InviteUser) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Email, validation.Required.Error("email is required"),
+			is.EmailFormat.Error("invalid email provided")),
+		validation.Field(&i.Role, validation.Required.Error("role is required"),
+			validation.In(InvitableRoles...).Error("role must be one of ADMIN, DEVELOPER, FINANCE or SUPPORT")),
+	)
+}
+
+type CreateUserInvitation struct {
+	CompanyID uuid.UUID     `json:"company_id"`
+	Email     string        `json:"email"`
+	Role      constant.Role `json:"role"`
+	TokenID   uuid.UUID     `json:"token_id"`
+	InvitedBy uuid.UUID     `json:"invited_by"`
+}
+
+type UserInvitation struct {
+	ID         uuid.UUID       `json:"id,omitempty"`
+	CompanyID  uuid.UUID       `json:"company_id,omitempty"`
+	Email      string          `json:"email,omitempty"`
+	Role       constant.Role   `json:"role,omitempty"`
+	TokenID    uuid.UUID       `json:"-"`
+	InvitedBy  uuid.UUID       `json:"invited_by,omitempty"`
+	Status     constant.Status `json:"status,omitempty"`
+	AcceptedAt time.Time       `json:"accepted_at,omitempty"`
+	CreatedAt  time.Time       `json:"created_at,omitempty"`
+	UpdatedAt  time.Time       `json:"updated_at,omitempty"`
+}
+
+type AcceptInvitation struct {
+	Token           string `json:"token" example:"v2.local.invite-token"`
+	FirstName       string `json:"first_name" example:"Hana"`
+	Phone           string `json:"phone" example:"+251911234567"`
+	Password        string `json:"password" example:"StrongPass@123"`
+	ConfirmPassword string `json:"confirm_password" example:"StrongPass@123"`
+}
+
+func (a AcceptInvitation) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Token, validation.Required.Error("token is required")),
+		validation.Field(&a.FirstName, validation.Required.Error("first name is required")),
+		validation.Field(&a.Phone, validation.Required.Error("phone is required"),
+			validation.By(ValidatePhone)),
+		validation.Field(&a.Password, validation.Required.Error("password is required"),
+			validation.Length(8, 0).Error("password must be at least 8 characters")),
+		validation.Field(&a.ConfirmPassword, validation.Required.Error("confirm password is required"),
+			validation.In(a.Password).Error("password and confirm password does not match")),
+	)
+}
+
+type UpdateMemberRole struct {
+	Role constant.Role `json:"role" example:"DEVELOPER"`
+}
+
+func (u UpdateMemberRole) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Role, validation.Required.Error("role is required"),
+			validation.In(InvitableRoles...).Error("role must be one of ADMIN, DEVELOPER, FINANCE or SUPPORT")),
+	)
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"pg/internal/constant"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,16 +21,25 @@ type User struct {
 	TimezoneID        string    `json:"timezone_id,omitempty"`
 	Bio               string    `json:"bio,omitempty"`
 	ProfilePictureUrl string    `json:"profile_picture_url,omitempty"`
+	EmailVerifiedAt   time.Time `json:"email_verified_at,omitempty"`
+	PhoneVerifiedAt   time.Time `json:"phone_verified_at,omitempty"`
+	MFAEnabledAt      time.Time `json:"mfa_enabled_at,omitempty"`
+	MFASecret         string    `json:"-"`
+	MFALastStep       int64     `json:"-"`
+	SessionsRevokedAt time.Time `json:"-"`
 	CreatedAt         time.Time `json:"created_at,omitempty"`
 	UpdatedAt         time.Time `json:"updated_at,omitempty"`
 }
 
 type CreateUser struct {
-	CompanyID uuid.UUID `json:"company_id"`
-	FirstName string    `json:"first_name"`
-	Email     string    `json:"email"`
-	Phone     string    `json:"phone"`
-	Password  string    `json:"password"`
+	CompanyID       uuid.UUID       `json:"company_id"`
+	FirstName       string          `json:"first_name"`
+	Email           string          `json:"email"`
+	Phone           string          `json:"phone"`
+	Password        string          `json:"password"`
+	Role            constant.Role   `json:"role"`
+	Status          constant.Status `json:"status"`
+	EmailVerifiedAt time.Time       `json:"email_verified_at"`
 }
 
 type UserToken struct {
@@ -41,3 +51,13 @@ type UserToken struct {
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 	DeletedAt time.Time `json:"deleted_at,omitempty"`
 }
+
+type PasswordReset struct {
+	ID        uuid.UUID `json:"id,omitempty"`
+	UserID    uuid.UUID `json:"user_id,omitempty"`
+	TokenID   uuid.UUID `json:"token_id,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	UsedAt    time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
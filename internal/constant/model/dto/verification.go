@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/google/uuid"
+)
+
+type UserVerification struct {
+	ID         uuid.UUID `json:"id,omitempty"`
+	UserID     uuid.UUID `json:"user_id,omitempty"`
+	Channel    string    `json:"channel,omitempty"`
+	TokenID    uuid.UUID `json:"-"`
+	CodeHash   string    `json:"-"`
+	Attempts   int32     `json:"attempts,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+	VerifiedAt time.Time `json:"verified_at,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+}
+
+type CreateUserVerification struct {
+	UserID    uuid.UUID
+	Channel   string
+	TokenID   uuid.UUID
+	CodeHash  string
+	ExpiresAt time.Time
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" example:"v2.local.verification-token"`
+}
+
+func (v VerifyEmailRequest) Validate() error {
+	return validation.ValidateStruct(&v,
+		validation.Field(&v.Token, validation.Required.Error("token is required")),
+	)
+}
+
+type VerifyPhoneRequest struct {
+	Phone string `json:"phone" example:"+251933456789"`
+	Code  string `json:"code" example:"123456"`
+}
+
+func (v *VerifyPhoneRequest) Validate() error {
+	return validation.ValidateStruct(v,
+		validation.Field(&v.Phone, validation.Required.Error("phone is required"),
+			validation.By(func(value interface{}) error {
+				normalized, err := ParsePhoneNumber(value.(string))
+				if err != nil {
+					return fmt.Errorf("invalid phone number: %v", err)
+				}
+				v.Phone = *normalized
+				return nil
+			})),
+		validation.Field(&v.Code, validation.Required.Error("code is required"),
+			validation.Length(6, 6).Error("code must be 6 digits"), is.Digit),
+	)
+}
+
+type ResendVerificationRequest struct {
+	PhoneOrEmail string `json:"phone" example:"+251933456789"`
+}
+
+func (r *ResendVerificationRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.PhoneOrEmail, validation.Required.Error("phone or email is required"),
+			validation.When(IsPhoneNumber(r.PhoneOrEmail), validation.By(func(value interface{}) error {
+				normalized, err := ParsePhoneNumber(value.(string))
+				if err != nil {
+					return fmt.Errorf("invalid phone number: %v", err)
+				}
+				r.PhoneOrEmail = *normalized
+				return nil
+			}))),
+	)
+}
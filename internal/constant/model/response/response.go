@@ -32,6 +32,10 @@ type ErrorResponse struct {
 	StackTrace string `json:"stack_trace"`
 	// FieldError is the error detail for each field, if available that is.
 	FieldError []FieldError `json:"field_error"`
+	// Limit is the limit a request broke, for transaction limit errors.
+	Limit interface{} `json:"limit,omitempty"`
+	// Blocked is the blocklist entry type that refused a payment intent.
+	Blocked interface{} `json:"blocked,omitempty"`
 }
 
 type FieldError struct {
@@ -42,3 +42,29 @@ func ErrorFields(err error) []FieldError {
 
 	return nil
 }
+
+// defaultPerPage matches the page size list endpoints use when the request
+// does not set one.
+const defaultPerPage = 50
+
+// PageMetaData describes one page of a paginated list: the total count and
+// the numbers of the neighbouring pages, if any.
+func PageMetaData(page, perPage, total int) *MetaData {
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	metaData := &MetaData{Count: total}
+	if page*perPage < total {
+		next := page + 1
+		metaData.Next = &next
+	}
+	if page > 1 {
+		previous := page - 1
+		metaData.Previous = &previous
+	}
+
+	return metaData
+}
@@ -29,7 +29,10 @@ func (q *PersistenceDB) GenerateCompanyCredential(ctx context.Context,
 	}()
 
 	tQ := q.WithTx(tx)
-	if err = tQ.InActiveCompanyToken(ctx, req.CompanyID); err != nil {
+	if err = tQ.InActiveCompanyToken(ctx, db.InActiveCompanyTokenParams{
+		CompanyID: req.CompanyID,
+		Livemode:  req.Livemode,
+	}); err != nil {
 		err = errors.ErrUnableToUpdate.Wrap(err, "error updating the merchant key")
 		q.log.Error(ctx, "unable to update merchant key", zap.Error(err),
 			zap.String("merchant-id", req.CompanyID.String()))
@@ -38,6 +41,7 @@ func (q *PersistenceDB) GenerateCompanyCredential(ctx context.Context,
 	if _, err = tQ.CreateCompanyToken(ctx, db.CreateCompanyTokenParams{
 		TokenID:   req.TokenID,
 		CompanyID: req.CompanyID,
+		Livemode:  req.Livemode,
 	}); err != nil {
 		err = errors.ErrUnableToCreate.Wrap(err, "error creating the merchant key")
 		q.log.Error(ctx, "unable to create merchant key",
@@ -0,0 +1,80 @@
+package persistencedb
+
+import (
+	"context"
+	"pg/initiator/platform/amqp"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"time"
+)
+
+// CreatePaymentConfirmationTx stores a new code for a payment intent and
+// revokes the one sent before it, so that only the latest code works. The
+// intent is locked while its earlier codes are counted, and a code past
+// param.MaxSends or within param.Cooldown of the previous one fails with
+// errors.ErrTooManyRequests.
+func (q PersistenceDB) CreatePaymentConfirmationTx(ctx context.Context,
+	param dto.CreatePaymentConfirmation) (*db.PaymentIntentConfirmation, error) {
+	var confirmation db.PaymentIntentConfirmation
+	err := q.WithTransaction(ctx, func(tx PersistenceDB) error {
+		if _, err := tx.GetPaymentIntentByIDForUpdate(ctx, param.PaymentIntentID); err != nil {
+			return err
+		}
+		sent, err := tx.GetPaymentConfirmationSends(ctx, param.PaymentIntentID)
+		if err != nil {
+			return err
+		}
+		if int(sent.Sends) >= param.MaxSends {
+			return errors.ErrTooManyRequests.New("too many confirmation codes were sent for this payment intent")
+		}
+		if wait := time.Until(sent.LastSentAt.Add(param.Cooldown)); wait > 0 {
+			return errors.ErrTooManyRequests.New("a confirmation code was just sent, retry in %d seconds",
+				int(wait.Seconds())+1)
+		}
+
+		if err := tx.RevokePendingPaymentConfirmations(ctx, param.PaymentIntentID); err != nil {
+			return err
+		}
+		confirmation, err = tx.CreatePaymentConfirmation(ctx, db.CreatePaymentConfirmationParams{
+			PaymentIntentID: param.PaymentIntentID,
+			CodeHash:        param.CodeHash,
+			ExpiresAt:       param.ExpiresAt,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &confirmation, nil
+}
+
+// ConfirmPaymentIntentTx uses up a confirmation code and moves its payment
+// intent to PENDING, queueing it to be processed in the same transaction.
+// It fails with sqlcerr.ErrNoRows when the code expired or was used, or
+// the intent no longer requires confirmation.
+func (q PersistenceDB) ConfirmPaymentIntentTx(ctx context.Context,
+	confirmation dto.PaymentConfirmation, client amqp.Client) (*db.PaymentIntent, error) {
+	var confirmed db.PaymentIntent
+	err := q.WithTransaction(ctx, func(tx PersistenceDB) error {
+		completed, err := tx.CompletePaymentConfirmation(ctx, confirmation.ID)
+		if err != nil {
+			return err
+		}
+		if completed == 0 {
+			return sqlcerr.ErrNoRows
+		}
+		confirmed, err = tx.ConfirmPaymentIntent(ctx, confirmation.PaymentIntentID)
+		if err != nil {
+			return err
+		}
+		return publishPaymentIntent(ctx, client, confirmed.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &confirmed, nil
+}
@@ -0,0 +1,317 @@
+package persistencedb
+
+import (
+	"context"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"pg/platform/hcrypto"
+
+	"github.com/google/uuid"
+)
+
+// CustomerPII is the personal data of a customer.
+type CustomerPII struct {
+	FullName    string
+	PhoneNumber string
+	Email       string
+}
+
+// SealedCustomer is CustomerPII as it is stored: each field sealed with a
+// data key, and the phone number also as a blind index.
+type SealedCustomer struct {
+	DataKeyID        uuid.NullUUID
+	FullName         []byte
+	PhoneNumber      []byte
+	Email            []byte
+	PhoneNumberIndex []byte
+}
+
+// SealCustomer seals a customer's personal data with the active data key.
+// Each field is bound to the company, the customer and the field name, so a
+// ciphertext copied to another row or column does not open. Empty fields
+// stay NULL.
+func (q PersistenceDB) SealCustomer(ctx context.Context, companyID, customerID uuid.UUID,
+	pii CustomerPII) (SealedCustomer, error) {
+	id, key, err := q.activeDataKey(ctx)
+	if err != nil {
+		return SealedCustomer{}, err
+	}
+	sealed := SealedCustomer{
+		DataKeyID:        uuid.NullUUID{UUID: id, Valid: true},
+		PhoneNumberIndex: q.CustomerPhoneIndex(companyID, pii.PhoneNumber),
+	}
+	fields := []struct {
+		name  string
+		value string
+		out   *[]byte
+	}{
+		{"full_name", pii.FullName, &sealed.FullName},
+		{"phone_number", pii.PhoneNumber, &sealed.PhoneNumber},
+		{"email", pii.Email, &sealed.Email},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		*field.out, err = hcrypto.Seal(key, []byte(field.value),
+			customerAAD(companyID, customerID, field.name))
+		if err != nil {
+			return SealedCustomer{}, err
+		}
+	}
+
+	return sealed, nil
+}
+
+// CustomerPhoneIndex is the blind index of a normalized phone number. It is
+// scoped to the company, so the same number cannot be linked across
+// companies.
+func (q PersistenceDB) CustomerPhoneIndex(companyID uuid.UUID, phoneNumber string) []byte {
+	if phoneNumber == "" {
+		return nil
+	}
+	return q.vault.keys.BlindIndex(companyID.String(), phoneNumber)
+}
+
+// AuditIdentifierIndex is the blind index of a phone or email recorded in
+// the audit log. The same identifier hashes alike in every event, so failed
+// attempts can still be correlated.
+func (q PersistenceDB) AuditIdentifierIndex(identifier string) []byte {
+	return q.vault.keys.BlindIndex("audit_events.identifier", identifier)
+}
+
+// OpenCustomer returns a stored customer with its personal data opened.
+// Rows not sealed yet are read from the plaintext columns.
+func (q PersistenceDB) OpenCustomer(ctx context.Context, customer db.Customer) (*dto.Customer, error) {
+	pii, err := q.openCustomerPII(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.Customer{
+		ID:          customer.ID,
+		CompanyID:   customer.CompanyID,
+		FullName:    pii.FullName,
+		PhoneNumber: pii.PhoneNumber,
+		Email:       pii.Email,
+		Livemode:    customer.Livemode,
+		CreatedAt:   customer.CreatedAt,
+		UpdatedAt:   customer.UpdatedAt,
+	}, nil
+}
+
+// OpenPaymentIntentCustomer opens the customer joined to a payment intent.
+func (q PersistenceDB) OpenPaymentIntentCustomer(ctx context.Context,
+	pi db.GetPaymentIntentByIDRow) (*dto.Customer, error) {
+	return q.OpenCustomer(ctx, db.Customer{
+		ID:                    pi.CustomerID,
+		CompanyID:             pi.CustomerCompanyID,
+		FullName:              pi.CustomerFullName,
+		PhoneNumber:           pi.CustomerPhoneNumber,
+		Email:                 pi.CustomerEmail,
+		Livemode:              pi.CustomerLivemode,
+		DataKeyID:             pi.CustomerDataKeyID,
+		FullNameCiphertext:    pi.CustomerFullNameCiphertext,
+		PhoneNumberCiphertext: pi.CustomerPhoneNumberCiphertext,
+		EmailCiphertext:       pi.CustomerEmailCiphertext,
+		PiiBoundToID:          pi.CustomerPiiBoundToID,
+		CreatedAt:             pi.CustomerCreatedAt,
+		UpdatedAt:             pi.CustomerUpdatedAt,
+	})
+}
+
+func (q PersistenceDB) openCustomerPII(ctx context.Context, customer db.Customer) (CustomerPII, error) {
+	if !customer.DataKeyID.Valid {
+		return CustomerPII{
+			FullName:    customer.FullName.String,
+			PhoneNumber: customer.PhoneNumber.String,
+			Email:       customer.Email.String,
+		}, nil
+	}
+	key, err := q.dataKey(ctx, customer.DataKeyID.UUID)
+	if err != nil {
+		return CustomerPII{}, err
+	}
+	pii := CustomerPII{}
+	fields := []struct {
+		name   string
+		sealed []byte
+		out    *string
+	}{
+		{"full_name", customer.FullNameCiphertext, &pii.FullName},
+		{"phone_number", customer.PhoneNumberCiphertext, &pii.PhoneNumber},
+		{"email", customer.EmailCiphertext, &pii.Email},
+	}
+	for _, field := range fields {
+		if field.sealed == nil {
+			continue
+		}
+		aad := customerAAD(customer.CompanyID, customer.ID, field.name)
+		if !customer.PiiBoundToID {
+			aad = legacyCustomerAAD(customer.CompanyID, field.name)
+		}
+		value, err := hcrypto.Open(key, field.sealed, aad)
+		if err != nil {
+			return CustomerPII{}, err
+		}
+		*field.out = string(value)
+	}
+
+	return pii, nil
+}
+
+// ResealCustomers seals up to batchSize customers that are not sealed with
+// the active data key, or not bound to their id, yet, including rows still
+// in plaintext. It returns how
+// many rows it looked at; zero means none are left.
+func (q PersistenceDB) ResealCustomers(ctx context.Context, batchSize int) (int, error) {
+	active, _, err := q.activeDataKey(ctx)
+	if err != nil {
+		return 0, err
+	}
+	customers, err := q.ListCustomersToReseal(ctx, db.ListCustomersToResealParams{
+		DataKeyID: uuid.NullUUID{UUID: active, Valid: true},
+		BatchSize: int32(batchSize),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i, customer := range customers {
+		pii, err := q.openCustomerPII(ctx, customer)
+		if err != nil {
+			return i, err
+		}
+		sealed, err := q.SealCustomer(ctx, customer.CompanyID, customer.ID, pii)
+		if err != nil {
+			return i, err
+		}
+		// A row changed since it was read keeps its newer values; it is
+		// picked up again by the next batch if it still needs resealing.
+		_, err = q.ResealCustomer(ctx, db.ResealCustomerParams{
+			DataKeyID:             sealed.DataKeyID,
+			FullNameCiphertext:    sealed.FullName,
+			PhoneNumberCiphertext: sealed.PhoneNumber,
+			EmailCiphertext:       sealed.Email,
+			PhoneNumberIndex:      sealed.PhoneNumberIndex,
+			ID:                    customer.ID,
+			UpdatedAt:             customer.UpdatedAt,
+			PreviousDataKeyID:     customer.DataKeyID,
+		})
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(customers), nil
+}
+
+// EraseCustomerTx clears the customer's personal data and anonymizes the
+// blocklist hits of its phone number, which are linked by blind index.
+func (q PersistenceDB) EraseCustomerTx(ctx context.Context,
+	param db.EraseCustomerParams) (db.EraseCustomerRow, error) {
+	var erased db.EraseCustomerRow
+	err := q.WithTransaction(ctx, func(tx PersistenceDB) error {
+		index, err := tx.GetCustomerPhoneNumberIndex(ctx, db.GetCustomerPhoneNumberIndexParams{
+			ID:        param.ID,
+			CompanyID: param.CompanyID,
+			Livemode:  param.Livemode,
+		})
+		if err != nil {
+			return err
+		}
+		erased, err = tx.EraseCustomer(ctx, param)
+		if err != nil {
+			return err
+		}
+		if index == nil {
+			return nil
+		}
+		_, err = tx.AnonymizeBlocklistHits(ctx, db.AnonymizeBlocklistHitsParams{
+			CompanyID:        param.CompanyID,
+			PhoneNumberIndex: index,
+		})
+		return err
+	})
+
+	return erased, err
+}
+
+// saveCustomer stores a new customer of the company, or updates the
+// existing one with the same phone number, and returns its id. The id is
+// known before the data is sealed, since the ciphertexts are bound to it.
+// It runs in the caller's transaction.
+func (q PersistenceDB) saveCustomer(ctx context.Context, companyID uuid.UUID,
+	livemode bool, pii CustomerPII) (uuid.UUID, error) {
+	index := q.CustomerPhoneIndex(companyID, pii.PhoneNumber)
+	// A customer inserted concurrently with the same phone number makes the
+	// insert return no row; the second pass then updates that customer.
+	for range 2 {
+		if index != nil {
+			existing, err := q.GetCustomerByPhoneNumberIndexForUpdate(ctx,
+				db.GetCustomerByPhoneNumberIndexForUpdateParams{
+					CompanyID:        companyID,
+					Livemode:         livemode,
+					PhoneNumberIndex: index,
+				})
+			switch {
+			case err == nil:
+				return existing.ID, q.updateCustomerPII(ctx, existing, pii)
+			case !sqlcerr.Is(err, sqlcerr.ErrNoRows):
+				return uuid.Nil, err
+			}
+		}
+
+		id := uuid.New()
+		sealed, err := q.SealCustomer(ctx, companyID, id, pii)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		_, err = q.CreateCustomer(ctx, db.CreateCustomerParams{
+			ID:                    id,
+			CompanyID:             companyID,
+			Livemode:              livemode,
+			DataKeyID:             sealed.DataKeyID,
+			FullNameCiphertext:    sealed.FullName,
+			PhoneNumberCiphertext: sealed.PhoneNumber,
+			EmailCiphertext:       sealed.Email,
+			PhoneNumberIndex:      sealed.PhoneNumberIndex,
+		})
+		if !sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			return id, err
+		}
+	}
+
+	return uuid.Nil, sqlcerr.ErrNoRows
+}
+
+func (q PersistenceDB) updateCustomerPII(ctx context.Context, customer db.Customer,
+	pii CustomerPII) error {
+	sealed, err := q.SealCustomer(ctx, customer.CompanyID, customer.ID, pii)
+	if err != nil {
+		return err
+	}
+	_, err = q.UpdateCustomer(ctx, db.UpdateCustomerParams{
+		DataKeyID:             sealed.DataKeyID,
+		FullNameCiphertext:    sealed.FullName,
+		PhoneNumberCiphertext: sealed.PhoneNumber,
+		EmailCiphertext:       sealed.Email,
+		PhoneNumberIndex:      sealed.PhoneNumberIndex,
+		ID:                    customer.ID,
+		CompanyID:             customer.CompanyID,
+		Livemode:              customer.Livemode,
+	})
+
+	return err
+}
+
+func customerAAD(companyID, customerID uuid.UUID, field string) []byte {
+	return []byte("customers." + field + ":" + companyID.String() + ":" + customerID.String())
+}
+
+// legacyCustomerAAD is what rows sealed before customerAAD included the
+// customer id are bound to.
+func legacyCustomerAAD(companyID uuid.UUID, field string) []byte {
+	return []byte("customers." + field + ":" + companyID.String())
+}
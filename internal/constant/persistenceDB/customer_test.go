@@ -0,0 +1,86 @@
+package persistencedb
+
+import (
+	"bytes"
+	"context"
+	"pg/internal/constant/model/db"
+	"pg/platform/hcrypto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCustomerAADBindsCustomer(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	company, customer, other := uuid.New(), uuid.New(), uuid.New()
+	sealed, err := hcrypto.Seal(key, []byte("+251911000000"),
+		customerAAD(company, customer, "phone_number"))
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+
+	if _, err := hcrypto.Open(key, sealed, customerAAD(company, customer, "phone_number")); err != nil {
+		t.Fatalf("open for the same customer: %v", err)
+	}
+	if _, err := hcrypto.Open(key, sealed, customerAAD(company, other, "phone_number")); err == nil {
+		t.Fatal("ciphertext opened for another customer of the company")
+	}
+	if _, err := hcrypto.Open(key, sealed, customerAAD(company, customer, "email")); err == nil {
+		t.Fatal("ciphertext opened for another field")
+	}
+	if _, err := hcrypto.Open(key, sealed, legacyCustomerAAD(company, "phone_number")); err == nil {
+		t.Fatal("ciphertext opened with the company-only binding")
+	}
+}
+
+// testVaultDB is a PersistenceDB whose active data key is already cached,
+// so sealing and opening never reach the database.
+func testVaultDB(t *testing.T) PersistenceDB {
+	t.Helper()
+	keys, err := hcrypto.NewFieldKeyring(hcrypto.FieldKeys{
+		MasterKey:     strings.Repeat("m", 32),
+		BlindIndexKey: strings.Repeat("b", 32),
+	})
+	if err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+	dataKey, err := keys.NewDataKey()
+	if err != nil {
+		t.Fatalf("data key: %v", err)
+	}
+	v := newVault(keys)
+	v.active, v.activeUntil = uuid.New(), time.Now().Add(time.Hour)
+	v.dataKeys[v.active] = dataKey
+
+	return PersistenceDB{vault: v}
+}
+
+func TestOpenPaymentIntentCustomer(t *testing.T) {
+	ctx := context.Background()
+	q := testVaultDB(t)
+	company, customer := uuid.New(), uuid.New()
+	pii := CustomerPII{FullName: "Abebe Kebede", PhoneNumber: "251911000000"}
+	sealed, err := q.SealCustomer(ctx, company, customer, pii)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+
+	opened, err := q.OpenPaymentIntentCustomer(ctx, db.GetPaymentIntentByIDRow{
+		CustomerID:                    customer,
+		CustomerCompanyID:             company,
+		CustomerDataKeyID:             sealed.DataKeyID,
+		CustomerFullNameCiphertext:    sealed.FullName,
+		CustomerPhoneNumberCiphertext: sealed.PhoneNumber,
+		CustomerEmailCiphertext:       sealed.Email,
+		CustomerPiiBoundToID:          true,
+	})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if opened.FullName != pii.FullName || opened.PhoneNumber != pii.PhoneNumber ||
+		opened.Email != "" {
+		t.Errorf("opened %+v, want %+v", opened, pii)
+	}
+}
@@ -3,6 +3,7 @@ package persistencedb
 import (
 	"context"
 	"pg/internal/constant/model/db"
+	"pg/platform/hcrypto"
 	"pg/platform/hlog"
 
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -13,12 +14,15 @@ type PersistenceDB struct {
 	pool    *pgxpool.Pool
 	log     hlog.Logger
 	options Options
+	vault   *vault
 }
 
 type Options struct {
 	SSODB        Sibling
 	AuthzDB      Sibling
 	AccountingDB Sibling
+	// FieldKeys seals customer personal data.
+	FieldKeys *hcrypto.FieldKeyring
 }
 
 func setOptions(options Options) Options {
@@ -66,14 +70,21 @@ func (q PersistenceDB) WithTx(tx db.DBTX) PersistenceDB {
 		pool:    q.pool,
 		log:     q.log,
 		options: q.options,
+		vault:   q.vault,
 	}
 }
 
+// New fails when options.FieldKeys is nil: without them customers could
+// neither be read nor kept unique by phone number.
 func New(pool *pgxpool.Pool, log hlog.Logger, options Options) PersistenceDB {
+	if options.FieldKeys == nil {
+		log.Fatal(context.Background(), "personal data keys are required")
+	}
 	return PersistenceDB{
 		Queries: db.New(pool),
 		pool:    pool,
 		log:     log,
 		options: setOptions(options),
+		vault:   newVault(options.FieldKeys),
 	}
 }
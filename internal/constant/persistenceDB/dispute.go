@@ -0,0 +1,264 @@
+package persistencedb
+
+import (
+	"context"
+	"encoding/json"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// OpenDispute stores a new dispute and the dispute.created event for the
+// company. It runs in the caller's transaction, so that the payment worker
+// can open the disputes of the simulated processor while it settles.
+func (q PersistenceDB) OpenDispute(ctx context.Context,
+	param dto.CreateDispute) (db.Dispute, error) {
+	dispute, err := q.CreateDispute(ctx, db.CreateDisputeParams{
+		CompanyID:         param.CompanyID,
+		PaymentIntentID:   param.PaymentIntentID,
+		Livemode:          param.Livemode,
+		Amount:            param.Amount,
+		Currency:          string(param.Currency),
+		Reason:            string(param.Reason),
+		EvidenceDueBy:     param.EvidenceDueBy,
+		CreatedByOperator: sql.UUIDOrNull(param.CreatedByOperator),
+	})
+	if err != nil {
+		return dispute, err
+	}
+	if err := q.enqueueWebhook(ctx, dispute.CompanyID, dispute.Livemode,
+		constant.WebhookDisputeCreated, ToDispute(dispute)); err != nil {
+		return dispute, err
+	}
+
+	return dispute, nil
+}
+
+func (q PersistenceDB) CreateDisputeTx(ctx context.Context,
+	param dto.CreateDispute) (*db.Dispute, error) {
+	var dispute db.Dispute
+	err := q.WithTransaction(ctx, func(tx PersistenceDB) error {
+		var err error
+		dispute, err = tx.OpenDispute(ctx, param)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dispute, nil
+}
+
+// CreditPayment records what the company is owed for a successful payment
+// intent. It runs in the caller's transaction.
+func (q PersistenceDB) CreditPayment(ctx context.Context, pi db.PaymentIntent) error {
+	settlement := PaymentIntentSettlement(pi)
+	_, err := q.CreateLedgerEntry(ctx, db.CreateLedgerEntryParams{
+		CompanyID:       pi.CompanyID,
+		PaymentIntentID: pi.ID,
+		Type:            string(constant.LedgerPayment),
+		Amount:          settlement.Amount,
+		Currency:        string(settlement.Currency),
+		Livemode:        pi.Livemode,
+	})
+
+	return err
+}
+
+// ResolveDisputeTx closes a dispute. A lost dispute reverses what the
+// company was credited for the payment intent. Either outcome is sent to
+// the company as a webhook event. It fails with sqlcerr.ErrNoRows when the
+// dispute cannot be resolved yet or was already.
+func (q PersistenceDB) ResolveDisputeTx(ctx context.Context, id, operatorID uuid.UUID,
+	param dto.ResolveDispute) (*db.Dispute, error) {
+	var dispute db.Dispute
+	err := q.WithTransaction(ctx, func(tx PersistenceDB) error {
+		var err error
+		dispute, err = tx.ResolveDispute(ctx, db.ResolveDisputeParams{
+			ID:             id,
+			Status:         string(param.Status),
+			ResolvedBy:     sql.UUIDOrNull(operatorID),
+			ResolutionNote: sql.StringOrNull(param.Note),
+		})
+		if err != nil {
+			return err
+		}
+
+		event := constant.WebhookDisputeWon
+		if param.Status == constant.Lost {
+			event = constant.WebhookDisputeLost
+			if err := tx.reverseDispute(ctx, dispute); err != nil {
+				return err
+			}
+		}
+		return tx.enqueueWebhook(ctx, dispute.CompanyID, dispute.Livemode, event, ToDispute(dispute))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dispute, nil
+}
+
+// reverseDispute takes back what the company was credited for the disputed
+// payment intent.
+func (q PersistenceDB) reverseDispute(ctx context.Context, dispute db.Dispute) error {
+	var credit *db.LedgerEntry
+	entry, err := q.GetPaymentLedgerEntry(ctx, dispute.PaymentIntentID)
+	switch {
+	case err == nil:
+		credit = &entry
+	case !sqlcerr.Is(err, sqlcerr.ErrNoRows):
+		return err
+	}
+	pi, err := q.GetPaymentIntentByIDForUpdate(ctx, dispute.PaymentIntentID)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateLedgerEntry(ctx, disputeReversal(dispute, credit, PaymentIntentSettlement(pi)))
+
+	return err
+}
+
+// disputeReversal negates the credit of the disputed payment intent.
+// Intents settled before the ledger existed, or credited by a correction,
+// have no PAYMENT entry, so their settlement is reversed instead.
+func disputeReversal(dispute db.Dispute, credit *db.LedgerEntry,
+	settlement dto.Settlement) db.CreateLedgerEntryParams {
+	reversal := db.CreateLedgerEntryParams{
+		CompanyID:       dispute.CompanyID,
+		PaymentIntentID: dispute.PaymentIntentID,
+		DisputeID:       sql.UUIDOrNull(dispute.ID),
+		Type:            string(constant.LedgerDisputeReversal),
+		Livemode:        dispute.Livemode,
+		Amount:          settlement.Amount.Neg(),
+		Currency:        string(settlement.Currency),
+	}
+	if credit != nil {
+		reversal.Amount, reversal.Currency = credit.Amount.Neg(), credit.Currency
+	}
+	return reversal
+}
+
+// CorrectPaymentIntentStatusTx moves a payment intent from expected to
+// status and its ledger balance to match: the settlement once it is
+// SUCCESS, nothing otherwise. It fails with sqlcerr.ErrNoRows when the
+// intent is no longer in expected. The status of a disputed intent only
+// changes through its dispute.
+func (q PersistenceDB) CorrectPaymentIntentStatusTx(ctx context.Context, id uuid.UUID,
+	expected, status constant.Status) error {
+	return q.WithTransaction(ctx, func(tx PersistenceDB) error {
+		pi, err := tx.GetPaymentIntentByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		disputed, err := tx.PaymentIntentHasDispute(ctx, id)
+		if err != nil {
+			return err
+		}
+		if disputed {
+			return errors.ErrInvalidUserInput.New("payment intent is disputed, its outcome follows the dispute")
+		}
+		if _, err := tx.CorrectPaymentIntentStatus(ctx, db.CorrectPaymentIntentStatusParams{
+			ID:             id,
+			Status:         string(status),
+			ExpectedStatus: string(expected),
+		}); err != nil {
+			return err
+		}
+
+		balance, err := tx.GetPaymentIntentLedgerBalance(ctx, id)
+		if err != nil {
+			return err
+		}
+		settlement := PaymentIntentSettlement(pi)
+		amount := correctionAmount(status, settlement, balance)
+		if amount.IsZero() {
+			return nil
+		}
+		_, err = tx.CreateLedgerEntry(ctx, db.CreateLedgerEntryParams{
+			CompanyID:       pi.CompanyID,
+			PaymentIntentID: pi.ID,
+			Type:            string(constant.LedgerCorrection),
+			Amount:          amount,
+			Currency:        string(settlement.Currency),
+			Livemode:        pi.Livemode,
+		})
+		return err
+	})
+}
+
+// correctionAmount is what moves an intent's ledger balance to what its
+// new status is worth to the company.
+func correctionAmount(status constant.Status, settlement dto.Settlement,
+	balance decimal.Decimal) decimal.Decimal {
+	target := decimal.Zero
+	if status == constant.Success {
+		target = settlement.Amount
+	}
+	return target.Sub(balance)
+}
+
+func (q PersistenceDB) enqueueWebhook(ctx context.Context, companyID uuid.UUID, livemode bool,
+	eventType constant.WebhookEventType, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateWebhookEvent(ctx, db.CreateWebhookEventParams{
+		CompanyID: companyID,
+		Type:      string(eventType),
+		Livemode:  livemode,
+		Payload:   pgtype.JSONB{Bytes: payload, Status: pgtype.Present},
+	})
+
+	return err
+}
+
+// ToDispute converts a dispute row, without its files and reversals.
+func ToDispute(dispute db.Dispute) dto.Dispute {
+	result := dto.Dispute{
+		ID:              dispute.ID,
+		CompanyID:       dispute.CompanyID,
+		PaymentIntentID: dispute.PaymentIntentID,
+		Livemode:        dispute.Livemode,
+		Amount:          dispute.Amount,
+		Currency:        constant.Currency(dispute.Currency),
+		Reason:          constant.DisputeReason(dispute.Reason),
+		Status:          constant.Status(dispute.Status),
+		EvidenceDueBy:   dispute.EvidenceDueBy,
+		EvidenceText:    dispute.EvidenceText.String,
+		ResolutionNote:  dispute.ResolutionNote.String,
+		CreatedAt:       dispute.CreatedAt,
+		UpdatedAt:       dispute.UpdatedAt,
+	}
+	if dispute.SubmittedBy.Valid {
+		submittedBy := dispute.SubmittedBy.UUID
+		result.SubmittedBy = &submittedBy
+	}
+	if dispute.SubmittedAt.Valid {
+		submittedAt := dispute.SubmittedAt.Time
+		result.SubmittedAt = &submittedAt
+	}
+	if dispute.CreatedByOperator.Valid {
+		createdBy := dispute.CreatedByOperator.UUID
+		result.CreatedByOperator = &createdBy
+	}
+	if dispute.ResolvedBy.Valid {
+		resolvedBy := dispute.ResolvedBy.UUID
+		result.ResolvedBy = &resolvedBy
+	}
+	if dispute.ResolvedAt.Valid {
+		resolvedAt := dispute.ResolvedAt.Time
+		result.ResolvedAt = &resolvedAt
+	}
+
+	return result
+}
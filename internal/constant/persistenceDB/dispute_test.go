@@ -0,0 +1,81 @@
+package persistencedb
+
+import (
+	"pg/internal/constant"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestDisputeReversal(t *testing.T) {
+	dispute := db.Dispute{
+		ID:              uuid.New(),
+		CompanyID:       uuid.New(),
+		PaymentIntentID: uuid.New(),
+		Livemode:        true,
+	}
+	settlement := dto.Settlement{
+		Currency: constant.Currency("ETB"),
+		Amount:   decimal.RequireFromString("2529.33"),
+	}
+	cases := []struct {
+		name         string
+		credit       *db.LedgerEntry
+		wantAmount   string
+		wantCurrency string
+	}{
+		{
+			name: "credited payment",
+			credit: &db.LedgerEntry{
+				Amount:   decimal.RequireFromString("2500.00"),
+				Currency: "USD",
+			},
+			wantAmount:   "-2500",
+			wantCurrency: "USD",
+		},
+		{
+			name:         "payment settled before the ledger",
+			wantAmount:   "-2529.33",
+			wantCurrency: "ETB",
+		},
+	}
+	for _, c := range cases {
+		got := disputeReversal(dispute, c.credit, settlement)
+		if !got.Amount.Equal(decimal.RequireFromString(c.wantAmount)) || got.Currency != c.wantCurrency {
+			t.Errorf("%s: reversal = %s %s, want %s %s", c.name,
+				got.Amount, got.Currency, c.wantAmount, c.wantCurrency)
+		}
+		if got.Type != string(constant.LedgerDisputeReversal) || got.PaymentIntentID != dispute.PaymentIntentID ||
+			got.CompanyID != dispute.CompanyID || !got.Livemode {
+			t.Errorf("%s: reversal %+v is not booked against the dispute", c.name, got)
+		}
+	}
+}
+
+func TestCorrectionAmount(t *testing.T) {
+	settlement := dto.Settlement{
+		Currency: constant.Currency("ETB"),
+		Amount:   decimal.RequireFromString("100.50"),
+	}
+	cases := []struct {
+		name    string
+		status  constant.Status
+		balance string
+		want    string
+	}{
+		{"failed payment corrected to success", constant.Success, "0", "100.50"},
+		{"credited payment corrected to failed", constant.Failed, "100.50", "-100.50"},
+		{"credited payment corrected to pending", constant.Pending, "100.50", "-100.50"},
+		{"uncredited payment corrected to failed", constant.Failed, "0", "0"},
+		{"credited payment corrected to success", constant.Success, "100.50", "0"},
+	}
+	for _, c := range cases {
+		got := correctionAmount(c.status, settlement, decimal.RequireFromString(c.balance))
+		if !got.Equal(decimal.RequireFromString(c.want)) {
+			t.Errorf("%s: correctionAmount = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
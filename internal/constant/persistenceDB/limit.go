@@ -0,0 +1,134 @@
+package persistencedb
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// limitQueries are the queries enforceLimits reads the limits and the
+// usage with.
+type limitQueries interface {
+	GetCompanyLimitForUpdate(ctx context.Context,
+		arg db.GetCompanyLimitForUpdateParams) (db.CompanyLimit, error)
+	SumPaymentIntentsSince(ctx context.Context,
+		arg db.SumPaymentIntentsSinceParams) (decimal.Decimal, error)
+	CountCustomerPaymentIntentsSince(ctx context.Context,
+		arg db.CountCustomerPaymentIntentsSinceParams) (int32, error)
+}
+
+// enforceLimits refuses a payment intent that breaks the company's limits
+// in its currency. It locks the company's limits row until the transaction
+// ends, so it must run in the transaction that creates the intent.
+func (q PersistenceDB) enforceLimits(ctx context.Context, param dto.CreatePaymentIntent,
+	customerID uuid.UUID, now time.Time) error {
+	return enforceLimits(ctx, q.Queries, param, customerID, now)
+}
+
+func enforceLimits(ctx context.Context, q limitQueries, param dto.CreatePaymentIntent,
+	customerID uuid.UUID, now time.Time) error {
+	limit, err := q.GetCompanyLimitForUpdate(ctx, db.GetCompanyLimitForUpdateParams{
+		CompanyID:    param.CompanyID,
+		CurrencyCode: string(param.Currency),
+	})
+	if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	amount := param.Amount
+	if limit.MinAmount.Valid && amount.LessThan(limit.MinAmount.Decimal) {
+		return limitExceeded(dto.LimitViolation{
+			Rule:      constant.LimitMinAmount,
+			Currency:  param.Currency,
+			Limit:     limit.MinAmount.Decimal,
+			Requested: amount,
+		}, "amount is below the minimum of %s %s", limit.MinAmount.Decimal, param.Currency)
+	}
+	if limit.MaxAmount.Valid && amount.GreaterThan(limit.MaxAmount.Decimal) {
+		return limitExceeded(dto.LimitViolation{
+			Rule:      constant.LimitMaxAmount,
+			Currency:  param.Currency,
+			Limit:     limit.MaxAmount.Decimal,
+			Requested: amount,
+		}, "amount is above the maximum of %s %s", limit.MaxAmount.Decimal, param.Currency)
+	}
+
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	totals := []struct {
+		rule   constant.LimitRule
+		period string
+		limit  decimal.NullDecimal
+		since  time.Time
+		resets time.Time
+	}{
+		{constant.LimitDailyTotal, "daily", limit.DailyTotal, day, day.AddDate(0, 0, 1)},
+		{constant.LimitMonthlyTotal, "monthly", limit.MonthlyTotal, month, month.AddDate(0, 1, 0)},
+	}
+	for _, total := range totals {
+		if !total.limit.Valid {
+			continue
+		}
+		used, err := q.SumPaymentIntentsSince(ctx, db.SumPaymentIntentsSinceParams{
+			CompanyID: param.CompanyID,
+			Currency:  string(param.Currency),
+			Livemode:  param.Livemode,
+			Since:     total.since,
+		})
+		if err != nil {
+			return err
+		}
+		if used.Add(amount).GreaterThan(total.limit.Decimal) {
+			resets := total.resets
+			return limitExceeded(dto.LimitViolation{
+				Rule:      total.rule,
+				Currency:  param.Currency,
+				Limit:     total.limit.Decimal,
+				Used:      used,
+				Requested: amount,
+				ResetsAt:  &resets,
+			}, "amount would exceed the %s total of %s %s", total.period,
+				total.limit.Decimal, param.Currency)
+		}
+	}
+
+	if limit.CustomerMaxPayments.Valid {
+		window := time.Duration(limit.CustomerWindowMinutes) * time.Minute
+		payments, err := q.CountCustomerPaymentIntentsSince(ctx, db.CountCustomerPaymentIntentsSinceParams{
+			CustomerID: customerID,
+			Livemode:   param.Livemode,
+			Since:      now.Add(-window),
+		})
+		if err != nil {
+			return err
+		}
+		if payments >= limit.CustomerMaxPayments.Int32 {
+			return limitExceeded(dto.LimitViolation{
+				Rule:      constant.LimitCustomerVelocity,
+				Currency:  param.Currency,
+				Limit:     decimal.NewFromInt32(limit.CustomerMaxPayments.Int32),
+				Used:      decimal.NewFromInt32(payments),
+				Requested: decimal.NewFromInt(1),
+			}, "customer already has %d payment intents in the last %s",
+				payments, window)
+		}
+	}
+
+	return nil
+}
+
+func limitExceeded(violation dto.LimitViolation, format string, args ...any) error {
+	return errors.ErrLimitExceeded.New(format, args...).
+		WithProperty(errors.PropertyLimit, violation)
+}
@@ -0,0 +1,192 @@
+package persistencedb
+
+import (
+	"context"
+	"database/sql"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
+	"github.com/shopspring/decimal"
+)
+
+// fakeLimitQueries serves one limits row and fixed usage, and records the
+// windows it was asked about.
+type fakeLimitQueries struct {
+	limit    *db.CompanyLimit
+	used     decimal.Decimal
+	payments int32
+
+	sumSince   []time.Time
+	countSince time.Time
+}
+
+func (f *fakeLimitQueries) GetCompanyLimitForUpdate(context.Context,
+	db.GetCompanyLimitForUpdateParams) (db.CompanyLimit, error) {
+	if f.limit == nil {
+		return db.CompanyLimit{}, sqlcerr.ErrNoRows
+	}
+	return *f.limit, nil
+}
+
+func (f *fakeLimitQueries) SumPaymentIntentsSince(_ context.Context,
+	arg db.SumPaymentIntentsSinceParams) (decimal.Decimal, error) {
+	f.sumSince = append(f.sumSince, arg.Since)
+	return f.used, nil
+}
+
+func (f *fakeLimitQueries) CountCustomerPaymentIntentsSince(_ context.Context,
+	arg db.CountCustomerPaymentIntentsSinceParams) (int32, error) {
+	f.countSince = arg.Since
+	return f.payments, nil
+}
+
+func nullDecimal(value string) decimal.NullDecimal {
+	return decimal.NullDecimal{Decimal: decimal.RequireFromString(value), Valid: true}
+}
+
+func TestEnforceLimits(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	cases := []struct {
+		name      string
+		limit     *db.CompanyLimit
+		amount    string
+		used      string
+		payments  int32
+		wantRule  constant.LimitRule
+		wantReset time.Time
+	}{
+		{name: "no limits", amount: "1000000"},
+		{
+			name:     "below minimum",
+			limit:    &db.CompanyLimit{MinAmount: nullDecimal("10")},
+			amount:   "9.99",
+			wantRule: constant.LimitMinAmount,
+		},
+		{
+			name:   "at minimum",
+			limit:  &db.CompanyLimit{MinAmount: nullDecimal("10")},
+			amount: "10",
+		},
+		{
+			name:     "above maximum",
+			limit:    &db.CompanyLimit{MaxAmount: nullDecimal("500")},
+			amount:   "500.01",
+			wantRule: constant.LimitMaxAmount,
+		},
+		{
+			name:   "daily total reached exactly",
+			limit:  &db.CompanyLimit{DailyTotal: nullDecimal("1000")},
+			amount: "400",
+			used:   "600",
+		},
+		{
+			name:      "daily total exceeded",
+			limit:     &db.CompanyLimit{DailyTotal: nullDecimal("1000")},
+			amount:    "400.01",
+			used:      "600",
+			wantRule:  constant.LimitDailyTotal,
+			wantReset: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "monthly total exceeded",
+			limit:     &db.CompanyLimit{MonthlyTotal: nullDecimal("5000")},
+			amount:    "100",
+			used:      "4950",
+			wantRule:  constant.LimitMonthlyTotal,
+			wantReset: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "customer below velocity",
+			limit: &db.CompanyLimit{
+				CustomerMaxPayments:   sql.NullInt32{Int32: 3, Valid: true},
+				CustomerWindowMinutes: 60,
+			},
+			amount:   "100",
+			payments: 2,
+		},
+		{
+			name: "customer velocity reached",
+			limit: &db.CompanyLimit{
+				CustomerMaxPayments:   sql.NullInt32{Int32: 3, Valid: true},
+				CustomerWindowMinutes: 60,
+			},
+			amount:   "100",
+			payments: 3,
+			wantRule: constant.LimitCustomerVelocity,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			queries := &fakeLimitQueries{limit: c.limit, payments: c.payments}
+			if c.used != "" {
+				queries.used = decimal.RequireFromString(c.used)
+			}
+			err := enforceLimits(context.Background(), queries, dto.CreatePaymentIntent{
+				CompanyID: uuid.New(),
+				Currency:  constant.Currency("ETB"),
+				Amount:    decimal.RequireFromString(c.amount),
+			}, uuid.New(), now)
+
+			if c.wantRule == "" {
+				if err != nil {
+					t.Fatalf("enforceLimits() = %v, want nil", err)
+				}
+				return
+			}
+			if !errorx.IsOfType(err, errors.ErrLimitExceeded) {
+				t.Fatalf("enforceLimits() = %v, want ErrLimitExceeded", err)
+			}
+			property, _ := errorx.Cast(err).Property(errors.PropertyLimit)
+			violation, ok := property.(dto.LimitViolation)
+			if !ok {
+				t.Fatalf("limit property = %#v, want dto.LimitViolation", property)
+			}
+			if violation.Rule != c.wantRule {
+				t.Errorf("rule = %s, want %s", violation.Rule, c.wantRule)
+			}
+			if !c.wantReset.IsZero() &&
+				(violation.ResetsAt == nil || !violation.ResetsAt.Equal(c.wantReset)) {
+				t.Errorf("resets at = %v, want %v", violation.ResetsAt, c.wantReset)
+			}
+		})
+	}
+}
+
+func TestEnforceLimitsWindows(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, time.FixedZone("EAT", 3*60*60))
+	queries := &fakeLimitQueries{limit: &db.CompanyLimit{
+		DailyTotal:            nullDecimal("1000"),
+		MonthlyTotal:          nullDecimal("5000"),
+		CustomerMaxPayments:   sql.NullInt32{Int32: 3, Valid: true},
+		CustomerWindowMinutes: 30,
+	}}
+	if err := enforceLimits(context.Background(), queries, dto.CreatePaymentIntent{
+		Currency: constant.Currency("ETB"),
+		Amount:   decimal.RequireFromString("1"),
+	}, uuid.New(), now); err != nil {
+		t.Fatalf("enforceLimits() = %v, want nil", err)
+	}
+
+	wantSums := []time.Time{
+		time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if len(queries.sumSince) != len(wantSums) {
+		t.Fatalf("summed %d windows, want %d", len(queries.sumSince), len(wantSums))
+	}
+	for i, want := range wantSums {
+		if !queries.sumSince[i].Equal(want) {
+			t.Errorf("window %d starts %v, want %v", i, queries.sumSince[i], want)
+		}
+	}
+	if want := now.Add(-30 * time.Minute); !queries.countSince.Equal(want) {
+		t.Errorf("velocity window starts %v, want %v", queries.countSince, want)
+	}
+}
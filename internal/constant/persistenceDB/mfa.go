@@ -0,0 +1,195 @@
+package persistencedb
+
+import (
+	"context"
+	"database/sql"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/db"
+	"pg/platform/hcrypto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ReplaceUserRecoveryCodesTx swaps every recovery code of the user for the
+// given hashes. When enable is set the user's pending MFA enrollment is
+// activated in the same transaction.
+func (q *PersistenceDB) ReplaceUserRecoveryCodesTx(ctx context.Context,
+	userID uuid.UUID, codeHashes []string, enable bool) (err error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return errors.ErrUnableToUpdate.Wrap(err, "error starting transaction")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+			panic(p) // re-throw panic after Rollback
+		} else if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+		}
+	}()
+
+	tQ := q.WithTx(tx)
+	if enable {
+		if err = tQ.EnableUserMFA(ctx, userID); err != nil {
+			err = errors.ErrUnableToUpdate.Wrap(err, "error enabling mfa")
+			q.log.Error(ctx, "unable to enable mfa", zap.Error(err),
+				zap.String("user-id", userID.String()))
+			return err
+		}
+	}
+	if err = tQ.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "error deleting recovery codes")
+		q.log.Error(ctx, "unable to delete recovery codes", zap.Error(err),
+			zap.String("user-id", userID.String()))
+		return err
+	}
+	for _, codeHash := range codeHashes {
+		if err = tQ.CreateUserRecoveryCode(ctx, db.CreateUserRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: codeHash,
+		}); err != nil {
+			err = errors.ErrUnableToCreate.Wrap(err, "error creating recovery code")
+			q.log.Error(ctx, "unable to create recovery code", zap.Error(err),
+				zap.String("user-id", userID.String()))
+			return err
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return errors.ErrUnableToUpdate.Wrap(err, "error committing transaction")
+	}
+	return nil
+}
+
+func (q *PersistenceDB) DisableUserMFATx(ctx context.Context,
+	userID uuid.UUID) (err error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return errors.ErrUnableToUpdate.Wrap(err, "error starting transaction")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+			panic(p) // re-throw panic after Rollback
+		} else if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+		}
+	}()
+
+	tQ := q.WithTx(tx)
+	if err = tQ.DisableUserMFA(ctx, userID); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "error disabling mfa")
+		q.log.Error(ctx, "unable to disable mfa", zap.Error(err),
+			zap.String("user-id", userID.String()))
+		return err
+	}
+	if err = tQ.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "error deleting recovery codes")
+		q.log.Error(ctx, "unable to delete recovery codes", zap.Error(err),
+			zap.String("user-id", userID.String()))
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return errors.ErrUnableToUpdate.Wrap(err, "error committing transaction")
+	}
+	return nil
+}
+
+// SealedMFASecret is a TOTP secret as it is stored: sealed with a data key.
+type SealedMFASecret struct {
+	DataKeyID  uuid.NullUUID
+	Ciphertext []byte
+}
+
+// SealUserMFASecret seals a user's TOTP secret with the active data key. The
+// ciphertext is bound to the user, so it does not open on another row.
+func (q PersistenceDB) SealUserMFASecret(ctx context.Context, userID uuid.UUID,
+	secret string) (SealedMFASecret, error) {
+	id, key, err := q.activeDataKey(ctx)
+	if err != nil {
+		return SealedMFASecret{}, err
+	}
+	sealed, err := hcrypto.Seal(key, []byte(secret), mfaSecretAAD(userID))
+	if err != nil {
+		return SealedMFASecret{}, err
+	}
+
+	return SealedMFASecret{
+		DataKeyID:  uuid.NullUUID{UUID: id, Valid: true},
+		Ciphertext: sealed,
+	}, nil
+}
+
+// OpenUserMFASecret returns a user's TOTP secret. Secrets not sealed yet are
+// read from the plaintext column; users without a secret get "".
+func (q PersistenceDB) OpenUserMFASecret(ctx context.Context, userID uuid.UUID,
+	dataKeyID uuid.NullUUID, plaintext sql.NullString, ciphertext []byte) (string, error) {
+	if ciphertext == nil || !dataKeyID.Valid {
+		return plaintext.String, nil
+	}
+	key, err := q.dataKey(ctx, dataKeyID.UUID)
+	if err != nil {
+		return "", err
+	}
+	secret, err := hcrypto.Open(key, ciphertext, mfaSecretAAD(userID))
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
+
+// ResealUserMFASecrets seals up to batchSize TOTP secrets that are still in
+// plaintext or not sealed with the active data key. It returns how many
+// rows it looked at; zero means none are left.
+func (q PersistenceDB) ResealUserMFASecrets(ctx context.Context, batchSize int) (int, error) {
+	active, _, err := q.activeDataKey(ctx)
+	if err != nil {
+		return 0, err
+	}
+	users, err := q.ListUserMFASecretsToReseal(ctx, db.ListUserMFASecretsToResealParams{
+		DataKeyID: uuid.NullUUID{UUID: active, Valid: true},
+		BatchSize: int32(batchSize),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i, user := range users {
+		secret, err := q.OpenUserMFASecret(ctx, user.ID, user.MfaDataKeyID,
+			user.MfaSecret, user.MfaSecretCiphertext)
+		if err != nil {
+			return i, err
+		}
+		sealed, err := q.SealUserMFASecret(ctx, user.ID, secret)
+		if err != nil {
+			return i, err
+		}
+		// A secret replaced or removed since it was read is left alone.
+		_, err = q.ResealUserMFASecret(ctx, db.ResealUserMFASecretParams{
+			DataKeyID:           sealed.DataKeyID,
+			MfaSecretCiphertext: sealed.Ciphertext,
+			ID:                  user.ID,
+			PreviousDataKeyID:   user.MfaDataKeyID,
+			PreviousSecret:      user.MfaSecret,
+			PreviousCiphertext:  user.MfaSecretCiphertext,
+		})
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(users), nil
+}
+
+func mfaSecretAAD(userID uuid.UUID) []byte {
+	return []byte("users.mfa_secret:" + userID.String())
+}
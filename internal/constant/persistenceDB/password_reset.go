@@ -0,0 +1,109 @@
+package persistencedb
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/db"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (q *PersistenceDB) CreatePasswordResetTx(ctx context.Context,
+	userID, tokenID uuid.UUID) (reset db.PasswordReset, err error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return reset, errors.ErrUnableToCreate.Wrap(err, "error starting transaction")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+			panic(p) // re-throw panic after Rollback
+		} else if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+		}
+	}()
+
+	tQ := q.WithTx(tx)
+	// Only the most recently requested reset token stays usable.
+	if err = tQ.RevokePendingPasswordResets(ctx, userID); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "error revoking pending password resets")
+		q.log.Error(ctx, "unable to revoke pending password resets", zap.Error(err),
+			zap.String("user-id", userID.String()))
+		return reset, err
+	}
+	reset, err = tQ.CreatePasswordReset(ctx, db.CreatePasswordResetParams{
+		UserID:  userID,
+		TokenID: tokenID,
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "error creating password reset")
+		q.log.Error(ctx, "unable to create password reset", zap.Error(err),
+			zap.String("user-id", userID.String()))
+		return reset, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return reset, errors.ErrUnableToCreate.Wrap(err, "error committing transaction")
+	}
+	return reset, nil
+}
+
+// ResetPasswordTx consumes the reset record, stores the new password hash
+// and revokes every session the user currently holds.
+func (q *PersistenceDB) ResetPasswordTx(ctx context.Context,
+	resetID, userID uuid.UUID, hashedPassword string) (err error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return errors.ErrUnableToReset.Wrap(err, "error starting transaction")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+			panic(p) // re-throw panic after Rollback
+		} else if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+		}
+	}()
+
+	tQ := q.WithTx(tx)
+	used, err := tQ.UsePasswordReset(ctx, resetID)
+	if err != nil {
+		err = errors.ErrUnableToReset.Wrap(err, "error using password reset")
+		q.log.Error(ctx, "unable to use password reset", zap.Error(err),
+			zap.String("reset-id", resetID.String()))
+		return err
+	}
+	if used == 0 {
+		err = errors.ErrInvalidAccessToken.New("reset token is no longer valid")
+		q.log.Warn(ctx, "password reset already used or revoked", zap.Error(err),
+			zap.String("reset-id", resetID.String()))
+		return err
+	}
+	if err = tQ.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+		ID:       userID,
+		Password: hashedPassword,
+	}); err != nil {
+		err = errors.ErrUnableToReset.Wrap(err, "error updating password")
+		q.log.Error(ctx, "unable to update password", zap.Error(err),
+			zap.String("user-id", userID.String()))
+		return err
+	}
+	if err = tQ.ResetActiveToken(ctx, userID); err != nil {
+		err = errors.ErrUnableToReset.Wrap(err, "error revoking user tokens")
+		q.log.Error(ctx, "unable to revoke user tokens", zap.Error(err),
+			zap.String("user-id", userID.String()))
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return errors.ErrUnableToReset.Wrap(err, "error committing transaction")
+	}
+	return nil
+}
@@ -9,7 +9,9 @@ import (
 	"pg/internal/constant/model/db"
 	"pg/internal/constant/model/dto"
 	"pg/platform/sql"
+	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -33,13 +35,24 @@ func (q *PersistenceDB) CreatePaymentIntentTx(ctx context.Context,
 	}()
 
 	tQ := q.WithTx(tx)
-	customer, err := tQ.CreateCustomer(ctx,
-		db.CreateCustomerParams{
-			CompanyID:   param.CompanyID,
-			FullName:    sql.StringOrNull(param.Customer.FullName),
+	customerID := param.CustomerID
+	if customerID == uuid.Nil {
+		// err must not be shadowed here, the deferred rollback reads it.
+		customerID, err = tQ.saveCustomer(ctx, param.CompanyID, param.Livemode, CustomerPII{
+			FullName:    param.Customer.FullName,
 			PhoneNumber: param.Customer.PhoneNumber,
-			Email:       sql.StringOrNull(param.Customer.Email),
+			Email:       param.Customer.Email,
 		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if err = tQ.enforceLimits(ctx, param, customerID, now); err != nil {
+		return nil, err
+	}
+	risk, err := tQ.assessRisk(ctx, param, customerID, now)
 	if err != nil {
 		return nil, err
 	}
@@ -49,34 +62,49 @@ func (q *PersistenceDB) CreatePaymentIntentTx(ctx context.Context,
 		return nil, err
 	}
 
-	paymentIntent, err := tQ.CreatePaymentIntent(ctx,
-		db.CreatePaymentIntentParams{
-			CompanyID:   param.CompanyID,
-			PaymentType: constant.PaymentTypeOnetime,
-			Amount:      param.Amount,
-			Status:      string(constant.Pending),
-			Currency:    string(param.Currency),
-			CallbackUrl: param.CallBackURL,
-			ReturnUrl:   param.ReturnURL,
-			Description: sql.StringOrNull(param.Description),
-			CustomerID:  customer.ID,
-			Extra:       sql.MapJSONOrNull(extra),
-			BillRefNo:   sql.StringOrNull(param.BillRefNO),
-		})
+	arg := db.CreatePaymentIntentParams{
+		CompanyID:           param.CompanyID,
+		PaymentType:         constant.PaymentTypeOnetime,
+		Amount:              param.Amount,
+		Currency:            string(param.Currency),
+		CallbackUrl:         param.CallBackURL,
+		ReturnUrl:           param.ReturnURL,
+		Description:         sql.StringOrNull(param.Description),
+		CustomerID:          customerID,
+		Extra:               sql.MapJSONOrNull(extra),
+		BillRefNo:           sql.StringOrNull(param.BillRefNO),
+		Livemode:            param.Livemode,
+		SettlementCurrency:  sql.StringOrNull(string(param.Settlement.Currency)),
+		SettlementAmount:    sql.DecimalOrNull(param.Settlement.Amount),
+		RequireConfirmation: param.RequireConfirmation,
+	}
+	if fx := param.Settlement.FX; fx != nil {
+		spreadBps := int32(fx.SpreadBps)
+		arg.FxMidRate = sql.DecimalOrNullPntr(&fx.MidRate)
+		arg.FxSpreadBps = sql.Int32OrNullpntr(&spreadBps)
+		arg.FxRate = sql.DecimalOrNullPntr(&fx.Rate)
+		arg.FxRateSource = sql.StringOrNull(fx.Source)
+		arg.FxRateAsOf = sql.TimeOrNull(fx.AsOf)
+	}
+	// Intents held for review or blocked are not sent to be processed, nor
+	// are those waiting for the payer to confirm them.
+	status, err := setRisk(&arg, risk)
 	if err != nil {
 		return nil, err
 	}
-
-	// Publish to RabbitMQ
-	payload, err := json.Marshal(map[string]string{
-		"payment_intent_id": paymentIntent.ID.String(),
-	})
+	if status == constant.Pending && param.RequireConfirmation {
+		status = constant.RequiresConfirmation
+	}
+	arg.Status = string(status)
+	paymentIntent, err := tQ.CreatePaymentIntent(ctx, arg)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := client.Publish(ctx, "", "payment_processing", payload); err != nil {
-		return nil, err
+	if status == constant.Pending {
+		if err = publishPaymentIntent(ctx, client, paymentIntent.ID); err != nil {
+			return nil, err
+		}
 	}
 
 	if err = tx.Commit(ctx); err != nil {
@@ -85,3 +113,39 @@ func (q *PersistenceDB) CreatePaymentIntentTx(ctx context.Context,
 
 	return &paymentIntent, nil
 }
+
+// publishPaymentIntent queues a payment intent to be processed.
+func publishPaymentIntent(ctx context.Context, client amqp.Client, id uuid.UUID) error {
+	payload, err := json.Marshal(map[string]string{
+		"payment_intent_id": id.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return client.Publish(ctx, "", "payment_processing", payload)
+}
+
+// PaymentIntentSettlement is what the merchant is paid for a payment
+// intent. Intents created before settlement was recorded settle in their
+// own currency.
+func PaymentIntentSettlement(pi db.PaymentIntent) dto.Settlement {
+	if !pi.SettlementCurrency.Valid {
+		return dto.Settlement{Currency: constant.Currency(pi.Currency), Amount: pi.Amount}
+	}
+	settlement := dto.Settlement{
+		Currency: constant.Currency(pi.SettlementCurrency.String),
+		Amount:   pi.SettlementAmount.Decimal,
+	}
+	if pi.FxRate.Valid {
+		settlement.FX = &dto.FXSnapshot{
+			MidRate:   pi.FxMidRate.Decimal,
+			SpreadBps: int(pi.FxSpreadBps.Int32),
+			Rate:      pi.FxRate.Decimal,
+			Source:    pi.FxRateSource.String,
+			AsOf:      pi.FxRateAsOf.Time,
+		}
+	}
+
+	return settlement
+}
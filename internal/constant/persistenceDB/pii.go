@@ -0,0 +1,180 @@
+package persistencedb
+
+import (
+	"context"
+	"errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/platform/hcrypto"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// activeKeyTTL bounds how long an instance keeps sealing with a data key
+// after another instance rotated it.
+const activeKeyTTL = time.Minute
+
+// vault caches unwrapped data keys. It is shared by every copy of a
+// PersistenceDB, including the ones bound to a transaction.
+type vault struct {
+	keys        *hcrypto.FieldKeyring
+	mu          sync.Mutex
+	dataKeys    map[uuid.UUID][]byte
+	active      uuid.UUID
+	activeUntil time.Time
+}
+
+func newVault(keys *hcrypto.FieldKeyring) *vault {
+	return &vault{
+		keys:     keys,
+		dataKeys: map[uuid.UUID][]byte{},
+	}
+}
+
+// activeDataKey returns the data key new values are sealed with, creating
+// the first one if there is none yet. Keys are read and created outside any
+// transaction q is bound to, so a rollback cannot undo a cached key.
+func (q PersistenceDB) activeDataKey(ctx context.Context) (uuid.UUID, []byte, error) {
+	v := q.vault
+	if v == nil || v.keys == nil {
+		return uuid.Nil, nil, errors.New("personal data keys are not configured")
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.active != uuid.Nil && time.Now().Before(v.activeUntil) {
+		return v.active, v.dataKeys[v.active], nil
+	}
+
+	pool := q.WithTx(q.pool)
+	dataKey, err := pool.GetActivePIIDataKey(ctx)
+	if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+		dataKey, err = pool.createDataKey(ctx)
+	}
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	key, err := v.unwrap(dataKey)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	v.active, v.activeUntil = dataKey.ID, time.Now().Add(activeKeyTTL)
+
+	return dataKey.ID, key, nil
+}
+
+// dataKey returns the data key a value was sealed with.
+func (q PersistenceDB) dataKey(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	v := q.vault
+	if v == nil || v.keys == nil {
+		return nil, errors.New("personal data keys are not configured")
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if key, ok := v.dataKeys[id]; ok {
+		return key, nil
+	}
+
+	dataKey, err := q.WithTx(q.pool).GetPIIDataKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return v.unwrap(dataKey)
+}
+
+// createDataKey stores a new active data key. If another instance created
+// one first, that one is returned instead.
+func (q PersistenceDB) createDataKey(ctx context.Context) (db.PiiDataKey, error) {
+	key, err := q.vault.keys.NewDataKey()
+	if err != nil {
+		return db.PiiDataKey{}, err
+	}
+	id := uuid.New()
+	masterKeyID, wrapped, err := q.vault.keys.WrapDataKey(id.String(), key)
+	if err != nil {
+		return db.PiiDataKey{}, err
+	}
+	dataKey, err := q.CreatePIIDataKey(ctx, db.CreatePIIDataKeyParams{
+		ID:          id,
+		MasterKeyID: masterKeyID,
+		WrappedKey:  wrapped,
+	})
+	if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+		return q.GetActivePIIDataKey(ctx)
+	}
+
+	return dataKey, err
+}
+
+func (v *vault) unwrap(dataKey db.PiiDataKey) ([]byte, error) {
+	if key, ok := v.dataKeys[dataKey.ID]; ok {
+		return key, nil
+	}
+	key, err := v.keys.UnwrapDataKey(dataKey.MasterKeyID, dataKey.ID.String(), dataKey.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	v.dataKeys[dataKey.ID] = key
+
+	return key, nil
+}
+
+// RotatePIIDataKey retires the active data key and makes a new one active.
+// Values sealed with the retired key stay readable until they are resealed.
+func (q PersistenceDB) RotatePIIDataKey(ctx context.Context) (db.PiiDataKey, error) {
+	if q.vault == nil || q.vault.keys == nil {
+		return db.PiiDataKey{}, errors.New("personal data keys are not configured")
+	}
+	var dataKey db.PiiDataKey
+	err := q.WithTransaction(ctx, func(tx PersistenceDB) error {
+		if err := tx.RetireActivePIIDataKey(ctx); err != nil {
+			return err
+		}
+		var err error
+		dataKey, err = tx.createDataKey(ctx)
+		return err
+	})
+	if err != nil {
+		return db.PiiDataKey{}, err
+	}
+
+	q.vault.mu.Lock()
+	q.vault.active = uuid.Nil
+	q.vault.mu.Unlock()
+
+	return dataKey, nil
+}
+
+// RewrapPIIDataKeys wraps every data key that is still wrapped by a previous
+// master key with the current one, so the previous master key can be
+// removed. It returns how many keys were rewrapped.
+func (q PersistenceDB) RewrapPIIDataKeys(ctx context.Context) (int, error) {
+	if q.vault == nil || q.vault.keys == nil {
+		return 0, errors.New("personal data keys are not configured")
+	}
+	dataKeys, err := q.ListPIIDataKeysToRewrap(ctx, q.vault.keys.MasterKeyID())
+	if err != nil {
+		return 0, err
+	}
+	for i, dataKey := range dataKeys {
+		key, err := q.vault.keys.UnwrapDataKey(dataKey.MasterKeyID, dataKey.ID.String(),
+			dataKey.WrappedKey)
+		if err != nil {
+			return i, err
+		}
+		masterKeyID, wrapped, err := q.vault.keys.WrapDataKey(dataKey.ID.String(), key)
+		if err != nil {
+			return i, err
+		}
+		if err := q.RewrapPIIDataKey(ctx, db.RewrapPIIDataKeyParams{
+			ID:          dataKey.ID,
+			MasterKeyID: masterKeyID,
+			WrappedKey:  wrapped,
+		}); err != nil {
+			return i, err
+		}
+	}
+
+	return len(dataKeys), nil
+}
@@ -0,0 +1,302 @@
+package persistencedb
+
+import (
+	"context"
+	"encoding/json"
+	"pg/initiator/platform/amqp"
+	"pg/internal/constant"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"pg/platform/riskexpr"
+	"pg/platform/sql"
+	"strings"
+	"time"
+
+	"github.com/dongri/phonenumber"
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// merchantHistory is how far back a company's payment intents are averaged
+// for the amount_ratio signal.
+const merchantHistory = 30 * 24 * time.Hour
+
+// assessRisk scores a new payment intent against the company's enabled risk
+// rules. It returns nil when the company has none. Rules are checked when
+// they are saved, so one that no longer compiles or evaluates is logged and
+// skipped rather than failing the payment.
+func (q PersistenceDB) assessRisk(ctx context.Context, param dto.CreatePaymentIntent,
+	customerID uuid.UUID, now time.Time) (*dto.RiskAssessment, error) {
+	rules, err := q.ListEnabledRiskRules(ctx, param.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	signals, err := q.riskSignals(ctx, param, customerID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	assessment := &dto.RiskAssessment{Rules: []string{}, Signals: signals}
+	for _, rule := range rules {
+		expr, err := riskexpr.Compile(rule.Expression, dto.RiskSignals)
+		if err != nil {
+			q.log.Warn(ctx, "skipping risk rule that does not compile", zap.Error(err),
+				zap.String("risk-rule-id", rule.ID.String()))
+			continue
+		}
+		matched, err := expr.Eval(signals)
+		if err != nil {
+			q.log.Warn(ctx, "skipping risk rule that failed to evaluate", zap.Error(err),
+				zap.String("risk-rule-id", rule.ID.String()))
+			continue
+		}
+		if matched {
+			assessment.Score += int(rule.Score)
+			assessment.Rules = append(assessment.Rules, rule.Name)
+		}
+	}
+
+	settings, err := q.CompanyRiskSettings(ctx, param.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	assessment.Outcome = settings.Outcome(assessment.Score)
+
+	return assessment, nil
+}
+
+// CompanyRiskSettings returns the company's review and block scores, or the
+// defaults when an operator has not set them.
+func (q PersistenceDB) CompanyRiskSettings(ctx context.Context,
+	companyID uuid.UUID) (dto.RiskSettings, error) {
+	settings, err := q.GetCompanyRiskSettings(ctx, companyID)
+	if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+		return dto.RiskSettings{
+			CompanyID:   companyID,
+			ReviewScore: dto.DefaultRiskReviewScore,
+			BlockScore:  dto.DefaultRiskBlockScore,
+		}, nil
+	}
+	if err != nil {
+		return dto.RiskSettings{}, err
+	}
+
+	return dto.RiskSettings{
+		CompanyID:   settings.CompanyID,
+		ReviewScore: int(settings.ReviewScore),
+		BlockScore:  int(settings.BlockScore),
+		UpdatedAt:   settings.UpdatedAt,
+	}, nil
+}
+
+// riskSignals gathers the signals of dto.RiskSignals for a new payment
+// intent. The intent itself is not stored yet, so it is not in any count.
+func (q PersistenceDB) riskSignals(ctx context.Context, param dto.CreatePaymentIntent,
+	customerID uuid.UUID, now time.Time) (map[string]any, error) {
+	stats, err := q.GetPaymentIntentStatsSince(ctx, db.GetPaymentIntentStatsSinceParams{
+		CompanyID: param.CompanyID,
+		Currency:  string(param.Currency),
+		Livemode:  param.Livemode,
+		Since:     now.Add(-merchantHistory),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ratio := decimal.Zero
+	if stats.AverageAmount.IsPositive() {
+		ratio = param.Amount.DivRound(stats.AverageAmount, 4)
+	}
+
+	customerPayments := map[string]int32{}
+	for name, since := range map[string]time.Time{
+		"total": {},
+		"1h":    now.Add(-time.Hour),
+		"24h":   now.Add(-24 * time.Hour),
+	} {
+		count, err := q.CountCustomerPaymentIntentsSince(ctx, db.CountCustomerPaymentIntentsSinceParams{
+			CustomerID: customerID,
+			Livemode:   param.Livemode,
+			Since:      since,
+		})
+		if err != nil {
+			return nil, err
+		}
+		customerPayments[name] = count
+	}
+
+	phone := param.Customer.PhoneNumber
+	if param.CustomerID != uuid.Nil {
+		customer, err := q.GetCustomerByID(ctx, db.GetCustomerByIDParams{
+			ID:        param.CustomerID,
+			CompanyID: param.CompanyID,
+			Livemode:  param.Livemode,
+		})
+		if err != nil {
+			return nil, err
+		}
+		pii, err := q.openCustomerPII(ctx, customer)
+		if err != nil {
+			return nil, err
+		}
+		phone = pii.PhoneNumber
+	}
+	blocklistHits := int32(0)
+	if index := q.CustomerPhoneIndex(param.CompanyID, phone); index != nil {
+		blocklistHits, err = q.CountPhoneBlocklistHitsSince(ctx, db.CountPhoneBlocklistHitsSinceParams{
+			CompanyID:        param.CompanyID,
+			PhoneNumberIndex: index,
+			Since:            now.Add(-merchantHistory),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	company, err := q.GetCompanyByID(ctx, param.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	phoneCountry := phoneCountry(phone)
+	companyCountry := countryCode(company.AddressCountry.String)
+
+	return map[string]any{
+		"amount":                 param.Amount,
+		"currency":               string(param.Currency),
+		"livemode":               param.Livemode,
+		"merchant_avg_amount":    stats.AverageAmount.Round(4),
+		"merchant_payments_30d":  stats.Payments,
+		"amount_ratio":           ratio,
+		"new_customer":           customerPayments["total"] == 0,
+		"customer_payments_1h":   customerPayments["1h"],
+		"customer_payments_24h":  customerPayments["24h"],
+		"phone_country":          phoneCountry,
+		"company_country":        companyCountry,
+		"phone_country_mismatch": phoneCountry != "" && companyCountry != "" && phoneCountry != companyCountry,
+		"blocklist_hits":         blocklistHits,
+	}, nil
+}
+
+// phoneCountry is the ISO 3166 alpha-2 code of a phone number's country.
+// Numbers without a country code are read as Ethiopian, as everywhere else.
+func phoneCountry(phone string) string {
+	number := phonenumber.Parse(phone, "ET")
+	if number == "" {
+		return ""
+	}
+	return phonenumber.GetISO3166ByNumber(number, true).Alpha2
+}
+
+// countryCode turns a company's address country, which merchants type as a
+// code or a name, into an ISO 3166 alpha-2 code. It is empty when the
+// country is not recognised.
+func countryCode(country string) string {
+	country = strings.TrimSpace(country)
+	if country == "" {
+		return ""
+	}
+	for _, c := range phonenumber.GetISO3166() {
+		if strings.EqualFold(country, c.Alpha2) || strings.EqualFold(country, c.Alpha3) ||
+			strings.EqualFold(country, c.CountryName) {
+			return c.Alpha2
+		}
+	}
+	return ""
+}
+
+// setRisk records an assessment on a payment intent about to be created
+// and returns the status it starts in.
+func setRisk(arg *db.CreatePaymentIntentParams, assessment *dto.RiskAssessment) (constant.Status, error) {
+	if assessment == nil {
+		return constant.Pending, nil
+	}
+	rules, err := json.Marshal(assessment.Rules)
+	if err != nil {
+		return "", err
+	}
+	signals, err := json.Marshal(assessment.Signals)
+	if err != nil {
+		return "", err
+	}
+	score := int32(assessment.Score)
+	arg.RiskScore = sql.Int32OrNullpntr(&score)
+	arg.RiskOutcome = sql.StringOrNull(string(assessment.Outcome))
+	arg.RiskRules = pgtype.JSONB{Bytes: rules, Status: pgtype.Present}
+	arg.RiskSignals = pgtype.JSONB{Bytes: signals, Status: pgtype.Present}
+
+	switch assessment.Outcome {
+	case constant.RiskBlock:
+		return constant.Blocked, nil
+	case constant.RiskReview:
+		return constant.Review, nil
+	default:
+		return constant.Pending, nil
+	}
+}
+
+// PaymentIntentRisk is the assessment stored with a payment intent, nil
+// when it was not assessed.
+func PaymentIntentRisk(pi db.PaymentIntent) (*dto.RiskAssessment, error) {
+	if !pi.RiskOutcome.Valid {
+		return nil, nil
+	}
+	assessment := &dto.RiskAssessment{
+		Score:      int(pi.RiskScore.Int32),
+		Outcome:    constant.RiskOutcome(pi.RiskOutcome.String),
+		ReviewNote: pi.ReviewNote.String,
+	}
+	if pi.RiskRules.Bytes != nil {
+		if err := json.Unmarshal(pi.RiskRules.Bytes, &assessment.Rules); err != nil {
+			return nil, err
+		}
+	}
+	if pi.RiskSignals.Bytes != nil {
+		if err := json.Unmarshal(pi.RiskSignals.Bytes, &assessment.Signals); err != nil {
+			return nil, err
+		}
+	}
+	if pi.ReviewedBy.Valid {
+		reviewedBy := pi.ReviewedBy.UUID
+		assessment.ReviewedBy = &reviewedBy
+	}
+	if pi.ReviewedAt.Valid {
+		reviewedAt := pi.ReviewedAt.Time
+		assessment.ReviewedAt = &reviewedAt
+	}
+
+	return assessment, nil
+}
+
+// ReviewPaymentIntentTx records an operator's decision on a payment intent
+// held for review. An approved intent is queued to be processed in the same
+// transaction, unless it still needs the payer's confirmation. It fails
+// with sqlcerr.ErrNoRows when the intent is not held.
+func (q PersistenceDB) ReviewPaymentIntentTx(ctx context.Context,
+	param dto.ReviewPaymentIntent, client amqp.Client) (*db.PaymentIntent, error) {
+	var reviewed db.PaymentIntent
+	err := q.WithTransaction(ctx, func(tx PersistenceDB) error {
+		var err error
+		reviewed, err = tx.ReviewPaymentIntent(ctx, db.ReviewPaymentIntentParams{
+			ID:         param.PaymentIntentID,
+			Status:     string(param.Status),
+			ReviewedBy: sql.UUIDOrNull(param.ReviewedBy),
+			ReviewNote: sql.StringOrNull(param.Note),
+		})
+		if err != nil {
+			return err
+		}
+		if constant.Status(reviewed.Status) == constant.Pending {
+			return publishPaymentIntent(ctx, client, reviewed.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &reviewed, nil
+}
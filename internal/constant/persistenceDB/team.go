@@ -0,0 +1,116 @@
+package persistencedb
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (q *PersistenceDB) CreateUserInvitationTx(ctx context.Context,
+	param dto.CreateUserInvitation) (invitation db.UserInvitation, err error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return invitation, errors.ErrUnableToCreate.Wrap(err, "error starting transaction")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+			panic(p) // re-throw panic after Rollback
+		} else if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+		}
+	}()
+
+	tQ := q.WithTx(tx)
+	// A new invitation supersedes any pending one for the same email.
+	if err = tQ.RevokePendingUserInvitations(ctx, db.RevokePendingUserInvitationsParams{
+		CompanyID: param.CompanyID,
+		Email:     param.Email,
+	}); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "error revoking pending invitations")
+		q.log.Error(ctx, "unable to revoke pending invitations", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()))
+		return invitation, err
+	}
+	invitation, err = tQ.CreateUserInvitation(ctx, db.CreateUserInvitationParams{
+		CompanyID: param.CompanyID,
+		Email:     param.Email,
+		Role:      string(param.Role),
+		TokenID:   param.TokenID,
+		InvitedBy: param.InvitedBy,
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "error creating invitation")
+		q.log.Error(ctx, "unable to create invitation", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()))
+		return invitation, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return invitation, errors.ErrUnableToCreate.Wrap(err, "error committing transaction")
+	}
+	return invitation, nil
+}
+
+func (q *PersistenceDB) AcceptUserInvitationTx(ctx context.Context,
+	invitationID uuid.UUID, param dto.CreateUser) (user db.User, err error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return user, errors.ErrUnableToCreate.Wrap(err, "error starting transaction")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+			panic(p) // re-throw panic after Rollback
+		} else if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+		}
+	}()
+
+	tQ := q.WithTx(tx)
+	accepted, err := tQ.AcceptUserInvitation(ctx, invitationID)
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "error accepting invitation")
+		q.log.Error(ctx, "unable to accept invitation", zap.Error(err),
+			zap.String("invitation-id", invitationID.String()))
+		return user, err
+	}
+	if accepted == 0 {
+		err = errors.ErrInvalidUserInput.New("invitation is no longer valid")
+		q.log.Warn(ctx, "invitation already accepted or revoked", zap.Error(err),
+			zap.String("invitation-id", invitationID.String()))
+		return user, err
+	}
+	user, err = tQ.CreateUser(ctx, db.CreateUserParams{
+		CompanyID:       param.CompanyID,
+		FirstName:       sql.StringOrNull(param.FirstName),
+		Email:           param.Email,
+		Phone:           param.Phone,
+		Password:        param.Password,
+		Role:            string(param.Role),
+		Status:          string(param.Status),
+		EmailVerifiedAt: sql.TimeOrNull(param.EmailVerifiedAt),
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "error creating invited user")
+		q.log.Error(ctx, "unable to create invited user", zap.Error(err),
+			zap.String("invitation-id", invitationID.String()))
+		return user, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return user, errors.ErrUnableToCreate.Wrap(err, "error committing transaction")
+	}
+	return user, nil
+}
@@ -0,0 +1,115 @@
+package persistencedb
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"pg/platform/notifier"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (q *PersistenceDB) CreateUserVerificationTx(ctx context.Context,
+	param dto.CreateUserVerification) (verification db.UserVerification, err error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return verification, errors.ErrUnableToCreate.Wrap(err, "error starting transaction")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+			panic(p) // re-throw panic after Rollback
+		} else if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+		}
+	}()
+
+	tQ := q.WithTx(tx)
+	// Only the most recently sent link or code stays usable per channel.
+	if err = tQ.RevokePendingUserVerifications(ctx, db.RevokePendingUserVerificationsParams{
+		UserID:  param.UserID,
+		Channel: param.Channel,
+	}); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "error revoking pending verifications")
+		q.log.Error(ctx, "unable to revoke pending verifications", zap.Error(err),
+			zap.String("user-id", param.UserID.String()))
+		return verification, err
+	}
+	verification, err = tQ.CreateUserVerification(ctx, db.CreateUserVerificationParams{
+		UserID:    param.UserID,
+		Channel:   param.Channel,
+		TokenID:   uuid.NullUUID{UUID: param.TokenID, Valid: param.TokenID != uuid.Nil},
+		CodeHash:  sql.StringOrNull(param.CodeHash),
+		ExpiresAt: param.ExpiresAt,
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "error creating verification")
+		q.log.Error(ctx, "unable to create verification", zap.Error(err),
+			zap.String("user-id", param.UserID.String()))
+		return verification, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return verification, errors.ErrUnableToCreate.Wrap(err, "error committing transaction")
+	}
+	return verification, nil
+}
+
+// CompleteUserVerificationTx consumes the verification record and marks the
+// matching contact of the user as verified. The user becomes ACTIVE once
+// both the email and the phone are verified.
+func (q *PersistenceDB) CompleteUserVerificationTx(ctx context.Context,
+	verification dto.UserVerification) (user db.User, err error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return user, errors.ErrUnableToUpdate.Wrap(err, "error starting transaction")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+			panic(p) // re-throw panic after Rollback
+		} else if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				q.log.Error(ctx, "error rolling back transaction", zap.Error(rbErr))
+			}
+		}
+	}()
+
+	tQ := q.WithTx(tx)
+	completed, err := tQ.CompleteUserVerification(ctx, verification.ID)
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "error completing verification")
+		q.log.Error(ctx, "unable to complete verification", zap.Error(err),
+			zap.String("verification-id", verification.ID.String()))
+		return user, err
+	}
+	if completed == 0 {
+		err = errors.ErrInvalidUserInput.New("verification is no longer valid")
+		q.log.Warn(ctx, "verification already used, revoked or expired", zap.Error(err),
+			zap.String("verification-id", verification.ID.String()))
+		return user, err
+	}
+	if verification.Channel == string(notifier.ChannelSMS) {
+		user, err = tQ.MarkUserPhoneVerified(ctx, verification.UserID)
+	} else {
+		user, err = tQ.MarkUserEmailVerified(ctx, verification.UserID)
+	}
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "error marking user as verified")
+		q.log.Error(ctx, "unable to mark user as verified", zap.Error(err),
+			zap.String("user-id", verification.UserID.String()))
+		return user, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return user, errors.ErrUnableToUpdate.Wrap(err, "error committing transaction")
+	}
+	return user, nil
+}
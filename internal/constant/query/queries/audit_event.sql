@@ -0,0 +1,50 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+  company_id,
+  actor_type,
+  actor_id,
+  action,
+  ip_address,
+  request_id,
+  metadata,
+  before,
+  after
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: ListAuditEvents :many
+SELECT *
+FROM audit_events
+WHERE company_id = @company_id
+  AND (sqlc.narg('action')::VARCHAR IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('actor_id')::UUID IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('from_time')::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg('from_time'))
+  AND (sqlc.narg('to_time')::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg('to_time'))
+ORDER BY created_at DESC, id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: ListAuditEventsBefore :many
+-- Pages through the filtered log newest first, continuing after the event
+-- at before_created_at/before_id when they are set.
+SELECT *
+FROM audit_events
+WHERE company_id = @company_id
+  AND (sqlc.narg('action')::VARCHAR IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('actor_id')::UUID IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('from_time')::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg('from_time'))
+  AND (sqlc.narg('to_time')::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg('to_time'))
+  AND (sqlc.narg('before_created_at')::TIMESTAMPTZ IS NULL
+       OR (created_at, id) < (sqlc.narg('before_created_at')::TIMESTAMPTZ, sqlc.narg('before_id')::UUID))
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit;
+
+-- name: CountAuditEvents :one
+SELECT COUNT(*)::INT AS total
+FROM audit_events
+WHERE company_id = @company_id
+  AND (sqlc.narg('action')::VARCHAR IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('actor_id')::UUID IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('from_time')::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg('from_time'))
+  AND (sqlc.narg('to_time')::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg('to_time'));
@@ -0,0 +1,113 @@
+-- name: CreateBlocklistEntry :one
+INSERT INTO blocklist_entries (
+  company_id,
+  type,
+  value,
+  network,
+  reason,
+  created_by_user,
+  created_by_operator
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetBlocklistEntry :one
+SELECT * FROM blocklist_entries
+WHERE id = $1;
+
+-- name: ListBlocklistEntries :many
+SELECT * FROM blocklist_entries
+WHERE (sqlc.narg('company_id')::UUID IS NULL OR company_id = sqlc.narg('company_id'))
+  AND (NOT @platform_only::BOOLEAN OR company_id IS NULL)
+  AND (sqlc.narg('type')::TEXT IS NULL OR type = sqlc.narg('type'))
+ORDER BY created_at DESC, id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountBlocklistEntries :one
+SELECT COUNT(*) FROM blocklist_entries
+WHERE (sqlc.narg('company_id')::UUID IS NULL OR company_id = sqlc.narg('company_id'))
+  AND (NOT @platform_only::BOOLEAN OR company_id IS NULL)
+  AND (sqlc.narg('type')::TEXT IS NULL OR type = sqlc.narg('type'));
+
+-- name: UpdateBlocklistEntry :one
+UPDATE blocklist_entries
+SET type = $2,
+    value = $3,
+    network = $4,
+    reason = $5,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBlocklistEntry :execrows
+DELETE FROM blocklist_entries
+WHERE id = $1;
+
+-- name: MatchBlocklist :many
+SELECT * FROM blocklist_entries
+WHERE (company_id IS NULL OR company_id = @company_id)
+  AND ((type = 'PHONE' AND value = sqlc.narg('phone_number'))
+    OR (type = 'EMAIL_DOMAIN' AND value = ANY(@email_domains::TEXT[]))
+    OR (type = 'IP' AND network >>= sqlc.narg('ip_address')::INET))
+ORDER BY company_id NULLS LAST, created_at;
+
+-- name: RecordBlocklistHit :exec
+WITH hit AS (
+  INSERT INTO blocklist_hits (
+    entry_id,
+    company_id,
+    type,
+    value,
+    amount,
+    currency,
+    livemode,
+    phone_number_index
+  ) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+  )
+  RETURNING entry_id, created_at
+)
+UPDATE blocklist_entries e
+SET hit_count = e.hit_count + 1,
+    last_hit_at = hit.created_at
+FROM hit
+WHERE e.id = hit.entry_id;
+
+-- name: AnonymizeBlocklistHits :execrows
+UPDATE blocklist_hits
+SET value = CASE WHEN type = 'EMAIL_DOMAIN' THEN value ELSE '' END,
+    phone_number_index = NULL
+WHERE company_id = @company_id
+  AND phone_number_index = @phone_number_index;
+
+-- name: ListBlocklistHits :many
+SELECT
+    h.id,
+    h.entry_id,
+    h.company_id,
+    c.name AS company_name,
+    h.type,
+    h.value,
+    h.amount,
+    h.currency,
+    h.livemode,
+    h.created_at
+FROM blocklist_hits h
+JOIN companies c ON h.company_id = c.id
+WHERE (sqlc.narg('company_id')::UUID IS NULL OR h.company_id = sqlc.narg('company_id'))
+  AND (sqlc.narg('entry_id')::UUID IS NULL OR h.entry_id = sqlc.narg('entry_id'))
+ORDER BY h.created_at DESC, h.id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountBlocklistHits :one
+SELECT COUNT(*) FROM blocklist_hits
+WHERE (sqlc.narg('company_id')::UUID IS NULL OR company_id = sqlc.narg('company_id'))
+  AND (sqlc.narg('entry_id')::UUID IS NULL OR entry_id = sqlc.narg('entry_id'));
+
+-- name: CountPhoneBlocklistHitsSince :one
+SELECT COUNT(*)::INT AS total
+FROM blocklist_hits
+WHERE company_id = @company_id
+  AND phone_number_index = @phone_number_index
+  AND created_at >= @since;
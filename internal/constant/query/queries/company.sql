@@ -16,10 +16,10 @@ INSERT INTO companies (
 ) VALUES (
   $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 )
-RETURNING id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, created_at, updated_at;
+RETURNING id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, mfa_required, created_at, updated_at;
 
 -- name: GetCompanyByID :one
-SELECT id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, created_at, updated_at
+SELECT id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, mfa_required, created_at, updated_at
 FROM companies
 WHERE id = $1 AND deleted_at IS NULL;
 
@@ -27,15 +27,67 @@ WHERE id = $1 AND deleted_at IS NULL;
 UPDATE company_tokens ct 
 SET status = 'INACTIVE'
 WHERE ct.company_id = $1 
+AND ct.livemode = $2
 AND ct.deleted_at IS NULL 
 AND ct.status = 'ACTIVE';
 
 -- name: CreateCompanyToken :one
-INSERT INTO company_tokens (token_id, company_id) 
-VALUES ($1, $2) 
+INSERT INTO company_tokens (token_id, company_id, livemode) 
+VALUES ($1, $2, $3) 
 RETURNING *;
 
--- name: GetActiveCompanyTokenByID :one
+-- name: GetActiveCompanyTokenByTokenID :one
 SELECT * 
 FROM company_tokens
-WHERE company_id = $1 AND status = 'ACTIVE' AND deleted_at IS NULL;
+WHERE token_id = $1 AND status = 'ACTIVE' AND deleted_at IS NULL;
+
+-- name: UpdateCompanyProfile :one
+UPDATE companies
+SET address_street = COALESCE(sqlc.narg('address_street'), address_street),
+    address_city = COALESCE(sqlc.narg('address_city'), address_city),
+    address_state = COALESCE(sqlc.narg('address_state'), address_state),
+    address_postal_code = COALESCE(sqlc.narg('address_postal_code'), address_postal_code),
+    address_country = COALESCE(sqlc.narg('address_country'), address_country),
+    primary_phone = COALESCE(sqlc.narg('primary_phone'), primary_phone),
+    secondary_phone = COALESCE(sqlc.narg('secondary_phone'), secondary_phone),
+    website = COALESCE(sqlc.narg('website'), website),
+    callback_url = COALESCE(sqlc.narg('callback_url'), callback_url),
+    return_url = COALESCE(sqlc.narg('return_url'), return_url),
+    updated_at = NOW()
+WHERE id = @id AND deleted_at IS NULL
+RETURNING id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, mfa_required, created_at, updated_at;
+
+-- name: ListCompanies :many
+SELECT id, name, registration_number, address_street, address_city, address_state, address_postal_code, address_country, primary_phone, secondary_phone, email, status, website, callback_url, return_url, mfa_required, created_at, updated_at
+FROM companies
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('status')::TEXT IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('search')::TEXT IS NULL
+    OR name ILIKE '%' || sqlc.narg('search') || '%'
+    OR email ILIKE '%' || sqlc.narg('search') || '%'
+    OR registration_number ILIKE '%' || sqlc.narg('search') || '%')
+ORDER BY created_at DESC, id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountCompanies :one
+SELECT COUNT(*)
+FROM companies
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('status')::TEXT IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('search')::TEXT IS NULL
+    OR name ILIKE '%' || sqlc.narg('search') || '%'
+    OR email ILIKE '%' || sqlc.narg('search') || '%'
+    OR registration_number ILIKE '%' || sqlc.narg('search') || '%');
+
+-- name: SuspendCompany :execrows
+UPDATE companies
+SET status = 'SUSPENDED', suspended_from_status = status, updated_at = NOW()
+WHERE id = $1 AND status <> 'SUSPENDED' AND deleted_at IS NULL;
+
+-- name: ReactivateCompany :one
+UPDATE companies
+SET status = COALESCE(suspended_from_status, 'ACTIVE'),
+    suspended_from_status = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'SUSPENDED' AND deleted_at IS NULL
+RETURNING status;
@@ -0,0 +1,53 @@
+-- name: ListCurrencies :many
+SELECT * FROM currencies
+ORDER BY code;
+
+-- name: ListCompanyCurrencies :many
+-- A company uses the registry default for a currency until an operator
+-- enables or disables it for that company.
+SELECT c.code, c.numeric_code, c.name, c.minor_units, c.min_amount, c.max_amount,
+       COALESCE(cc.enabled, c.enabled_by_default)::BOOLEAN AS enabled
+FROM currencies c
+LEFT JOIN company_currencies cc ON cc.currency_code = c.code AND cc.company_id = $1
+ORDER BY c.code;
+
+-- name: GetCompanyCurrency :one
+SELECT c.code, c.numeric_code, c.name, c.minor_units, c.min_amount, c.max_amount,
+       COALESCE(cc.enabled, c.enabled_by_default)::BOOLEAN AS enabled
+FROM currencies c
+LEFT JOIN company_currencies cc ON cc.currency_code = c.code AND cc.company_id = $1
+WHERE c.code = $2;
+
+-- name: SetCompanyCurrency :exec
+INSERT INTO company_currencies (
+  company_id,
+  currency_code,
+  enabled,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (company_id, currency_code) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now();
+
+-- name: GetCompanyFXSettings :one
+SELECT * FROM company_fx_settings
+WHERE company_id = $1;
+
+-- name: SetCompanyFXSettings :one
+INSERT INTO company_fx_settings (
+  company_id,
+  settlement_currency,
+  spread_bps,
+  updated_by
+) VALUES (
+  $1, sqlc.narg('settlement_currency'), $2, $3
+)
+ON CONFLICT (company_id) DO UPDATE
+SET settlement_currency = EXCLUDED.settlement_currency,
+    spread_bps = EXCLUDED.spread_bps,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
@@ -1,11 +1,172 @@
 -- name: CreateCustomer :one
+-- Inserts nothing, and returns no row, when the company already has a
+-- customer with the phone number.
 INSERT INTO customers (
+  id,
   company_id,
-  full_name,
-  phone_number,
-  email
+  livemode,
+  data_key_id,
+  full_name_ciphertext,
+  phone_number_ciphertext,
+  email_ciphertext,
+  phone_number_index
 ) VALUES (
-  $1, $2, $3, $4
-) ON CONFLICT (company_id, phone_number) WHERE deleted_at IS NULL
-DO UPDATE SET email = excluded.email, full_name = excluded.full_name
-RETURNING *;
\ No newline at end of file
+  $1, $2, $3, $4, $5, $6, $7, $8
+) ON CONFLICT (company_id, livemode, phone_number_index) WHERE deleted_at IS NULL
+DO NOTHING
+RETURNING *;
+
+-- name: GetCustomerByPhoneNumberIndexForUpdate :one
+SELECT *
+FROM customers
+WHERE company_id = $1 AND livemode = $2 AND phone_number_index = $3 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: InsertCustomer :one
+INSERT INTO customers (
+  id,
+  company_id,
+  livemode,
+  data_key_id,
+  full_name_ciphertext,
+  phone_number_ciphertext,
+  email_ciphertext,
+  phone_number_index
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetCustomerByID :one
+SELECT *
+FROM customers
+WHERE id = $1 AND company_id = $2 AND livemode = $3 AND deleted_at IS NULL;
+
+-- name: GetCustomerByIDForUpdate :one
+SELECT *
+FROM customers
+WHERE id = $1 AND company_id = $2 AND livemode = $3 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: ListCustomers :many
+SELECT *
+FROM customers
+WHERE company_id = @company_id
+  AND livemode = @livemode
+  AND deleted_at IS NULL
+  AND (sqlc.narg('phone_number_index')::BYTEA IS NULL
+    OR phone_number_index = sqlc.narg('phone_number_index'))
+ORDER BY created_at DESC, id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountCustomers :one
+SELECT COUNT(*)::INT AS total
+FROM customers
+WHERE company_id = @company_id
+  AND livemode = @livemode
+  AND deleted_at IS NULL
+  AND (sqlc.narg('phone_number_index')::BYTEA IS NULL
+    OR phone_number_index = sqlc.narg('phone_number_index'));
+
+-- name: UpdateCustomer :one
+UPDATE customers
+SET data_key_id = @data_key_id,
+    full_name_ciphertext = @full_name_ciphertext,
+    phone_number_ciphertext = @phone_number_ciphertext,
+    email_ciphertext = @email_ciphertext,
+    phone_number_index = @phone_number_index,
+    pii_bound_to_id = TRUE,
+    full_name = NULL,
+    phone_number = NULL,
+    email = NULL,
+    updated_at = NOW()
+WHERE id = @id AND company_id = @company_id AND livemode = @livemode AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ListCustomersToReseal :many
+SELECT *
+FROM customers
+WHERE (data_key_id IS DISTINCT FROM @data_key_id OR NOT pii_bound_to_id)
+  AND erased_at IS NULL
+ORDER BY id
+LIMIT @batch_size;
+
+-- name: ResealCustomer :execrows
+UPDATE customers
+SET data_key_id = @data_key_id,
+    full_name_ciphertext = @full_name_ciphertext,
+    phone_number_ciphertext = @phone_number_ciphertext,
+    email_ciphertext = @email_ciphertext,
+    phone_number_index = @phone_number_index,
+    pii_bound_to_id = TRUE,
+    full_name = NULL,
+    phone_number = NULL,
+    email = NULL
+WHERE id = @id
+  AND updated_at = @updated_at
+  AND data_key_id IS NOT DISTINCT FROM sqlc.narg('previous_data_key_id');
+
+-- name: SoftDeleteCustomer :execrows
+UPDATE customers
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND company_id = $2 AND livemode = $3 AND deleted_at IS NULL;
+
+-- name: ListCustomerPaymentIntents :many
+SELECT *
+FROM payment_intents
+WHERE customer_id = @customer_id
+  AND company_id = @company_id
+  AND livemode = @livemode
+  AND deleted_at IS NULL
+ORDER BY created_at DESC, id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountCustomerPaymentIntents :one
+SELECT COUNT(*)::INT AS total
+FROM payment_intents
+WHERE customer_id = @customer_id
+  AND company_id = @company_id
+  AND livemode = @livemode
+  AND deleted_at IS NULL;
+
+-- name: GetCustomerPhoneNumberIndex :one
+SELECT phone_number_index
+FROM customers
+WHERE id = @id AND company_id = @company_id AND livemode = @livemode AND erased_at IS NULL
+FOR UPDATE;
+
+-- name: EraseCustomer :one
+UPDATE customers
+SET full_name = NULL,
+    phone_number = NULL,
+    email = NULL,
+    data_key_id = NULL,
+    full_name_ciphertext = NULL,
+    phone_number_ciphertext = NULL,
+    email_ciphertext = NULL,
+    phone_number_index = NULL,
+    erased_at = NOW(),
+    deleted_at = COALESCE(deleted_at, NOW()),
+    updated_at = NOW()
+WHERE customers.id = @id
+  AND customers.company_id = @company_id
+  AND customers.livemode = @livemode
+  AND customers.erased_at IS NULL
+  AND (sqlc.narg('inactive_before')::TIMESTAMPTZ IS NULL
+    OR (customers.updated_at < sqlc.narg('inactive_before')
+      AND NOT EXISTS (
+        SELECT 1 FROM payment_intents pi
+        WHERE pi.customer_id = customers.id AND pi.created_at >= sqlc.narg('inactive_before'))))
+RETURNING customers.id, customers.company_id, customers.livemode, customers.erased_at,
+    (SELECT COUNT(*) FROM payment_intents pi WHERE pi.customer_id = customers.id)::INT AS payment_intents;
+
+-- name: ListInactiveCustomers :many
+SELECT cu.id, cu.company_id, cu.livemode
+FROM customers cu
+WHERE cu.erased_at IS NULL
+  AND cu.updated_at < @inactive_before
+  AND NOT EXISTS (
+    SELECT 1 FROM payment_intents pi
+    WHERE pi.customer_id = cu.id AND pi.created_at >= @inactive_before)
+ORDER BY cu.updated_at, cu.id
+LIMIT @batch_size;
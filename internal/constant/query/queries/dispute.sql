@@ -0,0 +1,86 @@
+-- name: CreateDispute :one
+INSERT INTO disputes (
+  company_id,
+  payment_intent_id,
+  livemode,
+  amount,
+  currency,
+  reason,
+  evidence_due_by,
+  created_by_operator
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, sqlc.narg('created_by_operator')
+)
+RETURNING *;
+
+-- name: GetDispute :one
+SELECT *
+FROM disputes
+WHERE id = $1;
+
+-- name: ListDisputes :many
+SELECT *
+FROM disputes
+WHERE (sqlc.narg('company_id')::UUID IS NULL OR company_id = sqlc.narg('company_id'))
+  AND (sqlc.narg('status')::VARCHAR IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('page_limit') OFFSET sqlc.arg('page_offset');
+
+-- name: CountDisputes :one
+SELECT COUNT(*)
+FROM disputes
+WHERE (sqlc.narg('company_id')::UUID IS NULL OR company_id = sqlc.narg('company_id'))
+  AND (sqlc.narg('status')::VARCHAR IS NULL OR status = sqlc.narg('status'));
+
+-- name: UpdateDisputeEvidence :one
+UPDATE disputes
+SET evidence_text = sqlc.narg('evidence_text'), updated_at = NOW()
+WHERE id = sqlc.arg('id') AND status = 'NEEDS_RESPONSE' AND evidence_due_by > NOW()
+RETURNING *;
+
+-- name: SubmitDispute :one
+UPDATE disputes
+SET status = 'UNDER_REVIEW', submitted_by = $2, submitted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'NEEDS_RESPONSE' AND evidence_due_by > NOW()
+RETURNING *;
+
+-- name: ResolveDispute :one
+-- Disputes the merchant did not answer in time can be closed as well.
+UPDATE disputes
+SET status = @status,
+    resolved_by = @resolved_by,
+    resolved_at = NOW(),
+    resolution_note = sqlc.narg('resolution_note'),
+    updated_at = NOW()
+WHERE id = @id
+  AND (status = 'UNDER_REVIEW' OR (status = 'NEEDS_RESPONSE' AND evidence_due_by <= NOW()))
+RETURNING *;
+
+-- name: CreateDisputeEvidenceFile :one
+INSERT INTO dispute_evidence_files (
+  id,
+  dispute_id,
+  file_name,
+  content_type,
+  size_bytes,
+  sha256,
+  storage_key,
+  uploaded_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: ListDisputeEvidenceFiles :many
+SELECT *
+FROM dispute_evidence_files
+WHERE dispute_id = $1
+ORDER BY created_at;
+
+-- name: GetDisputeEvidenceFile :one
+SELECT *
+FROM dispute_evidence_files
+WHERE id = $1 AND dispute_id = $2;
+
+-- name: PaymentIntentHasDispute :one
+SELECT EXISTS (SELECT 1 FROM disputes WHERE payment_intent_id = $1);
@@ -0,0 +1,51 @@
+-- name: CreateHMACKey :one
+INSERT INTO company_hmac_keys (
+  key_id,
+  company_id,
+  secret,
+  livemode
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListHMACKeys :many
+SELECT *
+FROM company_hmac_keys
+WHERE company_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetActiveHMACKeyByKeyID :one
+SELECT *
+FROM company_hmac_keys
+WHERE key_id = $1 AND status = 'ACTIVE';
+
+-- name: RevokeHMACKey :one
+UPDATE company_hmac_keys
+SET status = 'REVOKED',
+    revoked_at = now()
+WHERE id = $1 AND company_id = $2 AND status = 'ACTIVE'
+RETURNING *;
+
+-- name: TouchHMACKey :exec
+UPDATE company_hmac_keys
+SET last_used_at = now()
+WHERE id = $1;
+
+-- name: GetLatestActiveHMACKey :one
+SELECT *
+FROM company_hmac_keys
+WHERE company_id = $1 AND livemode = $2 AND status = 'ACTIVE'
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: DeleteExpiredHMACNonces :exec
+DELETE FROM hmac_nonces
+WHERE hmac_key_id = $1 AND expires_at < NOW();
+
+-- name: UseHMACNonce :execrows
+INSERT INTO hmac_nonces (hmac_key_id, nonce, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (hmac_key_id, nonce) DO UPDATE
+SET expires_at = EXCLUDED.expires_at
+WHERE hmac_nonces.expires_at < NOW();
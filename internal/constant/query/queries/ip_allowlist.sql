@@ -0,0 +1,28 @@
+-- name: CreateIPAllowlistEntry :one
+INSERT INTO company_ip_allowlist (
+  company_id,
+  cidr,
+  description,
+  created_by
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING id, company_id, cidr::TEXT AS cidr, description, created_by, created_at, updated_at;
+
+-- name: ListIPAllowlist :many
+SELECT id, company_id, cidr::TEXT AS cidr, description, created_by, created_at, updated_at
+FROM company_ip_allowlist
+WHERE company_id = $1
+ORDER BY created_at;
+
+-- name: UpdateIPAllowlistEntry :one
+UPDATE company_ip_allowlist
+SET cidr = $3,
+    description = $4,
+    updated_at = now()
+WHERE id = $1 AND company_id = $2
+RETURNING id, company_id, cidr::TEXT AS cidr, description, created_by, created_at, updated_at;
+
+-- name: DeleteIPAllowlistEntry :execrows
+DELETE FROM company_ip_allowlist
+WHERE id = $1 AND company_id = $2;
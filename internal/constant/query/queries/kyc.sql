@@ -0,0 +1,49 @@
+-- name: CreateKYCDocument :one
+INSERT INTO kyc_documents (
+  id,
+  company_id,
+  document_type,
+  file_name,
+  content_type,
+  size_bytes,
+  sha256,
+  storage_key,
+  uploaded_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: ListKYCDocuments :many
+SELECT *
+FROM kyc_documents
+WHERE company_id = $1
+ORDER BY created_at;
+
+-- name: GetKYCDocumentByID :one
+SELECT *
+FROM kyc_documents
+WHERE id = $1;
+
+-- name: DeleteKYCDocument :exec
+DELETE FROM kyc_documents
+WHERE id = $1 AND company_id = $2;
+
+-- name: GetCompanyKYC :one
+SELECT id, status, kyc_submitted_at, kyc_reviewed_at, kyc_reviewed_by, kyc_review_note
+FROM companies
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: SubmitCompanyKYC :execrows
+UPDATE companies
+SET status = 'UNDER_REVIEW', kyc_submitted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status IN ('ONBOARDING', 'REJECTED') AND deleted_at IS NULL;
+
+-- name: ReviewCompanyKYC :execrows
+UPDATE companies
+SET status = @status,
+    kyc_reviewed_at = NOW(),
+    kyc_reviewed_by = @reviewed_by,
+    kyc_review_note = @review_note,
+    updated_at = NOW()
+WHERE id = @id AND status = 'UNDER_REVIEW' AND deleted_at IS NULL;
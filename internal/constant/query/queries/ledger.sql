@@ -0,0 +1,29 @@
+-- name: CreateLedgerEntry :one
+INSERT INTO ledger_entries (
+  company_id,
+  payment_intent_id,
+  dispute_id,
+  type,
+  amount,
+  currency,
+  livemode
+) VALUES (
+  $1, $2, sqlc.narg('dispute_id'), $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetPaymentLedgerEntry :one
+SELECT *
+FROM ledger_entries
+WHERE payment_intent_id = $1 AND type = 'PAYMENT';
+
+-- name: ListDisputeLedgerEntries :many
+SELECT *
+FROM ledger_entries
+WHERE dispute_id = $1
+ORDER BY created_at;
+
+-- name: GetPaymentIntentLedgerBalance :one
+SELECT COALESCE(SUM(amount), 0)::DECIMAL AS balance
+FROM ledger_entries
+WHERE payment_intent_id = $1;
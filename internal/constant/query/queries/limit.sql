@@ -0,0 +1,59 @@
+-- name: ListCompanyLimits :many
+SELECT * FROM company_limits
+WHERE company_id = $1
+ORDER BY currency_code;
+
+-- name: GetCompanyLimitForUpdate :one
+-- Locking the row serializes payment intents of the company in the
+-- currency, so two of them cannot both fit under the same remaining total.
+SELECT * FROM company_limits
+WHERE company_id = $1 AND currency_code = $2
+FOR UPDATE;
+
+-- name: SetCompanyLimit :one
+INSERT INTO company_limits (
+  company_id,
+  currency_code,
+  min_amount,
+  max_amount,
+  daily_total,
+  monthly_total,
+  customer_max_payments,
+  customer_window_minutes,
+  updated_by
+) VALUES (
+  $1, $2, sqlc.narg('min_amount'), sqlc.narg('max_amount'), sqlc.narg('daily_total'),
+  sqlc.narg('monthly_total'), sqlc.narg('customer_max_payments'), $3, $4
+)
+ON CONFLICT (company_id, currency_code) DO UPDATE
+SET min_amount = EXCLUDED.min_amount,
+    max_amount = EXCLUDED.max_amount,
+    daily_total = EXCLUDED.daily_total,
+    monthly_total = EXCLUDED.monthly_total,
+    customer_max_payments = EXCLUDED.customer_max_payments,
+    customer_window_minutes = EXCLUDED.customer_window_minutes,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteCompanyLimit :execrows
+DELETE FROM company_limits
+WHERE company_id = $1 AND currency_code = $2;
+
+-- name: SumPaymentIntentsSince :one
+SELECT COALESCE(SUM(amount), 0)::DECIMAL AS total
+FROM payment_intents
+WHERE company_id = $1
+  AND currency = $2
+  AND livemode = $3
+  AND created_at >= @since
+  AND status NOT IN ('FAILED', 'BLOCKED')
+  AND deleted_at IS NULL;
+
+-- name: CountCustomerPaymentIntentsSince :one
+SELECT COUNT(*)::INT AS payments
+FROM payment_intents
+WHERE customer_id = $1
+  AND livemode = $2
+  AND created_at >= @since
+  AND deleted_at IS NULL;
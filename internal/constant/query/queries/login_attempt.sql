@@ -0,0 +1,32 @@
+-- name: ReserveLoginAttempt :one
+-- Counts an attempt under the key unless it is locked out or inside its
+-- progressive delay; no row is returned then. A key idle since the given
+-- time starts over.
+INSERT INTO login_throttles (throttle_key, attempts, last_attempt_at)
+VALUES (sqlc.arg(throttle_key), 1, NOW())
+ON CONFLICT (throttle_key) DO UPDATE
+SET attempts = CASE
+      WHEN login_throttles.last_attempt_at <= sqlc.arg(since)::TIMESTAMPTZ THEN 1
+      ELSE login_throttles.attempts + 1
+    END,
+    last_attempt_at = NOW()
+WHERE login_throttles.last_attempt_at <= sqlc.arg(since)::TIMESTAMPTZ
+   OR (login_throttles.attempts < sqlc.arg(max_attempts)::INT
+       AND (login_throttles.attempts < sqlc.arg(delay_after)::INT
+            OR login_throttles.last_attempt_at + make_interval(secs => sqlc.arg(delay_base_seconds)::FLOAT8
+                 * POWER(2, LEAST(login_throttles.attempts - sqlc.arg(delay_after)::INT, 10))) <= NOW()))
+RETURNING attempts;
+
+-- name: GetLoginThrottle :one
+SELECT attempts, last_attempt_at
+FROM login_throttles
+WHERE throttle_key = $1;
+
+-- name: ReleaseLoginAttempt :exec
+UPDATE login_throttles
+SET attempts = attempts - 1
+WHERE throttle_key = $1 AND attempts > 0;
+
+-- name: ClearLoginThrottles :exec
+DELETE FROM login_throttles
+WHERE throttle_key = ANY(@throttle_keys::VARCHAR[]);
@@ -0,0 +1,80 @@
+-- name: SetUserMFASecret :exec
+UPDATE users
+SET mfa_data_key_id = $2,
+    mfa_secret_ciphertext = $3,
+    mfa_secret = NULL,
+    mfa_enabled_at = NULL,
+    mfa_last_step = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: EnableUserMFA :exec
+UPDATE users
+SET mfa_enabled_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND (mfa_secret_ciphertext IS NOT NULL OR mfa_secret IS NOT NULL)
+  AND deleted_at IS NULL;
+
+-- name: DisableUserMFA :exec
+UPDATE users
+SET mfa_secret = NULL,
+    mfa_data_key_id = NULL,
+    mfa_secret_ciphertext = NULL,
+    mfa_enabled_at = NULL,
+    mfa_last_step = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListUserMFASecretsToReseal :many
+SELECT id, mfa_data_key_id, mfa_secret, mfa_secret_ciphertext
+FROM users
+WHERE mfa_secret IS NOT NULL
+   OR (mfa_secret_ciphertext IS NOT NULL AND mfa_data_key_id IS DISTINCT FROM @data_key_id)
+ORDER BY id
+LIMIT @batch_size;
+
+-- name: ResealUserMFASecret :execrows
+-- Leaves a secret replaced since it was read alone.
+UPDATE users
+SET mfa_data_key_id = @data_key_id,
+    mfa_secret_ciphertext = @mfa_secret_ciphertext,
+    mfa_secret = NULL
+WHERE id = @id
+  AND mfa_data_key_id IS NOT DISTINCT FROM sqlc.narg('previous_data_key_id')
+  AND mfa_secret IS NOT DISTINCT FROM sqlc.narg('previous_secret')
+  AND mfa_secret_ciphertext IS NOT DISTINCT FROM sqlc.narg('previous_ciphertext');
+
+-- name: DeleteExpiredUsedTokens :exec
+DELETE FROM used_tokens
+WHERE expires_at < NOW();
+
+-- name: UseToken :execrows
+INSERT INTO used_tokens (token_id, token_type, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (token_id) DO NOTHING;
+
+-- name: UseUserMFAStep :execrows
+UPDATE users
+SET mfa_last_step = $2, updated_at = NOW()
+WHERE id = $1 AND (mfa_last_step IS NULL OR mfa_last_step < $2) AND deleted_at IS NULL;
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = $1;
+
+-- name: CreateUserRecoveryCode :exec
+INSERT INTO user_recovery_codes (
+  user_id,
+  code_hash
+) VALUES (
+  $1, $2
+);
+
+-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET used_at = NOW()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: SetCompanyMFARequired :exec
+UPDATE companies
+SET mfa_required = $2, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,30 @@
+-- name: CreateOperator :one
+INSERT INTO operators (
+  full_name,
+  email,
+  password,
+  created_by
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetOperatorByID :one
+SELECT *
+FROM operators
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetOperatorByEmail :one
+SELECT *
+FROM operators
+WHERE LOWER(email) = LOWER(@email) AND deleted_at IS NULL;
+
+-- name: CountOperators :one
+SELECT COUNT(*)
+FROM operators
+WHERE deleted_at IS NULL;
+
+-- name: TouchOperatorLogin :exec
+UPDATE operators
+SET last_login_at = NOW()
+WHERE id = $1;
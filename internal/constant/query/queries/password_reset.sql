@@ -0,0 +1,28 @@
+-- name: RevokePendingPasswordResets :exec
+UPDATE password_resets
+SET status = 'REVOKED', updated_at = NOW()
+WHERE user_id = $1 AND status = 'PENDING';
+
+-- name: CreatePasswordReset :one
+INSERT INTO password_resets (
+  user_id,
+  token_id
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: GetPasswordResetByTokenID :one
+SELECT *
+FROM password_resets
+WHERE token_id = $1;
+
+-- name: UsePasswordReset :execrows
+UPDATE password_resets
+SET status = 'USED', used_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'PENDING';
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $2, sessions_revoked_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
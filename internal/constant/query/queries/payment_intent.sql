@@ -10,9 +10,26 @@ INSERT INTO payment_intents (
     description,
     extra,
     status,
-    bill_ref_no
+    bill_ref_no,
+    livemode,
+    settlement_currency,
+    settlement_amount,
+    fx_mid_rate,
+    fx_spread_bps,
+    fx_rate,
+    fx_rate_source,
+    fx_rate_as_of,
+    risk_score,
+    risk_outcome,
+    risk_rules,
+    risk_signals,
+    require_confirmation
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+    $13, $14, sqlc.narg('fx_mid_rate'), sqlc.narg('fx_spread_bps'), sqlc.narg('fx_rate'),
+    sqlc.narg('fx_rate_source'), sqlc.narg('fx_rate_as_of'), sqlc.narg('risk_score'),
+    sqlc.narg('risk_outcome'), sqlc.narg('risk_rules'), sqlc.narg('risk_signals'),
+    sqlc.arg('require_confirmation')
 )
 RETURNING *;
 -- name: GetPaymentIntentByID :one
@@ -27,18 +44,37 @@ SELECT
     pi.description,
     pi.extra,
     pi.bill_ref_no,
+    pi.livemode,
+    pi.settlement_currency,
+    pi.settlement_amount,
+    pi.fx_mid_rate,
+    pi.fx_spread_bps,
+    pi.fx_rate,
+    pi.fx_rate_source,
+    pi.fx_rate_as_of,
+    pi.risk_score,
+    pi.risk_outcome,
+    pi.risk_rules,
+    pi.risk_signals,
+    pi.reviewed_by,
+    pi.reviewed_at,
+    pi.review_note,
     pi.expire_at,
     pi.created_at,
     pi.updated_at,
-    json_build_object (
-        'id',cu.id,
-        'company_id',cu.company_id,
-        'full_name',cu.full_name,
-        'phone_number',cu.phone_number,
-        'email',cu.email,
-        'created_at',cu.created_at,
-        'updated_at',cu.updated_at
-    ) AS customer,
+    cu.id AS customer_id,
+    cu.company_id AS customer_company_id,
+    cu.livemode AS customer_livemode,
+    cu.full_name AS customer_full_name,
+    cu.phone_number AS customer_phone_number,
+    cu.email AS customer_email,
+    cu.data_key_id AS customer_data_key_id,
+    cu.full_name_ciphertext AS customer_full_name_ciphertext,
+    cu.phone_number_ciphertext AS customer_phone_number_ciphertext,
+    cu.email_ciphertext AS customer_email_ciphertext,
+    cu.pii_bound_to_id AS customer_pii_bound_to_id,
+    cu.created_at AS customer_created_at,
+    cu.updated_at AS customer_updated_at,
     json_build_object (
         'id',c.id,
         'name',c.name,
@@ -65,4 +101,5 @@ JOIN
 JOIN 
     companies c ON pi.company_id = c.id
 WHERE 
-    pi.id = $1 AND pi.deleted_at IS NULL AND c.deleted_at IS NULL AND cu.deleted_at IS NULL;
\ No newline at end of file
+    -- A deleted or erased customer does not hide its payment intents.
+    pi.id = $1 AND pi.deleted_at IS NULL AND c.deleted_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,42 @@
+-- name: RevokePendingPaymentConfirmations :exec
+UPDATE payment_intent_confirmations
+SET status = 'REVOKED', updated_at = NOW()
+WHERE payment_intent_id = $1 AND status = 'PENDING';
+
+-- name: CreatePaymentConfirmation :one
+INSERT INTO payment_intent_confirmations (
+  payment_intent_id,
+  code_hash,
+  expires_at
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetPendingPaymentConfirmation :one
+SELECT *
+FROM payment_intent_confirmations
+WHERE payment_intent_id = $1 AND status = 'PENDING';
+
+-- name: IncrementPaymentConfirmationAttempts :one
+UPDATE payment_intent_confirmations
+SET attempts = attempts + 1, updated_at = NOW()
+WHERE id = $1 AND status = 'PENDING' AND attempts < sqlc.arg(max_attempts)::INT
+RETURNING attempts;
+
+-- name: GetPaymentConfirmationSends :one
+SELECT COUNT(*)::INT AS sends,
+       COALESCE(MAX(created_at), 'epoch'::TIMESTAMPTZ)::TIMESTAMPTZ AS last_sent_at
+FROM payment_intent_confirmations
+WHERE payment_intent_id = $1;
+
+-- name: CompletePaymentConfirmation :execrows
+UPDATE payment_intent_confirmations
+SET status = 'VERIFIED', confirmed_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'PENDING' AND expires_at > NOW();
+
+-- name: ConfirmPaymentIntent :one
+UPDATE payment_intents
+SET status = 'PENDING', updated_at = NOW()
+WHERE id = $1 AND status = 'REQUIRES_CONFIRMATION' AND deleted_at IS NULL
+RETURNING *;
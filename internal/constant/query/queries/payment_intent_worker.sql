@@ -6,3 +6,9 @@ RETURNING id, status, updated_at;
 
 -- name: GetPaymentIntentByIDForUpdate :one
 SELECT * FROM payment_intents WHERE id = $1 FOR UPDATE;
+
+-- name: CorrectPaymentIntentStatus :one
+UPDATE payment_intents
+SET status = @status, updated_at = NOW()
+WHERE id = @id AND status = @expected_status
+RETURNING id;
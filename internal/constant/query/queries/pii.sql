@@ -0,0 +1,40 @@
+-- name: GetActivePIIDataKey :one
+SELECT *
+FROM pii_data_keys
+WHERE active;
+
+-- name: GetPIIDataKey :one
+SELECT *
+FROM pii_data_keys
+WHERE id = $1;
+
+-- name: CreatePIIDataKey :one
+INSERT INTO pii_data_keys (
+  id,
+  master_key_id,
+  wrapped_key
+) VALUES (
+  $1, $2, $3
+) ON CONFLICT (active) WHERE active DO NOTHING
+RETURNING *;
+
+-- name: RetireActivePIIDataKey :exec
+UPDATE pii_data_keys
+SET active = FALSE, retired_at = NOW()
+WHERE active;
+
+-- name: ListPIIDataKeysToRewrap :many
+SELECT *
+FROM pii_data_keys
+WHERE master_key_id <> $1;
+
+-- name: RewrapPIIDataKey :exec
+UPDATE pii_data_keys
+SET master_key_id = $2, wrapped_key = $3
+WHERE id = $1;
+
+-- name: ListPIIDataKeys :many
+SELECT k.*,
+       (SELECT COUNT(*) FROM customers cu WHERE cu.data_key_id = k.id)::INT AS customers
+FROM pii_data_keys k
+ORDER BY k.created_at DESC;
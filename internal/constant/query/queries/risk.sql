@@ -0,0 +1,115 @@
+-- name: ListRiskRules :many
+SELECT * FROM risk_rules
+WHERE company_id = $1
+ORDER BY created_at, id;
+
+-- name: ListEnabledRiskRules :many
+SELECT * FROM risk_rules
+WHERE company_id = $1 AND enabled
+ORDER BY created_at, id;
+
+-- name: GetRiskRule :one
+SELECT * FROM risk_rules
+WHERE id = $1 AND company_id = $2;
+
+-- name: CreateRiskRule :one
+INSERT INTO risk_rules (
+  company_id,
+  name,
+  expression,
+  score,
+  enabled,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: UpdateRiskRule :one
+UPDATE risk_rules
+SET name = $3,
+    expression = $4,
+    score = $5,
+    enabled = $6,
+    updated_by = $7,
+    updated_at = now()
+WHERE id = $1 AND company_id = $2
+RETURNING *;
+
+-- name: DeleteRiskRule :execrows
+DELETE FROM risk_rules
+WHERE id = $1 AND company_id = $2;
+
+-- name: GetCompanyRiskSettings :one
+SELECT * FROM company_risk_settings
+WHERE company_id = $1;
+
+-- name: SetCompanyRiskSettings :one
+INSERT INTO company_risk_settings (
+  company_id,
+  review_score,
+  block_score,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (company_id) DO UPDATE
+SET review_score = EXCLUDED.review_score,
+    block_score = EXCLUDED.block_score,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetPaymentIntentStatsSince :one
+SELECT COUNT(*)::INT AS payments,
+       COALESCE(AVG(amount), 0)::DECIMAL AS average_amount
+FROM payment_intents
+WHERE company_id = $1
+  AND currency = $2
+  AND livemode = $3
+  AND created_at >= @since
+  AND status NOT IN ('FAILED', 'BLOCKED')
+  AND deleted_at IS NULL;
+
+-- name: ListPaymentIntentsForReview :many
+SELECT
+    pi.id,
+    pi.company_id,
+    c.name AS company_name,
+    pi.customer_id,
+    pi.amount,
+    pi.currency,
+    pi.livemode,
+    pi.risk_score,
+    pi.risk_rules,
+    pi.risk_signals,
+    pi.created_at
+FROM payment_intents pi
+JOIN companies c ON pi.company_id = c.id
+WHERE pi.status = 'REVIEW'
+  AND pi.deleted_at IS NULL
+  AND (sqlc.narg('company_id')::UUID IS NULL OR pi.company_id = sqlc.narg('company_id'))
+ORDER BY pi.created_at, pi.id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountPaymentIntentsForReview :one
+SELECT COUNT(*)
+FROM payment_intents
+WHERE status = 'REVIEW'
+  AND deleted_at IS NULL
+  AND (sqlc.narg('company_id')::UUID IS NULL OR company_id = sqlc.narg('company_id'));
+
+-- name: ReviewPaymentIntent :one
+-- An approved intent that requires confirmation waits for the payer.
+UPDATE payment_intents
+SET status = CASE
+        WHEN sqlc.arg(status)::VARCHAR = 'PENDING' AND require_confirmation
+        THEN 'REQUIRES_CONFIRMATION'
+        ELSE sqlc.arg(status)::VARCHAR
+    END,
+    reviewed_by = sqlc.arg(reviewed_by),
+    reviewed_at = now(),
+    review_note = sqlc.narg('review_note'),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'REVIEW' AND deleted_at IS NULL
+RETURNING *;
@@ -0,0 +1,44 @@
+-- name: CreateUserInvitation :one
+INSERT INTO user_invitations (
+  company_id,
+  email,
+  role,
+  token_id,
+  invited_by
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: RevokePendingUserInvitations :exec
+UPDATE user_invitations
+SET status = 'REVOKED', updated_at = NOW()
+WHERE company_id = $1 AND email = $2 AND status = 'PENDING';
+
+-- name: GetUserInvitationByTokenID :one
+SELECT *
+FROM user_invitations
+WHERE token_id = $1;
+
+-- name: AcceptUserInvitation :execrows
+UPDATE user_invitations
+SET status = 'ACCEPTED', accepted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'PENDING';
+
+-- name: ListCompanyUsers :many
+SELECT *
+FROM users
+WHERE company_id = $1 AND deleted_at IS NULL
+ORDER BY created_at;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = $3, updated_at = NOW()
+WHERE id = $1 AND company_id = $2 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateUserStatus :one
+UPDATE users
+SET status = $3, updated_at = NOW()
+WHERE id = $1 AND company_id = $2 AND deleted_at IS NULL
+RETURNING *;
@@ -4,10 +4,13 @@ INSERT INTO users (
   first_name,
   email,
   phone,
-  password
+  password,
+  role,
+  status,
+  email_verified_at
 )
 VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
 RETURNING *;
 -- name: GetUserByID :one
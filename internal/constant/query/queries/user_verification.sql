@@ -0,0 +1,59 @@
+-- name: RevokePendingUserVerifications :exec
+UPDATE user_verifications
+SET status = 'REVOKED', updated_at = NOW()
+WHERE user_id = $1 AND channel = $2 AND status = 'PENDING';
+
+-- name: CreateUserVerification :one
+INSERT INTO user_verifications (
+  user_id,
+  channel,
+  token_id,
+  code_hash,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetUserVerificationByTokenID :one
+SELECT *
+FROM user_verifications
+WHERE token_id = $1;
+
+-- name: GetPendingUserVerification :one
+SELECT *
+FROM user_verifications
+WHERE user_id = $1 AND channel = $2 AND status = 'PENDING';
+
+-- name: IncrementUserVerificationAttempts :one
+UPDATE user_verifications
+SET attempts = attempts + 1, updated_at = NOW()
+WHERE id = $1 AND status = 'PENDING' AND attempts < sqlc.arg(max_attempts)::INT
+RETURNING attempts;
+
+-- name: CompleteUserVerification :execrows
+UPDATE user_verifications
+SET status = 'VERIFIED', verified_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'PENDING' AND expires_at > NOW();
+
+-- name: MarkUserEmailVerified :one
+UPDATE users
+SET email_verified_at = COALESCE(email_verified_at, NOW()),
+    status = CASE
+      WHEN status = 'PENDING_VERIFICATION' AND phone_verified_at IS NOT NULL THEN 'ACTIVE'
+      ELSE status
+    END,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: MarkUserPhoneVerified :one
+UPDATE users
+SET phone_verified_at = COALESCE(phone_verified_at, NOW()),
+    status = CASE
+      WHEN status = 'PENDING_VERIFICATION' AND email_verified_at IS NOT NULL THEN 'ACTIVE'
+      ELSE status
+    END,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
@@ -0,0 +1,42 @@
+-- name: CreateWebhookEvent :one
+INSERT INTO webhook_events (
+  company_id,
+  type,
+  livemode,
+  payload
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ClaimDueWebhookEvents :many
+-- Claimed events are not due again for lease_seconds, so that a delivery
+-- in progress is not picked up twice.
+UPDATE webhook_events
+SET next_attempt_at = NOW() + make_interval(secs => sqlc.arg('lease_seconds')::INT),
+    updated_at = NOW()
+WHERE id IN (
+    SELECT id
+    FROM webhook_events
+    WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkWebhookEventDelivered :exec
+UPDATE webhook_events
+SET status = 'DELIVERED', attempts = attempts + 1, delivered_at = NOW(),
+    last_error = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: MarkWebhookEventFailed :exec
+-- The event gives up after max_attempts deliveries.
+UPDATE webhook_events
+SET attempts = attempts + 1,
+    status = CASE WHEN attempts + 1 >= sqlc.arg('max_attempts')::INT THEN 'FAILED' ELSE 'PENDING' END,
+    next_attempt_at = sqlc.arg('next_attempt_at'),
+    last_error = sqlc.arg('last_error'),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id');
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_company_role;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users
+    ALTER COLUMN role DROP NOT NULL;
@@ -0,0 +1,14 @@
+------------------------------------------------
+-- Users Role
+------------------------------------------------
+-- Every existing user was created through company registration and is
+-- therefore the owner of its company.
+UPDATE users SET role = 'OWNER' WHERE role IS NULL OR role = '';
+
+ALTER TABLE users
+    ALTER COLUMN role SET NOT NULL;
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check
+    CHECK (role IN ('OWNER', 'ADMIN', 'DEVELOPER', 'FINANCE', 'SUPPORT'));
+
+CREATE INDEX idx_users_company_role ON users (company_id, role) WHERE deleted_at IS NULL;
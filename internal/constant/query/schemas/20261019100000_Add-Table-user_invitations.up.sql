@@ -0,0 +1,27 @@
+------------------------------------------------
+-- User Invitations Table
+------------------------------------------------
+CREATE TABLE IF NOT EXISTS user_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    token_id UUID NOT NULL,
+    invited_by UUID NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'PENDING',
+    accepted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE user_invitations
+    ADD CONSTRAINT fk_user_invitations_company FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE;
+ALTER TABLE user_invitations
+    ADD CONSTRAINT fk_user_invitations_invited_by FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Only one pending invitation per email within a company
+CREATE UNIQUE INDEX idx_user_invitations_pending_unique
+    ON user_invitations (company_id, email)
+    WHERE status = 'PENDING';
+
+CREATE UNIQUE INDEX idx_user_invitations_token_id ON user_invitations (token_id);
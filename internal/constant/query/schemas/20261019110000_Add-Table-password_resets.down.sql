@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS sessions_revoked_at;
+
+DROP TABLE IF EXISTS password_resets;
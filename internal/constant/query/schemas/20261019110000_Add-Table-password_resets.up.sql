@@ -0,0 +1,22 @@
+------------------------------------------------
+-- Password Resets Table
+------------------------------------------------
+CREATE TABLE IF NOT EXISTS password_resets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    token_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'PENDING',
+    used_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE password_resets
+    ADD CONSTRAINT fk_password_resets_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_password_resets_token_id ON password_resets (token_id);
+CREATE INDEX idx_password_resets_user_pending ON password_resets (user_id) WHERE status = 'PENDING';
+
+-- Tokens issued before this moment are no longer accepted for the user.
+ALTER TABLE users
+    ADD COLUMN sessions_revoked_at TIMESTAMPTZ NULL;
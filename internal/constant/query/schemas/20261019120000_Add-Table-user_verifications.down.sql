@@ -0,0 +1,9 @@
+UPDATE users
+SET status = 'ACTIVE'
+WHERE status = 'PENDING_VERIFICATION';
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_verified_at,
+    DROP COLUMN IF EXISTS phone_verified_at;
+
+DROP TABLE IF EXISTS user_verifications;
@@ -0,0 +1,32 @@
+------------------------------------------------
+-- User Verifications Table
+------------------------------------------------
+CREATE TABLE IF NOT EXISTS user_verifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    channel VARCHAR(20) NOT NULL,      -- EMAIL or SMS
+    token_id UUID NULL,                -- EMAIL: id of the verification link token
+    code_hash TEXT NULL,               -- SMS: bcrypt hash of the one-time code
+    attempts INT NOT NULL DEFAULT 0,
+    status VARCHAR(50) NOT NULL DEFAULT 'PENDING',
+    expires_at TIMESTAMPTZ NOT NULL,
+    verified_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE user_verifications
+    ADD CONSTRAINT fk_user_verifications_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE user_verifications
+    ADD CONSTRAINT chk_user_verifications_channel CHECK (channel IN ('EMAIL', 'SMS'));
+
+CREATE UNIQUE INDEX idx_user_verifications_token_id ON user_verifications (token_id) WHERE token_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_user_verifications_pending ON user_verifications (user_id, channel) WHERE status = 'PENDING';
+
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMPTZ NULL,
+    ADD COLUMN phone_verified_at TIMESTAMPTZ NULL;
+
+-- Accounts that existed before verification was introduced are trusted.
+UPDATE users
+SET email_verified_at = created_at, phone_verified_at = created_at;
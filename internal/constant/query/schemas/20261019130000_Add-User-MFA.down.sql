@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS user_recovery_codes;
+
+ALTER TABLE companies
+    DROP COLUMN IF EXISTS mfa_required;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS mfa_secret,
+    DROP COLUMN IF EXISTS mfa_enabled_at,
+    DROP COLUMN IF EXISTS mfa_last_step;
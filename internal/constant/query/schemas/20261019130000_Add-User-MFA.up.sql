@@ -0,0 +1,23 @@
+ALTER TABLE users
+    ADD COLUMN mfa_secret TEXT NULL,              -- base32 TOTP secret, set on enrollment
+    ADD COLUMN mfa_enabled_at TIMESTAMPTZ NULL,   -- set once the first code is confirmed
+    ADD COLUMN mfa_last_step BIGINT NULL;         -- last accepted TOTP step, blocks code replay
+
+ALTER TABLE companies
+    ADD COLUMN mfa_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+------------------------------------------------
+-- User Recovery Codes Table
+------------------------------------------------
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE user_recovery_codes
+    ADD CONSTRAINT fk_user_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_user_recovery_codes_hash ON user_recovery_codes (user_id, code_hash);
@@ -0,0 +1,15 @@
+------------------------------------------------
+-- Login Attempts Table
+------------------------------------------------
+-- Failed sign-in attempts, keyed by the phone or email that was submitted
+-- so that unknown accounts are throttled exactly like real ones. Rows are
+-- removed when the account signs in successfully or is unlocked.
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    identifier VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_login_attempts_identifier ON login_attempts (identifier, created_at);
+CREATE INDEX idx_login_attempts_ip_address ON login_attempts (ip_address, created_at);
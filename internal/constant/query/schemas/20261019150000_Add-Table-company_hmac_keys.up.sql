@@ -0,0 +1,24 @@
+------------------------------------------------
+-- Company HMAC Keys Table
+------------------------------------------------
+-- Shared secrets merchants use to sign server-to-server requests with the
+-- PG-HMAC scheme. key_id is the public identifier sent in the Authorization
+-- header. The secret is needed to recompute signatures, so it cannot be
+-- hashed like passwords. A company may hold several active keys so that it
+-- can rotate without downtime.
+CREATE TABLE IF NOT EXISTS company_hmac_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key_id VARCHAR(64) NOT NULL,
+    company_id UUID NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    status VARCHAR(255) NOT NULL DEFAULT 'ACTIVE',
+    last_used_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMPTZ NULL
+);
+
+ALTER TABLE company_hmac_keys
+    ADD CONSTRAINT fk_company_hmac_keys_company FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_company_hmac_keys_key_id ON company_hmac_keys (key_id);
+CREATE INDEX idx_company_hmac_keys_company_id ON company_hmac_keys (company_id);
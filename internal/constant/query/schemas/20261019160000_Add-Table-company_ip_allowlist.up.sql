@@ -0,0 +1,22 @@
+------------------------------------------------
+-- Company IP Allowlist Table
+------------------------------------------------
+-- Networks a company's servers may call the merchant API from. An empty
+-- list allows every address; once a company adds an entry, secret-token
+-- and signed requests from other addresses are refused.
+CREATE TABLE IF NOT EXISTS company_ip_allowlist (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    cidr CIDR NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_by UUID NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE company_ip_allowlist
+    ADD CONSTRAINT fk_company_ip_allowlist_company FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE;
+ALTER TABLE company_ip_allowlist
+    ADD CONSTRAINT fk_company_ip_allowlist_created_by FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_company_ip_allowlist_cidr ON company_ip_allowlist (company_id, cidr);
@@ -0,0 +1,18 @@
+------------------------------------------------
+-- Audit Events Table
+------------------------------------------------
+-- Security relevant events. actor_type tells whether actor_id is a user or
+-- a company acting through its API credentials; both are empty for
+-- anonymous requests.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NULL,
+    actor_type VARCHAR(32) NOT NULL,
+    actor_id UUID NULL,
+    action VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    metadata JSONB NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_events_company_id ON audit_events (company_id, created_at);
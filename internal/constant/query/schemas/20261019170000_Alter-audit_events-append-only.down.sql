@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS trg_audit_events_no_truncate ON audit_events;
+DROP TRIGGER IF EXISTS trg_audit_events_no_update_delete ON audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
+DROP INDEX IF EXISTS idx_audit_events_company_actor;
+DROP INDEX IF EXISTS idx_audit_events_company_action;
+ALTER TABLE audit_events
+    DROP COLUMN IF EXISTS after,
+    DROP COLUMN IF EXISTS before,
+    DROP COLUMN IF EXISTS request_id;
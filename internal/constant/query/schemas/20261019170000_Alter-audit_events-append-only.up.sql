@@ -0,0 +1,25 @@
+------------------------------------------------
+-- Audit Events: request id, diffs and append-only guard
+------------------------------------------------
+ALTER TABLE audit_events
+    ADD COLUMN request_id VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN before JSONB NULL,
+    ADD COLUMN after JSONB NULL;
+
+CREATE INDEX idx_audit_events_company_action ON audit_events (company_id, action, created_at);
+CREATE INDEX idx_audit_events_company_actor ON audit_events (company_id, actor_id, created_at);
+
+-- The audit log is evidence: rows may be added but never changed or removed.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only: % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_events_no_update_delete
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TRIGGER trg_audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_companies_status;
+DROP TABLE IF EXISTS operators;
@@ -0,0 +1,21 @@
+------------------------------------------------
+-- Operators Table
+------------------------------------------------
+-- Platform operators run the back-office under /api/admin. They are not
+-- merchant users: they belong to no company and sign in separately.
+CREATE TABLE IF NOT EXISTS operators (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    full_name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    password VARCHAR(255) NOT NULL,
+    status VARCHAR(100) NOT NULL DEFAULT 'ACTIVE',
+    created_by UUID NULL,
+    last_login_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX idx_operators_email ON operators (LOWER(email)) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_companies_status ON companies (status) WHERE deleted_at IS NULL;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS kyc_documents;
+
+ALTER TABLE companies
+    ALTER COLUMN status SET DEFAULT 'ACTIVE',
+    DROP COLUMN IF EXISTS kyc_submitted_at,
+    DROP COLUMN IF EXISTS kyc_reviewed_at,
+    DROP COLUMN IF EXISTS kyc_reviewed_by,
+    DROP COLUMN IF EXISTS kyc_review_note;
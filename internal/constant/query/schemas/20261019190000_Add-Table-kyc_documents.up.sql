@@ -0,0 +1,31 @@
+------------------------------------------------
+-- Merchant KYC
+------------------------------------------------
+-- New companies start in ONBOARDING, move to UNDER_REVIEW when they submit
+-- their documents and to ACTIVE or REJECTED once an operator has decided.
+-- Companies registered before KYC keep their current status.
+ALTER TABLE companies
+    ALTER COLUMN status SET DEFAULT 'ONBOARDING',
+    ADD COLUMN kyc_submitted_at TIMESTAMPTZ NULL,
+    ADD COLUMN kyc_reviewed_at TIMESTAMPTZ NULL,
+    ADD COLUMN kyc_reviewed_by UUID NULL,
+    ADD COLUMN kyc_review_note TEXT NULL;
+
+-- The file itself lives in the blob store under storage_key.
+CREATE TABLE IF NOT EXISTS kyc_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    document_type VARCHAR(64) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    sha256 VARCHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    uploaded_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE kyc_documents
+    ADD CONSTRAINT fk_kyc_documents_company FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_kyc_documents_company_id ON kyc_documents (company_id, created_at);
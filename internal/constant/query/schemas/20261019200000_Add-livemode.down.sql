@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS idx_payment_intents_company_livemode;
+
+-- Test data has no place once the modes are merged again.
+DELETE FROM payment_intents WHERE livemode = FALSE;
+DELETE FROM customers WHERE livemode = FALSE;
+DELETE FROM company_hmac_keys WHERE livemode = FALSE;
+DELETE FROM company_tokens WHERE livemode = FALSE;
+
+DROP INDEX IF EXISTS unique_customer_company;
+CREATE UNIQUE INDEX unique_customer_company
+    ON customers (company_id ASC, phone_number) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_company_tokens_active_unique;
+CREATE UNIQUE INDEX idx_company_tokens_active_unique
+    ON company_tokens (company_id)
+    WHERE status = 'ACTIVE' AND deleted_at IS NULL;
+
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS livemode;
+ALTER TABLE customers DROP COLUMN IF EXISTS livemode;
+ALTER TABLE company_hmac_keys DROP COLUMN IF EXISTS livemode;
+ALTER TABLE company_tokens DROP COLUMN IF EXISTS livemode;
@@ -0,0 +1,23 @@
+------------------------------------------------
+-- Test and live mode
+------------------------------------------------
+-- Every API key is either a test or a live key, and everything created
+-- through it carries the same flag. Rows that predate test mode are live.
+ALTER TABLE company_tokens ADD COLUMN livemode BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE company_hmac_keys ADD COLUMN livemode BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE customers ADD COLUMN livemode BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE payment_intents ADD COLUMN livemode BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- A company holds one active secret token per mode.
+DROP INDEX IF EXISTS idx_company_tokens_active_unique;
+CREATE UNIQUE INDEX idx_company_tokens_active_unique
+    ON company_tokens (company_id, livemode)
+    WHERE status = 'ACTIVE' AND deleted_at IS NULL;
+
+-- The same phone number is a different customer in test and live mode.
+DROP INDEX IF EXISTS unique_customer_company;
+CREATE UNIQUE INDEX unique_customer_company
+    ON customers (company_id ASC, livemode, phone_number) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_payment_intents_company_livemode
+    ON payment_intents (company_id, livemode, created_at);
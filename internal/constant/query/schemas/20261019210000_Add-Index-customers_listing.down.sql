@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payment_intents_customer_id;
+DROP INDEX IF EXISTS idx_customers_company_livemode_created_at;
@@ -0,0 +1,7 @@
+------------------------------------------------
+-- Customer listing
+------------------------------------------------
+CREATE INDEX idx_customers_company_livemode_created_at
+    ON customers (company_id, livemode, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_payment_intents_customer_id
+    ON payment_intents (customer_id, created_at DESC);
@@ -0,0 +1,20 @@
+-- Sealed rows cannot be decrypted here. Setting NOT NULL first makes the
+-- rollback fail instead of dropping their data.
+ALTER TABLE customers ALTER COLUMN phone_number SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_customers_data_key_id;
+DROP INDEX IF EXISTS unique_customer_company;
+
+ALTER TABLE customers
+    DROP COLUMN IF EXISTS phone_number_index,
+    DROP COLUMN IF EXISTS email_ciphertext,
+    DROP COLUMN IF EXISTS phone_number_ciphertext,
+    DROP COLUMN IF EXISTS full_name_ciphertext,
+    DROP COLUMN IF EXISTS data_key_id;
+
+CREATE UNIQUE INDEX unique_customer_company
+    ON customers (company_id ASC, livemode, phone_number) WHERE deleted_at IS NULL;
+CREATE INDEX idx_customers_company_phone_number
+    ON customers (company_id ASC, phone_number) WHERE deleted_at IS NULL;
+
+DROP TABLE IF EXISTS pii_data_keys;
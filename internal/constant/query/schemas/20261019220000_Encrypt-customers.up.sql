@@ -0,0 +1,33 @@
+------------------------------------------------
+-- Customer PII encryption
+------------------------------------------------
+-- Data keys seal customer personal data. They are stored wrapped by a
+-- master key that never reaches the database; master_key_id says which one.
+-- New data is always sealed with the single active data key.
+CREATE TABLE IF NOT EXISTS pii_data_keys (
+    id UUID PRIMARY KEY,
+    master_key_id TEXT NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()::TIMESTAMPTZ,
+    retired_at TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX idx_pii_data_keys_active ON pii_data_keys (active) WHERE active;
+
+-- The plaintext columns stay until every row has been sealed; the server
+-- seals them at startup and clears them. phone_number_index is an HMAC of
+-- the phone number, so lookups and uniqueness still work.
+ALTER TABLE customers
+    ALTER COLUMN phone_number DROP NOT NULL,
+    ADD COLUMN data_key_id UUID NULL REFERENCES pii_data_keys (id),
+    ADD COLUMN full_name_ciphertext BYTEA NULL,
+    ADD COLUMN phone_number_ciphertext BYTEA NULL,
+    ADD COLUMN email_ciphertext BYTEA NULL,
+    ADD COLUMN phone_number_index BYTEA NULL;
+
+DROP INDEX IF EXISTS unique_customer_company;
+DROP INDEX IF EXISTS idx_customers_company_phone_number;
+CREATE UNIQUE INDEX unique_customer_company
+    ON customers (company_id, livemode, phone_number_index) WHERE deleted_at IS NULL;
+CREATE INDEX idx_customers_data_key_id ON customers (data_key_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_customers_retention;
+ALTER TABLE customers DROP COLUMN IF EXISTS erased_at;
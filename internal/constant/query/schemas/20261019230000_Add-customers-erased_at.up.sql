@@ -0,0 +1,10 @@
+------------------------------------------------
+-- Customer erasure
+------------------------------------------------
+-- An erased customer keeps its row, so payment intents still point at it,
+-- but every personal data column is cleared. Soft deletion alone keeps the
+-- personal data.
+ALTER TABLE customers ADD COLUMN erased_at TIMESTAMPTZ NULL;
+
+-- The retention job looks for customers not touched for a long time.
+CREATE INDEX idx_customers_retention ON customers (updated_at) WHERE erased_at IS NULL;
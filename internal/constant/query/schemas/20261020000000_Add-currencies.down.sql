@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS company_currencies;
+DROP TABLE IF EXISTS currencies;
@@ -0,0 +1,38 @@
+------------------------------------------------
+-- Currency registry
+------------------------------------------------
+-- Every currency a payment intent can be created in. minor_units is the
+-- number of decimals the currency allows (ISO 4217 exponent), and amounts
+-- must fall between min_amount and max_amount.
+CREATE TABLE IF NOT EXISTS currencies (
+    code CHAR(3) PRIMARY KEY,
+    numeric_code CHAR(3) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    minor_units SMALLINT NOT NULL CHECK (minor_units BETWEEN 0 AND 4),
+    min_amount DECIMAL NOT NULL CHECK (min_amount > 0),
+    max_amount DECIMAL NOT NULL,
+    -- enabled_by_default applies to companies without an entry in
+    -- company_currencies.
+    enabled_by_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (max_amount >= min_amount)
+);
+
+INSERT INTO currencies (code, numeric_code, name, minor_units, min_amount, max_amount, enabled_by_default)
+VALUES
+    ('ETB', '230', 'Ethiopian Birr', 2, 1, 10000000, TRUE),
+    ('USD', '840', 'US Dollar', 2, 1, 1000000, TRUE),
+    ('EUR', '978', 'Euro', 2, 1, 1000000, FALSE),
+    ('GBP', '826', 'Pound Sterling', 2, 1, 1000000, FALSE);
+
+-- Per company overrides of enabled_by_default.
+CREATE TABLE IF NOT EXISTS company_currencies (
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    currency_code CHAR(3) NOT NULL REFERENCES currencies(code),
+    enabled BOOLEAN NOT NULL,
+    updated_by UUID NULL REFERENCES operators(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (company_id, currency_code)
+);
@@ -0,0 +1,8 @@
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS fx_rate_as_of;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS fx_rate_source;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS fx_rate;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS fx_spread_bps;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS fx_mid_rate;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS settlement_amount;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS settlement_currency;
+DROP TABLE IF EXISTS company_fx_settings;
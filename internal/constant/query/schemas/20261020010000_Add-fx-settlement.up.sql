@@ -0,0 +1,25 @@
+------------------------------------------------
+-- Cross-currency settlement
+------------------------------------------------
+-- How a company is paid out. Without a settlement currency it is paid in
+-- the currency each payment intent is presented in. spread_bps is the markup
+-- taken off the mid rate, in basis points.
+CREATE TABLE IF NOT EXISTS company_fx_settings (
+    company_id UUID PRIMARY KEY REFERENCES companies(id) ON DELETE CASCADE,
+    settlement_currency CHAR(3) NULL REFERENCES currencies(code),
+    spread_bps INT NOT NULL DEFAULT 0 CHECK (spread_bps BETWEEN 0 AND 10000),
+    updated_by UUID NULL REFERENCES operators(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- amount and currency stay the presentment side. The settlement side and
+-- the rate it was converted at are snapshotted when the intent is created;
+-- intents created before this have none and settle in their own currency.
+ALTER TABLE payment_intents ADD COLUMN settlement_currency VARCHAR(3) NULL;
+ALTER TABLE payment_intents ADD COLUMN settlement_amount DECIMAL NULL;
+ALTER TABLE payment_intents ADD COLUMN fx_mid_rate DECIMAL NULL;
+ALTER TABLE payment_intents ADD COLUMN fx_spread_bps INT NULL;
+ALTER TABLE payment_intents ADD COLUMN fx_rate DECIMAL NULL;
+ALTER TABLE payment_intents ADD COLUMN fx_rate_source VARCHAR(100) NULL;
+ALTER TABLE payment_intents ADD COLUMN fx_rate_as_of TIMESTAMPTZ NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_payment_intents_customer_velocity;
+DROP INDEX IF EXISTS idx_payment_intents_company_totals;
+DROP TABLE IF EXISTS company_limits;
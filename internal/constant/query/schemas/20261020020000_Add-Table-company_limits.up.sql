@@ -0,0 +1,26 @@
+------------------------------------------------
+-- Transaction limits
+------------------------------------------------
+-- Limits of one company in one currency. A NULL column is not enforced.
+-- Totals add up the amounts of payment intents that have not failed, in
+-- UTC days and months. The customer rule caps how many payment intents a
+-- customer (one phone number) may be sent within a sliding window.
+CREATE TABLE IF NOT EXISTS company_limits (
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    currency_code CHAR(3) NOT NULL REFERENCES currencies(code),
+    min_amount DECIMAL NULL CHECK (min_amount > 0),
+    max_amount DECIMAL NULL CHECK (max_amount > 0),
+    daily_total DECIMAL NULL CHECK (daily_total > 0),
+    monthly_total DECIMAL NULL CHECK (monthly_total > 0),
+    customer_max_payments INT NULL CHECK (customer_max_payments > 0),
+    customer_window_minutes INT NOT NULL DEFAULT 60 CHECK (customer_window_minutes > 0),
+    updated_by UUID NULL REFERENCES operators(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (company_id, currency_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_intents_company_totals
+    ON payment_intents (company_id, currency, livemode, created_at);
+CREATE INDEX IF NOT EXISTS idx_payment_intents_customer_velocity
+    ON payment_intents (customer_id, created_at);
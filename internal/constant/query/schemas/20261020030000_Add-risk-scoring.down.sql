@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_payment_intents_status_created_at;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS review_note;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS risk_signals;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS risk_rules;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS risk_outcome;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS risk_score;
+DROP TABLE IF EXISTS company_risk_settings;
+DROP TABLE IF EXISTS risk_rules;
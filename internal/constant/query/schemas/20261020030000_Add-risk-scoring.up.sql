@@ -0,0 +1,43 @@
+------------------------------------------------
+-- Risk scoring
+------------------------------------------------
+-- Rules of one company. expression is a condition over the signals of a
+-- new payment intent; when it holds, score is added to the intent's risk
+-- score. A negative score lowers the risk of intents it trusts.
+CREATE TABLE IF NOT EXISTS risk_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    expression TEXT NOT NULL,
+    score INT NOT NULL CHECK (score BETWEEN -1000 AND 1000),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID NULL REFERENCES operators(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (company_id, name)
+);
+
+-- Scores at which a company's payment intents are held for review and
+-- blocked. Companies without a row use the defaults.
+CREATE TABLE IF NOT EXISTS company_risk_settings (
+    company_id UUID PRIMARY KEY REFERENCES companies(id) ON DELETE CASCADE,
+    review_score INT NOT NULL DEFAULT 50 CHECK (review_score > 0),
+    block_score INT NOT NULL DEFAULT 100 CHECK (block_score >= review_score),
+    updated_by UUID NULL REFERENCES operators(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- The assessment made when the intent was created: the score, the outcome,
+-- the rules that matched and the signals they saw. Intents of companies
+-- without rules are not assessed and keep them NULL.
+ALTER TABLE payment_intents ADD COLUMN risk_score INT NULL;
+ALTER TABLE payment_intents ADD COLUMN risk_outcome VARCHAR(10) NULL;
+ALTER TABLE payment_intents ADD COLUMN risk_rules JSONB NULL;
+ALTER TABLE payment_intents ADD COLUMN risk_signals JSONB NULL;
+ALTER TABLE payment_intents ADD COLUMN reviewed_by UUID NULL REFERENCES operators(id);
+ALTER TABLE payment_intents ADD COLUMN reviewed_at TIMESTAMPTZ NULL;
+ALTER TABLE payment_intents ADD COLUMN review_note TEXT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payment_intents_status_created_at
+    ON payment_intents (status, created_at);
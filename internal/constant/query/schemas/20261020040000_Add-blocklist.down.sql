@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS blocklist_hits;
+DROP TABLE IF EXISTS blocklist_entries;
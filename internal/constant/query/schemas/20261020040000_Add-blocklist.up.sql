@@ -0,0 +1,47 @@
+------------------------------------------------
+-- Blocklist
+------------------------------------------------
+-- Customer phone numbers, email domains and IP networks refused when a
+-- payment intent is created. Entries without a company are set by
+-- operators and apply to every company. value is the normalized phone
+-- number, the lower-case domain or the network; network is only set for IP
+-- entries, where it is matched against the customer's address.
+CREATE TABLE IF NOT EXISTS blocklist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NULL REFERENCES companies(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('PHONE', 'EMAIL_DOMAIN', 'IP')),
+    value VARCHAR(255) NOT NULL,
+    network CIDR NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_by_user UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_by_operator UUID NULL REFERENCES operators(id) ON DELETE SET NULL,
+    hit_count INT NOT NULL DEFAULT 0,
+    last_hit_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK ((type = 'IP') = (network IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX idx_blocklist_entries_company_value
+    ON blocklist_entries (company_id, type, value) WHERE company_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_blocklist_entries_platform_value
+    ON blocklist_entries (type, value) WHERE company_id IS NULL;
+CREATE INDEX idx_blocklist_entries_network
+    ON blocklist_entries USING GIST (network inet_ops) WHERE network IS NOT NULL;
+
+-- Payment intents refused by an entry, one row per entry that matched.
+-- value is what matched: the phone number, the email domain or the address.
+CREATE TABLE IF NOT EXISTS blocklist_hits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entry_id UUID NULL REFERENCES blocklist_entries(id) ON DELETE SET NULL,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    amount DECIMAL NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    livemode BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_blocklist_hits_company_created_at ON blocklist_hits (company_id, created_at);
+CREATE INDEX idx_blocklist_hits_entry_id ON blocklist_hits (entry_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS payment_intent_confirmations;
+ALTER TABLE payment_intents DROP COLUMN IF EXISTS require_confirmation;
@@ -0,0 +1,24 @@
+------------------------------------------------
+-- Payment intent confirmations
+------------------------------------------------
+-- Intents created with require_confirmation wait for the payer before they
+-- are processed, including those an operator approves after review.
+ALTER TABLE payment_intents ADD COLUMN require_confirmation BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- One-time codes sent by SMS to the payer of an intent created in
+-- REQUIRES_CONFIRMATION. The intent is only queued to be processed once the
+-- payer enters the code. Resending revokes the pending code.
+CREATE TABLE IF NOT EXISTS payment_intent_confirmations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_intent_id UUID NOT NULL REFERENCES payment_intents(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,           -- bcrypt hash of the one-time code
+    attempts INT NOT NULL DEFAULT 0,
+    status VARCHAR(50) NOT NULL DEFAULT 'PENDING',
+    expires_at TIMESTAMPTZ NOT NULL,
+    confirmed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_payment_intent_confirmations_pending
+    ON payment_intent_confirmations (payment_intent_id) WHERE status = 'PENDING';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS webhook_events;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS dispute_evidence_files;
+DROP TABLE IF EXISTS disputes;
@@ -0,0 +1,94 @@
+------------------------------------------------
+-- Disputes
+------------------------------------------------
+-- A chargeback against a successful payment intent, opened by the processor
+-- (created_by_operator NULL) or by an operator. The merchant answers with
+-- evidence until evidence_due_by, which moves it from NEEDS_RESPONSE to
+-- UNDER_REVIEW; an operator then closes it as WON or LOST.
+CREATE TABLE IF NOT EXISTS disputes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    payment_intent_id UUID NOT NULL REFERENCES payment_intents(id) ON DELETE CASCADE,
+    livemode BOOLEAN NOT NULL,
+    amount DECIMAL NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'NEEDS_RESPONSE'
+        CHECK (status IN ('NEEDS_RESPONSE', 'UNDER_REVIEW', 'WON', 'LOST')),
+    evidence_due_by TIMESTAMPTZ NOT NULL,
+    evidence_text TEXT NULL,
+    submitted_by UUID NULL REFERENCES users(id),
+    submitted_at TIMESTAMPTZ NULL,
+    created_by_operator UUID NULL REFERENCES operators(id),
+    resolved_by UUID NULL REFERENCES operators(id),
+    resolved_at TIMESTAMPTZ NULL,
+    resolution_note TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (payment_intent_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_disputes_company_id ON disputes (company_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_disputes_status ON disputes (status, evidence_due_by);
+
+-- The file itself lives in the blob store under storage_key.
+CREATE TABLE IF NOT EXISTS dispute_evidence_files (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dispute_id UUID NOT NULL REFERENCES disputes(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    sha256 VARCHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    uploaded_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dispute_evidence_files_dispute_id
+    ON dispute_evidence_files (dispute_id, created_at);
+
+------------------------------------------------
+-- Ledger
+------------------------------------------------
+-- What each company is owed, in its settlement currency. A successful
+-- payment intent is credited; a lost dispute reverses it with a negative
+-- entry. Entries are never updated.
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    payment_intent_id UUID NOT NULL REFERENCES payment_intents(id) ON DELETE CASCADE,
+    dispute_id UUID NULL REFERENCES disputes(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL CHECK (type IN ('PAYMENT', 'DISPUTE_REVERSAL')),
+    amount DECIMAL NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    livemode BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (payment_intent_id, type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_company_id ON ledger_entries (company_id, created_at);
+
+------------------------------------------------
+-- Webhook events
+------------------------------------------------
+-- Events are written in the transaction that causes them and delivered to
+-- the company's callback_url afterwards, retried with a growing delay until
+-- they are DELIVERED or FAILED.
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    type VARCHAR(100) NOT NULL,
+    livemode BOOLEAN NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING'
+        CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT NULL,
+    delivered_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_events_due
+    ON webhook_events (next_attempt_at) WHERE status = 'PENDING';
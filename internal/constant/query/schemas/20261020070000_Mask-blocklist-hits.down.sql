@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_blocklist_hits_phone_number_index;
+ALTER TABLE blocklist_hits DROP COLUMN IF EXISTS phone_number_index;
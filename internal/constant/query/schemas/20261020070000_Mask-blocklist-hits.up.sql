@@ -0,0 +1,24 @@
+------------------------------------------------
+-- Blocklist hits without personal data
+------------------------------------------------
+-- Hits keep a masked value instead of the payer's phone number or address.
+-- phone_number_index is the blind index of the payer's phone number, the
+-- same HMAC as customers.phone_number_index, so that erasing a customer
+-- also anonymizes the hits of its phone number.
+ALTER TABLE blocklist_hits ADD COLUMN phone_number_index BYTEA NULL;
+
+CREATE INDEX idx_blocklist_hits_phone_number_index
+    ON blocklist_hits (company_id, phone_number_index) WHERE phone_number_index IS NOT NULL;
+
+-- Hits recorded before have no index and cannot be linked to a customer,
+-- so they are masked in place.
+UPDATE blocklist_hits
+SET value = CASE WHEN length(value) > 6
+        THEN left(value, 4) || repeat('*', length(value) - 6) || right(value, 2)
+        ELSE repeat('*', length(value)) END
+WHERE type = 'PHONE';
+
+UPDATE blocklist_hits
+SET value = set_masklen(value::INET,
+        CASE family(value::INET) WHEN 4 THEN 24 ELSE 48 END)::CIDR::TEXT
+WHERE type = 'IP';
@@ -0,0 +1,7 @@
+DELETE FROM ledger_entries WHERE type = 'CORRECTION';
+DROP INDEX IF EXISTS idx_ledger_entries_payment_intent_type;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_payment_intent_id_type_key
+    UNIQUE (payment_intent_id, type);
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('PAYMENT', 'DISPUTE_REVERSAL'));
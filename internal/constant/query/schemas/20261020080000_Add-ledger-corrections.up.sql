@@ -0,0 +1,14 @@
+------------------------------------------------
+-- Ledger corrections
+------------------------------------------------
+-- An operator correcting the status of a payment intent moves its balance
+-- with a CORRECTION entry, so that the ledger follows the status. An intent
+-- may be corrected several times; it still has at most one PAYMENT and one
+-- DISPUTE_REVERSAL entry.
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('PAYMENT', 'DISPUTE_REVERSAL', 'CORRECTION'));
+
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_payment_intent_id_type_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_entries_payment_intent_type
+    ON ledger_entries (payment_intent_id, type) WHERE type <> 'CORRECTION';
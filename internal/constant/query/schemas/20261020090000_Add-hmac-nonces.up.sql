@@ -0,0 +1,14 @@
+------------------------------------------------
+-- PG-HMAC nonces
+------------------------------------------------
+-- Nonces of signed requests, shared by every instance of the service so a
+-- request replayed against another instance is refused too. A nonce is
+-- kept until its timestamp can no longer pass the clock-skew check.
+CREATE TABLE IF NOT EXISTS hmac_nonces (
+    hmac_key_id UUID NOT NULL REFERENCES company_hmac_keys(id) ON DELETE CASCADE,
+    nonce VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (hmac_key_id, nonce)
+);
+
+CREATE INDEX idx_hmac_nonces_expires_at ON hmac_nonces (hmac_key_id, expires_at);
@@ -0,0 +1,7 @@
+------------------------------------------------
+-- Company suspension
+------------------------------------------------
+-- A company can be suspended in any status, including while it is still
+-- onboarding or under review. The status it was suspended from is kept so
+-- that reactivating it does not skip verification.
+ALTER TABLE companies ADD COLUMN suspended_from_status VARCHAR(50) NULL;
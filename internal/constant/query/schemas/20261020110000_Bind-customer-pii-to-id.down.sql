@@ -0,0 +1 @@
+ALTER TABLE customers DROP COLUMN IF EXISTS pii_bound_to_id;
@@ -0,0 +1,9 @@
+------------------------------------------------
+-- Customer personal data bound to the customer
+------------------------------------------------
+-- Sealed fields are now bound to the customer id as well as the company,
+-- so a ciphertext copied to another customer of the company does not open.
+-- Existing rows were bound to the company only; they keep opening that way
+-- until the PII worker reseals them.
+ALTER TABLE customers ADD COLUMN pii_bound_to_id BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE customers ALTER COLUMN pii_bound_to_id SET DEFAULT TRUE;
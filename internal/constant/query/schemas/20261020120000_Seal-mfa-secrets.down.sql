@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS used_tokens;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS mfa_secret_ciphertext,
+    DROP COLUMN IF EXISTS mfa_data_key_id;
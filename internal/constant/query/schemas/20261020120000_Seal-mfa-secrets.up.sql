@@ -0,0 +1,20 @@
+------------------------------------------------
+-- Sealed MFA secrets and single-use tokens
+------------------------------------------------
+-- TOTP secrets are sealed with the personal data keys like customer data.
+-- Plaintext secrets are sealed by the PII worker and stay readable until
+-- then.
+ALTER TABLE users
+    ADD COLUMN mfa_data_key_id UUID NULL REFERENCES pii_data_keys(id),
+    ADD COLUMN mfa_secret_ciphertext BYTEA NULL;
+
+-- Tokens that may be used once, such as MFA challenges, are recorded here
+-- when they are used and kept until they expire.
+CREATE TABLE IF NOT EXISTS used_tokens (
+    token_id UUID PRIMARY KEY,
+    token_type VARCHAR(50) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_used_tokens_expires_at ON used_tokens (expires_at);
@@ -0,0 +1,13 @@
+------------------------------------------------
+-- Login Throttles Table
+------------------------------------------------
+-- Sign-in attempts counted per phone or email, and per client address
+-- under an "ip:" prefix. An attempt is counted before the credentials are
+-- checked, by one conditional upsert, so concurrent attempts cannot slip
+-- past the limits. Rows are removed when the account signs in
+-- successfully or is unlocked.
+CREATE TABLE IF NOT EXISTS login_throttles (
+    throttle_key VARCHAR(255) PRIMARY KEY,
+    attempts INT NOT NULL,
+    last_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
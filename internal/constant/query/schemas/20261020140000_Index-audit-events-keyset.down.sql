@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_audit_events_company_id;
+CREATE INDEX idx_audit_events_company_id ON audit_events (company_id, created_at);
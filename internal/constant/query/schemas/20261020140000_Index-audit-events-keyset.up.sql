@@ -0,0 +1,3 @@
+-- Audit exports page through a company's log by (created_at, id).
+DROP INDEX IF EXISTS idx_audit_events_company_id;
+CREATE INDEX idx_audit_events_company_id ON audit_events (company_id, created_at, id);
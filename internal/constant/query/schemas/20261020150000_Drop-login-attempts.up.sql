@@ -0,0 +1,3 @@
+-- Sign-in attempts are counted in login_throttles since operators moved
+-- there too; nothing reads or writes login_attempts any more.
+DROP TABLE IF EXISTS login_attempts;
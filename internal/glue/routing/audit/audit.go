@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/glue/routing"
+	"pg/internal/handler/middleware"
+	"pg/internal/handler/rest"
+
+	"github.com/labstack/echo/v4"
+)
+
+func Route(
+	grp *echo.Group,
+	authMiddle middleware.AuthMiddleware,
+	handler rest.Audit,
+) {
+	router := []routing.Router{
+		{
+			Method:  http.MethodGet,
+			Path:    "/audit-events",
+			Handler: handler.ListAuditEvents,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionViewAuditLog),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/audit-events/export",
+			Handler: handler.ExportAuditEvents,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionViewAuditLog),
+			},
+		},
+	}
+
+	routing.RegisterRoute(grp, router)
+}
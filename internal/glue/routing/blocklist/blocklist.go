@@ -0,0 +1,68 @@
+package blocklist
+
+import (
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/glue/routing"
+	"pg/internal/handler/middleware"
+	"pg/internal/handler/rest"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Route registers the company's own blocklist for dashboard users.
+func Route(
+	grp *echo.Group,
+	authMiddle middleware.AuthMiddleware,
+	handler rest.Blocklist,
+) {
+	router := []routing.Router{
+		{
+			Method:  http.MethodGet,
+			Path:    "/blocklist",
+			Handler: handler.ListEntries,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/blocklist",
+			Handler: handler.AddEntry,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/blocklist/:id",
+			Handler: handler.UpdateEntry,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/blocklist/:id",
+			Handler: handler.DeleteEntry,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/blocklist-hits",
+			Handler: handler.ListHits,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionViewPayments),
+			},
+		},
+	}
+
+	routing.RegisterRoute(grp, router)
+}
@@ -2,6 +2,7 @@ package company
 
 import (
 	"net/http"
+	"pg/internal/constant"
 	"pg/internal/glue/routing"
 	"pg/internal/handler/middleware"
 	"pg/internal/handler/rest"
@@ -27,12 +28,176 @@ func Route(
 			Handler:     handler.Login,
 			Middlewares: []echo.MiddlewareFunc{},
 		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/login/mfa",
+			Handler:     handler.LoginMFA,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/login/unlock",
+			Handler:     handler.UnlockAccount,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
 		{
 			Method:  http.MethodPost,
 			Path:    "/generate-secret-token",
 			Handler: handler.GenerateSecretToken,
 			Middlewares: []echo.MiddlewareFunc{
 				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionGenerateSecretToken),
+			},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/password/forgot",
+			Handler:     handler.ForgotPassword,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/password/reset",
+			Handler:     handler.ResetPassword,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/verify/email",
+			Handler:     handler.VerifyEmail,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/verify/phone",
+			Handler:     handler.VerifyPhone,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/verify/resend",
+			Handler:     handler.ResendVerification,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/enroll",
+			Handler: handler.EnrollMFA,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/enable",
+			Handler: handler.EnableMFA,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/disable",
+			Handler: handler.DisableMFA,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/recovery-codes",
+			Handler: handler.RegenerateRecoveryCodes,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/company",
+			Handler: handler.GetCompany,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+			},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/company",
+			Handler: handler.UpdateCompany,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/company/mfa",
+			Handler: handler.UpdateCompanyMFA,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/hmac-keys",
+			Handler: handler.CreateHMACKey,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionGenerateSecretToken),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/hmac-keys",
+			Handler: handler.ListHMACKeys,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionGenerateSecretToken),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/hmac-keys/:id",
+			Handler: handler.RevokeHMACKey,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionGenerateSecretToken),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/ip-allowlist",
+			Handler: handler.ListIPAllowlist,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/ip-allowlist",
+			Handler: handler.AddIPAllowlistEntry,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/ip-allowlist/:id",
+			Handler: handler.UpdateIPAllowlistEntry,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/ip-allowlist/:id",
+			Handler: handler.DeleteIPAllowlistEntry,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
 			},
 		},
 	}
@@ -0,0 +1,30 @@
+package currency
+
+import (
+	"net/http"
+	"pg/internal/glue/routing"
+	"pg/internal/handler/middleware"
+	"pg/internal/handler/rest"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Route registers the currency registry as seen by the caller's company.
+func Route(
+	grp *echo.Group,
+	authMiddle middleware.AuthMiddleware,
+	handler rest.Currency,
+) {
+	router := []routing.Router{
+		{
+			Method:  http.MethodGet,
+			Path:    "/currencies",
+			Handler: handler.ListCurrencies,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateAdminUser(),
+			},
+		},
+	}
+
+	routing.RegisterRoute(grp, router)
+}
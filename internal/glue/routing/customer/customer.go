@@ -0,0 +1,71 @@
+package customer
+
+import (
+	"net/http"
+	"pg/internal/glue/routing"
+	"pg/internal/handler/middleware"
+	"pg/internal/handler/rest"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Route registers the customers API. It is called by merchant servers with
+// their API keys, like the payment intent routes.
+func Route(
+	grp *echo.Group,
+	authMiddle middleware.AuthMiddleware,
+	handler rest.Customer,
+) {
+	router := []routing.Router{
+		{
+			Method:  http.MethodPost,
+			Path:    "/customers",
+			Handler: handler.CreateCustomer,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateAdminUser(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/customers",
+			Handler: handler.ListCustomers,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateAdminUser(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/customers/:id",
+			Handler: handler.GetCustomer,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateAdminUser(),
+			},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/customers/:id",
+			Handler: handler.UpdateCustomer,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateAdminUser(),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/customers/:id",
+			Handler: handler.DeleteCustomer,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateAdminUser(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/customers/:id/payment-intents",
+			Handler: handler.ListPaymentIntents,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateAdminUser(),
+			},
+		},
+	}
+
+	routing.RegisterRoute(grp, router)
+}
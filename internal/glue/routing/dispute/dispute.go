@@ -0,0 +1,68 @@
+package dispute
+
+import (
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/glue/routing"
+	"pg/internal/handler/middleware"
+	"pg/internal/handler/rest"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Route registers the company's disputes for dashboard users.
+func Route(
+	grp *echo.Group,
+	authMiddle middleware.AuthMiddleware,
+	handler rest.Dispute,
+) {
+	router := []routing.Router{
+		{
+			Method:  http.MethodGet,
+			Path:    "/disputes",
+			Handler: handler.List,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionViewPayments),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/disputes/:id",
+			Handler: handler.Get,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionViewPayments),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/disputes/:id/evidence",
+			Handler: handler.UpdateEvidence,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageDisputes),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/disputes/:id/evidence/files",
+			Handler: handler.UploadEvidence,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageDisputes),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/disputes/:id/submit",
+			Handler: handler.Submit,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageDisputes),
+			},
+		},
+	}
+
+	routing.RegisterRoute(grp, router)
+}
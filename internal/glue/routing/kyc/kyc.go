@@ -0,0 +1,58 @@
+package kyc
+
+import (
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/glue/routing"
+	"pg/internal/handler/middleware"
+	"pg/internal/handler/rest"
+
+	"github.com/labstack/echo/v4"
+)
+
+func Route(
+	grp *echo.Group,
+	authMiddle middleware.AuthMiddleware,
+	handler rest.KYC,
+) {
+	router := []routing.Router{
+		{
+			Method:  http.MethodGet,
+			Path:    "/kyc",
+			Handler: handler.GetKYC,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/kyc/documents",
+			Handler: handler.UploadDocument,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/kyc/documents/:id",
+			Handler: handler.DeleteDocument,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/kyc/submit",
+			Handler: handler.Submit,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageCompany),
+			},
+		},
+	}
+
+	routing.RegisterRoute(grp, router)
+}
@@ -0,0 +1,326 @@
+package operator
+
+import (
+	"net/http"
+	"pg/internal/glue/routing"
+	"pg/internal/handler/middleware"
+	"pg/internal/handler/rest"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Route registers the operator back-office under /admin. Every route but
+// sign in requires an operator access token.
+func Route(
+	grp *echo.Group,
+	authMiddle middleware.AuthMiddleware,
+	handler rest.Operator,
+) {
+	admin := grp.Group("/admin")
+	router := []routing.Router{
+		{
+			Method:      http.MethodPost,
+			Path:        "/login",
+			Handler:     handler.Login,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/operators",
+			Handler: handler.CreateOperator,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/companies",
+			Handler: handler.ListCompanies,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/companies/:id",
+			Handler: handler.GetCompany,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/companies/:id/suspend",
+			Handler: handler.SuspendCompany,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/companies/:id/reactivate",
+			Handler: handler.ReactivateCompany,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/payment-intents/:id",
+			Handler: handler.GetPaymentIntent,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/payment-intents/:id/status",
+			Handler: handler.CorrectPaymentIntentStatus,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/companies/:id/kyc",
+			Handler: handler.GetCompanyKYC,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/companies/:id/kyc/review",
+			Handler: handler.ReviewKYC,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/kyc-documents/:id/file",
+			Handler: handler.DownloadKYCDocument,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/pii/data-keys",
+			Handler: handler.ListDataKeys,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/pii/data-keys/rotate",
+			Handler: handler.RotateDataKey,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/companies/:id/currencies",
+			Handler: handler.ListCompanyCurrencies,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/companies/:id/currencies/:code",
+			Handler: handler.SetCompanyCurrency,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/companies/:id/fx-settings",
+			Handler: handler.GetFXSettings,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/companies/:id/fx-settings",
+			Handler: handler.SetFXSettings,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/companies/:id/limits",
+			Handler: handler.ListLimits,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/companies/:id/limits/:currency",
+			Handler: handler.SetLimit,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/companies/:id/limits/:currency",
+			Handler: handler.DeleteLimit,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/companies/:id/risk-rules",
+			Handler: handler.ListRiskRules,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/companies/:id/risk-rules",
+			Handler: handler.CreateRiskRule,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/companies/:id/risk-rules/:rule_id",
+			Handler: handler.UpdateRiskRule,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/companies/:id/risk-rules/:rule_id",
+			Handler: handler.DeleteRiskRule,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/companies/:id/risk-settings",
+			Handler: handler.GetRiskSettings,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/companies/:id/risk-settings",
+			Handler: handler.SetRiskSettings,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/risk-reviews",
+			Handler: handler.ListRiskReviews,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/payment-intents/:id/risk-review",
+			Handler: handler.ReviewPaymentIntent,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/blocklist",
+			Handler: handler.ListBlocklist,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/blocklist",
+			Handler: handler.AddBlocklistEntry,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/blocklist/:id",
+			Handler: handler.UpdateBlocklistEntry,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/blocklist/:id",
+			Handler: handler.DeleteBlocklistEntry,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/blocklist-hits",
+			Handler: handler.ListBlocklistHits,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/disputes",
+			Handler: handler.ListDisputes,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/disputes",
+			Handler: handler.OpenDispute,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/disputes/:id",
+			Handler: handler.GetDispute,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/disputes/:id/resolve",
+			Handler: handler.ResolveDispute,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/disputes/:id/evidence/:file_id",
+			Handler: handler.DownloadDisputeEvidence,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateOperator(),
+			},
+		},
+	}
+
+	routing.RegisterRoute(admin, router)
+}
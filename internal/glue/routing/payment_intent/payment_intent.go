@@ -31,6 +31,22 @@ func Route(
 				authMiddle.AuthenticateAdminUser(),
 			},
 		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/payment-intents/:id/confirm",
+			Handler: handler.ConfirmPaymentIntent,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateAdminUser(),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/payment-intents/:id/resend-confirmation",
+			Handler: handler.ResendPaymentConfirmation,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateAdminUser(),
+			},
+		},
 	}
 
 	routing.RegisterRoute(grp, router)
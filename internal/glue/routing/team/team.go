@@ -0,0 +1,64 @@
+package team
+
+import (
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/glue/routing"
+	"pg/internal/handler/middleware"
+	"pg/internal/handler/rest"
+
+	"github.com/labstack/echo/v4"
+)
+
+func Route(
+	grp *echo.Group,
+	authMiddle middleware.AuthMiddleware,
+	handler rest.Team,
+) {
+	router := []routing.Router{
+		{
+			Method:  http.MethodPost,
+			Path:    "/team/invitations",
+			Handler: handler.InviteUser,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageTeam),
+			},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/team/invitations/accept",
+			Handler:     handler.AcceptInvitation,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/team/members",
+			Handler: handler.ListMembers,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionViewTeam),
+			},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/team/members/:id/role",
+			Handler: handler.UpdateMemberRole,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageTeam),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/team/members/:id/deactivate",
+			Handler: handler.DeactivateMember,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddle.AuthenticateUser(),
+				authMiddle.Authorize(constant.PermissionManageTeam),
+			},
+		},
+	}
+
+	routing.RegisterRoute(grp, router)
+}
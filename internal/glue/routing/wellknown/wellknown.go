@@ -0,0 +1,28 @@
+package wellknown
+
+import (
+	"net/http"
+	"pg/internal/glue/routing"
+	"pg/internal/handler/rest"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Route registers the well-known endpoints. They are served from the server
+// root, outside the /api group, so verifiers can discover them at the
+// conventional path.
+func Route(
+	grp *echo.Group,
+	handler rest.WellKnown,
+) {
+	router := []routing.Router{
+		{
+			Method:      http.MethodGet,
+			Path:        "/paseto-keys",
+			Handler:     handler.PasetoKeys,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+	}
+
+	routing.RegisterRoute(grp, router)
+}
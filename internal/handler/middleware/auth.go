@@ -5,10 +5,12 @@ import (
 	"net/http"
 	"pg/internal/constant"
 	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
 	"pg/internal/storage"
 	"pg/platform/hcrypto"
 	"pg/platform/hlog"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -18,73 +20,120 @@ import (
 type AuthMiddleware interface {
 	AuthenticateAdminUser() echo.MiddlewareFunc
 	AuthenticateUser() echo.MiddlewareFunc
+	Authorize(permissions ...constant.Permission) echo.MiddlewareFunc
+	AuthenticateOperator() echo.MiddlewareFunc
 }
 
 type authMiddleware struct {
-	logger         hlog.Logger
-	maker          hcrypto.Maker
-	companyStorage storage.Company
+	logger          hlog.Logger
+	maker           hcrypto.Maker
+	companyStorage  storage.Company
+	auditStorage    storage.Audit
+	operatorStorage storage.Operator
+	hmacClockSkew   time.Duration
 }
 
+// InitAuthMiddleware builds the auth middleware. hmacClockSkew is how far a
+// PG-HMAC timestamp may be from the server clock; nonces are remembered for
+// twice that long.
 func InitAuthMiddleware(
 	logger hlog.Logger,
 	maker hcrypto.Maker,
 	companyStorage storage.Company,
-
+	auditStorage storage.Audit,
+	operatorStorage storage.Operator,
+	hmacClockSkew time.Duration,
 ) AuthMiddleware {
 	return &authMiddleware{
-		logger:         logger,
-		maker:          maker,
-		companyStorage: companyStorage,
+		logger:          logger,
+		maker:           maker,
+		companyStorage:  companyStorage,
+		auditStorage:    auditStorage,
+		operatorStorage: operatorStorage,
+		hmacClockSkew:   hmacClockSkew,
 	}
 }
+
+// AuthenticateAdminUser authenticates merchant servers, either with a
+// Bearer secret token or with a PG-HMAC signed request. The mode of the key
+// is put in the context as x-livemode.
 func (a *authMiddleware) AuthenticateAdminUser() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			ctx := c.Request().Context()
-			payload, err := a.VerifyPasetoToken(c)
-			if err != nil {
-				err = errors.ErrInvalidAccessToken.Wrap(err, "invalid token")
-				a.logger.Error(ctx, "invalid token", zap.Error(err))
-				return err
-			}
-			companyID, err := uuid.Parse(payload.UserID)
-			if err != nil {
-				err = errors.ErrInternalServerError.Wrap(err, "invalid company id")
-				a.logger.Error(ctx, "error parsing company id", zap.Error(err))
-				return c.JSON(http.StatusInternalServerError, err)
+			var (
+				company  *dto.Company
+				payload  *hcrypto.Payload
+				livemode bool
+				err      error
+			)
+			if a.authorizationType(c) == constant.AuthorizationTypeHMAC {
+				company, livemode, err = a.verifyHMACSignature(c)
+			} else {
+				company, payload, livemode, err = a.verifySecretToken(c)
 			}
-			company, err := a.companyStorage.GetCompanyByID(ctx, companyID)
 			if err != nil {
-				err = errors.ErrInvalidAccessToken.New("access denied")
 				return err
 			}
-			if company.Status != string(constant.Active) {
+			// Companies still going through verification may call the API;
+			// the payment modules decide what they are allowed to do.
+			switch constant.Status(company.Status) {
+			case constant.Active, constant.Onboarding, constant.UnderReview, constant.Rejected:
+			default:
 				err = errors.ErrAuthError.New("access denied company status is %s", company.Status)
 				return err
 			}
-			companyToken, err := a.companyStorage.GetActiveCompanyTokenByID(ctx, companyID)
-			if err != nil {
-				err = errors.ErrInvalidAccessToken.New("unable to get active company token")
-				a.logger.Error(ctx, "unable to get active company token")
-				return err
-			}
-			if payload.TokenID != companyToken.TokenID {
-				err = errors.ErrInvalidAccessToken.New("invalid token")
-				a.logger.Error(ctx, "invalid token", zap.Error(err))
+			if err := a.checkIPAllowlist(c, company); err != nil {
 				return err
 			}
 
 			req := c.Request()
 			req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey("x-companyID"), company.ID.String()))
 			req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey("x-company"), *company))
-			req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey(constant.AuthorizationPayloadKey), *payload))
+			req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey("x-livemode"), livemode))
+			if payload != nil {
+				req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey(constant.AuthorizationPayloadKey), *payload))
+			}
 			c.SetRequest(req)
 
 			return next(c)
 		}
 	}
 }
+
+func (a *authMiddleware) verifySecretToken(c echo.Context) (*dto.Company, *hcrypto.Payload, bool, error) {
+	ctx := c.Request().Context()
+	payload, err := a.VerifyPasetoToken(c)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.Wrap(err, "invalid token")
+		a.logger.Error(ctx, "invalid token", zap.Error(err))
+		return nil, nil, false, err
+	}
+	companyID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.Wrap(err, "invalid company id")
+		a.logger.Error(ctx, "error parsing company id", zap.Error(err))
+		return nil, nil, false, err
+	}
+	company, err := a.companyStorage.GetCompanyByID(ctx, companyID)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.New("access denied")
+		return nil, nil, false, err
+	}
+	companyToken, err := a.companyStorage.GetActiveCompanyTokenByTokenID(ctx, payload.TokenID)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.New("unable to get active company token")
+		a.logger.Error(ctx, "unable to get active company token")
+		return nil, nil, false, err
+	}
+	if companyToken.CompanyID != company.ID {
+		err = errors.ErrInvalidAccessToken.New("invalid token")
+		a.logger.Error(ctx, "invalid token", zap.Error(err))
+		return nil, nil, false, err
+	}
+
+	return company, payload, companyToken.Livemode, nil
+}
+
 func (a *authMiddleware) AuthenticateUser() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -95,6 +144,12 @@ func (a *authMiddleware) AuthenticateUser() echo.MiddlewareFunc {
 				a.logger.Error(ctx, "invalid token", zap.Error(err))
 				return err
 			}
+			if payload.TokenType != constant.AccessToken {
+				err = errors.ErrInvalidAccessToken.New("invalid token type")
+				a.logger.Error(ctx, "invalid token type", zap.Error(err),
+					zap.String("token-type", string(payload.TokenType)))
+				return err
+			}
 			userID, err := uuid.Parse(payload.UserID)
 			if err != nil {
 				err = errors.ErrInternalServerError.Wrap(err, "invalid user id")
@@ -109,9 +164,16 @@ func (a *authMiddleware) AuthenticateUser() echo.MiddlewareFunc {
 				err = errors.ErrAuthError.New("access denied")
 				return err
 			}
+			if payload.IssuedAt.Before(user.SessionsRevokedAt) {
+				err = errors.ErrInvalidAccessToken.New("session has been revoked")
+				a.logger.Warn(ctx, "token issued before sessions were revoked", zap.Error(err),
+					zap.String("user-id", user.ID.String()))
+				return err
+			}
 
 			req := c.Request()
 			req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey("x-id"), payload.UserID))
+			req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey("x-user"), *user))
 			req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey(constant.AuthorizationPayloadKey), *payload))
 			c.SetRequest(req)
 
@@ -120,6 +182,45 @@ func (a *authMiddleware) AuthenticateUser() echo.MiddlewareFunc {
 	}
 }
 
+// Authorize must run after AuthenticateUser. It rejects the request unless the
+// authenticated user's role holds every one of the given permissions and,
+// when the company requires MFA, the user has enabled it.
+func (a *authMiddleware) Authorize(permissions ...constant.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			user, ok := ctx.Value("x-user").(dto.User)
+			if !ok {
+				err := errors.ErrAcessError.New("access denied")
+				a.logger.Error(ctx, "authorize used without an authenticated user", zap.Error(err))
+				return err
+			}
+			company, err := a.companyStorage.GetCompanyByID(ctx, user.CompanyID)
+			if err != nil {
+				return err
+			}
+			if company.MFARequired && user.MFAEnabledAt.IsZero() {
+				err := errors.ErrAcessError.New("your company requires mfa, enroll before continuing")
+				a.logger.Warn(ctx, "user without mfa in a company that requires it", zap.Error(err),
+					zap.String("user-id", user.ID.String()))
+				return err
+			}
+			for _, permission := range permissions {
+				if !constant.Role(user.Role).HasPermission(permission) {
+					err := errors.ErrAcessError.New("you do not have permission to perform this action")
+					a.logger.Warn(ctx, "permission denied", zap.Error(err),
+						zap.String("user-id", user.ID.String()),
+						zap.String("role", user.Role),
+						zap.String("permission", string(permission)))
+					return err
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
 func (a *authMiddleware) VerifyPasetoToken(c echo.Context) (*hcrypto.Payload, error) {
 	ctx := c.Request().Context()
 	authorizationHeader := c.Request().Header.Get(constant.AuthorizationHeaderkey)
@@ -60,6 +60,12 @@ func CastErrorResponse(err error) *response2.ErrorResponse {
 			Message:    er.Message(),
 			FieldError: ErrorFields(er.Cause()),
 		}
+		if limit, ok := er.Property(errors.PropertyLimit); ok {
+			response.Limit = limit
+		}
+		if blocked, ok := er.Property(errors.PropertyBlocked); ok {
+			response.Blocked = blocked
+		}
 	}
 
 	if debugMode {
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/platform/hcrypto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joomcode/errorx"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// maxNonceLength bounds what a client can make the nonce table hold.
+const maxNonceLength = 128
+
+// maxSignedBodyBytes bounds the body read to check a signature. PG-HMAC
+// requests carry JSON, so a larger body is refused before it is read.
+const maxSignedBodyBytes = 1 << 20
+
+func (a *authMiddleware) authorizationType(c echo.Context) string {
+	fields := strings.Fields(c.Request().Header.Get(constant.AuthorizationHeaderkey))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// verifyHMACSignature authenticates a request signed with
+//
+//	Authorization: PG-HMAC keyId=<key id>,signature=<hex hmac-sha256>
+//
+// over hcrypto.HMACSigningString. The timestamp must be within the clock-skew
+// window and the nonce may be used once per key, across every instance of
+// the service.
+func (a *authMiddleware) verifyHMACSignature(c echo.Context) (*dto.Company, bool, error) {
+	ctx := c.Request().Context()
+	params, err := parseHMACAuthorization(c.Request().Header.Get(constant.AuthorizationHeaderkey))
+	if err != nil {
+		a.logger.Warn(ctx, "invalid hmac authorization header", zap.Error(err))
+		return nil, false, err
+	}
+	keyID, signature := params["keyId"], params["signature"]
+
+	timestamp := c.Request().Header.Get(constant.HMACTimestampHeader)
+	nonce := c.Request().Header.Get(constant.HMACNonceHeader)
+	if nonce == "" || len(nonce) > maxNonceLength {
+		err := errors.ErrAuthError.New("%s header is required and at most %d characters",
+			constant.HMACNonceHeader, maxNonceLength)
+		a.logger.Warn(ctx, "invalid hmac nonce", zap.Error(err), zap.String("key-id", keyID))
+		return nil, false, err
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		err := errors.ErrAuthError.New("%s header must be a unix timestamp", constant.HMACTimestampHeader)
+		a.logger.Warn(ctx, "invalid hmac timestamp", zap.Error(err), zap.String("key-id", keyID))
+		return nil, false, err
+	}
+	if skew := time.Since(time.Unix(unix, 0)); skew > a.hmacClockSkew || skew < -a.hmacClockSkew {
+		err := errors.ErrAuthError.New("request timestamp is outside the allowed window")
+		a.logger.Warn(ctx, "hmac timestamp outside the clock-skew window", zap.Error(err),
+			zap.String("key-id", keyID), zap.Duration("skew", skew))
+		return nil, false, err
+	}
+
+	key, err := a.companyStorage.GetActiveHMACKey(ctx, keyID)
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			return nil, false, errors.ErrInvalidAccessToken.New("invalid signature")
+		}
+		return nil, false, err
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body,
+		maxSignedBodyBytes))
+	if err != nil {
+		err = errors.ErrBadRequest.Wrap(err, "unable to read request body, it must be at most %d bytes",
+			maxSignedBodyBytes)
+		a.logger.Error(ctx, "unable to read request body", zap.Error(err))
+		return nil, false, err
+	}
+	c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+	signingString := hcrypto.HMACSigningString(c.Request().Method,
+		c.Request().URL.RequestURI(), timestamp, nonce, body)
+	if !hcrypto.VerifyHMAC(key.Secret, signingString, signature) {
+		err := errors.ErrInvalidAccessToken.New("invalid signature")
+		a.logger.Warn(ctx, "hmac signature mismatch", zap.Error(err), zap.String("key-id", keyID))
+		return nil, false, err
+	}
+	// The nonce is only recorded once the signature is valid, so that
+	// unauthenticated callers cannot burn nonces.
+	// A timestamp is accepted for the clock skew on either side of now, so
+	// the nonce is kept for the whole window.
+	fresh, err := a.companyStorage.UseHMACNonce(ctx, key.ID, nonce,
+		time.Now().Add(2*a.hmacClockSkew))
+	if err != nil {
+		return nil, false, err
+	}
+	if !fresh {
+		err := errors.ErrInvalidAccessToken.New("nonce has already been used")
+		a.logger.Warn(ctx, "replayed hmac nonce", zap.Error(err), zap.String("key-id", keyID))
+		return nil, false, err
+	}
+
+	company, err := a.companyStorage.GetCompanyByID(ctx, key.CompanyID)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.New("access denied")
+		return nil, false, err
+	}
+	if time.Since(key.LastUsedAt) > time.Minute {
+		// Usage tracking is best effort and must not fail the request.
+		_ = a.companyStorage.TouchHMACKey(ctx, key.ID)
+	}
+
+	return company, key.Livemode, nil
+}
+
+// parseHMACAuthorization parses "PG-HMAC keyId=...,signature=...".
+func parseHMACAuthorization(header string) (map[string]string, error) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	if scheme != constant.AuthorizationTypeHMAC {
+		return nil, errors.ErrAuthError.New("unsupported authorization type")
+	}
+	params := map[string]string{}
+	for _, part := range strings.Split(rest, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		params[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	if params["keyId"] == "" || params["signature"] == "" {
+		return nil, errors.ErrAuthError.New("PG-HMAC authorization requires keyId and signature")
+	}
+	return params, nil
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// checkIPAllowlist refuses merchant API requests from addresses outside the
+// company's allowlist and records each refusal. A company without entries
+// accepts requests from anywhere.
+func (a *authMiddleware) checkIPAllowlist(c echo.Context, company *dto.Company) error {
+	ctx := c.Request().Context()
+	entries, err := a.companyStorage.ListIPAllowlist(ctx, company.ID)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	clientIP := c.RealIP()
+	if ip := net.ParseIP(clientIP); ip != nil {
+		for _, entry := range entries {
+			_, network, err := net.ParseCIDR(entry.CIDR)
+			if err == nil && network.Contains(ip) {
+				return nil
+			}
+		}
+	}
+
+	requestID, _ := ctx.Value(constant.ContextKey("x-request-id")).(string)
+	if _, err := a.auditStorage.CreateAuditEvent(ctx, dto.CreateAuditEvent{
+		CompanyID: company.ID,
+		ActorType: constant.AuditActorCompany,
+		ActorID:   company.ID,
+		Action:    constant.AuditIPAllowlistBlocked,
+		IPAddress: clientIP,
+		RequestID: requestID,
+		Metadata: map[string]any{
+			"method": c.Request().Method,
+			"path":   c.Request().URL.Path,
+			"scheme": a.authorizationType(c),
+		},
+	}); err != nil {
+		a.logger.Error(ctx, "unable to audit blocked request", zap.Error(err),
+			zap.String("company-id", company.ID.String()))
+	}
+
+	err = errors.ErrAcessError.New("requests from this ip address are not allowed")
+	a.logger.Warn(ctx, "request blocked by ip allowlist", zap.Error(err),
+		zap.String("company-id", company.ID.String()), zap.String("ip", clientIP))
+	return err
+}
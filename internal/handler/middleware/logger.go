@@ -23,8 +23,9 @@ func Logger(logger hlog.Logger) echo.MiddlewareFunc {
 			query := req.URL.RawQuery
 			id := uuid.New().String()
 
-			// Add x-request-id and request-start-time to context
+			// Add x-request-id, x-ip-address and request-start-time to context
 			ctx := context.WithValue(req.Context(), constant.ContextKey("x-request-id"), id)
+			ctx = context.WithValue(ctx, constant.ContextKey("x-ip-address"), c.RealIP())
 			ctx = context.WithValue(ctx, constant.ContextKey("request-start-time"), start)
 			c.SetRequest(req.WithContext(ctx))
 
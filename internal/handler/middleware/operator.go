@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// AuthenticateOperator admits platform operators to the back-office. Merchant
+// access tokens are refused by their token type.
+func (a *authMiddleware) AuthenticateOperator() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			payload, err := a.VerifyPasetoToken(c)
+			if err != nil {
+				err = errors.ErrInvalidAccessToken.Wrap(err, "invalid token")
+				a.logger.Error(ctx, "invalid token", zap.Error(err))
+				return err
+			}
+			if payload.TokenType != constant.OperatorAccessToken {
+				err = errors.ErrInvalidAccessToken.New("invalid token type")
+				a.logger.Warn(ctx, "non operator token used on the back-office", zap.Error(err),
+					zap.String("token-type", string(payload.TokenType)))
+				return err
+			}
+			operatorID, err := uuid.Parse(payload.UserID)
+			if err != nil {
+				err = errors.ErrInvalidAccessToken.Wrap(err, "invalid operator id")
+				a.logger.Error(ctx, "error parsing operator id", zap.Error(err))
+				return err
+			}
+			operator, err := a.operatorStorage.GetOperatorByID(ctx, operatorID)
+			if err != nil {
+				return err
+			}
+			if operator.Status != string(constant.Active) {
+				err = errors.ErrAuthError.New("access denied")
+				return err
+			}
+
+			req := c.Request()
+			req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey("x-operator-id"), payload.UserID))
+			req = req.WithContext(context.WithValue(req.Context(), constant.ContextKey("x-operator"), *operator))
+			c.SetRequest(req)
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/constant/model/response"
+	"pg/internal/handler/rest"
+	"pg/internal/module"
+	"pg/platform/hlog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type audit struct {
+	log            hlog.Logger
+	auditModule    module.Audit
+	contextTimeout time.Duration
+}
+
+func New(log hlog.Logger, auditModule module.Audit,
+	ctx time.Duration) rest.Audit {
+	return &audit{
+		log:            log,
+		auditModule:    auditModule,
+		contextTimeout: ctx,
+	}
+}
+
+// ListAuditEvents
+//
+//	@Summary		List audit events
+//	@Description	List the audit log of the caller's company, newest first. Filter by action, actor and time range.
+//	@Tags			audit
+//	@Accept			json
+//	@Produce		json
+//	@Param			action		query		string	false	"Action, e.g. auth.login_succeeded"
+//	@Param			actor_id	query		string	false	"Actor id"
+//	@Param			from		query		string	false	"Earliest event time (RFC 3339)"
+//	@Param			to			query		string	false	"Latest event time (RFC 3339)"
+//	@Param			page		query		int		false	"Page number, starting at 1"
+//	@Param			per_page	query		int		false	"Events per page, at most 200"
+//	@Success		200			{object}	doc.SuccessResponse{data=[]dto.AuditEvent,meta_data=response.MetaData}
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403			{object}	doc.ErrorResponse	"Role is not allowed to view the audit log"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/audit-events [get]
+//	@Security		BearerAuth
+func (a *audit) ListAuditEvents(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), a.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	filter := dto.AuditEventFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind audit filter")
+		a.log.Error(ctx, "unable to bind audit filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := a.auditModule.ListAuditEvents(ctx, id, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
+
+// ExportAuditEvents
+//
+//	@Summary		Export audit events
+//	@Description	Download the filtered audit log of the caller's company as CSV, newest first. Pagination is ignored and the export is capped by the server.
+//	@Tags			audit
+//	@Produce		text/csv
+//	@Param			action		query		string	false	"Action, e.g. auth.login_succeeded"
+//	@Param			actor_id	query		string	false	"Actor id"
+//	@Param			from		query		string	false	"Earliest event time (RFC 3339)"
+//	@Param			to			query		string	false	"Latest event time (RFC 3339)"
+//	@Success		200			{file}		file
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403			{object}	doc.ErrorResponse	"Role is not allowed to view the audit log"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/audit-events/export [get]
+//	@Security		BearerAuth
+func (a *audit) ExportAuditEvents(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), a.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	filter := dto.AuditEventFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind audit filter")
+		a.log.Error(ctx, "unable to bind audit filter", zap.Error(err))
+		return er
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(
+		"attachment; filename=audit-events-%s.csv", time.Now().UTC().Format("20060102T150405Z")))
+	if err := a.auditModule.ExportAuditEvents(ctx, id, filter, c.Response()); err != nil {
+		if !c.Response().Committed {
+			header.Del(echo.HeaderContentDisposition)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package blocklist
+
+import (
+	"context"
+	"net/http"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/constant/model/response"
+	"pg/internal/handler/rest"
+	"pg/internal/module"
+	"pg/platform/hlog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type blocklist struct {
+	log             hlog.Logger
+	blocklistModule module.Blocklist
+	contextTimeout  time.Duration
+}
+
+func New(log hlog.Logger, blocklistModule module.Blocklist,
+	ctx time.Duration) rest.Blocklist {
+	return &blocklist{
+		log:             log,
+		blocklistModule: blocklistModule,
+		contextTimeout:  ctx,
+	}
+}
+
+// ListEntries
+//
+//	@Summary		List the blocklist
+//	@Description	List the company's blocklist entries, newest first, with how many payment intents each refused.
+//	@Tags			blocklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			type		query		string	false	"PHONE, EMAIL_DOMAIN or IP"
+//	@Param			page		query		int		false	"Page number, starting at 1"
+//	@Param			per_page	query		int		false	"Entries per page, at most 200"
+//	@Success		200			{object}	doc.SuccessResponse{data=[]dto.BlocklistEntry,meta_data=response.MetaData}
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403			{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/blocklist [get]
+//	@Security		BearerAuth
+func (b *blocklist) ListEntries(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), b.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	filter := dto.BlocklistFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind blocklist filter")
+		b.log.Error(ctx, "unable to bind blocklist filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := b.blocklistModule.ListCompanyEntries(ctx, id, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
+
+// AddEntry
+//
+//	@Summary		Add a blocklist entry
+//	@Description	Refuse new payment intents of customers with the phone number, an email address at the domain or its subdomains, or a customer_ip in the network. Refused intents fail with 403 and a blocked detail naming the entry type.
+//	@Tags			blocklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			blocklist_request_body	body		dto.BlocklistRequest	true	"Entry"
+//	@Success		201						{object}	doc.SuccessResponse{data=dto.BlocklistEntry,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403						{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/blocklist [post]
+//	@Security		BearerAuth
+func (b *blocklist) AddEntry(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), b.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.BlocklistRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind blocklist entry")
+		b.log.Error(ctx, "unable to bind blocklist entry", zap.Error(err))
+		return er
+	}
+
+	data, err := b.blocklistModule.AddCompanyEntry(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// UpdateEntry
+//
+//	@Summary		Update a blocklist entry
+//	@Description	Replace the type, value and reason of one of the company's blocklist entries.
+//	@Tags			blocklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string					true	"Blocklist entry id"
+//	@Param			blocklist_request_body	body		dto.BlocklistRequest	true	"Entry"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.BlocklistEntry,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403						{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		404						{object}	doc.ErrorResponse	"Entry not found"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/blocklist/{id} [put]
+//	@Security		BearerAuth
+func (b *blocklist) UpdateEntry(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), b.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.BlocklistRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind blocklist entry")
+		b.log.Error(ctx, "unable to bind blocklist entry", zap.Error(err))
+		return er
+	}
+
+	data, err := b.blocklistModule.UpdateCompanyEntry(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DeleteEntry
+//
+//	@Summary		Remove a blocklist entry
+//	@Description	Remove one of the company's blocklist entries. Its hits are kept.
+//	@Tags			blocklist
+//	@Produce		json
+//	@Param			id	path		string	true	"Blocklist entry id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		404	{object}	doc.ErrorResponse	"Entry not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/blocklist/{id} [delete]
+//	@Security		BearerAuth
+func (b *blocklist) DeleteEntry(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), b.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	if err := b.blocklistModule.DeleteCompanyEntry(ctx, id, c.Param("id")); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "blocklist entry removed",
+	}, nil)
+}
+
+// ListHits
+//
+//	@Summary		List payment intents refused by the blocklist
+//	@Description	List the company's payment intents the blocklist refused, newest first, including those refused by entries the platform keeps for every company.
+//	@Tags			blocklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			entry_id	query		string	false	"Only hits of this entry"
+//	@Param			page		query		int		false	"Page number, starting at 1"
+//	@Param			per_page	query		int		false	"Hits per page, at most 200"
+//	@Success		200			{object}	doc.SuccessResponse{data=[]dto.BlocklistHit,meta_data=response.MetaData}
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403			{object}	doc.ErrorResponse	"Role is not allowed to view payments"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/blocklist-hits [get]
+//	@Security		BearerAuth
+func (b *blocklist) ListHits(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), b.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	filter := dto.BlocklistHitFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind blocklist hit filter")
+		b.log.Error(ctx, "unable to bind blocklist hit filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := b.blocklistModule.ListCompanyHits(ctx, id, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
@@ -34,7 +34,7 @@ func New(log hlog.Logger, companyModule module.Company,
 // RegisterCompany
 //
 //	@Summary		Create a new company
-//	@Description	This endpoint registers a new company in the Activity Rewards application.
+//	@Description	This endpoint registers a new company in the Activity Rewards application. The owner account stays PENDING_VERIFICATION until the email link and SMS code sent on registration are confirmed.
 //	@Tags			company
 //	@Accept			json
 //	@Produce		json
@@ -68,14 +68,17 @@ func (cr *company) RegisterCompany(c echo.Context) error {
 // Login
 //
 //	@Summary		Authenticate a company
-//	@Description	This endpoint allows a company to log in to the application.
+//	@Description	This endpoint allows a company to log in to the application. Users with MFA enabled receive a 202 with an MFA challenge token to exchange at /login/mfa instead of a session.
 //	@Tags			company
 //	@Accept			json
 //	@Produce		json
 //	@Param			company_login_request_body	body		dto.LoginRequest	true	"Login credentials of the company"
 //	@Success		200							{object}	doc.SuccessResponse{data=dto.SignInResponse,meta_data=interface{}}
+//	@Success		202							{object}	doc.SuccessResponse{data=dto.MFAChallenge,meta_data=interface{}}
 //	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
-//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		401							{object}	doc.ErrorResponse	"Invalid phone, email or password"
+//	@Failure		403							{object}	doc.ErrorResponse	"Account is inactive or pending verification"
+//	@Failure		429							{object}	doc.ErrorResponse	"Too many failed attempts for the account or client address"
 //	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
 //	@Router			/login [post]
 func (cr *company) Login(c echo.Context) error {
@@ -89,11 +92,15 @@ func (cr *company) Login(c echo.Context) error {
 		cr.log.Error(ctx, "Unable to bind login data", zap.Error(err))
 		return er
 	}
+	param.IPAddress = c.RealIP()
 
-	data, err := cr.companyModule.Login(ctx, param)
+	data, challenge, err := cr.companyModule.Login(ctx, param)
 	if err != nil {
 		return err
 	}
+	if challenge != nil {
+		return response.SendSuccessResponse(c, http.StatusAccepted, challenge, nil)
+	}
 
 	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
 }
@@ -101,17 +108,627 @@ func (cr *company) Login(c echo.Context) error {
 // GenerateSecretToken
 //
 //	@Summary		Get secret token
-//	@Description	Get a company secret token.
+//	@Description	Get a company secret token. Send `{"mode": "test"}` for a test key, which only sees test data and is served by a simulated processor; keys are live otherwise. A new token replaces the previous token of the same mode.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			api_key_request_body	body		dto.APIKeyRequest	false	"Key mode"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.CompanyCredentialResponse,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403						{object}	doc.ErrorResponse	"Role is not allowed to generate secret tokens or account is pending verification"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/generate-secret-token [post]
+//	@Security		BearerAuth
+func (cr *company) GenerateSecretToken(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.APIKeyRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind key mode")
+		cr.log.Error(ctx, "unable to bind key mode", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.GenerateToken(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// ForgotPassword
+//
+//	@Summary		Request a password reset
+//	@Description	Send a single-use password reset link to the user. The response is the same whether or not the account exists.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			forgot_password_request_body	body		dto.ForgotPasswordRequest	true	"Phone or email of the account"
+//	@Success		200								{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/password/forgot [post]
+func (cr *company) ForgotPassword(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	param := dto.ForgotPasswordRequest{}
+	err := c.Bind(&param)
+	if err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind forgot password data")
+		cr.log.Error(ctx, "unable to bind forgot password data", zap.Error(err))
+		return er
+	}
+
+	if err := cr.companyModule.ForgotPassword(ctx, param); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "if the account exists, a password reset link has been sent",
+	}, nil)
+}
+
+// ResetPassword
+//
+//	@Summary		Reset password
+//	@Description	Set a new password using a reset token. All existing sessions of the user are revoked.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			reset_password_request_body	body		dto.ResetPasswordRequest	true	"Reset token and new password"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Invalid, used or expired reset token"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/password/reset [post]
+func (cr *company) ResetPassword(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	param := dto.ResetPasswordRequest{}
+	err := c.Bind(&param)
+	if err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind reset password data")
+		cr.log.Error(ctx, "unable to bind reset password data", zap.Error(err))
+		return er
+	}
+
+	if err := cr.companyModule.ResetPassword(ctx, param); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "password has been reset",
+	}, nil)
+}
+
+// VerifyEmail
+//
+//	@Summary		Verify email address
+//	@Description	Verify the email address of a newly registered owner using the link token sent by email. The account becomes active once both email and phone are verified.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			verify_email_request_body	body		dto.VerifyEmailRequest	true	"Verification token"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.User,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Invalid, used or expired verification token"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/verify/email [post]
+func (cr *company) VerifyEmail(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	param := dto.VerifyEmailRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind email verification data")
+		cr.log.Error(ctx, "unable to bind email verification data", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.VerifyEmail(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// VerifyPhone
+//
+//	@Summary		Verify phone number
+//	@Description	Verify the phone number of a newly registered owner using the code sent by SMS. The account becomes active once both email and phone are verified.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			verify_phone_request_body	body		dto.VerifyPhoneRequest	true	"Phone number and verification code"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.User,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input or code"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/verify/phone [post]
+func (cr *company) VerifyPhone(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	param := dto.VerifyPhoneRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind phone verification data")
+		cr.log.Error(ctx, "unable to bind phone verification data", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.VerifyPhone(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// ResendVerification
+//
+//	@Summary		Resend verification
+//	@Description	Send a new verification link and code for every unverified contact of the account. The response is the same whether or not the account exists.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			resend_verification_request_body	body		dto.ResendVerificationRequest	true	"Phone or email of the account"
+//	@Success		200									{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400									{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		500									{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/verify/resend [post]
+func (cr *company) ResendVerification(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	param := dto.ResendVerificationRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind resend verification data")
+		cr.log.Error(ctx, "unable to bind resend verification data", zap.Error(err))
+		return er
+	}
+
+	if err := cr.companyModule.ResendVerification(ctx, param); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "if the account is pending verification, a new link and code have been sent",
+	}, nil)
+}
+
+// LoginMFA
+//
+//	@Summary		Complete an MFA login
+//	@Description	Exchange the MFA challenge token returned by /login and a TOTP or recovery code for a session.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			mfa_login_request_body	body		dto.MFALoginRequest	true	"MFA challenge token and code"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.SignInResponse,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input or code"
+//	@Failure		401						{object}	doc.ErrorResponse	"Invalid or expired MFA token"
+//	@Failure		429						{object}	doc.ErrorResponse	"Too many failed attempts"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/login/mfa [post]
+func (cr *company) LoginMFA(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	param := dto.MFALoginRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind mfa login data")
+		cr.log.Error(ctx, "unable to bind mfa login data", zap.Error(err))
+		return er
+	}
+	param.IPAddress = c.RealIP()
+
+	data, err := cr.companyModule.LoginMFA(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// EnrollMFA
+//
+//	@Summary		Start MFA enrollment
+//	@Description	Generate a TOTP secret for the caller and return its provisioning URI for a QR code. MFA is enabled once a code is confirmed.
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.MFAEnrollment,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"MFA is already enabled"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/mfa/enroll [post]
+//	@Security		BearerAuth
+func (cr *company) EnrollMFA(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := cr.companyModule.EnrollMFA(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// EnableMFA
+//
+//	@Summary		Confirm MFA enrollment
+//	@Description	Confirm the pending enrollment with a TOTP code. The response holds one-time recovery codes that are not shown again.
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Param			mfa_code_request_body	body		dto.MFACodeRequest	true	"Current TOTP code"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.RecoveryCodes,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input or code"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/mfa/enable [post]
+//	@Security		BearerAuth
+func (cr *company) EnableMFA(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.MFACodeRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind mfa code")
+		cr.log.Error(ctx, "unable to bind mfa code", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.EnableMFA(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DisableMFA
+//
+//	@Summary		Disable MFA
+//	@Description	Turn MFA off for the caller using a TOTP or recovery code. Not allowed while the company requires MFA.
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Param			mfa_code_request_body	body		dto.MFACodeRequest	true	"TOTP or recovery code"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input or code"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403						{object}	doc.ErrorResponse	"Company requires MFA"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/mfa/disable [post]
+//	@Security		BearerAuth
+func (cr *company) DisableMFA(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.MFACodeRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind mfa code")
+		cr.log.Error(ctx, "unable to bind mfa code", zap.Error(err))
+		return er
+	}
+
+	if err := cr.companyModule.DisableMFA(ctx, id, param); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "mfa has been disabled",
+	}, nil)
+}
+
+// RegenerateRecoveryCodes
+//
+//	@Summary		Regenerate recovery codes
+//	@Description	Replace every recovery code of the caller. Requires a current TOTP code.
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Param			mfa_code_request_body	body		dto.MFACodeRequest	true	"Current TOTP code"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.RecoveryCodes,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input or code"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/mfa/recovery-codes [post]
+//	@Security		BearerAuth
+func (cr *company) RegenerateRecoveryCodes(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.MFACodeRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind mfa code")
+		cr.log.Error(ctx, "unable to bind mfa code", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.RegenerateRecoveryCodes(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// UpdateCompanyMFA
+//
+//	@Summary		Require MFA for the company
+//	@Description	Let the company owner require MFA for every member. Members without MFA are refused by permission checks until they enroll.
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Param			update_company_mfa_request_body	body		dto.UpdateCompanyMFA	true	"MFA policy"
+//	@Success		200								{object}	doc.SuccessResponse{data=dto.Company,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403								{object}	doc.ErrorResponse	"Only the owner can change the MFA policy"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/company/mfa [patch]
+//	@Security		BearerAuth
+func (cr *company) UpdateCompanyMFA(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.UpdateCompanyMFA{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind mfa policy")
+		cr.log.Error(ctx, "unable to bind mfa policy", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.UpdateCompanyMFA(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// GetCompany
+//
+//	@Summary		Get the company profile
+//	@Description	Return the profile of the caller's company.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.Company,meta_data=interface{}}
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/company [get]
+//	@Security		BearerAuth
+func (cr *company) GetCompany(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := cr.companyModule.GetCompany(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// UpdateCompany
+//
+//	@Summary		Update the company profile
+//	@Description	Change the address, phones, website, callback url or return url of the caller's company. Omitted fields keep their value.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			update_company_request_body	body		dto.UpdateCompany	true	"Profile fields to change"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.Company,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403							{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/company [patch]
+//	@Security		BearerAuth
+func (cr *company) UpdateCompany(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.UpdateCompany{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind company profile")
+		cr.log.Error(ctx, "unable to bind company profile", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.UpdateCompany(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// UnlockAccount
+//
+//	@Summary		Unlock an account
+//	@Description	Clear the failed sign-in attempts of an account using the unlock token emailed when it was locked.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			unlock_account_request_body	body		dto.UnlockAccountRequest	true	"Unlock token"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Invalid or expired unlock token"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/login/unlock [post]
+func (cr *company) UnlockAccount(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	param := dto.UnlockAccountRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind unlock data")
+		cr.log.Error(ctx, "unable to bind unlock data", zap.Error(err))
+		return er
+	}
+
+	if err := cr.companyModule.UnlockAccount(ctx, param); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "account has been unlocked",
+	}, nil)
+}
+
+// CreateHMACKey
+//
+//	@Summary		Create a request signing key
+//	@Description	Create a PG-HMAC key for signing server-to-server requests instead of sending the secret token. Sign `METHOD\nREQUEST_URI\nTIMESTAMP\nNONCE\nhex(sha256(body))` with HMAC-SHA256 and send `Authorization: PG-HMAC keyId=<key_id>,signature=<hex>` with the `X-PG-Timestamp` (unix seconds) and `X-PG-Nonce` headers. The secret is only returned once. Send `{"mode": "test"}` for a test key. The newest active key of each mode also signs the webhook events posted to callback_url: `X-PG-Signature: keyId=<key_id>,signature=<hex>` is the HMAC-SHA256 of `TIMESTAMP\nEVENT_ID\nhex(sha256(body))`, with the `X-PG-Timestamp` and `X-PG-Event-ID` headers; refuse timestamps more than a few minutes old. Events are held until the company has an active key.
 //	@Tags			company
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	doc.SuccessResponse{data=dto.CompanyCredentialResponse,meta_data=interface{}}
+//	@Param			api_key_request_body	body		dto.APIKeyRequest	false	"Key mode"
+//	@Success		201						{object}	doc.SuccessResponse{data=dto.CreatedHMACKey,meta_data=interface{}}
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403						{object}	doc.ErrorResponse	"Role is not allowed to manage credentials"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/hmac-keys [post]
+//	@Security		BearerAuth
+func (cr *company) CreateHMACKey(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.APIKeyRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind key mode")
+		cr.log.Error(ctx, "unable to bind key mode", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.CreateHMACKey(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// ListHMACKeys
+//
+//	@Summary		List request signing keys
+//	@Description	List the company's PG-HMAC keys, including revoked ones. Secrets are never returned.
+//	@Tags			company
+//	@Produce		json
+//	@Success		200	{object}	doc.SuccessResponse{data=[]dto.HMACKey,meta_data=interface{}}
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage credentials"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/hmac-keys [get]
+//	@Security		BearerAuth
+func (cr *company) ListHMACKeys(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := cr.companyModule.ListHMACKeys(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// RevokeHMACKey
+//
+//	@Summary		Revoke a request signing key
+//	@Description	Revoke a PG-HMAC key. Requests signed with it are rejected immediately.
+//	@Tags			company
+//	@Produce		json
+//	@Param			id	path		string	true	"HMAC key id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.HMACKey,meta_data=interface{}}
 //	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid input"
 //	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage credentials"
+//	@Failure		404	{object}	doc.ErrorResponse	"Active key not found"
 //	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
-//	@Router			/generate-secret-token [post]
+//	@Router			/hmac-keys/{id} [delete]
 //	@Security		BearerAuth
-func (cr *company) GenerateSecretToken(c echo.Context) error {
+func (cr *company) RevokeHMACKey(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
 	defer cancel()
 
@@ -122,10 +739,160 @@ func (cr *company) GenerateSecretToken(c echo.Context) error {
 		return err
 	}
 
-	data, err := cr.companyModule.GenerateToken(ctx, id)
+	data, err := cr.companyModule.RevokeHMACKey(ctx, id, c.Param("id"))
 	if err != nil {
 		return err
 	}
 
 	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
 }
+
+// ListIPAllowlist
+//
+//	@Summary		List the IP allowlist
+//	@Description	List the networks allowed to call the merchant API with the company's credentials. An empty list allows every address.
+//	@Tags			company
+//	@Produce		json
+//	@Success		200	{object}	doc.SuccessResponse{data=[]dto.IPAllowlistEntry,meta_data=interface{}}
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/ip-allowlist [get]
+//	@Security		BearerAuth
+func (cr *company) ListIPAllowlist(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := cr.companyModule.ListIPAllowlist(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// AddIPAllowlistEntry
+//
+//	@Summary		Add a network to the IP allowlist
+//	@Description	Allow a CIDR or single address to call the merchant API. Once the list has an entry, secret-token and signed requests from any other address are refused.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			ip_allowlist_request_body	body		dto.IPAllowlistRequest	true	"Network to allow"
+//	@Success		201							{object}	doc.SuccessResponse{data=dto.IPAllowlistEntry,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403							{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/ip-allowlist [post]
+//	@Security		BearerAuth
+func (cr *company) AddIPAllowlistEntry(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.IPAllowlistRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind allowlist entry")
+		cr.log.Error(ctx, "unable to bind allowlist entry", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.AddIPAllowlistEntry(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// UpdateIPAllowlistEntry
+//
+//	@Summary		Update an IP allowlist entry
+//	@Description	Change the network or description of an allowlist entry.
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string					true	"Allowlist entry id"
+//	@Param			ip_allowlist_request_body	body		dto.IPAllowlistRequest	true	"Network to allow"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.IPAllowlistEntry,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403							{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		404							{object}	doc.ErrorResponse	"Entry not found"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/ip-allowlist/{id} [put]
+//	@Security		BearerAuth
+func (cr *company) UpdateIPAllowlistEntry(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.IPAllowlistRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind allowlist entry")
+		cr.log.Error(ctx, "unable to bind allowlist entry", zap.Error(err))
+		return er
+	}
+
+	data, err := cr.companyModule.UpdateIPAllowlistEntry(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DeleteIPAllowlistEntry
+//
+//	@Summary		Remove an IP allowlist entry
+//	@Description	Remove a network from the allowlist. Removing the last entry allows requests from every address again.
+//	@Tags			company
+//	@Produce		json
+//	@Param			id	path		string	true	"Allowlist entry id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		404	{object}	doc.ErrorResponse	"Entry not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/ip-allowlist/{id} [delete]
+//	@Security		BearerAuth
+func (cr *company) DeleteIPAllowlistEntry(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cr.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	if err := cr.companyModule.DeleteIPAllowlistEntry(ctx, id, c.Param("id")); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "allowlist entry removed",
+	}, nil)
+}
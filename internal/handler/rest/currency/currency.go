@@ -0,0 +1,61 @@
+package currency
+
+import (
+	"context"
+	"net/http"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/response"
+	"pg/internal/handler/rest"
+	"pg/internal/module"
+	"pg/platform/hlog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type currency struct {
+	log            hlog.Logger
+	currencyModule module.Currency
+	contextTimeout time.Duration
+}
+
+func New(log hlog.Logger, currencyModule module.Currency,
+	ctx time.Duration) rest.Currency {
+	return &currency{
+		log:            log,
+		currencyModule: currencyModule,
+		contextTimeout: ctx,
+	}
+}
+
+// ListCurrencies
+//
+//	@Summary		List currencies
+//	@Description	List the currencies of the registry with their minor units and amount limits, and whether the company can create payment intents in each.
+//	@Tags			currencies
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	doc.SuccessResponse{data=[]dto.Currency,meta_data=interface{}}
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/currencies [get]
+//	@Security		BearerAuth
+func (cu *currency) ListCurrencies(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cu.contextTimeout)
+	defer cancel()
+
+	companyID, ok := ctx.Value("x-companyID").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New("invalid company id, it could be type of string")
+		cu.log.Error(ctx, "invalid company id", zap.Error(err))
+		return err
+	}
+
+	data, err := cu.currencyModule.ListCompanyCurrencies(ctx, companyID)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
@@ -0,0 +1,288 @@
+package customer
+
+import (
+	"context"
+	"net/http"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/constant/model/response"
+	"pg/internal/handler/rest"
+	"pg/internal/module"
+	"pg/platform/hlog"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type customer struct {
+	log            hlog.Logger
+	customerModule module.Customer
+	contextTimeout time.Duration
+}
+
+func New(log hlog.Logger, customerModule module.Customer,
+	ctx time.Duration) rest.Customer {
+	return &customer{
+		log:            log,
+		customerModule: customerModule,
+		contextTimeout: ctx,
+	}
+}
+
+// CreateCustomer
+//
+//	@Summary		Create a customer
+//	@Description	Create a customer in the mode of the key. The phone number must be unique among the company's customers.
+//	@Tags			customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			create_customer_request_body	body		dto.CreateCustomer	true	"Customer details"
+//	@Success		201								{object}	doc.SuccessResponse{data=dto.Customer,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input or duplicate phone number"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/customers [post]
+//	@Security		BearerAuth
+func (cu *customer) CreateCustomer(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cu.contextTimeout)
+	defer cancel()
+
+	companyID, livemode, err := cu.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	param := dto.CreateCustomer{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind customer data")
+		cu.log.Error(ctx, "unable to bind customer data", zap.Error(err))
+		return er
+	}
+
+	data, err := cu.customerModule.CreateCustomer(ctx, companyID, livemode, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// GetCustomer
+//
+//	@Summary		Get a customer
+//	@Description	Return one of the company's customers in the mode of the key.
+//	@Tags			customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Customer id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.Customer,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Customer not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/customers/{id} [get]
+//	@Security		BearerAuth
+func (cu *customer) GetCustomer(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cu.contextTimeout)
+	defer cancel()
+
+	companyID, livemode, err := cu.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := cu.customerModule.GetCustomer(ctx, companyID, livemode, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// ListCustomers
+//
+//	@Summary		List customers
+//	@Description	List the company's customers in the mode of the key, newest first. Customer details are stored encrypted, so the only search is by exact phone number.
+//	@Tags			customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			phone_number	query		string	false	"Phone number"
+//	@Param			page			query		int		false	"Page number, starting at 1"
+//	@Param			per_page		query		int		false	"Customers per page, at most 200"
+//	@Success		200				{object}	doc.SuccessResponse{data=[]dto.Customer,meta_data=response.MetaData}
+//	@Failure		400				{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401				{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500				{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/customers [get]
+//	@Security		BearerAuth
+func (cu *customer) ListCustomers(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cu.contextTimeout)
+	defer cancel()
+
+	companyID, livemode, err := cu.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter := dto.CustomerFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind customer filter")
+		cu.log.Error(ctx, "unable to bind customer filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := cu.customerModule.ListCustomers(ctx, companyID, livemode, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
+
+// UpdateCustomer
+//
+//	@Summary		Update a customer
+//	@Description	Change the fields that are sent and keep the others.
+//	@Tags			customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		string				true	"Customer id"
+//	@Param			update_customer_request_body	body		dto.UpdateCustomer	true	"Fields to change"
+//	@Success		200								{object}	doc.SuccessResponse{data=dto.Customer,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input or duplicate phone number"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404								{object}	doc.ErrorResponse	"Customer not found"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/customers/{id} [patch]
+//	@Security		BearerAuth
+func (cu *customer) UpdateCustomer(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cu.contextTimeout)
+	defer cancel()
+
+	companyID, livemode, err := cu.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	param := dto.UpdateCustomer{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind customer data")
+		cu.log.Error(ctx, "unable to bind customer data", zap.Error(err))
+		return er
+	}
+
+	data, err := cu.customerModule.UpdateCustomer(ctx, companyID, livemode, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DeleteCustomer
+//
+//	@Summary		Delete a customer
+//	@Description	Remove a customer from the API. Its payment intents are kept. With erase=true the customer's personal data is also erased, even if it was deleted before, and the erasure certificate is returned and written to the audit trail.
+//	@Tags			customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Customer id"
+//	@Param			erase	query		bool	false	"Erase the customer's personal data"
+//	@Success		200		{object}	doc.SuccessResponse{data=dto.CustomerErasure,meta_data=interface{}}
+//	@Failure		400		{object}	doc.ErrorResponse	"Bad request due to invalid id"
+//	@Failure		401		{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404		{object}	doc.ErrorResponse	"Customer not found"
+//	@Failure		500		{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/customers/{id} [delete]
+//	@Security		BearerAuth
+func (cu *customer) DeleteCustomer(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cu.contextTimeout)
+	defer cancel()
+
+	companyID, livemode, err := cu.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	erase := false
+	if value := c.QueryParam("erase"); value != "" {
+		erase, err = strconv.ParseBool(value)
+		if err != nil {
+			er := errors.ErrBadRequest.Wrap(err, "erase must be true or false")
+			cu.log.Warn(ctx, "invalid erase flag", zap.Error(err))
+			return er
+		}
+	}
+	if erase {
+		data, err := cu.customerModule.EraseCustomer(ctx, companyID, livemode, c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+	}
+
+	if err := cu.customerModule.DeleteCustomer(ctx, companyID, livemode, c.Param("id")); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, nil, nil)
+}
+
+// ListPaymentIntents
+//
+//	@Summary		List a customer's payments
+//	@Description	List the customer's payment intents, newest first.
+//	@Tags			customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Customer id"
+//	@Param			page		query		int		false	"Page number, starting at 1"
+//	@Param			per_page	query		int		false	"Payment intents per page, at most 200"
+//	@Success		200			{object}	doc.SuccessResponse{data=[]dto.PaymentIntent,meta_data=response.MetaData}
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid id or page"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404			{object}	doc.ErrorResponse	"Customer not found"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/customers/{id}/payment-intents [get]
+//	@Security		BearerAuth
+func (cu *customer) ListPaymentIntents(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), cu.contextTimeout)
+	defer cancel()
+
+	companyID, livemode, err := cu.caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	page := dto.Page{}
+	if err := c.Bind(&page); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind page")
+		cu.log.Error(ctx, "unable to bind page", zap.Error(err))
+		return er
+	}
+
+	data, total, err := cu.customerModule.ListPaymentIntents(ctx, companyID, livemode,
+		c.Param("id"), page)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(page.Page, page.PerPage, total))
+}
+
+// caller returns the company and mode of the API key.
+func (cu *customer) caller(ctx context.Context) (string, bool, error) {
+	companyID, ok := ctx.Value("x-companyID").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New("invalid company id, it could be type of string")
+		cu.log.Error(ctx, "invalid company id", zap.Error(err))
+		return "", false, err
+	}
+	livemode, _ := ctx.Value("x-livemode").(bool)
+
+	return companyID, livemode, nil
+}
@@ -0,0 +1,244 @@
+package dispute
+
+import (
+	"context"
+	"net/http"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/constant/model/response"
+	"pg/internal/handler/rest"
+	"pg/internal/module"
+	"pg/platform/hlog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type dispute struct {
+	log            hlog.Logger
+	disputeModule  module.Dispute
+	contextTimeout time.Duration
+	// maxFileBytes bounds the body of an evidence upload.
+	maxFileBytes int64
+}
+
+func New(log hlog.Logger, disputeModule module.Dispute,
+	ctx time.Duration, maxFileBytes int64) rest.Dispute {
+	return &dispute{
+		log:            log,
+		disputeModule:  disputeModule,
+		contextTimeout: ctx,
+		maxFileBytes:   maxFileBytes,
+	}
+}
+
+// List
+//
+//	@Summary		List disputes
+//	@Description	List the disputes against the company's payment intents, newest first.
+//	@Tags			dispute
+//	@Accept			json
+//	@Produce		json
+//	@Param			status		query		string	false	"NEEDS_RESPONSE, UNDER_REVIEW, WON or LOST"
+//	@Param			page		query		int		false	"Page number, starting at 1"
+//	@Param			per_page	query		int		false	"Disputes per page, at most 200"
+//	@Success		200			{object}	doc.SuccessResponse{data=[]dto.Dispute,meta_data=response.MetaData}
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403			{object}	doc.ErrorResponse	"Role is not allowed to view payments"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/disputes [get]
+//	@Security		BearerAuth
+func (d *dispute) List(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), d.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	filter := dto.DisputeFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind dispute filter")
+		d.log.Error(ctx, "unable to bind dispute filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := d.disputeModule.ListCompanyDisputes(ctx, id, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
+
+// Get
+//
+//	@Summary		Get a dispute
+//	@Description	Get a dispute with its evidence files and, once lost, the ledger entries reversing the payment.
+//	@Tags			dispute
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Dispute ID"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.Dispute,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to view payments"
+//	@Failure		404	{object}	doc.ErrorResponse	"Dispute not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/disputes/{id} [get]
+//	@Security		BearerAuth
+func (d *dispute) Get(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), d.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := d.disputeModule.GetCompanyDispute(ctx, id, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// UpdateEvidence
+//
+//	@Summary		Update dispute evidence
+//	@Description	Replace the written evidence of a dispute that needs a response, before its evidence_due_by.
+//	@Tags			dispute
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string				true	"Dispute ID"
+//	@Param			dispute_evidence_body	body		dto.DisputeEvidence	true	"Evidence"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.Dispute,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input, status or deadline"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403						{object}	doc.ErrorResponse	"Role is not allowed to manage disputes"
+//	@Failure		404						{object}	doc.ErrorResponse	"Dispute not found"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/disputes/{id}/evidence [put]
+//	@Security		BearerAuth
+func (d *dispute) UpdateEvidence(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), d.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.DisputeEvidence{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind dispute evidence")
+		d.log.Error(ctx, "unable to bind dispute evidence", zap.Error(err))
+		return er
+	}
+
+	data, err := d.disputeModule.UpdateEvidence(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// UploadEvidence
+//
+//	@Summary		Upload a dispute evidence file
+//	@Description	Attach a PDF, JPEG or PNG file of at most DISPUTE_MAX_EVIDENCE_BYTES (10 MiB by default) to a dispute that needs a response, before its evidence_due_by.
+//	@Tags			dispute
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string	true	"Dispute ID"
+//	@Param			file	formData	file	true	"Evidence file"
+//	@Success		201		{object}	doc.SuccessResponse{data=dto.DisputeEvidenceFile,meta_data=interface{}}
+//	@Failure		400		{object}	doc.ErrorResponse	"Bad request due to invalid file, status or deadline"
+//	@Failure		401		{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403		{object}	doc.ErrorResponse	"Role is not allowed to manage disputes"
+//	@Failure		404		{object}	doc.ErrorResponse	"Dispute not found"
+//	@Failure		500		{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/disputes/{id}/evidence/files [post]
+//	@Security		BearerAuth
+func (d *dispute) UploadEvidence(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), d.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	header, err := rest.FormFile(c, "file", d.maxFileBytes)
+	if err != nil {
+		d.log.Warn(ctx, "unable to read uploaded file", zap.Error(err))
+		return err
+	}
+	file, err := header.Open()
+	if err != nil {
+		er := errors.ErrUnableToUploadFile.Wrap(err, "unable to open uploaded file")
+		d.log.Error(ctx, "unable to open uploaded file", zap.Error(err))
+		return er
+	}
+	defer file.Close()
+
+	data, err := d.disputeModule.UploadEvidence(ctx, id, c.Param("id"), dto.UploadDisputeEvidence{
+		FileName: header.Filename,
+		Size:     header.Size,
+		File:     file,
+	})
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// Submit
+//
+//	@Summary		Submit dispute evidence
+//	@Description	Send the evidence for review, which moves the dispute to UNDER_REVIEW. The dispute needs written evidence or at least one file and can no longer be changed afterwards.
+//	@Tags			dispute
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Dispute ID"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.Dispute,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to missing evidence, status or deadline"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage disputes"
+//	@Failure		404	{object}	doc.ErrorResponse	"Dispute not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/disputes/{id}/submit [post]
+//	@Security		BearerAuth
+func (d *dispute) Submit(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), d.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := d.disputeModule.Submit(ctx, id, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
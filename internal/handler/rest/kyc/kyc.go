@@ -0,0 +1,187 @@
+package kyc
+
+import (
+	"context"
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/constant/model/response"
+	"pg/internal/handler/rest"
+	"pg/internal/module"
+	"pg/platform/hlog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type kyc struct {
+	log            hlog.Logger
+	kycModule      module.KYC
+	contextTimeout time.Duration
+	// maxFileBytes bounds the body of a document upload.
+	maxFileBytes int64
+}
+
+func New(log hlog.Logger, kycModule module.KYC,
+	ctx time.Duration, maxFileBytes int64) rest.KYC {
+	return &kyc{
+		log:            log,
+		kycModule:      kycModule,
+		contextTimeout: ctx,
+		maxFileBytes:   maxFileBytes,
+	}
+}
+
+// GetKYC
+//
+//	@Summary		Get verification status
+//	@Description	Return the caller's company verification status, its uploaded documents and the required documents still missing.
+//	@Tags			kyc
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.KYCStatus,meta_data=interface{}}
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/kyc [get]
+//	@Security		BearerAuth
+func (k *kyc) GetKYC(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), k.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := k.kycModule.GetKYC(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// UploadDocument
+//
+//	@Summary		Upload a verification document
+//	@Description	Upload a PDF, JPEG or PNG document of at most KYC_MAX_DOCUMENT_BYTES (10 MiB by default). Documents can only be changed before submission or after a rejection.
+//	@Tags			kyc
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			document_type	formData	string	true	"TRADE_LICENSE, TIN_CERTIFICATE, MEMORANDUM_OF_ASSOCIATION, OWNER_IDENTIFICATION or OTHER"
+//	@Param			file			formData	file	true	"Document file"
+//	@Success		201				{object}	doc.SuccessResponse{data=dto.KYCDocument,meta_data=interface{}}
+//	@Failure		400				{object}	doc.ErrorResponse	"Bad request due to invalid input or file"
+//	@Failure		401				{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403				{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		500				{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/kyc/documents [post]
+//	@Security		BearerAuth
+func (k *kyc) UploadDocument(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), k.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	header, err := rest.FormFile(c, "file", k.maxFileBytes)
+	if err != nil {
+		k.log.Warn(ctx, "unable to read uploaded file", zap.Error(err))
+		return err
+	}
+	file, err := header.Open()
+	if err != nil {
+		er := errors.ErrUnableToUploadFile.Wrap(err, "unable to open uploaded file")
+		k.log.Error(ctx, "unable to open uploaded file", zap.Error(err))
+		return er
+	}
+	defer file.Close()
+
+	data, err := k.kycModule.UploadDocument(ctx, id, dto.UploadKYCDocument{
+		DocumentType: constant.KYCDocumentType(c.FormValue("document_type")),
+		FileName:     header.Filename,
+		Size:         header.Size,
+		File:         file,
+	})
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// DeleteDocument
+//
+//	@Summary		Delete a verification document
+//	@Description	Remove an uploaded document before submission or after a rejection.
+//	@Tags			kyc
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Document id"
+//	@Success		200	{object}	doc.SuccessResponse{data=interface{},meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid id or status"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		404	{object}	doc.ErrorResponse	"Document not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/kyc/documents/{id} [delete]
+//	@Security		BearerAuth
+func (k *kyc) DeleteDocument(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), k.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	if err := k.kycModule.DeleteDocument(ctx, id, c.Param("id")); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, nil, nil)
+}
+
+// Submit
+//
+//	@Summary		Submit for verification
+//	@Description	Send the company's documents for review. Every required document must be uploaded first. Live payments are accepted once an operator approves the company.
+//	@Tags			kyc
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.KYCStatus,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Missing documents or company already submitted"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage the company"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/kyc/submit [post]
+//	@Security		BearerAuth
+func (k *kyc) Submit(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), k.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := k.kycModule.Submit(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
@@ -0,0 +1,1382 @@
+package operator
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/constant/model/response"
+	"pg/internal/handler/rest"
+	"pg/internal/module"
+	"pg/platform/hlog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type operator struct {
+	log             hlog.Logger
+	operatorModule  module.Operator
+	kycModule       module.KYC
+	piiModule       module.PII
+	currencyModule  module.Currency
+	limitModule     module.Limit
+	riskModule      module.Risk
+	blocklistModule module.Blocklist
+	disputeModule   module.Dispute
+	contextTimeout  time.Duration
+}
+
+func New(log hlog.Logger, operatorModule module.Operator, kycModule module.KYC,
+	piiModule module.PII, currencyModule module.Currency, limitModule module.Limit,
+	riskModule module.Risk, blocklistModule module.Blocklist, disputeModule module.Dispute,
+	ctx time.Duration) rest.Operator {
+	return &operator{
+		log:             log,
+		operatorModule:  operatorModule,
+		kycModule:       kycModule,
+		piiModule:       piiModule,
+		currencyModule:  currencyModule,
+		limitModule:     limitModule,
+		riskModule:      riskModule,
+		blocklistModule: blocklistModule,
+		disputeModule:   disputeModule,
+		contextTimeout:  ctx,
+	}
+}
+
+// Login
+//
+//	@Summary		Operator sign in
+//	@Description	Sign in a platform operator. The access token is only accepted by the /admin routes.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			operator_login_request_body	body		dto.OperatorLoginRequest	true	"Operator credentials"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.OperatorSignInResponse,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Invalid email or password"
+//	@Failure		429							{object}	doc.ErrorResponse	"Too many failed attempts"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/login [post]
+func (o *operator) Login(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	param := dto.OperatorLoginRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind login data")
+		o.log.Error(ctx, "unable to bind login data", zap.Error(err))
+		return er
+	}
+	param.IPAddress = c.RealIP()
+
+	data, err := o.operatorModule.Login(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// CreateOperator
+//
+//	@Summary		Add an operator
+//	@Description	Create another platform operator account.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			create_operator_request_body	body		dto.CreateOperator	true	"Operator details"
+//	@Success		201								{object}	doc.SuccessResponse{data=dto.Operator,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/operators [post]
+//	@Security		BearerAuth
+func (o *operator) CreateOperator(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.CreateOperator{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind operator data")
+		o.log.Error(ctx, "unable to bind operator data", zap.Error(err))
+		return er
+	}
+
+	data, err := o.operatorModule.CreateOperator(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// ListCompanies
+//
+//	@Summary		List companies
+//	@Description	List every merchant company, newest first. Search matches the name, email or registration number.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			search		query		string	false	"Name, email or registration number"
+//	@Param			status		query		string	false	"ACTIVE, SUSPENDED, INACTIVE, ONBOARDING, UNDER_REVIEW or REJECTED"
+//	@Param			page		query		int		false	"Page number, starting at 1"
+//	@Param			per_page	query		int		false	"Companies per page, at most 200"
+//	@Success		200			{object}	doc.SuccessResponse{data=[]dto.Company,meta_data=response.MetaData}
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies [get]
+//	@Security		BearerAuth
+func (o *operator) ListCompanies(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	filter := dto.CompanyFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind company filter")
+		o.log.Error(ctx, "unable to bind company filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := o.operatorModule.ListCompanies(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
+
+// GetCompany
+//
+//	@Summary		Get a company
+//	@Description	Return any merchant company by id.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Company id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.Company,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id} [get]
+//	@Security		BearerAuth
+func (o *operator) GetCompany(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.operatorModule.GetCompany(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// SuspendCompany
+//
+//	@Summary		Suspend a company
+//	@Description	Refuse the company's API credentials until it is reactivated. A company can be suspended in any status, including while it is onboarding or under review. The reason is kept in the company's audit log.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string					true	"Company id"
+//	@Param			company_status_request_body	body		dto.CompanyStatusChange	true	"Reason for the suspension"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.Company,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input or status"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/suspend [post]
+//	@Security		BearerAuth
+func (o *operator) SuspendCompany(c echo.Context) error {
+	return o.setCompanyStatus(c, constant.Suspended)
+}
+
+// ReactivateCompany
+//
+//	@Summary		Reactivate a company
+//	@Description	Accept a suspended company's API credentials again and restore the status it was suspended from, such as ONBOARDING or ACTIVE. The reason is kept in the company's audit log.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string					true	"Company id"
+//	@Param			company_status_request_body	body		dto.CompanyStatusChange	true	"Reason for the reactivation"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.Company,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input or status"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/reactivate [post]
+//	@Security		BearerAuth
+func (o *operator) ReactivateCompany(c echo.Context) error {
+	return o.setCompanyStatus(c, constant.Active)
+}
+
+func (o *operator) setCompanyStatus(c echo.Context, status constant.Status) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.CompanyStatusChange{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind status change")
+		o.log.Error(ctx, "unable to bind status change", zap.Error(err))
+		return er
+	}
+
+	data, err := o.operatorModule.SetCompanyStatus(ctx, id, c.Param("id"), status, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// GetPaymentIntent
+//
+//	@Summary		Get a payment intent
+//	@Description	Return any payment intent by id, with its customer and company.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Payment intent id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.PaymentIntentDetail,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/payment-intents/{id} [get]
+//	@Security		BearerAuth
+func (o *operator) GetPaymentIntent(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.operatorModule.GetPaymentIntent(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// CorrectPaymentIntentStatus
+//
+//	@Summary		Correct a payment intent status
+//	@Description	Force a payment intent into another status and book the difference in the company's ledger: a CORRECTION entry credits the settlement when the intent becomes SUCCESS and takes it back when it leaves SUCCESS. Disputed payment intents cannot be corrected, and a correction is refused if the status changed since it was read. A reason is required and is kept with the old and new status in the merchant's audit log.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		string								true	"Payment intent id"
+//	@Param			status_correction_request_body	body		dto.PaymentIntentStatusCorrection	true	"New status and reason"
+//	@Success		200								{object}	doc.SuccessResponse{data=dto.PaymentIntentDetail,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/payment-intents/{id}/status [post]
+//	@Security		BearerAuth
+func (o *operator) CorrectPaymentIntentStatus(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.PaymentIntentStatusCorrection{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind status correction")
+		o.log.Error(ctx, "unable to bind status correction", zap.Error(err))
+		return er
+	}
+
+	data, err := o.operatorModule.CorrectPaymentIntentStatus(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// GetCompanyKYC
+//
+//	@Summary		Get a company's verification
+//	@Description	Return a company's verification status and the documents it uploaded.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Company id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.KYCStatus,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/kyc [get]
+//	@Security		BearerAuth
+func (o *operator) GetCompanyKYC(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.kycModule.GetCompanyKYC(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DownloadKYCDocument
+//
+//	@Summary		Download a verification document
+//	@Description	Return the content of a document a company uploaded for verification.
+//	@Tags			admin
+//	@Produce		octet-stream
+//	@Param			id	path		string	true	"Document id"
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Document not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/kyc-documents/{id}/file [get]
+//	@Security		BearerAuth
+func (o *operator) DownloadKYCDocument(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	document, content, err := o.kycModule.OpenDocument(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}))
+	// Documents are only ever served with their sniffed type, never
+	// rendered inline.
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.Stream(http.StatusOK, document.ContentType, content)
+}
+
+// ReviewKYC
+//
+//	@Summary		Review a company's verification
+//	@Description	Approve or reject a company under review. Approval lets the company accept live payments; a rejection needs a note, which is emailed to the merchant.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string			true	"Company id"
+//	@Param			kyc_review_request_body	body		dto.KYCReview	true	"Decision and note"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.KYCStatus,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input or status"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404						{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/kyc/review [post]
+//	@Security		BearerAuth
+func (o *operator) ReviewKYC(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.KYCReview{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind kyc review")
+		o.log.Error(ctx, "unable to bind kyc review", zap.Error(err))
+		return er
+	}
+
+	data, err := o.kycModule.Review(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// ListDataKeys
+//
+//	@Summary		List personal data keys
+//	@Description	List the keys that seal customer personal data, with how many customers each one still seals.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	doc.SuccessResponse{data=[]dto.PIIDataKey,meta_data=interface{}}
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/pii/data-keys [get]
+//	@Security		BearerAuth
+func (o *operator) ListDataKeys(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.piiModule.ListDataKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// RotateDataKey
+//
+//	@Summary		Rotate the personal data key
+//	@Description	Make a new key seal customer personal data. Customers sealed with the previous key are resealed in the background.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	doc.SuccessResponse{data=dto.PIIDataKey,meta_data=interface{}}
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/pii/data-keys/rotate [post]
+//	@Security		BearerAuth
+func (o *operator) RotateDataKey(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	data, err := o.piiModule.RotateDataKey(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// ListCompanyCurrencies
+//
+//	@Summary		List a company's currencies
+//	@Description	List the currencies of the registry and whether the company can create payment intents in each.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Company id"
+//	@Success		200	{object}	doc.SuccessResponse{data=[]dto.Currency,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Invalid company id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/currencies [get]
+//	@Security		BearerAuth
+func (o *operator) ListCompanyCurrencies(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.currencyModule.ListCompanyCurrencies(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// SetCompanyCurrency
+//
+//	@Summary		Enable or disable a currency for a company
+//	@Description	Override the registry default of a currency for one company, for example to onboard a EUR or GBP merchant.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id									path		string					true	"Company id"
+//	@Param			code								path		string					true	"ISO 4217 currency code"
+//	@Param			set_company_currency_request_body	body		dto.SetCompanyCurrency	true	"Whether the currency is enabled"
+//	@Success		200									{object}	doc.SuccessResponse{data=dto.Currency,meta_data=interface{}}
+//	@Failure		400									{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401									{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404									{object}	doc.ErrorResponse	"Company or currency not found"
+//	@Failure		500									{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/currencies/{code} [put]
+//	@Security		BearerAuth
+func (o *operator) SetCompanyCurrency(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.SetCompanyCurrency{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind company currency")
+		o.log.Error(ctx, "unable to bind company currency", zap.Error(err))
+		return er
+	}
+
+	data, err := o.currencyModule.SetCompanyCurrency(ctx, id, c.Param("id"), c.Param("code"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// GetFXSettings
+//
+//	@Summary		Get a company's settlement settings
+//	@Description	Return the currency the company is paid out in and the spread taken on the mid rate when a payment intent is converted to it.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Company id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.CompanyFXSettings,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Invalid company id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/fx-settings [get]
+//	@Security		BearerAuth
+func (o *operator) GetFXSettings(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.currencyModule.GetFXSettings(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// SetFXSettings
+//
+//	@Summary		Set a company's settlement settings
+//	@Description	Set the currency the company is paid out in and the spread in basis points. Payment intents created afterwards snapshot the rate they are converted at.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		string						true	"Company id"
+//	@Param			set_fx_settings_request_body	body		dto.SetCompanyFXSettings	true	"Settlement currency and spread"
+//	@Success		200								{object}	doc.SuccessResponse{data=dto.CompanyFXSettings,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404								{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/fx-settings [put]
+//	@Security		BearerAuth
+func (o *operator) SetFXSettings(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.SetCompanyFXSettings{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind fx settings")
+		o.log.Error(ctx, "unable to bind fx settings", zap.Error(err))
+		return er
+	}
+
+	data, err := o.currencyModule.SetFXSettings(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// ListLimits
+//
+//	@Summary		List a company's transaction limits
+//	@Description	List the company's limits per currency: per transaction amount range, daily and monthly totals, and customer velocity.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Company id"
+//	@Success		200	{object}	doc.SuccessResponse{data=[]dto.CompanyLimit,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Invalid company id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/limits [get]
+//	@Security		BearerAuth
+func (o *operator) ListLimits(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.limitModule.ListLimits(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// SetLimit
+//
+//	@Summary		Set a company's transaction limits in a currency
+//	@Description	Replace the company's limits in a currency. Null limits are not enforced. Payment intents that break a limit are refused with status 422.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string				true	"Company id"
+//	@Param			currency				path		string				true	"ISO 4217 currency code"
+//	@Param			set_limit_request_body	body		dto.SetCompanyLimit	true	"Limits"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.CompanyLimit,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404						{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/limits/{currency} [put]
+//	@Security		BearerAuth
+func (o *operator) SetLimit(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.SetCompanyLimit{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind company limit")
+		o.log.Error(ctx, "unable to bind company limit", zap.Error(err))
+		return er
+	}
+
+	data, err := o.limitModule.SetLimit(ctx, id, c.Param("id"), c.Param("currency"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DeleteLimit
+//
+//	@Summary		Remove a company's transaction limits in a currency
+//	@Description	Remove the company's limits in a currency. Only the currency registry's amount range applies afterwards.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Company id"
+//	@Param			currency	path		string	true	"ISO 4217 currency code"
+//	@Success		200			{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400			{object}	doc.ErrorResponse	"Invalid company id or currency"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404			{object}	doc.ErrorResponse	"Company or limits not found"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/limits/{currency} [delete]
+//	@Security		BearerAuth
+func (o *operator) DeleteLimit(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	if err := o.limitModule.DeleteLimit(ctx, id, c.Param("id"), c.Param("currency")); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "limits removed",
+	}, nil)
+}
+
+// ListRiskRules
+//
+//	@Summary		List a company's risk rules
+//	@Description	List the rules new payment intents of the company are scored with.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Company id"
+//	@Success		200	{object}	doc.SuccessResponse{data=[]dto.RiskRule,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Invalid company id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/risk-rules [get]
+//	@Security		BearerAuth
+func (o *operator) ListRiskRules(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.riskModule.ListRules(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// CreateRiskRule
+//
+//	@Summary		Add a risk rule to a company
+//	@Description	Add a rule whose score is added to new payment intents its expression holds for. Expressions are conditions over the signals amount, currency, livemode, merchant_avg_amount, merchant_payments_30d, amount_ratio, new_customer, customer_payments_1h, customer_payments_24h, phone_country, company_country, phone_country_mismatch and blocklist_hits, for example "new_customer and amount_ratio >= 5".
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string			true	"Company id"
+//	@Param			set_risk_rule_request_body	body		dto.SetRiskRule	true	"Risk rule"
+//	@Success		201							{object}	doc.SuccessResponse{data=dto.RiskRule,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404							{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/risk-rules [post]
+//	@Security		BearerAuth
+func (o *operator) CreateRiskRule(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.SetRiskRule{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind risk rule")
+		o.log.Error(ctx, "unable to bind risk rule", zap.Error(err))
+		return er
+	}
+
+	data, err := o.riskModule.CreateRule(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// UpdateRiskRule
+//
+//	@Summary		Replace a company's risk rule
+//	@Description	Replace the name, expression, score and enabled flag of one of the company's risk rules.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string			true	"Company id"
+//	@Param			rule_id						path		string			true	"Risk rule id"
+//	@Param			set_risk_rule_request_body	body		dto.SetRiskRule	true	"Risk rule"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.RiskRule,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404							{object}	doc.ErrorResponse	"Company or risk rule not found"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/risk-rules/{rule_id} [put]
+//	@Security		BearerAuth
+func (o *operator) UpdateRiskRule(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.SetRiskRule{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind risk rule")
+		o.log.Error(ctx, "unable to bind risk rule", zap.Error(err))
+		return er
+	}
+
+	data, err := o.riskModule.UpdateRule(ctx, id, c.Param("id"), c.Param("rule_id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DeleteRiskRule
+//
+//	@Summary		Remove a company's risk rule
+//	@Description	Remove one of the company's risk rules. Intents already scored keep their score.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Company id"
+//	@Param			rule_id	path		string	true	"Risk rule id"
+//	@Success		200		{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400		{object}	doc.ErrorResponse	"Invalid company or risk rule id"
+//	@Failure		401		{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404		{object}	doc.ErrorResponse	"Company or risk rule not found"
+//	@Failure		500		{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/risk-rules/{rule_id} [delete]
+//	@Security		BearerAuth
+func (o *operator) DeleteRiskRule(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	if err := o.riskModule.DeleteRule(ctx, id, c.Param("id"), c.Param("rule_id")); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "risk rule removed",
+	}, nil)
+}
+
+// GetRiskSettings
+//
+//	@Summary		Get a company's risk thresholds
+//	@Description	Get the scores at which the company's payment intents are held for review and blocked.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Company id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.RiskSettings,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Invalid company id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/risk-settings [get]
+//	@Security		BearerAuth
+func (o *operator) GetRiskSettings(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.riskModule.GetSettings(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// SetRiskSettings
+//
+//	@Summary		Set a company's risk thresholds
+//	@Description	Set the scores at which the company's payment intents are held for review and blocked.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		string				true	"Company id"
+//	@Param			set_risk_settings_request_body	body		dto.SetRiskSettings	true	"Risk thresholds"
+//	@Success		200								{object}	doc.SuccessResponse{data=dto.RiskSettings,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404								{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/companies/{id}/risk-settings [put]
+//	@Security		BearerAuth
+func (o *operator) SetRiskSettings(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.SetRiskSettings{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind risk settings")
+		o.log.Error(ctx, "unable to bind risk settings", zap.Error(err))
+		return er
+	}
+
+	data, err := o.riskModule.SetSettings(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// ListRiskReviews
+//
+//	@Summary		List payment intents held for review
+//	@Description	List the payment intents the risk rules held for review, oldest first, with the rules that matched and the signals they saw.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			company_id	query		string	false	"Only this company's intents"
+//	@Param			page		query		int		false	"Page number, starting at 1"
+//	@Param			per_page	query		int		false	"Intents per page, at most 200"
+//	@Success		200			{object}	doc.SuccessResponse{data=[]dto.RiskReview,meta_data=response.MetaData}
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/risk-reviews [get]
+//	@Security		BearerAuth
+func (o *operator) ListRiskReviews(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	filter := dto.RiskReviewFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind risk review filter")
+		o.log.Error(ctx, "unable to bind risk review filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := o.riskModule.ListReviews(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
+
+// ReviewPaymentIntent
+//
+//	@Summary		Decide on a payment intent held for review
+//	@Description	Approve a held payment intent, sending it on to be processed or, when it was created with require_confirmation, to REQUIRES_CONFIRMATION, where the merchant resends the customer a code; or reject it, blocking it. The decision is kept in the merchant's audit log.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string					true	"Payment intent id"
+//	@Param			risk_review_request_body	body		dto.RiskReviewDecision	true	"Decision"
+//	@Success		200							{object}	doc.SuccessResponse{data=dto.PaymentIntentDetail,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input or an intent not held for review"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/payment-intents/{id}/risk-review [post]
+//	@Security		BearerAuth
+func (o *operator) ReviewPaymentIntent(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.RiskReviewDecision{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind risk review")
+		o.log.Error(ctx, "unable to bind risk review", zap.Error(err))
+		return er
+	}
+
+	data, err := o.riskModule.ReviewPaymentIntent(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// ListBlocklist
+//
+//	@Summary		List blocklist entries
+//	@Description	List blocklist entries of every company, newest first, with how many payment intents each refused. Filter by company, by type, or to the entries that apply to every company.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			company_id		query		string	false	"Only this company's entries"
+//	@Param			platform_only	query		bool	false	"Only entries that apply to every company"
+//	@Param			type			query		string	false	"PHONE, EMAIL_DOMAIN or IP"
+//	@Param			page			query		int		false	"Page number, starting at 1"
+//	@Param			per_page		query		int		false	"Entries per page, at most 200"
+//	@Success		200				{object}	doc.SuccessResponse{data=[]dto.BlocklistEntry,meta_data=response.MetaData}
+//	@Failure		400				{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401				{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500				{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/blocklist [get]
+//	@Security		BearerAuth
+func (o *operator) ListBlocklist(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	filter := dto.BlocklistFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind blocklist filter")
+		o.log.Error(ctx, "unable to bind blocklist filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := o.blocklistModule.ListEntries(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
+
+// AddBlocklistEntry
+//
+//	@Summary		Add a blocklist entry
+//	@Description	Refuse new payment intents of customers with the phone number, an email address at the domain or its subdomains, or a customer_ip in the network. Without company_id the entry applies to every company.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			blocklist_request_body	body		dto.OperatorBlocklistRequest	true	"Entry"
+//	@Success		201						{object}	doc.SuccessResponse{data=dto.BlocklistEntry,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404						{object}	doc.ErrorResponse	"Company not found"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/blocklist [post]
+//	@Security		BearerAuth
+func (o *operator) AddBlocklistEntry(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.OperatorBlocklistRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind blocklist entry")
+		o.log.Error(ctx, "unable to bind blocklist entry", zap.Error(err))
+		return er
+	}
+
+	data, err := o.blocklistModule.AddEntry(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// UpdateBlocklistEntry
+//
+//	@Summary		Update a blocklist entry
+//	@Description	Replace the type, value and reason of any blocklist entry. The company it applies to does not change.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string					true	"Blocklist entry id"
+//	@Param			blocklist_request_body	body		dto.BlocklistRequest	true	"Entry"
+//	@Success		200						{object}	doc.SuccessResponse{data=dto.BlocklistEntry,meta_data=interface{}}
+//	@Failure		400						{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401						{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404						{object}	doc.ErrorResponse	"Entry not found"
+//	@Failure		500						{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/blocklist/{id} [put]
+//	@Security		BearerAuth
+func (o *operator) UpdateBlocklistEntry(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.BlocklistRequest{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind blocklist entry")
+		o.log.Error(ctx, "unable to bind blocklist entry", zap.Error(err))
+		return er
+	}
+
+	data, err := o.blocklistModule.UpdateEntry(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DeleteBlocklistEntry
+//
+//	@Summary		Remove a blocklist entry
+//	@Description	Remove any blocklist entry. Its hits are kept.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"Blocklist entry id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.MessageResponse,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Entry not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/blocklist/{id} [delete]
+//	@Security		BearerAuth
+func (o *operator) DeleteBlocklistEntry(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	if err := o.blocklistModule.DeleteEntry(ctx, id, c.Param("id")); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, dto.MessageResponse{
+		Message: "blocklist entry removed",
+	}, nil)
+}
+
+// ListBlocklistHits
+//
+//	@Summary		List payment intents refused by the blocklist
+//	@Description	List the payment intents the blocklist refused, newest first, of every company, of one company or of one entry.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			company_id	query		string	false	"Only this company's hits"
+//	@Param			entry_id	query		string	false	"Only hits of this entry"
+//	@Param			page		query		int		false	"Page number, starting at 1"
+//	@Param			per_page	query		int		false	"Hits per page, at most 200"
+//	@Success		200			{object}	doc.SuccessResponse{data=[]dto.BlocklistHit,meta_data=response.MetaData}
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/blocklist-hits [get]
+//	@Security		BearerAuth
+func (o *operator) ListBlocklistHits(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	filter := dto.BlocklistHitFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind blocklist hit filter")
+		o.log.Error(ctx, "unable to bind blocklist hit filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := o.blocklistModule.ListHits(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
+
+// ListDisputes
+//
+//	@Summary		List disputes
+//	@Description	List disputes of every company or of one company, newest first.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			company_id	query		string	false	"Only this company's disputes"
+//	@Param			status		query		string	false	"NEEDS_RESPONSE, UNDER_REVIEW, WON or LOST"
+//	@Param			page		query		int		false	"Page number, starting at 1"
+//	@Param			per_page	query		int		false	"Disputes per page, at most 200"
+//	@Success		200			{object}	doc.SuccessResponse{data=[]dto.Dispute,meta_data=response.MetaData}
+//	@Failure		400			{object}	doc.ErrorResponse	"Bad request due to invalid filter"
+//	@Failure		401			{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		500			{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/disputes [get]
+//	@Security		BearerAuth
+func (o *operator) ListDisputes(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	filter := dto.DisputeFilter{}
+	if err := c.Bind(&filter); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind dispute filter")
+		o.log.Error(ctx, "unable to bind dispute filter", zap.Error(err))
+		return er
+	}
+
+	data, total, err := o.disputeModule.ListDisputes(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data,
+		response.PageMetaData(filter.Page, filter.PerPage, total))
+}
+
+// GetDispute
+//
+//	@Summary		Get a dispute
+//	@Description	Get a dispute with its evidence files and, once lost, the ledger entries reversing the payment.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Dispute id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.Dispute,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid id"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Dispute not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/disputes/{id} [get]
+//	@Security		BearerAuth
+func (o *operator) GetDispute(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	data, err := o.disputeModule.GetDispute(ctx, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// OpenDispute
+//
+//	@Summary		Open a dispute
+//	@Description	Record a dispute the processor reported against a successful payment intent, for its whole amount. The merchant has until evidence_due_by, seven days by default, to respond. The company receives a dispute.created webhook event.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			open_dispute_request_body	body		dto.OpenDispute	true	"Dispute"
+//	@Success		201							{object}	doc.SuccessResponse{data=dto.Dispute,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input, or the payment intent is not successful or already disputed"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404							{object}	doc.ErrorResponse	"Payment intent not found"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/disputes [post]
+//	@Security		BearerAuth
+func (o *operator) OpenDispute(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.OpenDispute{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind dispute")
+		o.log.Error(ctx, "unable to bind dispute", zap.Error(err))
+		return er
+	}
+
+	data, err := o.disputeModule.OpenDispute(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// ResolveDispute
+//
+//	@Summary		Resolve a dispute
+//	@Description	Close a dispute under review, or one the merchant did not answer before evidence_due_by, as WON or LOST. A lost dispute reverses the payment in the company's ledger. The company receives a dispute.won or dispute.lost webhook event.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		string				true	"Dispute id"
+//	@Param			resolve_dispute_request_body	body		dto.ResolveDispute	true	"Outcome and note"
+//	@Success		200								{object}	doc.SuccessResponse{data=dto.Dispute,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input or status"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404								{object}	doc.ErrorResponse	"Dispute not found"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/disputes/{id}/resolve [post]
+//	@Security		BearerAuth
+func (o *operator) ResolveDispute(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-operator-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid operator id, it could be type of string")
+		return err
+	}
+
+	param := dto.ResolveDispute{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind dispute resolution")
+		o.log.Error(ctx, "unable to bind dispute resolution", zap.Error(err))
+		return er
+	}
+
+	data, err := o.disputeModule.ResolveDispute(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DownloadDisputeEvidence
+//
+//	@Summary		Download a dispute evidence file
+//	@Description	Return the content of a file a merchant attached to a dispute.
+//	@Tags			admin
+//	@Produce		octet-stream
+//	@Param			id		path		string	true	"Dispute id"
+//	@Param			file_id	path		string	true	"Evidence file id"
+//	@Success		200		{file}		file
+//	@Failure		400		{object}	doc.ErrorResponse	"Bad request due to invalid id"
+//	@Failure		401		{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404		{object}	doc.ErrorResponse	"Evidence file not found"
+//	@Failure		500		{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/admin/disputes/{id}/evidence/{file_id} [get]
+//	@Security		BearerAuth
+func (o *operator) DownloadDisputeEvidence(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), o.contextTimeout)
+	defer cancel()
+
+	file, content, err := o.disputeModule.OpenEvidence(ctx, c.Param("id"), c.Param("file_id"))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.Stream(http.StatusOK, file.ContentType, content)
+}
@@ -34,7 +34,7 @@ func New(log hlog.Logger, PaymentIntentModule module.PaymentIntent,
 // Initiate PaymentIntent
 //
 //	@Summary		InitPaymentIntent
-//	@Description	Initiate onetme paymentIntent
+//	@Description	Initiate onetme paymentIntent. The intent takes the mode of the key: test intents are settled by a simulated processor, where amounts ending in .13 fail, amounts ending in .14 succeed and are then disputed, and all others succeed. Live intents require a verified company. Send customer_id to charge a customer created through the customers API, or customer to create one by phone number. Customers whose phone number, email domain or customer_ip is blocklisted are refused. With require_confirmation the intent starts in REQUIRES_CONFIRMATION and the customer is sent an SMS code to confirm it with.
 //	@Tags			payments
 //	@Accept			json
 //	@Produce		json
@@ -42,6 +42,8 @@ func New(log hlog.Logger, PaymentIntentModule module.PaymentIntent,
 //	@Success		201									{object}	doc.SuccessResponse{data=dto.PaymentIntent,meta_data=interface{}}
 //	@Failure		400									{object}	doc.ErrorResponse	"Bad request due to invalid input"
 //	@Failure		401									{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403									{object}	doc.ErrorResponse	"Live key used before the company is verified, or the customer is blocklisted; the blocked detail names the entry type"
+//	@Failure		422									{object}	doc.ErrorResponse	"A transaction limit would be exceeded; the limit detail names it"
 //	@Failure		500									{object}	doc.ErrorResponse	"Internal server error"
 //	@Router			/payment-intents [post]
 //	@Security		BearerAuth
@@ -56,6 +58,8 @@ func (p *paymentIntent) InitPaymentIntent(c echo.Context) error {
 		return err
 	}
 
+	livemode, _ := ctx.Value("x-livemode").(bool)
+
 	param := dto.InitPaymentIntent{}
 	err := c.Bind(&param)
 	if err != nil {
@@ -64,7 +68,7 @@ func (p *paymentIntent) InitPaymentIntent(c echo.Context) error {
 		return er
 	}
 
-	data, err := p.PaymentIntentModule.InitPaymentIntent(ctx, param, id)
+	data, err := p.PaymentIntentModule.InitPaymentIntent(ctx, param, id, livemode)
 	if err != nil {
 		return err
 	}
@@ -75,7 +79,7 @@ func (p *paymentIntent) InitPaymentIntent(c echo.Context) error {
 // Get PaymentIntent Detail By ID
 //
 //	@Summary		Get PaymentIntent By ID
-//	@Description	Get payment details by id
+//	@Description	Get payment details by id. Only intents of the caller's company in the key's mode are found.
 //	@Tags			payments
 //	@Accept			json
 //	@Produce		json
@@ -83,6 +87,7 @@ func (p *paymentIntent) InitPaymentIntent(c echo.Context) error {
 //	@Success		200	{object}	doc.SuccessResponse{data=dto.PaymentIntentDetail,meta_data=interface{}}
 //	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid input"
 //	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Payment intent not found"
 //	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
 //	@Router			/payment-intents/{id} [get]
 //	@Security		BearerAuth
@@ -90,10 +95,96 @@ func (p *paymentIntent) GetPaymentIntentDetail(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), p.contextTimeout)
 	defer cancel()
 
-	data, err := p.PaymentIntentModule.GetPaymentIntentDetail(ctx, c.Param("id"))
+	id, ok := ctx.Value("x-companyID").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New("invalid company id, it could be type of string")
+		p.log.Error(ctx, "invalid company id", zap.Error(err))
+		return err
+	}
+	livemode, _ := ctx.Value("x-livemode").(bool)
+
+	data, err := p.PaymentIntentModule.GetPaymentIntentDetail(ctx, c.Param("id"), id, livemode)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// Confirm PaymentIntent
+//
+//	@Summary		Confirm PaymentIntent
+//	@Description	Confirm a payment intent created with require_confirmation using the code sent to the customer by SMS. The confirmed intent is PENDING and queued to be processed. A code expires after a few minutes or a few wrong attempts, after which a new one has to be requested.
+//	@Tags			payments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		string						true	"payment intent id"
+//	@Param			confirm_payment_intent_request	body		dto.ConfirmPaymentIntent	true	"confirmation code"
+//	@Success		200								{object}	doc.SuccessResponse{data=dto.PaymentIntent,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Invalid, incorrect or expired code, or the intent does not require confirmation"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404								{object}	doc.ErrorResponse	"Payment intent not found"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/payment-intents/{id}/confirm [post]
+//	@Security		BearerAuth
+func (p *paymentIntent) ConfirmPaymentIntent(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), p.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-companyID").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New("invalid company id, it could be type of string")
+		p.log.Error(ctx, "invalid company id", zap.Error(err))
+		return err
+	}
+	livemode, _ := ctx.Value("x-livemode").(bool)
+
+	param := dto.ConfirmPaymentIntent{}
+	if err := c.Bind(&param); err != nil {
+		err = errors.ErrBadRequest.Wrap(err, "unable to bind confirmation data")
+		p.log.Error(ctx, "unable to bind confirmation data", zap.Error(err))
+		return err
+	}
+
+	data, err := p.PaymentIntentModule.ConfirmPaymentIntent(ctx, c.Param("id"), id, livemode, param)
 	if err != nil {
 		return err
 	}
 
 	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
 }
+
+// Resend PaymentIntent Confirmation
+//
+//	@Summary		Resend PaymentIntent Confirmation
+//	@Description	Send the customer a new confirmation code for a payment intent in REQUIRES_CONFIRMATION. The code sent before stops working. An intent is sent a limited number of codes, with a cooldown between two of them.
+//	@Tags			payments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"payment intent id"
+//	@Success		200	{object}	doc.SuccessResponse
+//	@Failure		400	{object}	doc.ErrorResponse	"The intent does not require confirmation"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		404	{object}	doc.ErrorResponse	"Payment intent not found"
+//	@Failure		429	{object}	doc.ErrorResponse	"Too many codes sent, or sent too recently"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/payment-intents/{id}/resend-confirmation [post]
+//	@Security		BearerAuth
+func (p *paymentIntent) ResendPaymentConfirmation(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), p.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-companyID").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New("invalid company id, it could be type of string")
+		p.log.Error(ctx, "invalid company id", zap.Error(err))
+		return err
+	}
+	livemode, _ := ctx.Value("x-livemode").(bool)
+
+	if err := p.PaymentIntentModule.ResendPaymentConfirmation(ctx, c.Param("id"), id, livemode); err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, nil, nil)
+}
@@ -8,9 +8,126 @@ type Company interface {
 	RegisterCompany(c echo.Context) error
 	Login(c echo.Context) error
 	GenerateSecretToken(c echo.Context) error
+	ForgotPassword(c echo.Context) error
+	ResetPassword(c echo.Context) error
+	VerifyEmail(c echo.Context) error
+	VerifyPhone(c echo.Context) error
+	ResendVerification(c echo.Context) error
+	LoginMFA(c echo.Context) error
+	UnlockAccount(c echo.Context) error
+	EnrollMFA(c echo.Context) error
+	EnableMFA(c echo.Context) error
+	DisableMFA(c echo.Context) error
+	RegenerateRecoveryCodes(c echo.Context) error
+	UpdateCompanyMFA(c echo.Context) error
+	GetCompany(c echo.Context) error
+	UpdateCompany(c echo.Context) error
+	CreateHMACKey(c echo.Context) error
+	ListHMACKeys(c echo.Context) error
+	RevokeHMACKey(c echo.Context) error
+	ListIPAllowlist(c echo.Context) error
+	AddIPAllowlistEntry(c echo.Context) error
+	UpdateIPAllowlistEntry(c echo.Context) error
+	DeleteIPAllowlistEntry(c echo.Context) error
 }
 
 type PaymentIntent interface {
 	InitPaymentIntent(c echo.Context) error
 	GetPaymentIntentDetail(c echo.Context) error
+	ConfirmPaymentIntent(c echo.Context) error
+	ResendPaymentConfirmation(c echo.Context) error
+}
+
+type Team interface {
+	InviteUser(c echo.Context) error
+	AcceptInvitation(c echo.Context) error
+	ListMembers(c echo.Context) error
+	UpdateMemberRole(c echo.Context) error
+	DeactivateMember(c echo.Context) error
+}
+
+type Audit interface {
+	ListAuditEvents(c echo.Context) error
+	ExportAuditEvents(c echo.Context) error
+}
+
+type Customer interface {
+	CreateCustomer(c echo.Context) error
+	GetCustomer(c echo.Context) error
+	ListCustomers(c echo.Context) error
+	UpdateCustomer(c echo.Context) error
+	DeleteCustomer(c echo.Context) error
+	ListPaymentIntents(c echo.Context) error
+}
+
+type KYC interface {
+	GetKYC(c echo.Context) error
+	UploadDocument(c echo.Context) error
+	DeleteDocument(c echo.Context) error
+	Submit(c echo.Context) error
+}
+
+type Operator interface {
+	Login(c echo.Context) error
+	CreateOperator(c echo.Context) error
+	ListCompanies(c echo.Context) error
+	GetCompany(c echo.Context) error
+	SuspendCompany(c echo.Context) error
+	ReactivateCompany(c echo.Context) error
+	GetPaymentIntent(c echo.Context) error
+	CorrectPaymentIntentStatus(c echo.Context) error
+	GetCompanyKYC(c echo.Context) error
+	DownloadKYCDocument(c echo.Context) error
+	ReviewKYC(c echo.Context) error
+	ListDataKeys(c echo.Context) error
+	RotateDataKey(c echo.Context) error
+	ListCompanyCurrencies(c echo.Context) error
+	SetCompanyCurrency(c echo.Context) error
+	GetFXSettings(c echo.Context) error
+	SetFXSettings(c echo.Context) error
+	ListLimits(c echo.Context) error
+	SetLimit(c echo.Context) error
+	DeleteLimit(c echo.Context) error
+	ListRiskRules(c echo.Context) error
+	CreateRiskRule(c echo.Context) error
+	UpdateRiskRule(c echo.Context) error
+	DeleteRiskRule(c echo.Context) error
+	GetRiskSettings(c echo.Context) error
+	SetRiskSettings(c echo.Context) error
+	ListRiskReviews(c echo.Context) error
+	ReviewPaymentIntent(c echo.Context) error
+	ListBlocklist(c echo.Context) error
+	AddBlocklistEntry(c echo.Context) error
+	UpdateBlocklistEntry(c echo.Context) error
+	DeleteBlocklistEntry(c echo.Context) error
+	ListBlocklistHits(c echo.Context) error
+	ListDisputes(c echo.Context) error
+	GetDispute(c echo.Context) error
+	OpenDispute(c echo.Context) error
+	ResolveDispute(c echo.Context) error
+	DownloadDisputeEvidence(c echo.Context) error
+}
+
+type Currency interface {
+	ListCurrencies(c echo.Context) error
+}
+
+type Blocklist interface {
+	ListEntries(c echo.Context) error
+	AddEntry(c echo.Context) error
+	UpdateEntry(c echo.Context) error
+	DeleteEntry(c echo.Context) error
+	ListHits(c echo.Context) error
+}
+
+type Dispute interface {
+	List(c echo.Context) error
+	Get(c echo.Context) error
+	UpdateEvidence(c echo.Context) error
+	UploadEvidence(c echo.Context) error
+	Submit(c echo.Context) error
+}
+
+type WellKnown interface {
+	PasetoKeys(c echo.Context) error
 }
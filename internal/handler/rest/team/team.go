@@ -0,0 +1,214 @@
+package team
+
+import (
+	"context"
+	"net/http"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/constant/model/response"
+	"pg/internal/handler/rest"
+	"pg/internal/module"
+	"pg/platform/hlog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type team struct {
+	log            hlog.Logger
+	teamModule     module.Team
+	contextTimeout time.Duration
+}
+
+func New(log hlog.Logger, teamModule module.Team,
+	ctx time.Duration) rest.Team {
+	return &team{
+		log:            log,
+		teamModule:     teamModule,
+		contextTimeout: ctx,
+	}
+}
+
+// InviteUser
+//
+//	@Summary		Invite a team member
+//	@Description	Invite a user into the caller's company. The invitee receives an email with an invitation token.
+//	@Tags			team
+//	@Accept			json
+//	@Produce		json
+//	@Param			invite_user_request_body	body		dto.InviteUser	true	"Invitee email and role"
+//	@Success		201							{object}	doc.SuccessResponse{data=dto.UserInvitation,meta_data=interface{}}
+//	@Failure		400							{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401							{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403							{object}	doc.ErrorResponse	"Role is not allowed to manage the team"
+//	@Failure		500							{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/team/invitations [post]
+//	@Security		BearerAuth
+func (t *team) InviteUser(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), t.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.InviteUser{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind invitation data")
+		t.log.Error(ctx, "unable to bind invitation data", zap.Error(err))
+		return er
+	}
+
+	data, err := t.teamModule.InviteUser(ctx, id, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// AcceptInvitation
+//
+//	@Summary		Accept a team invitation
+//	@Description	Create the invitee's account from an invitation token and set their password.
+//	@Tags			team
+//	@Accept			json
+//	@Produce		json
+//	@Param			accept_invitation_request_body	body		dto.AcceptInvitation	true	"Invitation token and account details"
+//	@Success		201								{object}	doc.SuccessResponse{data=dto.User,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401								{object}	doc.ErrorResponse	"Invalid or expired invitation token"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/team/invitations/accept [post]
+func (t *team) AcceptInvitation(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), t.contextTimeout)
+	defer cancel()
+
+	param := dto.AcceptInvitation{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind invitation data")
+		t.log.Error(ctx, "unable to bind invitation data", zap.Error(err))
+		return er
+	}
+
+	data, err := t.teamModule.AcceptInvitation(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusCreated, data, nil)
+}
+
+// ListMembers
+//
+//	@Summary		List team members
+//	@Description	List every user of the caller's company.
+//	@Tags			team
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	doc.SuccessResponse{data=[]dto.User,meta_data=interface{}}
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to view the team"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/team/members [get]
+//	@Security		BearerAuth
+func (t *team) ListMembers(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), t.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := t.teamModule.ListMembers(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// UpdateMemberRole
+//
+//	@Summary		Change a member's role
+//	@Description	Change the role of a member of the caller's company. The owner's role cannot be changed.
+//	@Tags			team
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		string					true	"member user id"
+//	@Param			update_member_role_request_body	body		dto.UpdateMemberRole	true	"New role"
+//	@Success		200								{object}	doc.SuccessResponse{data=dto.User,meta_data=interface{}}
+//	@Failure		400								{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401								{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403								{object}	doc.ErrorResponse	"Role is not allowed to manage the team"
+//	@Failure		404								{object}	doc.ErrorResponse	"Member not found"
+//	@Failure		500								{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/team/members/{id}/role [patch]
+//	@Security		BearerAuth
+func (t *team) UpdateMemberRole(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), t.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	param := dto.UpdateMemberRole{}
+	if err := c.Bind(&param); err != nil {
+		er := errors.ErrBadRequest.Wrap(err, "unable to bind member role data")
+		t.log.Error(ctx, "unable to bind member role data", zap.Error(err))
+		return er
+	}
+
+	data, err := t.teamModule.UpdateMemberRole(ctx, id, c.Param("id"), param)
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
+
+// DeactivateMember
+//
+//	@Summary		Deactivate a member
+//	@Description	Deactivate a member of the caller's company and end their sessions. The owner cannot be deactivated.
+//	@Tags			team
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"member user id"
+//	@Success		200	{object}	doc.SuccessResponse{data=dto.User,meta_data=interface{}}
+//	@Failure		400	{object}	doc.ErrorResponse	"Bad request due to invalid input"
+//	@Failure		401	{object}	doc.ErrorResponse	"Unauthorized request"
+//	@Failure		403	{object}	doc.ErrorResponse	"Role is not allowed to manage the team"
+//	@Failure		404	{object}	doc.ErrorResponse	"Member not found"
+//	@Failure		500	{object}	doc.ErrorResponse	"Internal server error"
+//	@Router			/team/members/{id}/deactivate [post]
+//	@Security		BearerAuth
+func (t *team) DeactivateMember(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), t.contextTimeout)
+	defer cancel()
+
+	id, ok := ctx.Value("x-id").(string)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New(
+			"invalid user id, it could be type of string")
+		return err
+	}
+
+	data, err := t.teamModule.DeactivateMember(ctx, id, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return response.SendSuccessResponse(c, http.StatusOK, data, nil)
+}
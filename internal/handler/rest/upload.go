@@ -0,0 +1,37 @@
+package rest
+
+import (
+	stderrors "errors"
+	"mime/multipart"
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// multipartOverhead is the room an upload request has besides its file, for
+// the other form fields and the part headers.
+const multipartOverhead = 1 << 20
+
+// FormFile returns the named file of a multipart request. The request body
+// is cut off at maxBytes plus multipartOverhead before it is parsed, so an
+// oversized upload is refused without being read to the end. A maxBytes of
+// zero uses constant.DefaultMaxUploadBytes.
+func FormFile(c echo.Context, name string, maxBytes int64) (*multipart.FileHeader, error) {
+	if maxBytes <= 0 {
+		maxBytes = constant.DefaultMaxUploadBytes
+	}
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body,
+		maxBytes+multipartOverhead)
+	header, err := c.FormFile(name)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			return nil, errors.ErrBadRequest.Wrap(err, "file must be at most %d bytes", maxBytes)
+		}
+		return nil, errors.ErrBadRequest.Wrap(err, "file is required")
+	}
+
+	return header, nil
+}
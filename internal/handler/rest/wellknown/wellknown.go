@@ -0,0 +1,37 @@
+package wellknown
+
+import (
+	"net/http"
+	"pg/internal/handler/rest"
+	"pg/platform/hcrypto"
+	"pg/platform/hlog"
+
+	"github.com/labstack/echo/v4"
+)
+
+type wellKnown struct {
+	log        hlog.Logger
+	tokenMaker hcrypto.Maker
+}
+
+func New(log hlog.Logger, tokenMaker hcrypto.Maker) rest.WellKnown {
+	return &wellKnown{
+		log:        log,
+		tokenMaker: tokenMaker,
+	}
+}
+
+// PasetoKeys
+//
+//	@Summary		List PASETO public keys
+//	@Description	Publish the Ed25519 keys that verify v4.public tokens. Services match a token's footer kid against these keys to verify it offline. The response is a plain key set, not wrapped in the usual envelope.
+//	@Tags			well-known
+//	@Produce		json
+//	@Success		200	{object}	hcrypto.PublicKeySet
+//	@Router			/.well-known/paseto-keys [get]
+func (w *wellKnown) PasetoKeys(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, hcrypto.PublicKeySet{
+		Keys: w.tokenMaker.PublicKeys(),
+	})
+}
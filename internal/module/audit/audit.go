@@ -0,0 +1,208 @@
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPerPage  = 50
+	exportBatchSize = 500
+)
+
+type Options struct {
+	// MaxExportRows caps a single CSV export. Narrow the time range to
+	// export more.
+	MaxExportRows int
+}
+
+type audit struct {
+	log            hlog.Logger
+	auditStorage   storage.Audit
+	companyStorage storage.Company
+	options        Options
+}
+
+func New(auditStorage storage.Audit,
+	companyStorage storage.Company,
+	log hlog.Logger,
+	options Options) module.Audit {
+	if options.MaxExportRows <= 0 {
+		options.MaxExportRows = 10000
+	}
+	return &audit{
+		log:            log,
+		auditStorage:   auditStorage,
+		companyStorage: companyStorage,
+		options:        options,
+	}
+}
+
+// Record writes an audit event, taking the client address and request id
+// from the request context when the event does not set them. A failure is
+// logged and never fails the action being audited.
+func (a *audit) Record(ctx context.Context, event dto.CreateAuditEvent) {
+	if event.IPAddress == "" {
+		event.IPAddress, _ = ctx.Value("x-ip-address").(string)
+	}
+	if event.RequestID == "" {
+		event.RequestID, _ = ctx.Value("x-request-id").(string)
+	}
+	if event.ActorType == "" {
+		event.ActorType = constant.AuditActorAnonymous
+	}
+	if _, err := a.auditStorage.CreateAuditEvent(ctx, event); err != nil {
+		a.log.Error(ctx, "unable to record audit event", zap.Error(err),
+			zap.String("action", string(event.Action)),
+			zap.String("actor-id", event.ActorID.String()))
+	}
+}
+
+func (a *audit) ListAuditEvents(ctx context.Context, userID string,
+	filter dto.AuditEventFilter) ([]dto.AuditEvent, int, error) {
+	query, err := a.query(ctx, userID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return a.auditStorage.ListAuditEvents(ctx, *query)
+}
+
+// ExportAuditEvents writes the filtered audit log as CSV, newest first,
+// ignoring pagination. Batches continue after the last event written, so
+// events recorded during the export neither repeat nor shift rows out.
+func (a *audit) ExportAuditEvents(ctx context.Context, userID string,
+	filter dto.AuditEventFilter, w io.Writer) error {
+	query, err := a.query(ctx, userID, filter)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{
+		"id", "created_at", "actor_type", "actor_id", "action",
+		"ip_address", "request_id", "metadata", "before", "after",
+	}); err != nil {
+		return a.exportError(ctx, err)
+	}
+	var last *dto.AuditEvent
+	for written := 0; written < a.options.MaxExportRows; written += query.Limit {
+		query.Limit = min(exportBatchSize, a.options.MaxExportRows-written)
+		events, err := a.auditStorage.ListAuditEventsBefore(ctx, *query, last)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			actorID := ""
+			if event.ActorID != uuid.Nil {
+				actorID = event.ActorID.String()
+			}
+			if err := writer.Write([]string{
+				event.ID.String(),
+				event.CreatedAt.UTC().Format(time.RFC3339),
+				string(event.ActorType),
+				actorID,
+				csvSafe(string(event.Action)),
+				csvSafe(event.IPAddress),
+				csvSafe(event.RequestID),
+				csvSafe(jsonCell(event.Metadata)),
+				csvSafe(jsonCell(event.Before)),
+				csvSafe(jsonCell(event.After)),
+			}); err != nil {
+				return a.exportError(ctx, err)
+			}
+		}
+		if len(events) < query.Limit {
+			break
+		}
+		last = &events[len(events)-1]
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return a.exportError(ctx, err)
+	}
+
+	return nil
+}
+
+func (a *audit) query(ctx context.Context, userID string,
+	filter dto.AuditEventFilter) (*dto.AuditEventQuery, error) {
+	if err := filter.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid filter")
+		a.log.Error(ctx, "invalid audit filter", zap.Error(err))
+		return nil, err
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "Invalid user id")
+		a.log.Error(ctx, "Invalid user id", zap.Error(err))
+		return nil, err
+	}
+	user, err := a.companyStorage.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage <= 0 {
+		filter.PerPage = defaultPerPage
+	}
+	query := &dto.AuditEventQuery{
+		CompanyID: user.CompanyID,
+		Action:    filter.Action,
+		Limit:     filter.PerPage,
+		Offset:    (filter.Page - 1) * filter.PerPage,
+	}
+	if filter.ActorID != "" {
+		query.ActorID = uuid.MustParse(filter.ActorID)
+	}
+	if filter.From != "" {
+		query.From, _ = time.Parse(time.RFC3339, filter.From)
+	}
+	if filter.To != "" {
+		query.To, _ = time.Parse(time.RFC3339, filter.To)
+	}
+
+	return query, nil
+}
+
+func (a *audit) exportError(ctx context.Context, err error) error {
+	err = errors.ErrInternalServerError.Wrap(err, "unable to export audit events")
+	a.log.Error(ctx, "unable to export audit events", zap.Error(err))
+	return err
+}
+
+func jsonCell(value map[string]any) string {
+	if len(value) == 0 {
+		return ""
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+// csvSafe stops spreadsheet applications from evaluating a cell as a
+// formula when the export is opened.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,414 @@
+package blocklist
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultPerPage = 50
+
+type blocklist struct {
+	log              hlog.Logger
+	blocklistStorage storage.Blocklist
+	companyStorage   storage.Company
+	operatorStorage  storage.Operator
+	auditLog         module.Audit
+}
+
+func New(blocklistStorage storage.Blocklist,
+	companyStorage storage.Company,
+	operatorStorage storage.Operator,
+	auditLog module.Audit,
+	log hlog.Logger) module.Blocklist {
+	return &blocklist{
+		log:              log,
+		blocklistStorage: blocklistStorage,
+		companyStorage:   companyStorage,
+		operatorStorage:  operatorStorage,
+		auditLog:         auditLog,
+	}
+}
+
+// ListCompanyEntries lists the entries of the user's company. Entries
+// operators set for every company are not shown.
+func (b *blocklist) ListCompanyEntries(ctx context.Context, userID string,
+	filter dto.BlocklistFilter) ([]dto.BlocklistEntry, int, error) {
+	user, err := b.getUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.CompanyID, filter.PlatformOnly = "", false
+	query, err := b.entryQuery(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	query.CompanyID = user.CompanyID
+
+	return b.blocklistStorage.ListBlocklistEntries(ctx, query)
+}
+
+// AddCompanyEntry refuses new payment intents of the company's customers
+// who match the entry.
+func (b *blocklist) AddCompanyEntry(ctx context.Context, userID string,
+	param dto.BlocklistRequest) (*dto.BlocklistEntry, error) {
+	if err := b.validate(ctx, param); err != nil {
+		return nil, err
+	}
+	user, err := b.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	value, network, _ := param.Normalize()
+
+	entry, err := b.blocklistStorage.CreateBlocklistEntry(ctx, dto.CreateBlocklistEntry{
+		CompanyID:     user.CompanyID,
+		Type:          param.Type,
+		Value:         value,
+		Network:       network,
+		Reason:        strings.TrimSpace(param.Reason),
+		CreatedByUser: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditBlocklistEntryCreated)
+	event.Metadata = map[string]any{"entry_id": entry.ID}
+	event.After = entryFields(*entry)
+	b.auditLog.Record(ctx, event)
+
+	return entry, nil
+}
+
+func (b *blocklist) UpdateCompanyEntry(ctx context.Context, userID, entryID string,
+	param dto.BlocklistRequest) (*dto.BlocklistEntry, error) {
+	if err := b.validate(ctx, param); err != nil {
+		return nil, err
+	}
+	user, err := b.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	before, err := b.companyEntry(ctx, user.CompanyID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	after, err := b.update(ctx, before.ID, param)
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditBlocklistEntryUpdated)
+	event.Metadata = map[string]any{"entry_id": after.ID}
+	event.Before = entryFields(*before)
+	event.After = entryFields(*after)
+	b.auditLog.Record(ctx, event)
+
+	return after, nil
+}
+
+func (b *blocklist) DeleteCompanyEntry(ctx context.Context, userID, entryID string) error {
+	user, err := b.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	before, err := b.companyEntry(ctx, user.CompanyID, entryID)
+	if err != nil {
+		return err
+	}
+	if err := b.blocklistStorage.DeleteBlocklistEntry(ctx, before.ID); err != nil {
+		return err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditBlocklistEntryDeleted)
+	event.Metadata = map[string]any{"entry_id": before.ID}
+	event.Before = entryFields(*before)
+	b.auditLog.Record(ctx, event)
+
+	return nil
+}
+
+// ListCompanyHits lists the company's payment intents the blocklist
+// refused, newest first, including those refused by entries set for every
+// company.
+func (b *blocklist) ListCompanyHits(ctx context.Context, userID string,
+	filter dto.BlocklistHitFilter) ([]dto.BlocklistHit, int, error) {
+	user, err := b.getUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.CompanyID = ""
+	query, err := b.hitQuery(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	query.CompanyID = user.CompanyID
+
+	return b.blocklistStorage.ListBlocklistHits(ctx, query)
+}
+
+// ListEntries lists the entries of every company, of one company, or only
+// those set for every company.
+func (b *blocklist) ListEntries(ctx context.Context,
+	filter dto.BlocklistFilter) ([]dto.BlocklistEntry, int, error) {
+	query, err := b.entryQuery(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return b.blocklistStorage.ListBlocklistEntries(ctx, query)
+}
+
+// AddEntry adds an entry for one company or, without a company, for every
+// company.
+func (b *blocklist) AddEntry(ctx context.Context, operatorID string,
+	param dto.OperatorBlocklistRequest) (*dto.BlocklistEntry, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		b.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	operator, err := b.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	var companyID uuid.UUID
+	if param.CompanyID != "" {
+		company, err := b.companyStorage.GetCompanyByID(ctx, uuid.MustParse(param.CompanyID))
+		if err != nil {
+			return nil, err
+		}
+		companyID = company.ID
+	}
+	value, network, _ := param.Normalize()
+
+	entry, err := b.blocklistStorage.CreateBlocklistEntry(ctx, dto.CreateBlocklistEntry{
+		CompanyID:         companyID,
+		Type:              param.Type,
+		Value:             value,
+		Network:           network,
+		Reason:            strings.TrimSpace(param.Reason),
+		CreatedByOperator: operator.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	event := dto.OperatorAuditEvent(*operator, companyID, constant.AuditBlocklistEntryCreated)
+	event.Metadata = map[string]any{"entry_id": entry.ID}
+	event.After = entryFields(*entry)
+	b.auditLog.Record(ctx, event)
+
+	return entry, nil
+}
+
+// UpdateEntry replaces the type, value and reason of any entry. The
+// company an entry applies to does not change.
+func (b *blocklist) UpdateEntry(ctx context.Context, operatorID, entryID string,
+	param dto.BlocklistRequest) (*dto.BlocklistEntry, error) {
+	if err := b.validate(ctx, param); err != nil {
+		return nil, err
+	}
+	operator, err := b.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := b.parseID(ctx, entryID, "blocklist entry")
+	if err != nil {
+		return nil, err
+	}
+	before, err := b.blocklistStorage.GetBlocklistEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	after, err := b.update(ctx, before.ID, param)
+	if err != nil {
+		return nil, err
+	}
+	event := dto.OperatorAuditEvent(*operator, entryCompany(*after), constant.AuditBlocklistEntryUpdated)
+	event.Metadata = map[string]any{"entry_id": after.ID}
+	event.Before = entryFields(*before)
+	event.After = entryFields(*after)
+	b.auditLog.Record(ctx, event)
+
+	return after, nil
+}
+
+func (b *blocklist) DeleteEntry(ctx context.Context, operatorID, entryID string) error {
+	operator, err := b.getOperator(ctx, operatorID)
+	if err != nil {
+		return err
+	}
+	id, err := b.parseID(ctx, entryID, "blocklist entry")
+	if err != nil {
+		return err
+	}
+	before, err := b.blocklistStorage.GetBlocklistEntry(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := b.blocklistStorage.DeleteBlocklistEntry(ctx, before.ID); err != nil {
+		return err
+	}
+	event := dto.OperatorAuditEvent(*operator, entryCompany(*before), constant.AuditBlocklistEntryDeleted)
+	event.Metadata = map[string]any{"entry_id": before.ID}
+	event.Before = entryFields(*before)
+	b.auditLog.Record(ctx, event)
+
+	return nil
+}
+
+// ListHits lists the payment intents the blocklist refused, newest first.
+func (b *blocklist) ListHits(ctx context.Context,
+	filter dto.BlocklistHitFilter) ([]dto.BlocklistHit, int, error) {
+	query, err := b.hitQuery(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return b.blocklistStorage.ListBlocklistHits(ctx, query)
+}
+
+func (b *blocklist) validate(ctx context.Context, param dto.BlocklistRequest) error {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		b.log.Warn(ctx, "invalid input", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (b *blocklist) update(ctx context.Context, id uuid.UUID,
+	param dto.BlocklistRequest) (*dto.BlocklistEntry, error) {
+	value, network, _ := param.Normalize()
+	return b.blocklistStorage.UpdateBlocklistEntry(ctx, dto.UpdateBlocklistEntry{
+		ID:      id,
+		Type:    param.Type,
+		Value:   value,
+		Network: network,
+		Reason:  strings.TrimSpace(param.Reason),
+	})
+}
+
+// companyEntry returns one of the company's own entries. Entries of other
+// companies and those set for every company are reported as not found.
+func (b *blocklist) companyEntry(ctx context.Context, companyID uuid.UUID,
+	entryID string) (*dto.BlocklistEntry, error) {
+	id, err := b.parseID(ctx, entryID, "blocklist entry")
+	if err != nil {
+		return nil, err
+	}
+	entry, err := b.blocklistStorage.GetBlocklistEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.CompanyID == nil || *entry.CompanyID != companyID {
+		err := errors.ErrNoRecordFound.New("blocklist entry not found")
+		b.log.Warn(ctx, "blocklist entry of another company", zap.Error(err),
+			zap.String("blocklist-entry-id", entryID),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (b *blocklist) entryQuery(ctx context.Context,
+	filter dto.BlocklistFilter) (dto.BlocklistQuery, error) {
+	if err := filter.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid filter")
+		b.log.Warn(ctx, "invalid blocklist filter", zap.Error(err))
+		return dto.BlocklistQuery{}, err
+	}
+	query := dto.BlocklistQuery{Type: filter.Type, PlatformOnly: filter.PlatformOnly}
+	if filter.CompanyID != "" {
+		query.CompanyID = uuid.MustParse(filter.CompanyID)
+	}
+	query.Limit, query.Offset = page(filter.Page, filter.PerPage)
+
+	return query, nil
+}
+
+func (b *blocklist) hitQuery(ctx context.Context,
+	filter dto.BlocklistHitFilter) (dto.BlocklistHitQuery, error) {
+	if err := filter.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid filter")
+		b.log.Warn(ctx, "invalid blocklist hit filter", zap.Error(err))
+		return dto.BlocklistHitQuery{}, err
+	}
+	query := dto.BlocklistHitQuery{}
+	if filter.CompanyID != "" {
+		query.CompanyID = uuid.MustParse(filter.CompanyID)
+	}
+	if filter.EntryID != "" {
+		query.EntryID = uuid.MustParse(filter.EntryID)
+	}
+	query.Limit, query.Offset = page(filter.Page, filter.PerPage)
+
+	return query, nil
+}
+
+func (b *blocklist) getUser(ctx context.Context, userID string) (*dto.User, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid user id")
+		b.log.Error(ctx, "invalid user id", zap.Error(err))
+		return nil, err
+	}
+
+	return b.companyStorage.GetUserByID(ctx, id)
+}
+
+func (b *blocklist) getOperator(ctx context.Context, operatorID string) (*dto.Operator, error) {
+	id, err := uuid.Parse(operatorID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid operator id")
+		b.log.Error(ctx, "invalid operator id", zap.Error(err))
+		return nil, err
+	}
+
+	return b.operatorStorage.GetOperatorByID(ctx, id)
+}
+
+func (b *blocklist) parseID(ctx context.Context, value, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid %s id", name)
+		b.log.Warn(ctx, "invalid "+name+" id", zap.Error(err), zap.String("id", value))
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+func page(page, perPage int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	return perPage, (page - 1) * perPage
+}
+
+// entryCompany is the company an entry's audit event belongs to, none for
+// entries set for every company.
+func entryCompany(entry dto.BlocklistEntry) uuid.UUID {
+	if entry.CompanyID == nil {
+		return uuid.Nil
+	}
+	return *entry.CompanyID
+}
+
+func entryFields(entry dto.BlocklistEntry) map[string]any {
+	return map[string]any{
+		"type":   entry.Type,
+		"value":  entry.Value,
+		"reason": entry.Reason,
+	}
+}
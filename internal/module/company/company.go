@@ -2,6 +2,8 @@ package company
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"pg/internal/constant"
 	"pg/internal/constant/errors"
 	"pg/internal/constant/model/dto"
@@ -9,26 +11,98 @@ import (
 	"pg/internal/storage"
 	"pg/platform/hcrypto"
 	"pg/platform/hlog"
+	"pg/platform/notifier"
 	"pg/platform/utils"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
+type Options struct {
+	// PasswordResetURL is the dashboard page that completes a password
+	// reset. The reset token is appended as the "token" query parameter.
+	PasswordResetURL string
+	// EmailVerificationURL is the dashboard page that verifies an email
+	// address. The verification token is appended as the "token" query
+	// parameter.
+	EmailVerificationURL string
+	// VerificationCodeExpires is how long an SMS verification code is valid.
+	VerificationCodeExpires time.Duration
+	// MaxVerificationAttempts is how many wrong SMS codes are accepted
+	// before a new code has to be requested.
+	MaxVerificationAttempts int
+	// MFAIssuer is the account issuer shown by authenticator apps.
+	MFAIssuer string
+	// LoginMaxFailures is how many failed attempts lock a phone or email
+	// for LoginLockoutDuration.
+	LoginMaxFailures int
+	// LoginMaxFailuresPerIP is how many failed attempts, across all
+	// accounts, block a client address for LoginLockoutDuration.
+	LoginMaxFailuresPerIP int
+	LoginLockoutDuration  time.Duration
+	// LoginDelayAfter is the number of failures after which every further
+	// attempt has to wait LoginDelayBase, doubled per additional failure.
+	LoginDelayAfter int
+	LoginDelayBase  time.Duration
+	// UnlockURL is the dashboard page that unlocks an account. The unlock
+	// token is appended as the "token" query parameter.
+	UnlockURL string
+}
+
 type company struct {
 	log            hlog.Logger
 	companyStorage storage.Company
+	auditLog       module.Audit
 	maker          hcrypto.Maker
+	notifier       notifier.Notifier
+	options        Options
+	dummyHash      []byte
 }
 
 func New(storage storage.Company,
+	auditLog module.Audit,
 	log hlog.Logger,
-	maker hcrypto.Maker) module.Company {
+	maker hcrypto.Maker,
+	notifier notifier.Notifier,
+	options Options) module.Company {
+	if options.VerificationCodeExpires <= 0 {
+		options.VerificationCodeExpires = 10 * time.Minute
+	}
+	if options.MaxVerificationAttempts <= 0 {
+		options.MaxVerificationAttempts = 5
+	}
+	if options.MFAIssuer == "" {
+		options.MFAIssuer = "Payment Gateway"
+	}
+	if options.LoginMaxFailures <= 0 {
+		options.LoginMaxFailures = 5
+	}
+	if options.LoginMaxFailuresPerIP <= 0 {
+		options.LoginMaxFailuresPerIP = 50
+	}
+	if options.LoginLockoutDuration <= 0 {
+		options.LoginLockoutDuration = 15 * time.Minute
+	}
+	if options.LoginDelayAfter <= 0 {
+		options.LoginDelayAfter = 3
+	}
+	if options.LoginDelayBase <= 0 {
+		options.LoginDelayBase = time.Second
+	}
+	// Compared against when the account does not exist, so that unknown
+	// accounts take as long to reject as a wrong password.
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte(uuid.NewString()), bcrypt.DefaultCost)
 	return &company{
 		companyStorage: storage,
+		auditLog:       auditLog,
 		log:            log,
 		maker:          maker,
+		notifier:       notifier,
+		options:        options,
+		dummyHash:      dummyHash,
 	}
 }
 func (c *company) RegisterCompany(ctx context.Context,
@@ -63,34 +137,98 @@ func (c *company) RegisterCompany(ctx context.Context,
 		return nil, err
 	}
 	param.AdminPhone = *phone
-	if _, err = c.companyStorage.CreateUser(ctx, dto.CreateUser{
+	user, err := c.companyStorage.CreateUser(ctx, dto.CreateUser{
 		CompanyID: company.ID,
 		FirstName: param.AdminName,
 		Email:     param.AdminEmail,
 		Phone:     param.AdminPhone,
 		Password:  string(hashedPassword),
-	}); err != nil {
+		Role:      constant.Owner,
+		Status:    constant.PendingVerification,
+	})
+	if err != nil {
 		return nil, err
 	}
+	// The account exists at this point, so a delivery failure must not fail
+	// the registration; the owner can ask for the link and code again.
+	if err := c.sendVerifications(ctx, user); err != nil {
+		c.log.Warn(ctx, "unable to send verification to new owner", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+	}
 
 	return company, nil
 }
 
-func (c *company) Login(ctx context.Context, arg dto.LoginRequest) (*dto.SignInResponse, error) {
+func (c *company) Login(ctx context.Context,
+	arg dto.LoginRequest) (*dto.SignInResponse, *dto.MFAChallenge, error) {
 	if err := arg.Validate(); err != nil {
 		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
 		c.log.Error(ctx, "invalid input", zap.Error(err))
-		return nil, err
+		return nil, nil, err
+	}
+	identifier := loginIdentifier(arg.PhoneOrEmail)
+	attempts, err := c.reserveLoginAttempt(ctx, identifier, arg.IPAddress)
+	if err != nil {
+		return nil, nil, err
 	}
 	user, err := c.companyStorage.GetUserByPhoneOrEmail(ctx, arg.PhoneOrEmail)
 	if err != nil {
-		return nil, err
+		if !errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			return nil, nil, err
+		}
+		_ = bcrypt.CompareHashAndPassword(c.dummyHash, []byte(arg.Password))
+		c.auditLog.Record(ctx, dto.CreateAuditEvent{
+			ActorType:  constant.AuditActorAnonymous,
+			Action:     constant.AuditLoginFailed,
+			Identifier: identifier,
+			Metadata:   map[string]any{"reason": "unknown_account"},
+		})
+		c.recordLoginFailure(ctx, attempts, arg.IPAddress, nil)
+		return nil, nil, errInvalidCredentials()
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(arg.Password)); err != nil {
-		err := errors.ErrInvalidUserInput.Wrap(err, "incorrect password")
-		c.log.Error(ctx, "incorrect password", zap.Error(err))
-		return nil, err
+		c.log.Warn(ctx, "incorrect password", zap.String("user-id", user.ID.String()),
+			zap.String("ip-address", arg.IPAddress))
+		event := dto.UserAuditEvent(*user, constant.AuditLoginFailed)
+		event.Metadata = map[string]any{"reason": "invalid_password"}
+		c.auditLog.Record(ctx, event)
+		c.recordLoginFailure(ctx, attempts, arg.IPAddress, user)
+		return nil, nil, errInvalidCredentials()
 	}
+	// With MFA the attempt stays counted until the code is verified, so
+	// signing in again with the password cannot reset wrong codes.
+	if user.MFAEnabledAt.IsZero() {
+		err = c.clearLoginAttempts(ctx, arg.IPAddress, identifier)
+	} else {
+		err = c.releaseIPAttempt(ctx, arg.IPAddress)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.checkActive(ctx, user); err != nil {
+		return nil, nil, err
+	}
+	if !user.MFAEnabledAt.IsZero() {
+		challenge, err := c.createMFAChallenge(ctx, user)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, challenge, nil
+	}
+
+	session, err := c.issueSession(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditLoginSucceeded)
+	event.Metadata = map[string]any{"mfa": false}
+	c.auditLog.Record(ctx, event)
+	return session, nil, nil
+}
+
+// issueSession creates a new access and refresh token pair for the user,
+// replacing any refresh token issued before.
+func (c *company) issueSession(ctx context.Context, user *dto.User) (*dto.SignInResponse, error) {
 	accessToken, _, err := c.maker.CreatePasetoToken(hcrypto.UserData{
 		UserID:    user.ID.String(),
 		Email:     user.Email,
@@ -129,18 +267,22 @@ func (c *company) Login(ctx context.Context, arg dto.LoginRequest) (*dto.SignInR
 	}, nil
 }
 
-func (c *company) GenerateToken(ctx context.Context,
-	userID string) (*dto.CompanyCredentialResponse, error) {
-	id, err := uuid.Parse(userID)
-	if err != nil {
-		err = errors.ErrInvalidUserInput.Wrap(err, "Invalid user id")
-		c.log.Error(ctx, "Invalid user id", zap.Error(err))
+// GenerateToken issues a secret token for the requested mode, replacing the
+// company's previous token of that mode only.
+func (c *company) GenerateToken(ctx context.Context, userID string,
+	arg dto.APIKeyRequest) (*dto.CompanyCredentialResponse, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
 		return nil, err
 	}
-	user, err := c.companyStorage.GetUserByID(ctx, id)
+	user, err := c.getUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkActive(ctx, user); err != nil {
+		return nil, err
+	}
 	company, err := c.companyStorage.GetCompanyByID(ctx, user.CompanyID)
 	if err != nil {
 		return nil, err
@@ -157,10 +299,143 @@ func (c *company) GenerateToken(ctx context.Context,
 	if err := c.companyStorage.GenerateCompanyCredentials(ctx, dto.CreateCompanyToken{
 		TokenID:   tokenID,
 		CompanyID: user.CompanyID,
+		Livemode:  arg.Livemode(),
 	}); err != nil {
 		return nil, err
 	}
+	event := dto.UserAuditEvent(*user, constant.AuditSecretTokenGenerated)
+	event.Metadata = map[string]any{"token_id": tokenID, "livemode": arg.Livemode()}
+	c.auditLog.Record(ctx, event)
 	return &dto.CompanyCredentialResponse{
 		ScretToken: secret,
+		Livemode:   arg.Livemode(),
 	}, nil
 }
+
+// ForgotPassword issues a single-use reset token and sends it to the user.
+// It succeeds whether or not the account exists so that the endpoint
+// cannot be used to discover registered phones and emails.
+func (c *company) ForgotPassword(ctx context.Context,
+	arg dto.ForgotPasswordRequest) error {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Error(ctx, "invalid input", zap.Error(err))
+		return err
+	}
+	user, err := c.companyStorage.GetUserByPhoneOrEmail(ctx, arg.PhoneOrEmail)
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			return nil
+		}
+		return err
+	}
+	if user.Status != string(constant.Active) {
+		c.log.Warn(ctx, "password reset requested for inactive user",
+			zap.String("user-id", user.ID.String()))
+		return nil
+	}
+
+	token, tokenID, err := c.maker.CreatePasetoToken(hcrypto.UserData{
+		UserID:   user.ID.String(),
+		Email:    user.Email,
+		Provider: constant.Normal,
+	}, constant.ResetPassword)
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to generate reset token")
+		c.log.Error(ctx, "unable to generate reset token", zap.Error(err))
+		return err
+	}
+	if _, err := c.companyStorage.CreatePasswordReset(ctx, user.ID, tokenID); err != nil {
+		return err
+	}
+
+	link := token
+	if c.options.PasswordResetURL != "" {
+		link = c.options.PasswordResetURL + "?token=" + url.QueryEscape(token)
+	}
+	if err := c.notifier.Send(ctx, notifier.Message{
+		Channel: notifier.ChannelEmail,
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use the following link to reset your password. "+
+			"If you did not request this, you can ignore this email: %s", link),
+	}); err != nil {
+		err = errors.ErrUnableToSendMail.Wrap(err, "unable to send password reset")
+		c.log.Error(ctx, "unable to send password reset", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (c *company) ResetPassword(ctx context.Context,
+	arg dto.ResetPasswordRequest) error {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Error(ctx, "invalid input", zap.Error(err))
+		return err
+	}
+	payload, err := c.maker.VerifyPasetoToken(arg.Token)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.Wrap(err, "invalid reset token")
+		c.log.Warn(ctx, "invalid reset token", zap.Error(err))
+		return err
+	}
+	if payload.TokenType != constant.ResetPassword {
+		err := errors.ErrInvalidAccessToken.New("invalid reset token")
+		c.log.Warn(ctx, "token is not a reset token", zap.Error(err),
+			zap.String("token-type", string(payload.TokenType)))
+		return err
+	}
+	reset, err := c.companyStorage.GetPasswordResetByTokenID(ctx, payload.TokenID)
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			return errors.ErrInvalidAccessToken.New("invalid reset token")
+		}
+		return err
+	}
+	if reset.Status != string(constant.Pending) || reset.UserID.String() != payload.UserID {
+		err := errors.ErrInvalidAccessToken.New("reset token is no longer valid")
+		c.log.Warn(ctx, "reset token is no longer valid", zap.Error(err),
+			zap.String("reset-id", reset.ID.String()))
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(arg.Password), bcrypt.DefaultCost)
+	if err != nil {
+		err = errors.ErrUnableToHashPassword.Wrap(err,
+			"Unable to generate password hash")
+		c.log.Error(ctx, "unable to generate password hash", zap.Error(err))
+		return err
+	}
+
+	if err := c.companyStorage.ResetPassword(ctx, reset.ID, reset.UserID,
+		string(hashedPassword)); err != nil {
+		return err
+	}
+	if user, err := c.companyStorage.GetUserByID(ctx, reset.UserID); err == nil {
+		c.auditLog.Record(ctx, dto.UserAuditEvent(*user, constant.AuditPasswordReset))
+	}
+
+	return nil
+}
+
+// checkActive rejects users that may not sign in or act for their company,
+// telling unverified owners what is missing.
+func (c *company) checkActive(ctx context.Context, user *dto.User) error {
+	switch constant.Status(user.Status) {
+	case constant.Active:
+		return nil
+	case constant.PendingVerification:
+		err := errors.ErrAcessError.New("verify your email and phone before continuing")
+		c.log.Warn(ctx, "unverified user tried to sign in", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	default:
+		err := errors.ErrAcessError.New("user account is not active")
+		c.log.Warn(ctx, "inactive user tried to sign in", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+}
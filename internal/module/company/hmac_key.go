@@ -0,0 +1,102 @@
+package company
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CreateHMACKey issues a new PG-HMAC signing key for the user's company.
+// Existing keys stay active so that merchants can rotate without downtime.
+// The key id tells test keys from live ones at a glance.
+func (c *company) CreateHMACKey(ctx context.Context, userID string,
+	arg dto.APIKeyRequest) (*dto.CreatedHMACKey, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := make([]byte, 8)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(keyID); err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to generate hmac key")
+		c.log.Error(ctx, "unable to generate hmac key id", zap.Error(err))
+		return nil, err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to generate hmac key")
+		c.log.Error(ctx, "unable to generate hmac secret", zap.Error(err))
+		return nil, err
+	}
+
+	prefix := "hk_live_"
+	if !arg.Livemode() {
+		prefix = "hk_test_"
+	}
+	key, err := c.companyStorage.CreateHMACKey(ctx, dto.CreateHMACKey{
+		KeyID:     prefix + hex.EncodeToString(keyID),
+		CompanyID: user.CompanyID,
+		Secret:    base64.RawURLEncoding.EncodeToString(secret),
+		Livemode:  arg.Livemode(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditHMACKeyCreated)
+	event.Metadata = map[string]any{"key_id": key.KeyID, "livemode": key.Livemode}
+	c.auditLog.Record(ctx, event)
+
+	return &dto.CreatedHMACKey{
+		HMACKey: *key,
+		Secret:  key.Secret,
+	}, nil
+}
+
+func (c *company) ListHMACKeys(ctx context.Context,
+	userID string) ([]dto.HMACKey, error) {
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.companyStorage.ListHMACKeys(ctx, user.CompanyID)
+}
+
+// RevokeHMACKey stops the key from authenticating any further requests.
+func (c *company) RevokeHMACKey(ctx context.Context,
+	userID, id string) (*dto.HMACKey, error) {
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := uuid.Parse(id)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid hmac key id")
+		c.log.Warn(ctx, "invalid hmac key id", zap.Error(err), zap.String("id", id))
+		return nil, err
+	}
+
+	key, err := c.companyStorage.RevokeHMACKey(ctx, user.CompanyID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditHMACKeyRevoked)
+	event.Metadata = map[string]any{"key_id": key.KeyID}
+	event.Before = map[string]any{"status": string(constant.Active)}
+	event.After = map[string]any{"status": key.Status}
+	c.auditLog.Record(ctx, event)
+
+	return key, nil
+}
@@ -0,0 +1,146 @@
+package company
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (c *company) ListIPAllowlist(ctx context.Context,
+	userID string) ([]dto.IPAllowlistEntry, error) {
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.companyStorage.ListIPAllowlist(ctx, user.CompanyID)
+}
+
+// AddIPAllowlistEntry allows the network to call the merchant API. Adding
+// the first entry blocks every address that is not on the list.
+func (c *company) AddIPAllowlistEntry(ctx context.Context, userID string,
+	arg dto.IPAllowlistRequest) (*dto.IPAllowlistEntry, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Error(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	network, _ := arg.Network()
+
+	entry, err := c.companyStorage.CreateIPAllowlistEntry(ctx, dto.CreateIPAllowlistEntry{
+		CompanyID:   user.CompanyID,
+		Network:     network,
+		Description: arg.Description,
+		CreatedBy:   user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditIPAllowlistCreated)
+	event.Metadata = map[string]any{"entry_id": entry.ID}
+	event.After = map[string]any{"cidr": entry.CIDR, "description": entry.Description}
+	c.auditLog.Record(ctx, event)
+
+	return entry, nil
+}
+
+func (c *company) UpdateIPAllowlistEntry(ctx context.Context, userID, id string,
+	arg dto.IPAllowlistRequest) (*dto.IPAllowlistEntry, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Error(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	entryID, err := c.parseAllowlistEntryID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	network, _ := arg.Network()
+
+	before, err := c.findAllowlistEntry(ctx, user.CompanyID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := c.companyStorage.UpdateIPAllowlistEntry(ctx, dto.UpdateIPAllowlistEntry{
+		ID:          entryID,
+		CompanyID:   user.CompanyID,
+		Network:     network,
+		Description: arg.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditIPAllowlistUpdated)
+	event.Metadata = map[string]any{"entry_id": entry.ID}
+	event.Before = map[string]any{"cidr": before.CIDR, "description": before.Description}
+	event.After = map[string]any{"cidr": entry.CIDR, "description": entry.Description}
+	c.auditLog.Record(ctx, event)
+
+	return entry, nil
+}
+
+// DeleteIPAllowlistEntry removes the network. Removing the last entry
+// allows requests from every address again.
+func (c *company) DeleteIPAllowlistEntry(ctx context.Context,
+	userID, id string) error {
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	entryID, err := c.parseAllowlistEntryID(ctx, id)
+	if err != nil {
+		return err
+	}
+	before, err := c.findAllowlistEntry(ctx, user.CompanyID, entryID)
+	if err != nil {
+		return err
+	}
+	if err := c.companyStorage.DeleteIPAllowlistEntry(ctx, user.CompanyID, entryID); err != nil {
+		return err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditIPAllowlistDeleted)
+	event.Metadata = map[string]any{"entry_id": entryID}
+	event.Before = map[string]any{"cidr": before.CIDR, "description": before.Description}
+	c.auditLog.Record(ctx, event)
+
+	return nil
+}
+
+func (c *company) parseAllowlistEntryID(ctx context.Context, id string) (uuid.UUID, error) {
+	entryID, err := uuid.Parse(id)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid allowlist entry id")
+		c.log.Warn(ctx, "invalid allowlist entry id", zap.Error(err), zap.String("id", id))
+		return uuid.Nil, err
+	}
+	return entryID, nil
+}
+
+// findAllowlistEntry returns the company's entry, or ErrNoRecordFound.
+func (c *company) findAllowlistEntry(ctx context.Context,
+	companyID, id uuid.UUID) (*dto.IPAllowlistEntry, error) {
+	entries, err := c.companyStorage.ListIPAllowlist(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.ID == id {
+			return &entry, nil
+		}
+	}
+	err = errors.ErrNoRecordFound.New("allowlist entry not found")
+	c.log.Warn(ctx, "allowlist entry not found", zap.Error(err), zap.String("id", id.String()))
+	return nil, err
+}
@@ -0,0 +1,252 @@
+package company
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/platform/hcrypto"
+	"pg/platform/notifier"
+	"strings"
+	"time"
+
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+)
+
+// errInvalidCredentials is returned for an unknown account and for a wrong
+// password alike, so that login does not reveal which accounts exist.
+func errInvalidCredentials() error {
+	return errors.ErrInvalidCredentials.New("invalid phone, email or password")
+}
+
+// loginIdentifier is the key failed attempts are counted under. Phones are
+// already normalized by LoginRequest.Validate.
+func loginIdentifier(phoneOrEmail string) string {
+	return strings.ToLower(strings.TrimSpace(phoneOrEmail))
+}
+
+// reserveLoginAttempt counts an attempt under the client address and the
+// identifier before the credentials are checked, and refuses it while
+// either is locked out or the identifier is still inside its progressive
+// delay. Each count is a single conditional statement, so concurrent
+// attempts cannot slip past the limits. It only looks at attempts, never at
+// the account, so the answer is the same for unknown accounts. It returns
+// the attempts counted under the identifier.
+func (c *company) reserveLoginAttempt(ctx context.Context, identifier, ipAddress string) (int, error) {
+	since := time.Now().Add(-c.options.LoginLockoutDuration)
+
+	if ipAddress != "" {
+		if _, err := c.companyStorage.ReserveLoginAttempt(ctx, dto.LoginThrottle{
+			Key:         ipThrottleKey(ipAddress),
+			Since:       since,
+			MaxAttempts: c.options.LoginMaxFailuresPerIP,
+			DelayAfter:  c.options.LoginMaxFailuresPerIP,
+		}); err != nil {
+			if errorx.IsOfType(err, errors.ErrTooManyRequests) {
+				c.log.Warn(ctx, "login blocked for ip address", zap.Error(err),
+					zap.String("ip-address", ipAddress))
+			}
+			return 0, err
+		}
+	}
+
+	attempts, err := c.companyStorage.ReserveLoginAttempt(ctx, dto.LoginThrottle{
+		Key:         identifier,
+		Since:       since,
+		MaxAttempts: c.options.LoginMaxFailures,
+		DelayAfter:  c.options.LoginDelayAfter,
+		DelayBase:   c.options.LoginDelayBase,
+	})
+	if err != nil {
+		if !errorx.IsOfType(err, errors.ErrTooManyRequests) {
+			return 0, err
+		}
+		return 0, c.loginRefusal(ctx, identifier, ipAddress)
+	}
+
+	return attempts, nil
+}
+
+// loginRefusal tells a refused client whether the identifier is locked out
+// or how long its progressive delay still runs.
+func (c *company) loginRefusal(ctx context.Context, identifier, ipAddress string) error {
+	stats, err := c.companyStorage.GetLoginThrottle(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if wait := c.loginDelay(stats.Failures); stats.Failures < c.options.LoginMaxFailures && wait > 0 {
+		if retryAt := stats.LastFailedAt.Add(wait); now.Before(retryAt) {
+			err := errors.ErrTooManyRequests.New("too many failed sign-in attempts, retry in %d seconds",
+				int(math.Ceil(retryAt.Sub(now).Seconds())))
+			c.log.Warn(ctx, "login attempt inside progressive delay", zap.Error(err),
+				zap.String("ip-address", ipAddress), zap.Int("failures", stats.Failures))
+			return err
+		}
+	}
+	err = errors.ErrTooManyRequests.New("too many failed sign-in attempts, try again later")
+	c.log.Warn(ctx, "login blocked for locked identifier", zap.Error(err),
+		zap.String("ip-address", ipAddress), zap.Int("failures", stats.Failures))
+
+	return err
+}
+
+// clearLoginAttempts forgets the attempts of identifiers that signed in,
+// and uncounts the successful attempt from the client address.
+func (c *company) clearLoginAttempts(ctx context.Context, ipAddress string,
+	identifiers ...string) error {
+	if err := c.companyStorage.ClearLoginAttempts(ctx, identifiers...); err != nil {
+		return err
+	}
+
+	return c.releaseIPAttempt(ctx, ipAddress)
+}
+
+// releaseIPAttempt uncounts an attempt with the right password from the
+// client address, which only limits password guessing.
+func (c *company) releaseIPAttempt(ctx context.Context, ipAddress string) error {
+	if ipAddress == "" {
+		return nil
+	}
+
+	return c.companyStorage.ReleaseLoginAttempt(ctx, ipThrottleKey(ipAddress))
+}
+
+func ipThrottleKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
+
+// loginDelay doubles the wait after every failure past LoginDelayAfter.
+func (c *company) loginDelay(failures int) time.Duration {
+	if failures < c.options.LoginDelayAfter {
+		return 0
+	}
+	shift := failures - c.options.LoginDelayAfter
+	if shift > 10 {
+		shift = 10
+	}
+
+	return c.options.LoginDelayBase << shift
+}
+
+// recordLoginFailure handles a failed attempt, which reserveLoginAttempt
+// already counted. When the failure locks an existing account, the owner of
+// the account is emailed an unlock link.
+func (c *company) recordLoginFailure(ctx context.Context, attempts int, ipAddress string,
+	user *dto.User) {
+	if user == nil || attempts != c.options.LoginMaxFailures {
+		return
+	}
+
+	c.log.Warn(ctx, "account locked after failed sign-in attempts",
+		zap.String("user-id", user.ID.String()), zap.String("ip-address", ipAddress))
+	event := dto.UserAuditEvent(*user, constant.AuditAccountLocked)
+	event.Metadata = map[string]any{
+		"failures":         attempts,
+		"lockout_duration": c.options.LoginLockoutDuration.String(),
+	}
+	c.auditLog.Record(ctx, event)
+	if err := c.sendUnlockEmail(ctx, user); err != nil {
+		c.log.Warn(ctx, "unable to send unlock email", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+	}
+}
+
+// recordMFAFailure counts a rejected MFA code against the user's email so
+// that codes cannot be guessed with a stolen password.
+func (c *company) recordMFAFailure(ctx context.Context, user *dto.User,
+	attempts int, ipAddress string, err error) error {
+	if !errorx.IsOfType(err, errors.ErrInvalidUserInput) {
+		return err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditLoginFailed)
+	event.Metadata = map[string]any{"reason": "invalid_mfa_code"}
+	c.auditLog.Record(ctx, event)
+	c.recordLoginFailure(ctx, attempts, ipAddress, user)
+
+	return err
+}
+
+func (c *company) sendUnlockEmail(ctx context.Context, user *dto.User) error {
+	token, _, err := c.maker.CreatePasetoToken(hcrypto.UserData{
+		UserID:   user.ID.String(),
+		Email:    user.Email,
+		Provider: constant.Normal,
+	}, constant.UnlockAccountToken)
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to generate unlock token")
+		c.log.Error(ctx, "unable to generate unlock token", zap.Error(err))
+		return err
+	}
+
+	link := token
+	if c.options.UnlockURL != "" {
+		link = c.options.UnlockURL + "?token=" + url.QueryEscape(token)
+	}
+	if err := c.notifier.Send(ctx, notifier.Message{
+		Channel: notifier.ChannelEmail,
+		To:      user.Email,
+		Subject: "Your account has been locked",
+		Body: fmt.Sprintf("We blocked sign-in to your account after several failed attempts. "+
+			"If this was you, unlock your account here: %s. "+
+			"If it was not, consider resetting your password.", link),
+	}); err != nil {
+		err = errors.ErrUnableToSendMail.Wrap(err, "unable to send unlock email")
+		c.log.Error(ctx, "unable to send unlock email", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+
+	return nil
+}
+
+// UnlockAccount clears the failed attempts of the account named in the
+// unlock token, under both its email and its phone.
+func (c *company) UnlockAccount(ctx context.Context, arg dto.UnlockAccountRequest) error {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return err
+	}
+	payload, err := c.maker.VerifyPasetoToken(arg.Token)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.Wrap(err, "invalid unlock token")
+		c.log.Warn(ctx, "invalid unlock token", zap.Error(err))
+		return err
+	}
+	if payload.TokenType != constant.UnlockAccountToken {
+		err := errors.ErrInvalidAccessToken.New("invalid unlock token")
+		c.log.Warn(ctx, "token is not an unlock token", zap.Error(err),
+			zap.String("token-type", string(payload.TokenType)))
+		return err
+	}
+	user, err := c.getUser(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	// A link unlocks once, so a leaked email cannot keep an attacker's
+	// guesses going.
+	unused, err := c.companyStorage.UseToken(ctx, payload.TokenID,
+		string(constant.UnlockAccountToken), payload.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if !unused {
+		err := errors.ErrInvalidAccessToken.New("unlock token already used")
+		c.log.Warn(ctx, "unlock token reused", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+
+	if err := c.companyStorage.ClearLoginAttempts(ctx,
+		loginIdentifier(user.Email), loginIdentifier(user.Phone)); err != nil {
+		return err
+	}
+	c.auditLog.Record(ctx, dto.UserAuditEvent(*user, constant.AuditAccountUnlocked))
+
+	return nil
+}
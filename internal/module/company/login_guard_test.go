@@ -0,0 +1,173 @@
+package company
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hcrypto"
+	"pg/platform/hlog"
+	"pg/platform/notifier"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeLoginStorage keeps one user and counts login attempts in memory the
+// way ReserveLoginAttempt does, without the progressive delay.
+type fakeLoginStorage struct {
+	storage.Company
+	user     dto.User
+	attempts map[string]int
+}
+
+func (f *fakeLoginStorage) GetUserByPhoneOrEmail(context.Context, string) (*dto.User, error) {
+	user := f.user
+	return &user, nil
+}
+
+func (f *fakeLoginStorage) GetUserByID(context.Context, uuid.UUID) (*dto.User, error) {
+	user := f.user
+	return &user, nil
+}
+
+func (f *fakeLoginStorage) ReserveLoginAttempt(_ context.Context,
+	throttle dto.LoginThrottle) (int, error) {
+	if f.attempts[throttle.Key] >= throttle.MaxAttempts {
+		return 0, errors.ErrTooManyRequests.New("too many attempts")
+	}
+	f.attempts[throttle.Key]++
+	return f.attempts[throttle.Key], nil
+}
+
+func (f *fakeLoginStorage) GetLoginThrottle(_ context.Context,
+	key string) (*dto.LoginFailureStats, error) {
+	return &dto.LoginFailureStats{Failures: f.attempts[key], LastFailedAt: time.Now()}, nil
+}
+
+func (f *fakeLoginStorage) ReleaseLoginAttempt(_ context.Context, key string) error {
+	if f.attempts[key] > 0 {
+		f.attempts[key]--
+	}
+	return nil
+}
+
+func (f *fakeLoginStorage) ClearLoginAttempts(_ context.Context, identifiers ...string) error {
+	for _, identifier := range identifiers {
+		delete(f.attempts, identifier)
+	}
+	return nil
+}
+
+func (f *fakeLoginStorage) UseRecoveryCode(context.Context, uuid.UUID, string) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeLoginStorage) UseToken(context.Context, uuid.UUID, string, time.Time) (bool, error) {
+	return true, nil
+}
+
+// fakeMaker issues tokens that name their type and user.
+type fakeMaker struct {
+	hcrypto.Maker
+}
+
+func (fakeMaker) CreatePasetoToken(data hcrypto.UserData,
+	tokenType constant.TokenType) (string, uuid.UUID, error) {
+	return string(tokenType) + ":" + data.UserID, uuid.New(), nil
+}
+
+func (fakeMaker) VerifyPasetoToken(token string) (*hcrypto.Payload, error) {
+	tokenType, userID, _ := strings.Cut(token, ":")
+	return &hcrypto.Payload{
+		TokenID:   uuid.New(),
+		TokenType: constant.TokenType(tokenType),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(5 * time.Minute),
+	}, nil
+}
+
+type nopAudit struct {
+	module.Audit
+}
+
+func (nopAudit) Record(context.Context, dto.CreateAuditEvent) {}
+
+type nopNotifier struct{}
+
+func (nopNotifier) Send(context.Context, notifier.Message) error { return nil }
+
+func testLogger(t *testing.T) hlog.Logger {
+	t.Helper()
+	client, err := sentry.NewClient(sentry.ClientOptions{})
+	if err != nil {
+		t.Fatalf("sentry client: %v", err)
+	}
+	return hlog.New(zap.NewNop(), hlog.Options{}, client)
+}
+
+func TestPasswordLoginDoesNotResetMFAFailures(t *testing.T) {
+	ctx := context.Background()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	store := &fakeLoginStorage{
+		user: dto.User{
+			ID:           uuid.New(),
+			Email:        "owner@example.com",
+			Password:     string(hash),
+			Status:       string(constant.Active),
+			MFAEnabledAt: time.Now(),
+		},
+		attempts: map[string]int{},
+	}
+	const maxFailures = 5
+	c := &company{
+		log:            testLogger(t),
+		companyStorage: store,
+		auditLog:       nopAudit{},
+		maker:          fakeMaker{},
+		notifier:       nopNotifier{},
+		options: Options{
+			LoginMaxFailures:      maxFailures,
+			LoginMaxFailuresPerIP: 100,
+			LoginLockoutDuration:  time.Hour,
+			LoginDelayAfter:       100,
+		},
+	}
+
+	for round := 0; round < maxFailures; round++ {
+		_, challenge, err := c.Login(ctx, dto.LoginRequest{
+			PhoneOrEmail: store.user.Email,
+			Password:     "correct horse",
+			IPAddress:    "196.188.12.34",
+		})
+		if errorx.IsOfType(err, errors.ErrTooManyRequests) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("round %d: Login() = %v", round, err)
+		}
+		_, err = c.LoginMFA(ctx, dto.MFALoginRequest{
+			MFAToken:  challenge.MFAToken,
+			Code:      "wrong-code",
+			IPAddress: "196.188.12.34",
+		})
+		if errorx.IsOfType(err, errors.ErrTooManyRequests) {
+			return
+		}
+		if !errorx.IsOfType(err, errors.ErrInvalidUserInput) {
+			t.Fatalf("round %d: LoginMFA() = %v, want invalid code", round, err)
+		}
+	}
+	t.Fatalf("%d password logins and wrong codes were never locked out", maxFailures)
+}
@@ -0,0 +1,368 @@
+package company
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/platform/hcrypto"
+	"pg/platform/totp"
+	"pg/platform/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	// totpSkew is the number of 30 second steps of clock drift accepted on
+	// either side of the server time.
+	totpSkew = 1
+)
+
+func (c *company) EnrollMFA(ctx context.Context, userID string) (*dto.MFAEnrollment, error) {
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabledAt.IsZero() {
+		err := errors.ErrInvalidUserInput.New("mfa is already enabled")
+		c.log.Warn(ctx, "user tried to enroll mfa twice", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to generate mfa secret")
+		c.log.Error(ctx, "unable to generate mfa secret", zap.Error(err))
+		return nil, err
+	}
+	if err := c.companyStorage.SetMFASecret(ctx, user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(c.options.MFAIssuer, user.Email, secret),
+	}, nil
+}
+
+// EnableMFA confirms a pending enrollment with the first TOTP code and
+// returns the recovery codes. The codes are not retrievable afterwards.
+func (c *company) EnableMFA(ctx context.Context, userID string,
+	arg dto.MFACodeRequest) (*dto.RecoveryCodes, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFASecret == "" || !user.MFAEnabledAt.IsZero() {
+		err := errors.ErrInvalidUserInput.New("there is no pending mfa enrollment")
+		c.log.Warn(ctx, "no pending mfa enrollment", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return nil, err
+	}
+	if err := c.verifyTOTP(ctx, user, arg.Code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes := generateRecoveryCodes()
+	if err := c.companyStorage.EnableMFA(ctx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+	c.auditLog.Record(ctx, dto.UserAuditEvent(*user, constant.AuditMFAEnabled))
+
+	return &dto.RecoveryCodes{Codes: codes}, nil
+}
+
+func (c *company) DisableMFA(ctx context.Context, userID string,
+	arg dto.MFACodeRequest) error {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return err
+	}
+	user, err := c.getEnabledMFAUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	company, err := c.companyStorage.GetCompanyByID(ctx, user.CompanyID)
+	if err != nil {
+		return err
+	}
+	if company.MFARequired {
+		err := errors.ErrAcessError.New("your company requires mfa")
+		c.log.Warn(ctx, "user tried to disable required mfa", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+	if err := c.verifyMFACode(ctx, user, arg.Code); err != nil {
+		return err
+	}
+
+	if err := c.companyStorage.DisableMFA(ctx, user.ID); err != nil {
+		return err
+	}
+	c.auditLog.Record(ctx, dto.UserAuditEvent(*user, constant.AuditMFADisabled))
+
+	return nil
+}
+
+func (c *company) RegenerateRecoveryCodes(ctx context.Context, userID string,
+	arg dto.MFACodeRequest) (*dto.RecoveryCodes, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	user, err := c.getEnabledMFAUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyTOTP(ctx, user, arg.Code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes := generateRecoveryCodes()
+	if err := c.companyStorage.ReplaceRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+	c.auditLog.Record(ctx, dto.UserAuditEvent(*user, constant.AuditRecoveryCodesRegenerated))
+
+	return &dto.RecoveryCodes{Codes: codes}, nil
+}
+
+// LoginMFA completes a login that was answered with an MFA challenge.
+func (c *company) LoginMFA(ctx context.Context,
+	arg dto.MFALoginRequest) (*dto.SignInResponse, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	payload, err := c.maker.VerifyPasetoToken(arg.MFAToken)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.Wrap(err, "invalid mfa token")
+		c.log.Warn(ctx, "invalid mfa token", zap.Error(err))
+		return nil, err
+	}
+	if payload.TokenType != constant.MFAChallengeToken {
+		err := errors.ErrInvalidAccessToken.New("invalid mfa token")
+		c.log.Warn(ctx, "token is not an mfa challenge token", zap.Error(err),
+			zap.String("token-type", string(payload.TokenType)))
+		return nil, err
+	}
+	user, err := c.getUser(ctx, payload.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkActive(ctx, user); err != nil {
+		return nil, err
+	}
+	if user.MFAEnabledAt.IsZero() {
+		err := errors.ErrInvalidAccessToken.New("invalid mfa token")
+		c.log.Warn(ctx, "mfa challenge for user without mfa", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return nil, err
+	}
+	identifier := loginIdentifier(user.Email)
+	attempts, err := c.reserveLoginAttempt(ctx, identifier, arg.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyMFACode(ctx, user, arg.Code); err != nil {
+		return nil, c.recordMFAFailure(ctx, user, attempts, arg.IPAddress, err)
+	}
+	// A challenge signs in once; a captured token cannot be replayed with a
+	// later code.
+	unused, err := c.companyStorage.UseToken(ctx, payload.TokenID,
+		string(constant.MFAChallengeToken), payload.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !unused {
+		err := errors.ErrInvalidAccessToken.New("mfa token already used")
+		c.log.Warn(ctx, "mfa challenge token reused", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return nil, err
+	}
+	// The password step was counted under the identifier the user signed
+	// in with, which may be the phone.
+	if err := c.clearLoginAttempts(ctx, arg.IPAddress, identifier,
+		loginIdentifier(user.Phone)); err != nil {
+		return nil, err
+	}
+
+	session, err := c.issueSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditLoginSucceeded)
+	event.Metadata = map[string]any{"mfa": true}
+	c.auditLog.Record(ctx, event)
+	return session, nil
+}
+
+// UpdateCompanyMFA lets the company owner require MFA for every member.
+// Members without MFA keep signing in but are refused by permission checks
+// until they enroll.
+func (c *company) UpdateCompanyMFA(ctx context.Context, userID string,
+	arg dto.UpdateCompanyMFA) (*dto.Company, error) {
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if constant.Role(user.Role) != constant.Owner {
+		err := errors.ErrAcessError.New("only the company owner can change the mfa policy")
+		c.log.Warn(ctx, "non-owner tried to change mfa policy", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return nil, err
+	}
+	if arg.Required && user.MFAEnabledAt.IsZero() {
+		err := errors.ErrInvalidUserInput.New("enable mfa on your own account first")
+		c.log.Warn(ctx, "owner without mfa tried to require it", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return nil, err
+	}
+	before, err := c.companyStorage.GetCompanyByID(ctx, user.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.companyStorage.SetCompanyMFARequired(ctx, user.CompanyID, arg.Required); err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditCompanyMFAPolicyUpdated)
+	event.Before = map[string]any{"mfa_required": before.MFARequired}
+	event.After = map[string]any{"mfa_required": arg.Required}
+	c.auditLog.Record(ctx, event)
+
+	return c.companyStorage.GetCompanyByID(ctx, user.CompanyID)
+}
+
+func (c *company) createMFAChallenge(ctx context.Context,
+	user *dto.User) (*dto.MFAChallenge, error) {
+	token, _, err := c.maker.CreatePasetoToken(hcrypto.UserData{
+		UserID:   user.ID.String(),
+		Email:    user.Email,
+		Provider: constant.Normal,
+	}, constant.MFAChallengeToken)
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to generate mfa token")
+		c.log.Error(ctx, "unable to generate mfa token", zap.Error(err))
+		return nil, err
+	}
+	payload, err := c.maker.VerifyPasetoToken(token)
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to read mfa token")
+		c.log.Error(ctx, "unable to read mfa token", zap.Error(err))
+		return nil, err
+	}
+
+	return &dto.MFAChallenge{
+		MFAToken:  token,
+		ExpiresAt: payload.ExpiresAt,
+	}, nil
+}
+
+// verifyMFACode accepts either a TOTP code or an unused recovery code.
+func (c *company) verifyMFACode(ctx context.Context, user *dto.User, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return c.verifyTOTP(ctx, user, code)
+	}
+
+	used, err := c.companyStorage.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		err := errors.ErrInvalidUserInput.New("invalid mfa code")
+		c.log.Warn(ctx, "invalid recovery code", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+	c.log.Info(ctx, "recovery code used", zap.String("user-id", user.ID.String()))
+
+	return nil
+}
+
+func (c *company) verifyTOTP(ctx context.Context, user *dto.User, code string) error {
+	step, ok := totp.Validate(user.MFASecret, code, time.Now(), totpSkew)
+	if !ok {
+		err := errors.ErrInvalidUserInput.New("invalid mfa code")
+		c.log.Warn(ctx, "invalid totp code", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+	fresh, err := c.companyStorage.UseMFAStep(ctx, user.ID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		err := errors.ErrInvalidUserInput.New("mfa code was already used")
+		c.log.Warn(ctx, "totp code replayed", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (c *company) getEnabledMFAUser(ctx context.Context, userID string) (*dto.User, error) {
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabledAt.IsZero() {
+		err := errors.ErrInvalidUserInput.New("mfa is not enabled")
+		c.log.Warn(ctx, "mfa is not enabled", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (c *company) getUser(ctx context.Context, userID string) (*dto.User, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "Invalid user id")
+		c.log.Error(ctx, "Invalid user id", zap.Error(err))
+		return nil, err
+	}
+
+	return c.companyStorage.GetUserByID(ctx, id)
+}
+
+// generateRecoveryCodes returns the codes to show the user, formatted as
+// xxxxx-xxxxx, together with the hashes to store.
+func generateRecoveryCodes() ([]string, []string) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := utils.GenerateCustomRandomString(utils.SmallLetters+utils.Digits, recoveryCodeLength)
+		code := raw[:recoveryCodeLength/2] + "-" + raw[recoveryCodeLength/2:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes
+}
+
+// hashRecoveryCode normalizes the code so that case and the separator do
+// not matter. Recovery codes carry enough entropy for a plain SHA-256.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,92 @@
+package company
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/platform/utils"
+
+	"go.uber.org/zap"
+)
+
+func (c *company) GetCompany(ctx context.Context, userID string) (*dto.Company, error) {
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.companyStorage.GetCompanyByID(ctx, user.CompanyID)
+}
+
+// UpdateCompany changes the company profile. Every changed field is recorded
+// in the audit log, so callback and return URL changes can be traced.
+func (c *company) UpdateCompany(ctx context.Context, userID string,
+	arg dto.UpdateCompany) (*dto.Company, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	for _, phone := range []*string{arg.PrimaryPhone, arg.SecondaryPhone} {
+		if phone == nil || *phone == "" {
+			continue
+		}
+		normalized, err := utils.ParsePhoneNumber(*phone)
+		if err != nil {
+			err = errors.ErrInvalidUserInput.Wrap(err, "failed to parse phone number")
+			c.log.Warn(ctx, "failed to parse phone number", zap.Error(err))
+			return nil, err
+		}
+		*phone = *normalized
+	}
+	user, err := c.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	before, err := c.companyStorage.GetCompanyByID(ctx, user.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := c.companyStorage.UpdateCompanyProfile(ctx, user.CompanyID, arg)
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditCompanyProfileUpdated)
+	event.Before, event.After = profileChanges(before, after)
+	if len(event.After) > 0 {
+		c.auditLog.Record(ctx, event)
+	}
+
+	return after, nil
+}
+
+// profileChanges returns the old and new values of the profile fields that
+// differ between two versions of a company.
+func profileChanges(before, after *dto.Company) (map[string]any, map[string]any) {
+	fields := []struct {
+		name          string
+		before, after string
+	}{
+		{"address_street", before.AddressStreet, after.AddressStreet},
+		{"address_city", before.AddressCity, after.AddressCity},
+		{"address_state", before.AddressState, after.AddressState},
+		{"address_postal_code", before.AddressPostalCode, after.AddressPostalCode},
+		{"address_country", before.AddressCountry, after.AddressCountry},
+		{"primary_phone", before.PrimaryPhone, after.PrimaryPhone},
+		{"secondary_phone", before.SecondaryPhone, after.SecondaryPhone},
+		{"website", before.Website, after.Website},
+		{"callback_url", before.CallBackURL, after.CallBackURL},
+		{"return_url", before.ReturnURL, after.ReturnURL},
+	}
+	old, changed := map[string]any{}, map[string]any{}
+	for _, field := range fields {
+		if field.before != field.after {
+			old[field.name] = field.before
+			changed[field.name] = field.after
+		}
+	}
+
+	return old, changed
+}
@@ -0,0 +1,225 @@
+package company
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/platform/hcrypto"
+	"pg/platform/notifier"
+	"pg/platform/utils"
+	"time"
+
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const verificationCodeLength = 6
+
+func (c *company) VerifyEmail(ctx context.Context,
+	arg dto.VerifyEmailRequest) (*dto.User, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	payload, err := c.maker.VerifyPasetoToken(arg.Token)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.Wrap(err, "invalid verification token")
+		c.log.Warn(ctx, "invalid verification token", zap.Error(err))
+		return nil, err
+	}
+	if payload.TokenType != constant.VerificationTokenType {
+		err := errors.ErrInvalidAccessToken.New("invalid verification token")
+		c.log.Warn(ctx, "token is not a verification token", zap.Error(err),
+			zap.String("token-type", string(payload.TokenType)))
+		return nil, err
+	}
+	verification, err := c.companyStorage.GetUserVerificationByTokenID(ctx, payload.TokenID)
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			return nil, errors.ErrInvalidAccessToken.New("invalid verification token")
+		}
+		return nil, err
+	}
+	if verification.Status != string(constant.Pending) ||
+		verification.UserID.String() != payload.UserID {
+		err := errors.ErrInvalidAccessToken.New("verification link is no longer valid")
+		c.log.Warn(ctx, "verification link is no longer valid", zap.Error(err),
+			zap.String("verification-id", verification.ID.String()))
+		return nil, err
+	}
+
+	return c.companyStorage.CompleteUserVerification(ctx, *verification)
+}
+
+func (c *company) VerifyPhone(ctx context.Context,
+	arg dto.VerifyPhoneRequest) (*dto.User, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	// Every failure below returns the same error so that the endpoint does
+	// not tell which phones are registered.
+	invalidCode := errors.ErrInvalidUserInput.New("invalid or expired verification code")
+	user, err := c.companyStorage.GetUserByPhoneOrEmail(ctx, arg.Phone)
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			return nil, invalidCode
+		}
+		return nil, err
+	}
+	verification, err := c.companyStorage.GetPendingUserVerification(ctx,
+		user.ID, string(notifier.ChannelSMS))
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			return nil, invalidCode
+		}
+		return nil, err
+	}
+	if verification.ExpiresAt.Before(time.Now()) {
+		c.log.Warn(ctx, "verification code expired",
+			zap.String("verification-id", verification.ID.String()))
+		return nil, invalidCode
+	}
+	// The attempt is counted before the code is compared, and refused once
+	// the code is exhausted, so concurrent guesses share the limit.
+	if _, err := c.companyStorage.IncrementVerificationAttempts(ctx, verification.ID,
+		c.options.MaxVerificationAttempts); err != nil {
+		if errorx.IsOfType(err, errors.ErrInvalidUserInput) {
+			return nil, invalidCode
+		}
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(verification.CodeHash),
+		[]byte(arg.Code)); err != nil {
+		c.log.Warn(ctx, "incorrect verification code",
+			zap.String("verification-id", verification.ID.String()))
+		return nil, invalidCode
+	}
+
+	return c.companyStorage.CompleteUserVerification(ctx, *verification)
+}
+
+// ResendVerification sends a fresh link and code for every contact of the
+// user that is still unverified. Like ForgotPassword it succeeds for
+// unknown accounts.
+func (c *company) ResendVerification(ctx context.Context,
+	arg dto.ResendVerificationRequest) error {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return err
+	}
+	user, err := c.companyStorage.GetUserByPhoneOrEmail(ctx, arg.PhoneOrEmail)
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			return nil
+		}
+		return err
+	}
+	if user.Status != string(constant.PendingVerification) {
+		c.log.Warn(ctx, "verification resend requested for verified user",
+			zap.String("user-id", user.ID.String()))
+		return nil
+	}
+
+	return c.sendVerifications(ctx, user)
+}
+
+func (c *company) sendVerifications(ctx context.Context, user *dto.User) error {
+	if user.EmailVerifiedAt.IsZero() {
+		if err := c.sendEmailVerification(ctx, user); err != nil {
+			return err
+		}
+	}
+	if user.PhoneVerifiedAt.IsZero() {
+		if err := c.sendPhoneVerification(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *company) sendEmailVerification(ctx context.Context, user *dto.User) error {
+	token, tokenID, err := c.maker.CreatePasetoToken(hcrypto.UserData{
+		UserID:    user.ID.String(),
+		Email:     user.Email,
+		IsNewUser: true,
+		Provider:  constant.Normal,
+	}, constant.VerificationTokenType)
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to generate verification token")
+		c.log.Error(ctx, "unable to generate verification token", zap.Error(err))
+		return err
+	}
+	payload, err := c.maker.VerifyPasetoToken(token)
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to read verification token")
+		c.log.Error(ctx, "unable to read verification token", zap.Error(err))
+		return err
+	}
+	if _, err := c.companyStorage.CreateUserVerification(ctx, dto.CreateUserVerification{
+		UserID:    user.ID,
+		Channel:   string(notifier.ChannelEmail),
+		TokenID:   tokenID,
+		ExpiresAt: payload.ExpiresAt,
+	}); err != nil {
+		return err
+	}
+
+	link := token
+	if c.options.EmailVerificationURL != "" {
+		link = c.options.EmailVerificationURL + "?token=" + url.QueryEscape(token)
+	}
+	if err := c.notifier.Send(ctx, notifier.Message{
+		Channel: notifier.ChannelEmail,
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body:    fmt.Sprintf("Use the following link to verify your email address: %s", link),
+	}); err != nil {
+		err = errors.ErrUnableToSendMail.Wrap(err, "unable to send email verification")
+		c.log.Error(ctx, "unable to send email verification", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (c *company) sendPhoneVerification(ctx context.Context, user *dto.User) error {
+	code := utils.GenerateCustomRandomString(utils.Digits, verificationCodeLength)
+	codeHash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		err = errors.ErrUnableToHashPassword.Wrap(err, "unable to hash verification code")
+		c.log.Error(ctx, "unable to hash verification code", zap.Error(err))
+		return err
+	}
+	if _, err := c.companyStorage.CreateUserVerification(ctx, dto.CreateUserVerification{
+		UserID:    user.ID,
+		Channel:   string(notifier.ChannelSMS),
+		CodeHash:  string(codeHash),
+		ExpiresAt: time.Now().Add(c.options.VerificationCodeExpires),
+	}); err != nil {
+		return err
+	}
+
+	if err := c.notifier.Send(ctx, notifier.Message{
+		Channel: notifier.ChannelSMS,
+		To:      user.Phone,
+		Body: fmt.Sprintf("Your verification code is %s. It expires in %d minutes.",
+			code, int(c.options.VerificationCodeExpires.Minutes())),
+	}); err != nil {
+		err = errors.ErrUnableToSendMail.Wrap(err, "unable to send phone verification")
+		c.log.Error(ctx, "unable to send phone verification", zap.Error(err),
+			zap.String("user-id", user.ID.String()))
+		return err
+	}
+
+	return nil
+}
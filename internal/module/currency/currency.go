@@ -0,0 +1,180 @@
+package currency
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+)
+
+type currency struct {
+	log             hlog.Logger
+	currencyStorage storage.Currency
+	companyStorage  storage.Company
+	operatorStorage storage.Operator
+	auditLog        module.Audit
+}
+
+func New(currencyStorage storage.Currency,
+	companyStorage storage.Company,
+	operatorStorage storage.Operator,
+	auditLog module.Audit,
+	log hlog.Logger) module.Currency {
+	return &currency{
+		log:             log,
+		currencyStorage: currencyStorage,
+		companyStorage:  companyStorage,
+		operatorStorage: operatorStorage,
+		auditLog:        auditLog,
+	}
+}
+
+// ListCompanyCurrencies returns every registered currency and whether the
+// company can create payment intents in it.
+func (c *currency) ListCompanyCurrencies(ctx context.Context, companyID string) ([]dto.Currency, error) {
+	id, err := c.parseID(ctx, companyID, "company")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.companyStorage.GetCompanyByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return c.currencyStorage.ListCompanyCurrencies(ctx, id)
+}
+
+// SetCompanyCurrency enables or disables a currency for one company,
+// overriding the registry default.
+func (c *currency) SetCompanyCurrency(ctx context.Context, operatorID, companyID, code string,
+	param dto.SetCompanyCurrency) (*dto.Currency, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	opID, err := c.parseID(ctx, operatorID, "operator")
+	if err != nil {
+		return nil, err
+	}
+	operator, err := c.operatorStorage.GetOperatorByID(ctx, opID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := c.parseID(ctx, companyID, "company")
+	if err != nil {
+		return nil, err
+	}
+	company, err := c.companyStorage.GetCompanyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before, err := c.currencyStorage.GetCompanyCurrency(ctx, company.ID,
+		constant.Currency(strings.ToUpper(code)))
+	if err != nil {
+		return nil, err
+	}
+
+	param.CompanyID, param.Code, param.UpdatedBy = company.ID, before.Code, operator.ID
+	if err := c.currencyStorage.SetCompanyCurrency(ctx, param); err != nil {
+		return nil, err
+	}
+	after := *before
+	after.Enabled = *param.Enabled
+
+	event := dto.OperatorAuditEvent(*operator, company.ID, constant.AuditCompanyCurrencyUpdated)
+	event.Before = map[string]any{"currency": before.Code, "enabled": before.Enabled}
+	event.After = map[string]any{"currency": after.Code, "enabled": after.Enabled}
+	c.auditLog.Record(ctx, event)
+
+	return &after, nil
+}
+
+// GetFXSettings returns the currency the company is paid out in and the
+// spread taken when converting to it.
+func (c *currency) GetFXSettings(ctx context.Context, companyID string) (*dto.CompanyFXSettings, error) {
+	id, err := c.parseID(ctx, companyID, "company")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.companyStorage.GetCompanyByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return c.currencyStorage.GetCompanyFXSettings(ctx, id)
+}
+
+// SetFXSettings changes how a company is paid out. It applies to payment
+// intents created afterwards; existing ones keep their rate snapshot.
+func (c *currency) SetFXSettings(ctx context.Context, operatorID, companyID string,
+	param dto.SetCompanyFXSettings) (*dto.CompanyFXSettings, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	opID, err := c.parseID(ctx, operatorID, "operator")
+	if err != nil {
+		return nil, err
+	}
+	operator, err := c.operatorStorage.GetOperatorByID(ctx, opID)
+	if err != nil {
+		return nil, err
+	}
+	before, err := c.GetFXSettings(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if param.SettlementCurrency != "" {
+		// Any registered currency can be settled in; enabling it for
+		// payment intents is a separate decision.
+		settlement, err := c.currencyStorage.GetCompanyCurrency(ctx, before.CompanyID,
+			constant.Currency(strings.ToUpper(param.SettlementCurrency)))
+		if err != nil {
+			if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+				err = errors.ErrInvalidUserInput.New("currency %s is not supported",
+					param.SettlementCurrency)
+				c.log.Warn(ctx, "unsupported settlement currency", zap.Error(err))
+			}
+			return nil, err
+		}
+		param.SettlementCurrency = string(settlement.Code)
+	}
+
+	param.CompanyID, param.UpdatedBy = before.CompanyID, operator.ID
+	after, err := c.currencyStorage.SetCompanyFXSettings(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	event := dto.OperatorAuditEvent(*operator, after.CompanyID, constant.AuditCompanyFXSettingsUpdated)
+	event.Before = map[string]any{
+		"settlement_currency": before.SettlementCurrency,
+		"spread_bps":          before.SpreadBps,
+	}
+	event.After = map[string]any{
+		"settlement_currency": after.SettlementCurrency,
+		"spread_bps":          after.SpreadBps,
+	}
+	c.auditLog.Record(ctx, event)
+
+	return after, nil
+}
+
+func (c *currency) parseID(ctx context.Context, value, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid %s id", name)
+		c.log.Warn(ctx, "invalid "+name+" id", zap.Error(err), zap.String("id", value))
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,330 @@
+package customer
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+)
+
+const defaultPerPage = 50
+
+type Options struct {
+	// RetentionPeriod erases customers with no activity for this long. Zero
+	// disables the retention job.
+	RetentionPeriod time.Duration
+	// RetentionInterval is how often the retention job runs, daily by
+	// default.
+	RetentionInterval time.Duration
+	// RetentionBatchSize is how many customers are erased per query, 500 by
+	// default.
+	RetentionBatchSize int
+}
+
+type customer struct {
+	log             hlog.Logger
+	customerStorage storage.Customer
+	auditLog        module.Audit
+	options         Options
+}
+
+func New(customerStorage storage.Customer, auditLog module.Audit, log hlog.Logger,
+	options Options) module.Customer {
+	if options.RetentionInterval <= 0 {
+		options.RetentionInterval = 24 * time.Hour
+	}
+	if options.RetentionBatchSize <= 0 {
+		options.RetentionBatchSize = 500
+	}
+	return &customer{
+		log:             log,
+		customerStorage: customerStorage,
+		auditLog:        auditLog,
+		options:         options,
+	}
+}
+
+func (c *customer) CreateCustomer(ctx context.Context, companyID string, livemode bool,
+	param dto.CreateCustomer) (*dto.Customer, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	cID, err := c.parseID(ctx, companyID, "company")
+	if err != nil {
+		return nil, err
+	}
+	phone, err := c.normalizePhone(ctx, param.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	param.CompanyID = cID
+	param.Livemode = livemode
+	param.PhoneNumber = phone
+	param.FullName = strings.TrimSpace(param.FullName)
+
+	return c.customerStorage.CreateCustomer(ctx, param)
+}
+
+func (c *customer) GetCustomer(ctx context.Context, companyID string, livemode bool,
+	customerID string) (*dto.Customer, error) {
+	cID, err := c.parseID(ctx, companyID, "company")
+	if err != nil {
+		return nil, err
+	}
+	id, err := c.parseID(ctx, customerID, "customer")
+	if err != nil {
+		return nil, err
+	}
+
+	return c.customerStorage.GetCustomer(ctx, cID, livemode, id)
+}
+
+func (c *customer) ListCustomers(ctx context.Context, companyID string, livemode bool,
+	filter dto.CustomerFilter) ([]dto.Customer, int, error) {
+	if err := filter.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid filter")
+		c.log.Warn(ctx, "invalid customer filter", zap.Error(err))
+		return nil, 0, err
+	}
+	cID, err := c.parseID(ctx, companyID, "company")
+	if err != nil {
+		return nil, 0, err
+	}
+	phone := strings.TrimSpace(filter.PhoneNumber)
+	if phone != "" {
+		if phone, err = c.normalizePhone(ctx, phone); err != nil {
+			return nil, 0, err
+		}
+	}
+	page, perPage := pagination(filter.Page, filter.PerPage)
+
+	return c.customerStorage.ListCustomers(ctx, dto.CustomerQuery{
+		CompanyID:   cID,
+		Livemode:    livemode,
+		PhoneNumber: phone,
+		Limit:       perPage,
+		Offset:      (page - 1) * perPage,
+	})
+}
+
+func (c *customer) UpdateCustomer(ctx context.Context, companyID string, livemode bool,
+	customerID string, param dto.UpdateCustomer) (*dto.Customer, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		c.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	cID, err := c.parseID(ctx, companyID, "company")
+	if err != nil {
+		return nil, err
+	}
+	id, err := c.parseID(ctx, customerID, "customer")
+	if err != nil {
+		return nil, err
+	}
+	if param.PhoneNumber != nil {
+		phone, err := c.normalizePhone(ctx, *param.PhoneNumber)
+		if err != nil {
+			return nil, err
+		}
+		param.PhoneNumber = &phone
+	}
+	if param.FullName != nil {
+		name := strings.TrimSpace(*param.FullName)
+		param.FullName = &name
+	}
+
+	return c.customerStorage.UpdateCustomer(ctx, cID, livemode, id, param)
+}
+
+// DeleteCustomer hides the customer from the API. Its payment intents are
+// kept, and a new customer may reuse the phone number.
+func (c *customer) DeleteCustomer(ctx context.Context, companyID string, livemode bool,
+	customerID string) error {
+	cID, err := c.parseID(ctx, companyID, "company")
+	if err != nil {
+		return err
+	}
+	id, err := c.parseID(ctx, customerID, "customer")
+	if err != nil {
+		return err
+	}
+
+	return c.customerStorage.DeleteCustomer(ctx, cID, livemode, id)
+}
+
+// EraseCustomer anonymizes the customer: its personal data is cleared and
+// it is deleted, but its payment intents are kept for accounting. The
+// returned certificate is also written to the audit trail.
+func (c *customer) EraseCustomer(ctx context.Context, companyID string, livemode bool,
+	customerID string) (*dto.CustomerErasure, error) {
+	cID, err := c.parseID(ctx, companyID, "company")
+	if err != nil {
+		return nil, err
+	}
+	id, err := c.parseID(ctx, customerID, "customer")
+	if err != nil {
+		return nil, err
+	}
+
+	erasure, err := c.erase(ctx, dto.EraseCustomer{
+		ID:        id,
+		CompanyID: cID,
+		Livemode:  livemode,
+	}, constant.ErasureRequested, constant.AuditActorCompany, cID)
+	if err != nil {
+		return nil, err
+	}
+
+	return erasure, nil
+}
+
+// EraseInactiveCustomers erases every customer with no activity during the
+// retention period and returns how many were erased.
+func (c *customer) EraseInactiveCustomers(ctx context.Context) (int, error) {
+	if c.options.RetentionPeriod <= 0 {
+		return 0, nil
+	}
+	inactiveBefore := time.Now().Add(-c.options.RetentionPeriod)
+
+	total := 0
+	for {
+		customers, err := c.customerStorage.ListInactiveCustomers(ctx, inactiveBefore,
+			c.options.RetentionBatchSize)
+		if err != nil {
+			return total, err
+		}
+		if len(customers) == 0 {
+			return total, nil
+		}
+		for _, param := range customers {
+			_, err := c.erase(ctx, param, constant.ErasureRetention,
+				constant.AuditActorSystem, uuid.Nil)
+			// A customer active again since it was listed is skipped.
+			if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+				continue
+			}
+			if err != nil {
+				return total, err
+			}
+			total++
+		}
+	}
+}
+
+// StartRetentionWorker runs EraseInactiveCustomers every RetentionInterval
+// until ctx is done. It does nothing without a retention period.
+func (c *customer) StartRetentionWorker(ctx context.Context) {
+	if c.options.RetentionPeriod <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.options.RetentionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			erased, err := c.EraseInactiveCustomers(ctx)
+			if err != nil {
+				c.log.Error(ctx, "unable to erase inactive customers", zap.Error(err),
+					zap.Int("erased", erased))
+				continue
+			}
+			if erased > 0 {
+				c.log.Info(ctx, "erased inactive customers", zap.Int("count", erased))
+			}
+		}
+	}
+}
+
+func (c *customer) erase(ctx context.Context, param dto.EraseCustomer,
+	reason constant.ErasureReason, actorType constant.AuditActorType,
+	actorID uuid.UUID) (*dto.CustomerErasure, error) {
+	erasure, err := c.customerStorage.EraseCustomer(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	erasure.CertificateID = uuid.New()
+	erasure.Reason = reason
+
+	c.auditLog.Record(ctx, dto.CreateAuditEvent{
+		CompanyID: erasure.CompanyID,
+		ActorType: actorType,
+		ActorID:   actorID,
+		Action:    constant.AuditCustomerErased,
+		Metadata: map[string]any{
+			"certificate_id":           erasure.CertificateID,
+			"customer_id":              erasure.CustomerID,
+			"livemode":                 erasure.Livemode,
+			"reason":                   erasure.Reason,
+			"erased_fields":            erasure.ErasedFields,
+			"retained_payment_intents": erasure.RetainedPaymentIntents,
+			"erased_at":                erasure.ErasedAt,
+		},
+	})
+
+	return erasure, nil
+}
+
+func (c *customer) ListPaymentIntents(ctx context.Context, companyID string, livemode bool,
+	customerID string, param dto.Page) ([]dto.PaymentIntent, int, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid page")
+		c.log.Warn(ctx, "invalid page", zap.Error(err))
+		return nil, 0, err
+	}
+	customer, err := c.GetCustomer(ctx, companyID, livemode, customerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	page, perPage := pagination(param.Page, param.PerPage)
+
+	return c.customerStorage.ListCustomerPaymentIntents(ctx, customer.CompanyID, livemode,
+		customer.ID, perPage, (page-1)*perPage)
+}
+
+func (c *customer) normalizePhone(ctx context.Context, phone string) (string, error) {
+	normalized, err := utils.ParsePhoneNumber(phone)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "failed to parse phone number")
+		c.log.Warn(ctx, "failed to parse phone number", zap.Error(err))
+		return "", err
+	}
+
+	return *normalized, nil
+}
+
+func (c *customer) parseID(ctx context.Context, value, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid %s id", name)
+		c.log.Warn(ctx, "invalid "+name+" id", zap.Error(err), zap.String("id", value))
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+func pagination(page, perPage int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+
+	return page, perPage
+}
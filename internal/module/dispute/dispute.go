@@ -0,0 +1,511 @@
+package dispute
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/blobstore"
+	"pg/platform/hlog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultPerPage = 50
+
+// allowedContentTypes are the sniffed types an evidence file may have.
+var allowedContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+type Options struct {
+	// MaxEvidenceBytes is the largest evidence file accepted, 10 MiB by
+	// default.
+	MaxEvidenceBytes int64
+}
+
+type dispute struct {
+	log                  hlog.Logger
+	disputeStorage       storage.Dispute
+	paymentIntentStorage storage.PaymentIntent
+	companyStorage       storage.Company
+	operatorStorage      storage.Operator
+	blobStore            blobstore.Store
+	auditLog             module.Audit
+	options              Options
+}
+
+func New(disputeStorage storage.Dispute,
+	paymentIntentStorage storage.PaymentIntent,
+	companyStorage storage.Company,
+	operatorStorage storage.Operator,
+	blobStore blobstore.Store,
+	auditLog module.Audit,
+	log hlog.Logger,
+	options Options) module.Dispute {
+	if options.MaxEvidenceBytes <= 0 {
+		options.MaxEvidenceBytes = constant.DefaultMaxUploadBytes
+	}
+	return &dispute{
+		log:                  log,
+		disputeStorage:       disputeStorage,
+		paymentIntentStorage: paymentIntentStorage,
+		companyStorage:       companyStorage,
+		operatorStorage:      operatorStorage,
+		blobStore:            blobStore,
+		auditLog:             auditLog,
+		options:              options,
+	}
+}
+
+// ListCompanyDisputes lists the disputes of the user's company, newest
+// first.
+func (d *dispute) ListCompanyDisputes(ctx context.Context, userID string,
+	filter dto.DisputeFilter) ([]dto.Dispute, int, error) {
+	user, err := d.getUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.CompanyID = ""
+	query, err := d.disputeQuery(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	query.CompanyID = user.CompanyID
+
+	return d.disputeStorage.ListDisputes(ctx, query)
+}
+
+func (d *dispute) GetCompanyDispute(ctx context.Context, userID,
+	disputeID string) (*dto.Dispute, error) {
+	user, err := d.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.companyDispute(ctx, user.CompanyID, disputeID)
+}
+
+// UpdateEvidence replaces the written evidence of a dispute that still
+// needs a response.
+func (d *dispute) UpdateEvidence(ctx context.Context, userID, disputeID string,
+	param dto.DisputeEvidence) (*dto.Dispute, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		d.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	user, err := d.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := d.openForEvidence(ctx, user.CompanyID, disputeID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := d.disputeStorage.UpdateDisputeEvidence(ctx, current.ID,
+		strings.TrimSpace(param.Text))
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditDisputeEvidenceUpdated)
+	event.Metadata = map[string]any{"dispute_id": current.ID}
+	d.auditLog.Record(ctx, event)
+
+	return d.disputeStorage.GetDispute(ctx, updated.ID)
+}
+
+// UploadEvidence attaches a file to a dispute that still needs a response.
+func (d *dispute) UploadEvidence(ctx context.Context, userID, disputeID string,
+	param dto.UploadDisputeEvidence) (*dto.DisputeEvidenceFile, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		d.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	if param.Size > d.options.MaxEvidenceBytes {
+		err := errors.ErrUnableToUploadFile.New("file is larger than %d bytes",
+			d.options.MaxEvidenceBytes)
+		d.log.Warn(ctx, "dispute evidence too large", zap.Error(err),
+			zap.Int64("size", param.Size))
+		return nil, err
+	}
+	user, err := d.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := d.openForEvidence(ctx, user.CompanyID, disputeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read one byte past the limit so that a body larger than its declared
+	// size is still refused.
+	content, err := io.ReadAll(io.LimitReader(param.File, d.options.MaxEvidenceBytes+1))
+	if err != nil {
+		err = errors.ErrUnableToUploadFile.Wrap(err, "unable to read file")
+		d.log.Warn(ctx, "unable to read dispute evidence", zap.Error(err))
+		return nil, err
+	}
+	if int64(len(content)) > d.options.MaxEvidenceBytes {
+		err := errors.ErrUnableToUploadFile.New("file is larger than %d bytes",
+			d.options.MaxEvidenceBytes)
+		d.log.Warn(ctx, "dispute evidence too large", zap.Error(err))
+		return nil, err
+	}
+	if len(content) == 0 {
+		err := errors.ErrUnableToUploadFile.New("file is empty")
+		d.log.Warn(ctx, "empty dispute evidence", zap.Error(err))
+		return nil, err
+	}
+	contentType, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	if !slices.Contains(allowedContentTypes, contentType) {
+		err := errors.ErrUnableToUploadFile.New("file must be a PDF, JPEG or PNG file")
+		d.log.Warn(ctx, "unsupported dispute evidence type", zap.Error(err),
+			zap.String("content-type", contentType))
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+
+	id := uuid.New()
+	key := fmt.Sprintf("disputes/%s/%s/%s", user.CompanyID, current.ID, id)
+	if err := d.blobStore.Put(ctx, key, bytes.NewReader(content)); err != nil {
+		err = errors.ErrUnableToUploadFile.Wrap(err, "unable to store file")
+		d.log.Error(ctx, "unable to store dispute evidence", zap.Error(err),
+			zap.String("dispute-id", current.ID.String()))
+		return nil, err
+	}
+	file, err := d.disputeStorage.CreateDisputeEvidenceFile(ctx, dto.CreateDisputeEvidenceFile{
+		ID:          id,
+		DisputeID:   current.ID,
+		FileName:    path.Base(strings.ReplaceAll(param.FileName, "\\", "/")),
+		ContentType: contentType,
+		SizeBytes:   int64(len(content)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		StorageKey:  key,
+		UploadedBy:  user.ID,
+	})
+	if err != nil {
+		if err := d.blobStore.Delete(ctx, key); err != nil {
+			d.log.Error(ctx, "unable to remove dispute evidence blob", zap.Error(err),
+				zap.String("key", key))
+		}
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditDisputeEvidenceUploaded)
+	event.Metadata = map[string]any{
+		"dispute_id": current.ID,
+		"file_id":    file.ID,
+		"sha256":     file.SHA256,
+	}
+	d.auditLog.Record(ctx, event)
+
+	return file, nil
+}
+
+// Submit sends the evidence for review. A dispute can be submitted once,
+// before its deadline, with written evidence or at least one file.
+func (d *dispute) Submit(ctx context.Context, userID, disputeID string) (*dto.Dispute, error) {
+	user, err := d.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := d.openForEvidence(ctx, user.CompanyID, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if current.EvidenceText == "" && len(current.Files) == 0 {
+		err := errors.ErrInvalidUserInput.New("add evidence before submitting the dispute")
+		d.log.Warn(ctx, "dispute submitted without evidence", zap.Error(err),
+			zap.String("dispute-id", disputeID))
+		return nil, err
+	}
+
+	if _, err := d.disputeStorage.SubmitDispute(ctx, current.ID, user.ID); err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditDisputeSubmitted)
+	event.Metadata = map[string]any{"dispute_id": current.ID}
+	event.Before = map[string]any{"status": current.Status}
+	event.After = map[string]any{"status": constant.UnderReview}
+	d.auditLog.Record(ctx, event)
+
+	return d.disputeStorage.GetDispute(ctx, current.ID)
+}
+
+func (d *dispute) ListDisputes(ctx context.Context,
+	filter dto.DisputeFilter) ([]dto.Dispute, int, error) {
+	query, err := d.disputeQuery(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return d.disputeStorage.ListDisputes(ctx, query)
+}
+
+func (d *dispute) GetDispute(ctx context.Context, disputeID string) (*dto.Dispute, error) {
+	id, err := d.parseID(ctx, disputeID, "dispute")
+	if err != nil {
+		return nil, err
+	}
+
+	return d.disputeStorage.GetDispute(ctx, id)
+}
+
+// OpenDispute records a dispute the processor reported outside the API
+// against a successful payment intent.
+func (d *dispute) OpenDispute(ctx context.Context, operatorID string,
+	param dto.OpenDispute) (*dto.Dispute, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		d.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	operator, err := d.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := d.parseID(ctx, param.PaymentIntentID, "payment intent")
+	if err != nil {
+		return nil, err
+	}
+	paymentIntent, err := d.paymentIntentStorage.GetPaymentIntentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if paymentIntent.Status != constant.Success {
+		err := errors.ErrInvalidUserInput.New("payment intent is %s, only successful payments can be disputed",
+			paymentIntent.Status)
+		d.log.Warn(ctx, "dispute opened on unsuccessful payment intent", zap.Error(err),
+			zap.String("payment-intent-id", param.PaymentIntentID))
+		return nil, err
+	}
+	dueBy := param.EvidenceDueBy
+	if dueBy.IsZero() {
+		dueBy = time.Now().Add(dto.DefaultDisputeEvidenceWindow)
+	}
+
+	created, err := d.disputeStorage.CreateDispute(ctx, dto.CreateDispute{
+		CompanyID:         paymentIntent.Company.ID,
+		PaymentIntentID:   paymentIntent.ID,
+		Livemode:          paymentIntent.Livemode,
+		Amount:            paymentIntent.Amount,
+		Currency:          paymentIntent.Currency,
+		Reason:            param.Reason,
+		EvidenceDueBy:     dueBy,
+		CreatedByOperator: operator.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	event := dto.OperatorAuditEvent(*operator, created.CompanyID, constant.AuditDisputeCreated)
+	event.Metadata = map[string]any{
+		"dispute_id":        created.ID,
+		"payment_intent_id": created.PaymentIntentID,
+		"reason":            created.Reason,
+	}
+	d.auditLog.Record(ctx, event)
+
+	return created, nil
+}
+
+// ResolveDispute closes a dispute under review, or one the merchant did not
+// answer in time, as WON or LOST. A lost dispute reverses the payment in
+// the company's ledger.
+func (d *dispute) ResolveDispute(ctx context.Context, operatorID, disputeID string,
+	param dto.ResolveDispute) (*dto.Dispute, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		d.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	operator, err := d.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := d.GetDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if !resolvable(*current, time.Now()) {
+		err := errors.ErrInvalidUserInput.New("dispute is %s and can only be resolved under review or after its evidence deadline",
+			current.Status)
+		d.log.Warn(ctx, "dispute resolved in the wrong status", zap.Error(err),
+			zap.String("dispute-id", disputeID))
+		return nil, err
+	}
+
+	note := strings.TrimSpace(param.Note)
+	if _, err := d.disputeStorage.ResolveDispute(ctx, current.ID, operator.ID,
+		dto.ResolveDispute{Status: param.Status, Note: note}); err != nil {
+		return nil, err
+	}
+	event := dto.OperatorAuditEvent(*operator, current.CompanyID, constant.AuditDisputeResolved)
+	event.Metadata = map[string]any{"dispute_id": current.ID, "note": note}
+	event.Before = map[string]any{"status": current.Status}
+	event.After = map[string]any{"status": param.Status}
+	d.auditLog.Record(ctx, event)
+
+	return d.disputeStorage.GetDispute(ctx, current.ID)
+}
+
+// OpenEvidence returns an evidence file and its content for an operator to
+// review. The caller must close the reader.
+func (d *dispute) OpenEvidence(ctx context.Context, disputeID,
+	fileID string) (*dto.DisputeEvidenceFile, io.ReadCloser, error) {
+	dID, err := d.parseID(ctx, disputeID, "dispute")
+	if err != nil {
+		return nil, nil, err
+	}
+	fID, err := d.parseID(ctx, fileID, "file")
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := d.disputeStorage.GetDisputeEvidenceFile(ctx, dID, fID)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := d.blobStore.Get(ctx, file.StorageKey)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to read file")
+		d.log.Error(ctx, "unable to read dispute evidence", zap.Error(err),
+			zap.String("file-id", fileID))
+		return nil, nil, err
+	}
+
+	return file, content, nil
+}
+
+// companyDispute returns a dispute of the company. A dispute of another
+// company is reported as not found.
+func (d *dispute) companyDispute(ctx context.Context, companyID uuid.UUID,
+	disputeID string) (*dto.Dispute, error) {
+	current, err := d.GetDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if current.CompanyID != companyID {
+		err := errors.ErrNoRecordFound.New("dispute not found")
+		d.log.Warn(ctx, "dispute belongs to another company", zap.Error(err),
+			zap.String("dispute-id", disputeID))
+		return nil, err
+	}
+
+	return current, nil
+}
+
+// openForEvidence returns a dispute of the company that still takes
+// evidence: one that needs a response and whose deadline has not passed.
+func (d *dispute) openForEvidence(ctx context.Context, companyID uuid.UUID,
+	disputeID string) (*dto.Dispute, error) {
+	current, err := d.companyDispute(ctx, companyID, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := takesEvidence(*current, time.Now()); err != nil {
+		d.log.Warn(ctx, "dispute evidence changed when it is closed", zap.Error(err),
+			zap.String("dispute-id", disputeID))
+		return nil, err
+	}
+
+	return current, nil
+}
+
+// resolvable reports whether an operator may resolve a dispute at now:
+// once it is under review, or once the merchant let its evidence deadline
+// pass without answering.
+func resolvable(dispute dto.Dispute, now time.Time) bool {
+	switch dispute.Status {
+	case constant.UnderReview:
+		return true
+	case constant.NeedsResponse:
+		return !dispute.EvidenceDueBy.After(now)
+	default:
+		return false
+	}
+}
+
+// takesEvidence fails unless the merchant may still change the evidence of
+// a dispute at now: it needs a response and its deadline has not passed.
+func takesEvidence(dispute dto.Dispute, now time.Time) error {
+	if dispute.Status != constant.NeedsResponse {
+		return errors.ErrInvalidUserInput.New("dispute is %s and no longer takes evidence",
+			dispute.Status)
+	}
+	if !dispute.EvidenceDueBy.After(now) {
+		return errors.ErrInvalidUserInput.New("the evidence deadline of this dispute has passed")
+	}
+
+	return nil
+}
+
+func (d *dispute) disputeQuery(ctx context.Context,
+	filter dto.DisputeFilter) (dto.DisputeQuery, error) {
+	if err := filter.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid filter")
+		d.log.Warn(ctx, "invalid dispute filter", zap.Error(err))
+		return dto.DisputeQuery{}, err
+	}
+	query := dto.DisputeQuery{Status: filter.Status}
+	if filter.CompanyID != "" {
+		query.CompanyID = uuid.MustParse(filter.CompanyID)
+	}
+	query.Limit, query.Offset = page(filter.Page, filter.PerPage)
+
+	return query, nil
+}
+
+func (d *dispute) getUser(ctx context.Context, userID string) (*dto.User, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid user id")
+		d.log.Error(ctx, "invalid user id", zap.Error(err))
+		return nil, err
+	}
+
+	return d.companyStorage.GetUserByID(ctx, id)
+}
+
+func (d *dispute) getOperator(ctx context.Context, operatorID string) (*dto.Operator, error) {
+	id, err := uuid.Parse(operatorID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid operator id")
+		d.log.Error(ctx, "invalid operator id", zap.Error(err))
+		return nil, err
+	}
+
+	return d.operatorStorage.GetOperatorByID(ctx, id)
+}
+
+func (d *dispute) parseID(ctx context.Context, value, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid %s id", name)
+		d.log.Warn(ctx, "invalid "+name+" id", zap.Error(err))
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+func page(page, perPage int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	return perPage, (page - 1) * perPage
+}
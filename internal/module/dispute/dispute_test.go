@@ -0,0 +1,55 @@
+package dispute
+
+import (
+	"pg/internal/constant"
+	"pg/internal/constant/model/dto"
+	"testing"
+	"time"
+)
+
+func TestResolvable(t *testing.T) {
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name   string
+		status constant.Status
+		dueBy  time.Time
+		want   bool
+	}{
+		{"under review", constant.UnderReview, now.Add(time.Hour), true},
+		{"under review after deadline", constant.UnderReview, now.Add(-time.Hour), true},
+		{"needs response before deadline", constant.NeedsResponse, now.Add(time.Hour), false},
+		{"needs response at deadline", constant.NeedsResponse, now, true},
+		{"needs response after deadline", constant.NeedsResponse, now.Add(-time.Hour), true},
+		{"won", constant.Won, now.Add(-time.Hour), false},
+		{"lost", constant.Lost, now.Add(-time.Hour), false},
+	}
+	for _, c := range cases {
+		dispute := dto.Dispute{Status: c.status, EvidenceDueBy: c.dueBy}
+		if got := resolvable(dispute, now); got != c.want {
+			t.Errorf("%s: resolvable = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestTakesEvidence(t *testing.T) {
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name   string
+		status constant.Status
+		dueBy  time.Time
+		ok     bool
+	}{
+		{"needs response before deadline", constant.NeedsResponse, now.Add(time.Hour), true},
+		{"needs response at deadline", constant.NeedsResponse, now, false},
+		{"needs response after deadline", constant.NeedsResponse, now.Add(-time.Hour), false},
+		{"under review", constant.UnderReview, now.Add(time.Hour), false},
+		{"won", constant.Won, now.Add(time.Hour), false},
+		{"lost", constant.Lost, now.Add(time.Hour), false},
+	}
+	for _, c := range cases {
+		dispute := dto.Dispute{Status: c.status, EvidenceDueBy: c.dueBy}
+		if err := takesEvidence(dispute, now); (err == nil) != c.ok {
+			t.Errorf("%s: takesEvidence = %v, want ok %v", c.name, err, c.ok)
+		}
+	}
+}
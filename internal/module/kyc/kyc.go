@@ -0,0 +1,419 @@
+package kyc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/blobstore"
+	"pg/platform/hlog"
+	"pg/platform/notifier"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// allowedContentTypes are the sniffed types a KYC document may have.
+var allowedContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+type Options struct {
+	// MaxDocumentBytes is the largest document accepted, 10 MiB by default.
+	MaxDocumentBytes int64
+}
+
+type kyc struct {
+	log             hlog.Logger
+	kycStorage      storage.KYC
+	companyStorage  storage.Company
+	operatorStorage storage.Operator
+	blobStore       blobstore.Store
+	auditLog        module.Audit
+	notifier        notifier.Notifier
+	options         Options
+}
+
+func New(kycStorage storage.KYC,
+	companyStorage storage.Company,
+	operatorStorage storage.Operator,
+	blobStore blobstore.Store,
+	auditLog module.Audit,
+	log hlog.Logger,
+	notifier notifier.Notifier,
+	options Options) module.KYC {
+	if options.MaxDocumentBytes <= 0 {
+		options.MaxDocumentBytes = constant.DefaultMaxUploadBytes
+	}
+	return &kyc{
+		log:             log,
+		kycStorage:      kycStorage,
+		companyStorage:  companyStorage,
+		operatorStorage: operatorStorage,
+		blobStore:       blobStore,
+		auditLog:        auditLog,
+		notifier:        notifier,
+		options:         options,
+	}
+}
+
+func (k *kyc) GetKYC(ctx context.Context, userID string) (*dto.KYCStatus, error) {
+	user, err := k.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.getStatus(ctx, user.CompanyID)
+}
+
+// UploadDocument stores a document for the user's company. Documents can only
+// be changed before submission or after a rejection.
+func (k *kyc) UploadDocument(ctx context.Context, userID string,
+	param dto.UploadKYCDocument) (*dto.KYCDocument, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		k.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	if param.Size > k.options.MaxDocumentBytes {
+		err := errors.ErrUnableToUploadFile.New("document is larger than %d bytes",
+			k.options.MaxDocumentBytes)
+		k.log.Warn(ctx, "kyc document too large", zap.Error(err),
+			zap.Int64("size", param.Size))
+		return nil, err
+	}
+	user, err := k.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.checkEditable(ctx, user.CompanyID); err != nil {
+		return nil, err
+	}
+
+	// Read one byte past the limit so that a body larger than its declared
+	// size is still refused.
+	content, err := io.ReadAll(io.LimitReader(param.File, k.options.MaxDocumentBytes+1))
+	if err != nil {
+		err = errors.ErrUnableToUploadFile.Wrap(err, "unable to read document")
+		k.log.Warn(ctx, "unable to read kyc document", zap.Error(err))
+		return nil, err
+	}
+	if int64(len(content)) > k.options.MaxDocumentBytes {
+		err := errors.ErrUnableToUploadFile.New("document is larger than %d bytes",
+			k.options.MaxDocumentBytes)
+		k.log.Warn(ctx, "kyc document too large", zap.Error(err))
+		return nil, err
+	}
+	if len(content) == 0 {
+		err := errors.ErrUnableToUploadFile.New("document is empty")
+		k.log.Warn(ctx, "empty kyc document", zap.Error(err))
+		return nil, err
+	}
+	// The declared content type comes from the client, so the type is
+	// taken from the file itself.
+	contentType, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	if !slices.Contains(allowedContentTypes, contentType) {
+		err := errors.ErrUnableToUploadFile.New("document must be a PDF, JPEG or PNG file")
+		k.log.Warn(ctx, "unsupported kyc document type", zap.Error(err),
+			zap.String("content-type", contentType))
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+
+	id := uuid.New()
+	key := fmt.Sprintf("kyc/%s/%s", user.CompanyID, id)
+	if err := k.blobStore.Put(ctx, key, bytes.NewReader(content)); err != nil {
+		err = errors.ErrUnableToUploadFile.Wrap(err, "unable to store document")
+		k.log.Error(ctx, "unable to store kyc document", zap.Error(err),
+			zap.String("company-id", user.CompanyID.String()))
+		return nil, err
+	}
+	document, err := k.kycStorage.CreateKYCDocument(ctx, dto.CreateKYCDocument{
+		ID:           id,
+		CompanyID:    user.CompanyID,
+		DocumentType: param.DocumentType,
+		FileName:     path.Base(strings.ReplaceAll(param.FileName, "\\", "/")),
+		ContentType:  contentType,
+		SizeBytes:    int64(len(content)),
+		SHA256:       hex.EncodeToString(sum[:]),
+		StorageKey:   key,
+		UploadedBy:   user.ID,
+	})
+	if err != nil {
+		k.removeBlob(ctx, key)
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditKYCDocumentUploaded)
+	event.Metadata = map[string]any{
+		"document_id":   document.ID,
+		"document_type": document.DocumentType,
+		"sha256":        document.SHA256,
+	}
+	k.auditLog.Record(ctx, event)
+
+	return document, nil
+}
+
+func (k *kyc) DeleteDocument(ctx context.Context, userID, documentID string) error {
+	user, err := k.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	document, err := k.getDocument(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	if document.CompanyID != user.CompanyID {
+		err := errors.ErrNoRecordFound.New("kyc document not found")
+		k.log.Warn(ctx, "kyc document belongs to another company", zap.Error(err),
+			zap.String("document-id", documentID))
+		return err
+	}
+	if err := k.checkEditable(ctx, user.CompanyID); err != nil {
+		return err
+	}
+
+	if err := k.kycStorage.DeleteKYCDocument(ctx, user.CompanyID, document.ID); err != nil {
+		return err
+	}
+	k.removeBlob(ctx, document.StorageKey)
+	event := dto.UserAuditEvent(*user, constant.AuditKYCDocumentDeleted)
+	event.Metadata = map[string]any{
+		"document_id":   document.ID,
+		"document_type": document.DocumentType,
+	}
+	k.auditLog.Record(ctx, event)
+
+	return nil
+}
+
+// Submit sends the company's documents for review. Every required document
+// type must have been uploaded.
+func (k *kyc) Submit(ctx context.Context, userID string) (*dto.KYCStatus, error) {
+	user, err := k.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	status, err := k.getStatus(ctx, user.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(status.Missing) > 0 {
+		missing := make([]string, 0, len(status.Missing))
+		for _, documentType := range status.Missing {
+			missing = append(missing, string(documentType))
+		}
+		err := errors.ErrInvalidUserInput.New("missing documents: %s",
+			strings.Join(missing, ", "))
+		k.log.Warn(ctx, "kyc submitted without required documents", zap.Error(err),
+			zap.String("company-id", user.CompanyID.String()))
+		return nil, err
+	}
+
+	submitted, err := k.kycStorage.SubmitCompanyKYC(ctx, user.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if !submitted {
+		err := errors.ErrInvalidUserInput.New("company is %s", status.Status)
+		k.log.Warn(ctx, "kyc submitted in the wrong status", zap.Error(err),
+			zap.String("company-id", user.CompanyID.String()))
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditKYCSubmitted)
+	event.Before = map[string]any{"status": status.Status}
+	event.After = map[string]any{"status": constant.UnderReview}
+	k.auditLog.Record(ctx, event)
+
+	return k.getStatus(ctx, user.CompanyID)
+}
+
+func (k *kyc) GetCompanyKYC(ctx context.Context, companyID string) (*dto.KYCStatus, error) {
+	id, err := uuid.Parse(companyID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid company id")
+		k.log.Warn(ctx, "invalid company id", zap.Error(err))
+		return nil, err
+	}
+
+	return k.getStatus(ctx, id)
+}
+
+// OpenDocument returns a document and its content for an operator to review.
+// The caller must close the reader.
+func (k *kyc) OpenDocument(ctx context.Context,
+	documentID string) (*dto.KYCDocument, io.ReadCloser, error) {
+	document, err := k.getDocument(ctx, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := k.blobStore.Get(ctx, document.StorageKey)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to read document")
+		k.log.Error(ctx, "unable to read kyc document", zap.Error(err),
+			zap.String("document-id", documentID))
+		return nil, nil, err
+	}
+
+	return document, content, nil
+}
+
+// Review approves or rejects a company under review. Approval makes the
+// company ACTIVE so it can take live payments; a rejection sends it back to
+// the merchant with the note.
+func (k *kyc) Review(ctx context.Context, operatorID, companyID string,
+	param dto.KYCReview) (*dto.KYCStatus, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		k.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	operator, err := k.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	before, err := k.GetCompanyKYC(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	status, action := constant.Active, constant.AuditKYCApproved
+	if param.Decision == dto.KYCReject {
+		status, action = constant.Rejected, constant.AuditKYCRejected
+	}
+
+	reviewed, err := k.kycStorage.ReviewCompanyKYC(ctx, before.CompanyID, operator.ID,
+		status, strings.TrimSpace(param.Note))
+	if err != nil {
+		return nil, err
+	}
+	if !reviewed {
+		err := errors.ErrInvalidUserInput.New("company is %s", before.Status)
+		k.log.Warn(ctx, "kyc reviewed in the wrong status", zap.Error(err),
+			zap.String("company-id", companyID))
+		return nil, err
+	}
+	event := dto.OperatorAuditEvent(*operator, before.CompanyID, action)
+	event.Metadata = map[string]any{"note": strings.TrimSpace(param.Note)}
+	event.Before = map[string]any{"status": before.Status}
+	event.After = map[string]any{"status": status}
+	k.auditLog.Record(ctx, event)
+	k.notifyDecision(ctx, before.CompanyID, status, strings.TrimSpace(param.Note))
+
+	return k.getStatus(ctx, before.CompanyID)
+}
+
+// notifyDecision emails the company the outcome of its review. The decision
+// is already recorded, so a failure is only logged.
+func (k *kyc) notifyDecision(ctx context.Context, companyID uuid.UUID,
+	status constant.Status, note string) {
+	company, err := k.companyStorage.GetCompanyByID(ctx, companyID)
+	if err != nil {
+		return
+	}
+	msg := notifier.Message{
+		Channel: notifier.ChannelEmail,
+		To:      company.Email,
+		Subject: "Your business verification was approved",
+		Body: fmt.Sprintf("%s has been verified and can now accept live payments.",
+			company.Name),
+	}
+	if status == constant.Rejected {
+		msg.Subject = "Your business verification needs attention"
+		msg.Body = fmt.Sprintf("We could not verify %s: %s. Update your documents and submit them again.",
+			company.Name, note)
+	}
+	if err := k.notifier.Send(ctx, msg); err != nil {
+		err = errors.ErrUnableToSendMail.Wrap(err, "unable to send kyc decision")
+		k.log.Error(ctx, "unable to send kyc decision", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+	}
+}
+
+func (k *kyc) getStatus(ctx context.Context, companyID uuid.UUID) (*dto.KYCStatus, error) {
+	status, err := k.kycStorage.GetCompanyKYC(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := k.kycStorage.ListKYCDocuments(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	status.Documents = documents
+	status.Missing = []constant.KYCDocumentType{}
+	for _, required := range constant.RequiredKYCDocuments {
+		if !slices.ContainsFunc(documents, func(document dto.KYCDocument) bool {
+			return document.DocumentType == required
+		}) {
+			status.Missing = append(status.Missing, required)
+		}
+	}
+
+	return status, nil
+}
+
+// checkEditable refuses document changes once the company has submitted,
+// until a review sends it back.
+func (k *kyc) checkEditable(ctx context.Context, companyID uuid.UUID) error {
+	status, err := k.kycStorage.GetCompanyKYC(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if status.Status != constant.Onboarding && status.Status != constant.Rejected {
+		err := errors.ErrInvalidUserInput.New("documents cannot be changed while company is %s",
+			status.Status)
+		k.log.Warn(ctx, "kyc documents changed in the wrong status", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (k *kyc) removeBlob(ctx context.Context, key string) {
+	if err := k.blobStore.Delete(ctx, key); err != nil {
+		k.log.Error(ctx, "unable to remove kyc document blob", zap.Error(err),
+			zap.String("key", key))
+	}
+}
+
+func (k *kyc) getDocument(ctx context.Context, documentID string) (*dto.KYCDocument, error) {
+	id, err := uuid.Parse(documentID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid document id")
+		k.log.Warn(ctx, "invalid document id", zap.Error(err))
+		return nil, err
+	}
+
+	return k.kycStorage.GetKYCDocumentByID(ctx, id)
+}
+
+func (k *kyc) getUser(ctx context.Context, userID string) (*dto.User, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "Invalid user id")
+		k.log.Error(ctx, "Invalid user id", zap.Error(err))
+		return nil, err
+	}
+
+	return k.companyStorage.GetUserByID(ctx, id)
+}
+
+func (k *kyc) getOperator(ctx context.Context, operatorID string) (*dto.Operator, error) {
+	id, err := uuid.Parse(operatorID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid operator id")
+		k.log.Error(ctx, "invalid operator id", zap.Error(err))
+		return nil, err
+	}
+
+	return k.operatorStorage.GetOperatorByID(ctx, id)
+}
@@ -0,0 +1,193 @@
+package limit
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+)
+
+// defaultCustomerWindow is the customer velocity window when an operator
+// does not give one: N payments per hour.
+const defaultCustomerWindow = 60
+
+type limit struct {
+	log             hlog.Logger
+	limitStorage    storage.Limit
+	currencyStorage storage.Currency
+	companyStorage  storage.Company
+	operatorStorage storage.Operator
+	auditLog        module.Audit
+}
+
+func New(limitStorage storage.Limit,
+	currencyStorage storage.Currency,
+	companyStorage storage.Company,
+	operatorStorage storage.Operator,
+	auditLog module.Audit,
+	log hlog.Logger) module.Limit {
+	return &limit{
+		log:             log,
+		limitStorage:    limitStorage,
+		currencyStorage: currencyStorage,
+		companyStorage:  companyStorage,
+		operatorStorage: operatorStorage,
+		auditLog:        auditLog,
+	}
+}
+
+func (l *limit) ListLimits(ctx context.Context, companyID string) ([]dto.CompanyLimit, error) {
+	company, err := l.getCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.limitStorage.ListCompanyLimits(ctx, company.ID)
+}
+
+// SetLimit replaces the company's limits in a currency. Payment intents
+// created afterwards are checked against them.
+func (l *limit) SetLimit(ctx context.Context, operatorID, companyID, currency string,
+	param dto.SetCompanyLimit) (*dto.CompanyLimit, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		l.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	operator, err := l.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	company, err := l.getCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	code, err := l.getCurrency(ctx, company.ID, currency)
+	if err != nil {
+		return nil, err
+	}
+	before, err := l.find(ctx, company.ID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if param.CustomerWindowMinutes == 0 {
+		param.CustomerWindowMinutes = defaultCustomerWindow
+	}
+	param.CompanyID, param.Currency, param.UpdatedBy = company.ID, code, operator.ID
+	after, err := l.limitStorage.SetCompanyLimit(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	event := dto.OperatorAuditEvent(*operator, company.ID, constant.AuditCompanyLimitUpdated)
+	if before != nil {
+		event.Before = limitFields(*before)
+	}
+	event.After = limitFields(*after)
+	l.auditLog.Record(ctx, event)
+
+	return after, nil
+}
+
+// DeleteLimit removes the company's limits in a currency, leaving only the
+// currency registry's amount range.
+func (l *limit) DeleteLimit(ctx context.Context, operatorID, companyID, currency string) error {
+	operator, err := l.getOperator(ctx, operatorID)
+	if err != nil {
+		return err
+	}
+	company, err := l.getCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	code, err := l.getCurrency(ctx, company.ID, currency)
+	if err != nil {
+		return err
+	}
+	before, err := l.find(ctx, company.ID, code)
+	if err != nil {
+		return err
+	}
+	if err := l.limitStorage.DeleteCompanyLimit(ctx, company.ID, code); err != nil {
+		return err
+	}
+
+	event := dto.OperatorAuditEvent(*operator, company.ID, constant.AuditCompanyLimitDeleted)
+	if before != nil {
+		event.Before = limitFields(*before)
+	}
+	l.auditLog.Record(ctx, event)
+
+	return nil
+}
+
+func (l *limit) find(ctx context.Context, companyID uuid.UUID,
+	currency constant.Currency) (*dto.CompanyLimit, error) {
+	limits, err := l.limitStorage.ListCompanyLimits(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range limits {
+		if limits[i].Currency == currency {
+			return &limits[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (l *limit) getCurrency(ctx context.Context, companyID uuid.UUID,
+	code string) (constant.Currency, error) {
+	currency, err := l.currencyStorage.GetCompanyCurrency(ctx, companyID,
+		constant.Currency(strings.ToUpper(code)))
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			err = errors.ErrInvalidUserInput.New("currency %s is not supported", code)
+			l.log.Warn(ctx, "unsupported currency", zap.Error(err))
+		}
+		return "", err
+	}
+	return currency.Code, nil
+}
+
+func (l *limit) getCompany(ctx context.Context, companyID string) (*dto.Company, error) {
+	id, err := uuid.Parse(companyID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid company id")
+		l.log.Warn(ctx, "invalid company id", zap.Error(err), zap.String("id", companyID))
+		return nil, err
+	}
+
+	return l.companyStorage.GetCompanyByID(ctx, id)
+}
+
+func (l *limit) getOperator(ctx context.Context, operatorID string) (*dto.Operator, error) {
+	id, err := uuid.Parse(operatorID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid operator id")
+		l.log.Error(ctx, "invalid operator id", zap.Error(err))
+		return nil, err
+	}
+
+	return l.operatorStorage.GetOperatorByID(ctx, id)
+}
+
+func limitFields(limit dto.CompanyLimit) map[string]any {
+	return map[string]any{
+		"currency":                limit.Currency,
+		"min_amount":              limit.MinAmount,
+		"max_amount":              limit.MaxAmount,
+		"daily_total":             limit.DailyTotal,
+		"monthly_total":           limit.MonthlyTotal,
+		"customer_max_payments":   limit.CustomerMaxPayments,
+		"customer_window_minutes": limit.CustomerWindowMinutes,
+	}
+}
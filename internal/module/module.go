@@ -2,21 +2,204 @@ package module
 
 import (
 	"context"
+	"io"
+	"pg/internal/constant"
 	"pg/internal/constant/model/dto"
 )
 
 type Company interface {
 	RegisterCompany(ctx context.Context,
 		param dto.CreateCompany) (*dto.Company, error)
-	Login(ctx context.Context, arg dto.LoginRequest) (*dto.SignInResponse, error)
-	GenerateToken(ctx context.Context,
-		userID string) (*dto.CompanyCredentialResponse, error)
+	Login(ctx context.Context,
+		arg dto.LoginRequest) (*dto.SignInResponse, *dto.MFAChallenge, error)
+	GenerateToken(ctx context.Context, userID string,
+		arg dto.APIKeyRequest) (*dto.CompanyCredentialResponse, error)
+	ForgotPassword(ctx context.Context, arg dto.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, arg dto.ResetPasswordRequest) error
+	VerifyEmail(ctx context.Context, arg dto.VerifyEmailRequest) (*dto.User, error)
+	VerifyPhone(ctx context.Context, arg dto.VerifyPhoneRequest) (*dto.User, error)
+	ResendVerification(ctx context.Context, arg dto.ResendVerificationRequest) error
+	EnrollMFA(ctx context.Context, userID string) (*dto.MFAEnrollment, error)
+	EnableMFA(ctx context.Context, userID string,
+		arg dto.MFACodeRequest) (*dto.RecoveryCodes, error)
+	DisableMFA(ctx context.Context, userID string, arg dto.MFACodeRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID string,
+		arg dto.MFACodeRequest) (*dto.RecoveryCodes, error)
+	LoginMFA(ctx context.Context, arg dto.MFALoginRequest) (*dto.SignInResponse, error)
+	UnlockAccount(ctx context.Context, arg dto.UnlockAccountRequest) error
+	UpdateCompanyMFA(ctx context.Context, userID string,
+		arg dto.UpdateCompanyMFA) (*dto.Company, error)
+	GetCompany(ctx context.Context, userID string) (*dto.Company, error)
+	UpdateCompany(ctx context.Context, userID string,
+		arg dto.UpdateCompany) (*dto.Company, error)
+	CreateHMACKey(ctx context.Context, userID string,
+		arg dto.APIKeyRequest) (*dto.CreatedHMACKey, error)
+	ListHMACKeys(ctx context.Context, userID string) ([]dto.HMACKey, error)
+	RevokeHMACKey(ctx context.Context, userID, id string) (*dto.HMACKey, error)
+	ListIPAllowlist(ctx context.Context, userID string) ([]dto.IPAllowlistEntry, error)
+	AddIPAllowlistEntry(ctx context.Context, userID string,
+		arg dto.IPAllowlistRequest) (*dto.IPAllowlistEntry, error)
+	UpdateIPAllowlistEntry(ctx context.Context, userID, id string,
+		arg dto.IPAllowlistRequest) (*dto.IPAllowlistEntry, error)
+	DeleteIPAllowlistEntry(ctx context.Context, userID, id string) error
+}
+
+type Customer interface {
+	CreateCustomer(ctx context.Context, companyID string, livemode bool,
+		param dto.CreateCustomer) (*dto.Customer, error)
+	GetCustomer(ctx context.Context, companyID string, livemode bool,
+		customerID string) (*dto.Customer, error)
+	ListCustomers(ctx context.Context, companyID string, livemode bool,
+		filter dto.CustomerFilter) ([]dto.Customer, int, error)
+	UpdateCustomer(ctx context.Context, companyID string, livemode bool,
+		customerID string, param dto.UpdateCustomer) (*dto.Customer, error)
+	DeleteCustomer(ctx context.Context, companyID string, livemode bool,
+		customerID string) error
+	EraseCustomer(ctx context.Context, companyID string, livemode bool,
+		customerID string) (*dto.CustomerErasure, error)
+	EraseInactiveCustomers(ctx context.Context) (int, error)
+	StartRetentionWorker(ctx context.Context)
+	ListPaymentIntents(ctx context.Context, companyID string, livemode bool,
+		customerID string, param dto.Page) ([]dto.PaymentIntent, int, error)
+}
+
+type KYC interface {
+	GetKYC(ctx context.Context, userID string) (*dto.KYCStatus, error)
+	UploadDocument(ctx context.Context, userID string,
+		param dto.UploadKYCDocument) (*dto.KYCDocument, error)
+	DeleteDocument(ctx context.Context, userID, documentID string) error
+	Submit(ctx context.Context, userID string) (*dto.KYCStatus, error)
+	GetCompanyKYC(ctx context.Context, companyID string) (*dto.KYCStatus, error)
+	OpenDocument(ctx context.Context,
+		documentID string) (*dto.KYCDocument, io.ReadCloser, error)
+	Review(ctx context.Context, operatorID, companyID string,
+		param dto.KYCReview) (*dto.KYCStatus, error)
+}
+
+type Operator interface {
+	Bootstrap(ctx context.Context, param dto.CreateOperator) error
+	Login(ctx context.Context,
+		arg dto.OperatorLoginRequest) (*dto.OperatorSignInResponse, error)
+	CreateOperator(ctx context.Context, operatorID string,
+		param dto.CreateOperator) (*dto.Operator, error)
+	ListCompanies(ctx context.Context,
+		filter dto.CompanyFilter) ([]dto.Company, int, error)
+	GetCompany(ctx context.Context, companyID string) (*dto.Company, error)
+	SetCompanyStatus(ctx context.Context, operatorID, companyID string,
+		status constant.Status, param dto.CompanyStatusChange) (*dto.Company, error)
+	GetPaymentIntent(ctx context.Context,
+		paymentIntentID string) (*dto.PaymentIntentDetail, error)
+	CorrectPaymentIntentStatus(ctx context.Context, operatorID, paymentIntentID string,
+		param dto.PaymentIntentStatusCorrection) (*dto.PaymentIntentDetail, error)
 }
 
 type PaymentIntent interface {
-	InitPaymentIntent(ctx context.Context,
-		param dto.InitPaymentIntent, companyID string) (*dto.PaymentIntent, error)
-	GetPaymentIntentDetail(ctx context.Context,
-		id string) (*dto.PaymentIntentDetail, error)
+	InitPaymentIntent(ctx context.Context, param dto.InitPaymentIntent,
+		companyID string, livemode bool) (*dto.PaymentIntent, error)
+	GetPaymentIntentDetail(ctx context.Context, id, companyID string,
+		livemode bool) (*dto.PaymentIntentDetail, error)
+	ConfirmPaymentIntent(ctx context.Context, id, companyID string,
+		livemode bool, param dto.ConfirmPaymentIntent) (*dto.PaymentIntent, error)
+	ResendPaymentConfirmation(ctx context.Context, id, companyID string, livemode bool) error
+	StartWorker(ctx context.Context)
+}
+
+type Team interface {
+	InviteUser(ctx context.Context, userID string,
+		param dto.InviteUser) (*dto.UserInvitation, error)
+	AcceptInvitation(ctx context.Context,
+		param dto.AcceptInvitation) (*dto.User, error)
+	ListMembers(ctx context.Context, userID string) ([]dto.User, error)
+	UpdateMemberRole(ctx context.Context, userID, memberID string,
+		param dto.UpdateMemberRole) (*dto.User, error)
+	DeactivateMember(ctx context.Context,
+		userID, memberID string) (*dto.User, error)
+}
+
+type Audit interface {
+	Record(ctx context.Context, event dto.CreateAuditEvent)
+	ListAuditEvents(ctx context.Context, userID string,
+		filter dto.AuditEventFilter) ([]dto.AuditEvent, int, error)
+	ExportAuditEvents(ctx context.Context, userID string,
+		filter dto.AuditEventFilter, w io.Writer) error
+}
+
+type PII interface {
+	ListDataKeys(ctx context.Context) ([]dto.PIIDataKey, error)
+	RotateDataKey(ctx context.Context, operatorID string) (*dto.PIIDataKey, error)
+	Reseal(ctx context.Context) error
+	StartWorker(ctx context.Context)
+}
+
+type Currency interface {
+	ListCompanyCurrencies(ctx context.Context, companyID string) ([]dto.Currency, error)
+	SetCompanyCurrency(ctx context.Context, operatorID, companyID, code string,
+		param dto.SetCompanyCurrency) (*dto.Currency, error)
+	GetFXSettings(ctx context.Context, companyID string) (*dto.CompanyFXSettings, error)
+	SetFXSettings(ctx context.Context, operatorID, companyID string,
+		param dto.SetCompanyFXSettings) (*dto.CompanyFXSettings, error)
+}
+
+type Limit interface {
+	ListLimits(ctx context.Context, companyID string) ([]dto.CompanyLimit, error)
+	SetLimit(ctx context.Context, operatorID, companyID, currency string,
+		param dto.SetCompanyLimit) (*dto.CompanyLimit, error)
+	DeleteLimit(ctx context.Context, operatorID, companyID, currency string) error
+}
+
+type Risk interface {
+	ListRules(ctx context.Context, companyID string) ([]dto.RiskRule, error)
+	CreateRule(ctx context.Context, operatorID, companyID string,
+		param dto.SetRiskRule) (*dto.RiskRule, error)
+	UpdateRule(ctx context.Context, operatorID, companyID, ruleID string,
+		param dto.SetRiskRule) (*dto.RiskRule, error)
+	DeleteRule(ctx context.Context, operatorID, companyID, ruleID string) error
+	GetSettings(ctx context.Context, companyID string) (*dto.RiskSettings, error)
+	SetSettings(ctx context.Context, operatorID, companyID string,
+		param dto.SetRiskSettings) (*dto.RiskSettings, error)
+	ListReviews(ctx context.Context, filter dto.RiskReviewFilter) ([]dto.RiskReview, int, error)
+	ReviewPaymentIntent(ctx context.Context, operatorID, paymentIntentID string,
+		param dto.RiskReviewDecision) (*dto.PaymentIntentDetail, error)
+}
+
+type Blocklist interface {
+	ListCompanyEntries(ctx context.Context, userID string,
+		filter dto.BlocklistFilter) ([]dto.BlocklistEntry, int, error)
+	AddCompanyEntry(ctx context.Context, userID string,
+		param dto.BlocklistRequest) (*dto.BlocklistEntry, error)
+	UpdateCompanyEntry(ctx context.Context, userID, entryID string,
+		param dto.BlocklistRequest) (*dto.BlocklistEntry, error)
+	DeleteCompanyEntry(ctx context.Context, userID, entryID string) error
+	ListCompanyHits(ctx context.Context, userID string,
+		filter dto.BlocklistHitFilter) ([]dto.BlocklistHit, int, error)
+	ListEntries(ctx context.Context, filter dto.BlocklistFilter) ([]dto.BlocklistEntry, int, error)
+	AddEntry(ctx context.Context, operatorID string,
+		param dto.OperatorBlocklistRequest) (*dto.BlocklistEntry, error)
+	UpdateEntry(ctx context.Context, operatorID, entryID string,
+		param dto.BlocklistRequest) (*dto.BlocklistEntry, error)
+	DeleteEntry(ctx context.Context, operatorID, entryID string) error
+	ListHits(ctx context.Context, filter dto.BlocklistHitFilter) ([]dto.BlocklistHit, int, error)
+}
+
+type Dispute interface {
+	ListCompanyDisputes(ctx context.Context, userID string,
+		filter dto.DisputeFilter) ([]dto.Dispute, int, error)
+	GetCompanyDispute(ctx context.Context, userID, disputeID string) (*dto.Dispute, error)
+	UpdateEvidence(ctx context.Context, userID, disputeID string,
+		param dto.DisputeEvidence) (*dto.Dispute, error)
+	UploadEvidence(ctx context.Context, userID, disputeID string,
+		param dto.UploadDisputeEvidence) (*dto.DisputeEvidenceFile, error)
+	Submit(ctx context.Context, userID, disputeID string) (*dto.Dispute, error)
+	ListDisputes(ctx context.Context, filter dto.DisputeFilter) ([]dto.Dispute, int, error)
+	GetDispute(ctx context.Context, disputeID string) (*dto.Dispute, error)
+	OpenDispute(ctx context.Context, operatorID string,
+		param dto.OpenDispute) (*dto.Dispute, error)
+	ResolveDispute(ctx context.Context, operatorID, disputeID string,
+		param dto.ResolveDispute) (*dto.Dispute, error)
+	OpenEvidence(ctx context.Context, disputeID,
+		fileID string) (*dto.DisputeEvidenceFile, io.ReadCloser, error)
+}
+
+type Webhook interface {
 	StartWorker(ctx context.Context)
 }
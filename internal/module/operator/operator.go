@@ -0,0 +1,339 @@
+package operator
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hcrypto"
+	"pg/platform/hlog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const defaultPerPage = 50
+
+type Options struct {
+	// LoginMaxFailures failed sign-ins within LoginLockoutDuration lock an
+	// operator email until the window passes.
+	LoginMaxFailures     int
+	LoginLockoutDuration time.Duration
+}
+
+type operator struct {
+	log                  hlog.Logger
+	operatorStorage      storage.Operator
+	companyStorage       storage.Company
+	paymentIntentStorage storage.PaymentIntent
+	auditLog             module.Audit
+	maker                hcrypto.Maker
+	options              Options
+	dummyHash            []byte
+}
+
+func New(operatorStorage storage.Operator,
+	companyStorage storage.Company,
+	paymentIntentStorage storage.PaymentIntent,
+	auditLog module.Audit,
+	log hlog.Logger,
+	maker hcrypto.Maker,
+	options Options) module.Operator {
+	if options.LoginMaxFailures <= 0 {
+		options.LoginMaxFailures = 5
+	}
+	if options.LoginLockoutDuration <= 0 {
+		options.LoginLockoutDuration = 15 * time.Minute
+	}
+	// Compared against when the account does not exist, so that unknown
+	// accounts take as long to reject as a wrong password.
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte(uuid.NewString()), bcrypt.DefaultCost)
+	return &operator{
+		log:                  log,
+		operatorStorage:      operatorStorage,
+		companyStorage:       companyStorage,
+		paymentIntentStorage: paymentIntentStorage,
+		auditLog:             auditLog,
+		maker:                maker,
+		options:              options,
+		dummyHash:            dummyHash,
+	}
+}
+
+// Bootstrap creates the first operator when there is none, so that a fresh
+// deployment can sign in to the back-office. It does nothing otherwise.
+func (o *operator) Bootstrap(ctx context.Context, param dto.CreateOperator) error {
+	count, err := o.operatorStorage.CountOperators(ctx)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	created, err := o.createOperator(ctx, param)
+	if err != nil {
+		return err
+	}
+	o.log.Info(ctx, "bootstrapped first operator",
+		zap.String("operator-id", created.ID.String()))
+
+	return nil
+}
+
+func (o *operator) Login(ctx context.Context,
+	arg dto.OperatorLoginRequest) (*dto.OperatorSignInResponse, error) {
+	if err := arg.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		o.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	// Operator attempts are counted apart from merchant sign-ins that may
+	// use the same email. Each is counted before the password is checked,
+	// so concurrent attempts cannot slip past the limit.
+	identifier := "operator:" + strings.ToLower(strings.TrimSpace(arg.Email))
+	if _, err := o.companyStorage.ReserveLoginAttempt(ctx, dto.LoginThrottle{
+		Key:         identifier,
+		Since:       time.Now().Add(-o.options.LoginLockoutDuration),
+		MaxAttempts: o.options.LoginMaxFailures,
+		DelayAfter:  o.options.LoginMaxFailures,
+	}); err != nil {
+		if errorx.IsOfType(err, errors.ErrTooManyRequests) {
+			o.log.Warn(ctx, "operator login blocked for locked identifier", zap.Error(err),
+				zap.String("ip-address", arg.IPAddress))
+		}
+		return nil, err
+	}
+
+	operator, err := o.operatorStorage.GetOperatorByEmail(ctx, strings.TrimSpace(arg.Email))
+	if err != nil && !errorx.IsOfType(err, errors.ErrNoRecordFound) {
+		return nil, err
+	}
+	if operator == nil {
+		_ = bcrypt.CompareHashAndPassword(o.dummyHash, []byte(arg.Password))
+	}
+	if operator == nil ||
+		bcrypt.CompareHashAndPassword([]byte(operator.Password), []byte(arg.Password)) != nil {
+		event := dto.CreateAuditEvent{
+			ActorType:  constant.AuditActorAnonymous,
+			Action:     constant.AuditOperatorLoginFailed,
+			Identifier: identifier,
+		}
+		if operator != nil {
+			event = dto.OperatorAuditEvent(*operator, uuid.Nil, constant.AuditOperatorLoginFailed)
+		}
+		o.auditLog.Record(ctx, event)
+		return nil, errors.ErrInvalidCredentials.New("invalid email or password")
+	}
+	if err := o.companyStorage.ClearLoginAttempts(ctx, identifier); err != nil {
+		return nil, err
+	}
+	if operator.Status != string(constant.Active) {
+		err := errors.ErrAuthError.New("access denied")
+		o.log.Warn(ctx, "inactive operator tried to sign in", zap.Error(err),
+			zap.String("operator-id", operator.ID.String()))
+		return nil, err
+	}
+
+	accessToken, _, err := o.maker.CreatePasetoToken(hcrypto.UserData{
+		UserID:   operator.ID.String(),
+		Email:    operator.Email,
+		Provider: constant.Normal,
+	}, constant.OperatorAccessToken)
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to generate access token")
+		o.log.Error(ctx, "unable to generate access token", zap.Error(err))
+		return nil, err
+	}
+	if err := o.operatorStorage.TouchOperatorLogin(ctx, operator.ID); err != nil {
+		return nil, err
+	}
+	o.auditLog.Record(ctx,
+		dto.OperatorAuditEvent(*operator, uuid.Nil, constant.AuditOperatorLoginSucceeded))
+
+	return &dto.OperatorSignInResponse{AccessToken: accessToken}, nil
+}
+
+func (o *operator) CreateOperator(ctx context.Context, operatorID string,
+	param dto.CreateOperator) (*dto.Operator, error) {
+	actor, err := o.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	param.CreatedBy = actor.ID
+	created, err := o.createOperator(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	event := dto.OperatorAuditEvent(*actor, uuid.Nil, constant.AuditOperatorCreated)
+	event.Metadata = map[string]any{"operator_id": created.ID, "email": created.Email}
+	o.auditLog.Record(ctx, event)
+
+	return created, nil
+}
+
+func (o *operator) ListCompanies(ctx context.Context,
+	filter dto.CompanyFilter) ([]dto.Company, int, error) {
+	if err := filter.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid filter")
+		o.log.Warn(ctx, "invalid company filter", zap.Error(err))
+		return nil, 0, err
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage <= 0 {
+		filter.PerPage = defaultPerPage
+	}
+
+	return o.companyStorage.ListCompanies(ctx, dto.CompanyQuery{
+		Search: strings.TrimSpace(filter.Search),
+		Status: filter.Status,
+		Limit:  filter.PerPage,
+		Offset: (filter.Page - 1) * filter.PerPage,
+	})
+}
+
+func (o *operator) GetCompany(ctx context.Context, companyID string) (*dto.Company, error) {
+	id, err := uuid.Parse(companyID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid company id")
+		o.log.Warn(ctx, "invalid company id", zap.Error(err))
+		return nil, err
+	}
+
+	return o.companyStorage.GetCompanyByID(ctx, id)
+}
+
+// SetCompanyStatus suspends or reactivates a company. Any company that is
+// not suspended can be suspended, including one still onboarding or under
+// review, and reactivating it restores the status it was suspended from. A
+// suspended company's API credentials are refused until it is reactivated.
+func (o *operator) SetCompanyStatus(ctx context.Context, operatorID, companyID string,
+	status constant.Status, param dto.CompanyStatusChange) (*dto.Company, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		o.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	actor, err := o.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	company, err := o.GetCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	action := constant.AuditCompanySuspended
+	if status == constant.Active {
+		action = constant.AuditCompanyReactivated
+		status, err = o.companyStorage.ReactivateCompany(ctx, company.ID)
+	} else {
+		err = o.companyStorage.SuspendCompany(ctx, company.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	event := dto.OperatorAuditEvent(*actor, company.ID, action)
+	event.Metadata = map[string]any{"reason": strings.TrimSpace(param.Reason)}
+	event.Before = map[string]any{"status": company.Status}
+	event.After = map[string]any{"status": status}
+	o.auditLog.Record(ctx, event)
+
+	return o.companyStorage.GetCompanyByID(ctx, company.ID)
+}
+
+func (o *operator) GetPaymentIntent(ctx context.Context,
+	paymentIntentID string) (*dto.PaymentIntentDetail, error) {
+	id, err := uuid.Parse(paymentIntentID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid payment intent id")
+		o.log.Warn(ctx, "invalid payment intent id", zap.Error(err))
+		return nil, err
+	}
+
+	return o.paymentIntentStorage.GetPaymentIntentByID(ctx, id)
+}
+
+// CorrectPaymentIntentStatus forces a payment intent into another status and
+// books the difference in the company's ledger. The reason, old and new
+// status are kept in the merchant's audit log.
+func (o *operator) CorrectPaymentIntentStatus(ctx context.Context, operatorID,
+	paymentIntentID string, param dto.PaymentIntentStatusCorrection) (*dto.PaymentIntentDetail, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		o.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	actor, err := o.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	paymentIntent, err := o.GetPaymentIntent(ctx, paymentIntentID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentIntent.Status == param.Status {
+		err := errors.ErrInvalidUserInput.New("payment intent is already %s", param.Status)
+		o.log.Warn(ctx, "payment intent already has the requested status", zap.Error(err),
+			zap.String("payment-intent-id", paymentIntentID))
+		return nil, err
+	}
+
+	if err := o.paymentIntentStorage.CorrectPaymentIntentStatus(ctx, paymentIntent.ID,
+		paymentIntent.Status, param.Status); err != nil {
+		return nil, err
+	}
+	event := dto.OperatorAuditEvent(*actor, paymentIntent.Company.ID,
+		constant.AuditPaymentIntentCorrected)
+	event.Metadata = map[string]any{
+		"payment_intent_id": paymentIntent.ID,
+		"reason":            strings.TrimSpace(param.Reason),
+	}
+	event.Before = map[string]any{"status": paymentIntent.Status}
+	event.After = map[string]any{"status": param.Status}
+	o.auditLog.Record(ctx, event)
+	o.log.Info(ctx, "payment intent status corrected",
+		zap.String("payment-intent-id", paymentIntent.ID.String()),
+		zap.String("operator-id", actor.ID.String()),
+		zap.String("from", string(paymentIntent.Status)),
+		zap.String("to", string(param.Status)))
+
+	return o.paymentIntentStorage.GetPaymentIntentByID(ctx, paymentIntent.ID)
+}
+
+func (o *operator) createOperator(ctx context.Context,
+	param dto.CreateOperator) (*dto.Operator, error) {
+	param.Email = strings.ToLower(strings.TrimSpace(param.Email))
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		o.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(param.Password), bcrypt.DefaultCost)
+	if err != nil {
+		err = errors.ErrUnableToHashPassword.Wrap(err,
+			"Unable to generate password hash")
+		o.log.Error(ctx, "unable to generate password hash", zap.Error(err))
+		return nil, err
+	}
+	param.Password = string(hashedPassword)
+
+	return o.operatorStorage.CreateOperator(ctx, param)
+}
+
+func (o *operator) getOperator(ctx context.Context, operatorID string) (*dto.Operator, error) {
+	id, err := uuid.Parse(operatorID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid operator id")
+		o.log.Error(ctx, "invalid operator id", zap.Error(err))
+		return nil, err
+	}
+
+	return o.operatorStorage.GetOperatorByID(ctx, id)
+}
@@ -0,0 +1,166 @@
+package operator
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hcrypto"
+	"pg/platform/hlog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeThrottles counts login attempts in memory the way
+// ReserveLoginAttempt does, without the progressive delay.
+type fakeThrottles struct {
+	storage.Company
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+func (f *fakeThrottles) ReserveLoginAttempt(_ context.Context,
+	throttle dto.LoginThrottle) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.attempts[throttle.Key] >= throttle.MaxAttempts {
+		return 0, errors.ErrTooManyRequests.New("too many attempts")
+	}
+	f.attempts[throttle.Key]++
+	return f.attempts[throttle.Key], nil
+}
+
+func (f *fakeThrottles) ClearLoginAttempts(_ context.Context, identifiers ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, identifier := range identifiers {
+		delete(f.attempts, identifier)
+	}
+	return nil
+}
+
+type fakeOperators struct {
+	storage.Operator
+	operator dto.Operator
+}
+
+func (f *fakeOperators) GetOperatorByEmail(context.Context, string) (*dto.Operator, error) {
+	operator := f.operator
+	return &operator, nil
+}
+
+func (f *fakeOperators) TouchOperatorLogin(context.Context, uuid.UUID) error { return nil }
+
+type fakeMaker struct {
+	hcrypto.Maker
+}
+
+func (fakeMaker) CreatePasetoToken(hcrypto.UserData,
+	constant.TokenType) (string, uuid.UUID, error) {
+	return "access-token", uuid.New(), nil
+}
+
+type nopAudit struct {
+	module.Audit
+}
+
+func (nopAudit) Record(context.Context, dto.CreateAuditEvent) {}
+
+func testOperator(t *testing.T, maxFailures int) (*operator, *fakeThrottles) {
+	t.Helper()
+	client, err := sentry.NewClient(sentry.ClientOptions{})
+	if err != nil {
+		t.Fatalf("sentry client: %v", err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	throttles := &fakeThrottles{attempts: map[string]int{}}
+
+	return &operator{
+		log: hlog.New(zap.NewNop(), hlog.Options{}, client),
+		operatorStorage: &fakeOperators{operator: dto.Operator{
+			ID:       uuid.New(),
+			Email:    "ops@example.com",
+			Password: string(hash),
+			Status:   string(constant.Active),
+		}},
+		companyStorage: throttles,
+		auditLog:       nopAudit{},
+		maker:          fakeMaker{},
+		options: Options{
+			LoginMaxFailures:     maxFailures,
+			LoginLockoutDuration: time.Hour,
+		},
+	}, throttles
+}
+
+func TestLoginLocksOutConcurrentGuesses(t *testing.T) {
+	const maxFailures, guesses = 5, 20
+	o, _ := testOperator(t, maxFailures)
+
+	var wg sync.WaitGroup
+	results := make(chan error, guesses)
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := o.Login(context.Background(), dto.OperatorLoginRequest{
+				Email:    "ops@example.com",
+				Password: "wrong",
+			})
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	checked := 0
+	for err := range results {
+		if errorx.IsOfType(err, errors.ErrInvalidCredentials) {
+			checked++
+		} else if !errorx.IsOfType(err, errors.ErrTooManyRequests) {
+			t.Fatalf("Login() = %v", err)
+		}
+	}
+	if checked != maxFailures {
+		t.Errorf("%d passwords checked, want %d", checked, maxFailures)
+	}
+	_, err := o.Login(context.Background(), dto.OperatorLoginRequest{
+		Email:    "ops@example.com",
+		Password: "correct horse",
+	})
+	if !errorx.IsOfType(err, errors.ErrTooManyRequests) {
+		t.Errorf("Login() while locked = %v, want too many requests", err)
+	}
+}
+
+func TestLoginClearsAttemptsOnSuccess(t *testing.T) {
+	o, throttles := testOperator(t, 5)
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		if _, err := o.Login(ctx, dto.OperatorLoginRequest{
+			Email: "ops@example.com", Password: "wrong",
+		}); !errorx.IsOfType(err, errors.ErrInvalidCredentials) {
+			t.Fatalf("Login() = %v, want invalid credentials", err)
+		}
+	}
+	if _, err := o.Login(ctx, dto.OperatorLoginRequest{
+		Email: "ops@example.com", Password: "correct horse",
+	}); err != nil {
+		t.Fatalf("Login() = %v", err)
+	}
+	if n := throttles.attempts["operator:ops@example.com"]; n != 0 {
+		t.Errorf("%d attempts left after signing in, want 0", n)
+	}
+}
@@ -0,0 +1,74 @@
+package paymentintent
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// checkBlocklist refuses a payment intent whose customer is on the
+// company's blocklist or on the one operators keep for every company. Each
+// entry that matched is recorded as a hit; failing to record one does not
+// let the payment through.
+func (p *paymentIntent) checkBlocklist(ctx context.Context, check dto.BlocklistCheck,
+	amount decimal.Decimal, currency constant.Currency, livemode bool) error {
+	entries, err := p.blocklistStorage.MatchBlocklist(ctx, check)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	for _, entry := range entries {
+		value := entry.Value
+		switch entry.Type {
+		case constant.BlocklistIP:
+			value = check.IPAddress
+		case constant.BlocklistPhone:
+			value = check.PhoneNumber
+		}
+		// Hits are analytics only; the payment is refused either way.
+		if err := p.blocklistStorage.RecordBlocklistHit(ctx, dto.CreateBlocklistHit{
+			EntryID:     entry.ID,
+			CompanyID:   check.CompanyID,
+			Type:        entry.Type,
+			Value:       dto.MaskBlocklistValue(entry.Type, value),
+			Amount:      amount,
+			Currency:    currency,
+			Livemode:    livemode,
+			PhoneNumber: check.PhoneNumber,
+		}); err != nil {
+			p.log.Error(ctx, "unable to record blocklist hit", zap.Error(err),
+				zap.String("company-id", check.CompanyID.String()),
+				zap.String("blocklist-entry-id", entry.ID.String()))
+		}
+	}
+
+	entry := entries[0]
+	err = errors.ErrBlocked.New("payment refused: the customer's %s is blocklisted",
+		blocklistSubject(entry.Type)).
+		WithProperty(errors.PropertyBlocked, dto.BlocklistMatch{
+			Type:         entry.Type,
+			PlatformWide: entry.CompanyID == nil,
+		})
+	p.log.Warn(ctx, "payment intent refused by blocklist", zap.Error(err),
+		zap.String("company-id", check.CompanyID.String()),
+		zap.String("blocklist-entry-id", entry.ID.String()))
+	return err
+}
+
+func blocklistSubject(entryType constant.BlocklistType) string {
+	switch entryType {
+	case constant.BlocklistPhone:
+		return "phone number"
+	case constant.BlocklistEmailDomain:
+		return "email domain"
+	default:
+		return "ip address"
+	}
+}
@@ -0,0 +1,134 @@
+package paymentintent
+
+import (
+	"context"
+	"fmt"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/platform/notifier"
+	"pg/platform/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const confirmationCodeLength = 6
+
+// ConfirmPaymentIntent checks the code the payer received and queues the
+// payment intent to be processed.
+func (p *paymentIntent) ConfirmPaymentIntent(ctx context.Context, id, companyID string,
+	livemode bool, param dto.ConfirmPaymentIntent) (*dto.PaymentIntent, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		p.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	paymentIntent, err := p.awaitingConfirmation(ctx, id, companyID, livemode)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmation, err := p.paymentIntentStorage.GetPendingPaymentConfirmation(ctx, paymentIntent.ID)
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			return nil, errors.ErrInvalidUserInput.New("no confirmation code was sent, request a new one")
+		}
+		return nil, err
+	}
+	if confirmation.ExpiresAt.Before(time.Now()) {
+		err := errors.ErrInvalidUserInput.New("confirmation code expired, request a new one")
+		p.log.Warn(ctx, "confirmation code expired", zap.Error(err),
+			zap.String("payment-intent-id", id))
+		return nil, err
+	}
+	// The attempt is counted before the code is compared, and refused once
+	// the code is exhausted, so concurrent guesses share the limit.
+	if _, err := p.paymentIntentStorage.IncrementConfirmationAttempts(ctx, confirmation.ID,
+		p.options.MaxConfirmationAttempts); err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(confirmation.CodeHash),
+		[]byte(param.Code)); err != nil {
+		err := errors.ErrInvalidUserInput.New("incorrect confirmation code")
+		p.log.Warn(ctx, "incorrect confirmation code", zap.Error(err),
+			zap.String("payment-intent-id", id))
+		return nil, err
+	}
+
+	confirmed, err := p.paymentIntentStorage.ConfirmPaymentIntent(ctx, *confirmation, p.amqpClient)
+	if err != nil {
+		return nil, err
+	}
+	confirmed.Risk = confirmed.Risk.ForMerchant()
+
+	return confirmed, nil
+}
+
+// ResendPaymentConfirmation sends the payer a new code, which replaces the
+// one sent before and resets the attempts. An intent is sent at most
+// MaxConfirmationSends codes, ConfirmationResendCooldown apart.
+func (p *paymentIntent) ResendPaymentConfirmation(ctx context.Context, id, companyID string,
+	livemode bool) error {
+	paymentIntent, err := p.awaitingConfirmation(ctx, id, companyID, livemode)
+	if err != nil {
+		return err
+	}
+
+	return p.sendConfirmation(ctx, paymentIntent.ID, paymentIntent.Customer.PhoneNumber)
+}
+
+// awaitingConfirmation returns one of the company's payment intents and
+// refuses it unless it is in REQUIRES_CONFIRMATION.
+func (p *paymentIntent) awaitingConfirmation(ctx context.Context, id, companyID string,
+	livemode bool) (*dto.PaymentIntentDetail, error) {
+	paymentIntent, err := p.GetPaymentIntentDetail(ctx, id, companyID, livemode)
+	if err != nil {
+		return nil, err
+	}
+	if paymentIntent.Status != constant.RequiresConfirmation {
+		err := errors.ErrInvalidUserInput.New("payment intent does not require confirmation")
+		p.log.Warn(ctx, "payment intent not awaiting confirmation", zap.Error(err),
+			zap.String("payment-intent-id", id), zap.String("status", string(paymentIntent.Status)))
+		return nil, err
+	}
+
+	return paymentIntent, nil
+}
+
+func (p *paymentIntent) sendConfirmation(ctx context.Context, paymentIntentID uuid.UUID,
+	phone string) error {
+	code := utils.GenerateCustomRandomString(utils.Digits, confirmationCodeLength)
+	codeHash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		err = errors.ErrUnableToHashPassword.Wrap(err, "unable to hash confirmation code")
+		p.log.Error(ctx, "unable to hash confirmation code", zap.Error(err))
+		return err
+	}
+	if _, err := p.paymentIntentStorage.CreatePaymentConfirmation(ctx, dto.CreatePaymentConfirmation{
+		PaymentIntentID: paymentIntentID,
+		CodeHash:        string(codeHash),
+		ExpiresAt:       time.Now().Add(p.options.ConfirmationCodeExpires),
+		MaxSends:        p.options.MaxConfirmationSends,
+		Cooldown:        p.options.ConfirmationResendCooldown,
+	}); err != nil {
+		return err
+	}
+
+	if err := p.notifier.Send(ctx, notifier.Message{
+		Channel: notifier.ChannelSMS,
+		To:      phone,
+		Body: fmt.Sprintf("Your payment confirmation code is %s. It expires in %d minutes.",
+			code, int(p.options.ConfirmationCodeExpires.Minutes())),
+	}); err != nil {
+		err = errors.ErrUnableToSendMail.Wrap(err, "unable to send payment confirmation code")
+		p.log.Error(ctx, "unable to send payment confirmation code", zap.Error(err),
+			zap.String("payment-intent-id", paymentIntentID.String()))
+		return err
+	}
+
+	return nil
+}
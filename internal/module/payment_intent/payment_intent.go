@@ -11,44 +11,95 @@ import (
 	persistencedb "pg/internal/constant/persistenceDB"
 	"pg/internal/module"
 	"pg/internal/storage"
+	"pg/platform/fx"
 	"pg/platform/hlog"
 	"pg/platform/httpclient"
+	"pg/platform/notifier"
 	"pg/platform/utils"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
 	amqp091 "github.com/rabbitmq/amqp091-go"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
+type Options struct {
+	// ConfirmationCodeExpires is how long the code sent to the payer of an
+	// intent that requires confirmation is valid.
+	ConfirmationCodeExpires time.Duration
+	// MaxConfirmationAttempts is how many wrong codes are accepted before a
+	// new code has to be sent.
+	MaxConfirmationAttempts int
+	// MaxConfirmationSends is how many codes, the first included, the payer
+	// of an intent can be sent.
+	MaxConfirmationSends int
+	// ConfirmationResendCooldown is how long to wait after a code before a
+	// new one can be sent.
+	ConfirmationResendCooldown time.Duration
+}
+
 type paymentIntent struct {
 	log                  hlog.Logger
 	paymentIntentStorage storage.PaymentIntent
 	companyStorage       storage.Company
+	customerStorage      storage.Customer
+	currencyStorage      storage.Currency
+	blocklistStorage     storage.Blocklist
+	fxProvider           fx.Provider
 	httpClient           httpclient.HTTPClient
 	amqpClient           amqp.Client
+	notifier             notifier.Notifier
 	persistenceDB        persistencedb.PersistenceDB
+	options              Options
 }
 
 func New(paymentIntentStorage storage.PaymentIntent,
 	log hlog.Logger,
 	companyStorage storage.Company,
+	customerStorage storage.Customer,
+	currencyStorage storage.Currency,
+	blocklistStorage storage.Blocklist,
+	fxProvider fx.Provider,
 	httpClient httpclient.HTTPClient,
 	amqpClient amqp.Client,
-	persistenceDB persistencedb.PersistenceDB) module.PaymentIntent {
+	notifier notifier.Notifier,
+	persistenceDB persistencedb.PersistenceDB,
+	options Options) module.PaymentIntent {
+	if options.ConfirmationCodeExpires <= 0 {
+		options.ConfirmationCodeExpires = 5 * time.Minute
+	}
+	if options.MaxConfirmationAttempts <= 0 {
+		options.MaxConfirmationAttempts = 3
+	}
+	if options.MaxConfirmationSends <= 0 {
+		options.MaxConfirmationSends = 5
+	}
+	if options.ConfirmationResendCooldown <= 0 {
+		options.ConfirmationResendCooldown = time.Minute
+	}
 	return &paymentIntent{
 		log:                  log,
 		paymentIntentStorage: paymentIntentStorage,
 		companyStorage:       companyStorage,
+		customerStorage:      customerStorage,
+		currencyStorage:      currencyStorage,
+		blocklistStorage:     blocklistStorage,
+		fxProvider:           fxProvider,
 		httpClient:           httpClient,
 		amqpClient:           amqpClient,
+		notifier:             notifier,
 		persistenceDB:        persistenceDB,
+		options:              options,
 	}
 }
 
-func (p *paymentIntent) InitPaymentIntent(ctx context.Context,
-	param dto.InitPaymentIntent, companyID string) (*dto.PaymentIntent, error) {
+// InitPaymentIntent creates a payment intent in the mode of the caller's key.
+// Test intents are accepted before the company is verified.
+func (p *paymentIntent) InitPaymentIntent(ctx context.Context, param dto.InitPaymentIntent,
+	companyID string, livemode bool) (*dto.PaymentIntent, error) {
 	if err := param.Validate(); err != nil {
 		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
 		p.log.Warn(ctx, "invalid input", zap.Error(err))
@@ -66,6 +117,13 @@ func (p *paymentIntent) InitPaymentIntent(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	// Live money is only accepted from verified merchants.
+	if livemode && company.Status != string(constant.Active) {
+		err := errors.ErrAcessError.New("company verification is incomplete, use a test key until it is approved")
+		p.log.Warn(ctx, "unverified company tried to create a payment intent", zap.Error(err),
+			zap.String("company-id", companyID))
+		return nil, err
+	}
 
 	if param.CallBackURL == "" {
 		param.CallBackURL = company.CallBackURL
@@ -74,7 +132,21 @@ func (p *paymentIntent) InitPaymentIntent(ctx context.Context,
 		param.CallBackURL = company.ReturnURL
 	}
 
-	param.Currency = strings.ToUpper(param.Currency)
+	currency, err := p.companyCurrency(ctx, company.ID, param.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if err := currency.ValidateAmount(param.Amount); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid amount")
+		p.log.Warn(ctx, "invalid amount", zap.Error(err),
+			zap.String("amount", param.Amount.String()))
+		return nil, err
+	}
+	param.Amount = currency.Round(param.Amount)
+	settlement, err := p.settle(ctx, company.ID, *currency, param.Amount)
+	if err != nil {
+		return nil, err
+	}
 
 	billRefNO, err := utils.GenerateHash(uuid.NewString()+time.Now().String()+utils.CapitalLetters, 8)
 	if err != nil {
@@ -83,6 +155,23 @@ func (p *paymentIntent) InitPaymentIntent(ctx context.Context,
 		return nil, err
 	}
 
+	var customerID uuid.UUID
+	payer := param.Customer
+	if param.CustomerID != "" {
+		id, err := uuid.Parse(param.CustomerID)
+		if err != nil {
+			err = errors.ErrInvalidUserInput.Wrap(err, "invalid customer id")
+			p.log.Warn(ctx, "invalid customer id", zap.Error(err))
+			return nil, err
+		}
+		customer, err := p.customerStorage.GetCustomer(ctx, company.ID, livemode, id)
+		if err != nil {
+			return nil, err
+		}
+		customerID = customer.ID
+		payer = dto.PaymentCustomer{PhoneNumber: customer.PhoneNumber, Email: customer.Email}
+	}
+
 	// Parse phone number
 	if param.Customer.PhoneNumber != "" {
 		phone, err := utils.ParsePhoneNumber(param.Customer.PhoneNumber)
@@ -95,6 +184,23 @@ func (p *paymentIntent) InitPaymentIntent(ctx context.Context,
 			return nil, err
 		}
 		param.Customer.PhoneNumber = *phone
+		payer.PhoneNumber = *phone
+	}
+
+	if param.RequireConfirmation && payer.PhoneNumber == "" {
+		err := errors.ErrInvalidUserInput.New("the customer needs a phone number to confirm the payment")
+		p.log.Warn(ctx, "confirmation required for customer without phone", zap.Error(err),
+			zap.String("customer-id", customerID.String()))
+		return nil, err
+	}
+
+	if err := p.checkBlocklist(ctx, dto.BlocklistCheck{
+		CompanyID:   company.ID,
+		PhoneNumber: payer.PhoneNumber,
+		Email:       payer.Email,
+		IPAddress:   param.CustomerIP,
+	}, param.Amount, currency.Code, livemode); err != nil {
+		return nil, err
 	}
 
 	paymentIntent, err := p.paymentIntentStorage.CreatePaymentIntent(ctx,
@@ -103,23 +209,110 @@ func (p *paymentIntent) InitPaymentIntent(ctx context.Context,
 			PaymentType: constant.PaymentTypeOnetime,
 			Amount:      param.Amount,
 			Status:      constant.Pending,
-			Currency:    constant.Currency(param.Currency),
+			Currency:    currency.Code,
 			CallBackURL: param.CallBackURL,
 			ReturnURL:   param.ReturnURL,
 			Description: param.Description,
+			CustomerID:  customerID,
 			Customer:    param.Customer,
 			Extra:       param.Extra,
 			BillRefNO:   billRefNO,
+			Livemode:    livemode,
+			Settlement:  *settlement,
+
+			RequireConfirmation: param.RequireConfirmation,
 		}, p.amqpClient)
 	if err != nil {
 		return nil, err
 	}
+	switch paymentIntent.Status {
+	case constant.Review, constant.Blocked:
+		p.log.Info(ctx, "payment intent held by risk rules",
+			zap.String("payment-intent-id", paymentIntent.ID.String()),
+			zap.String("status", string(paymentIntent.Status)))
+	case constant.RequiresConfirmation:
+		// The intent exists either way; a code that fails to send can be
+		// sent again through the resend endpoint.
+		_ = p.sendConfirmation(ctx, paymentIntent.ID, payer.PhoneNumber)
+	}
+	paymentIntent.Risk = paymentIntent.Risk.ForMerchant()
 
 	return paymentIntent, nil
 }
 
-func (p *paymentIntent) GetPaymentIntentDetail(ctx context.Context,
-	id string) (*dto.PaymentIntentDetail, error) {
+// companyCurrency looks the currency up in the registry and refuses it
+// unless it is enabled for the company.
+func (p *paymentIntent) companyCurrency(ctx context.Context, companyID uuid.UUID,
+	code string) (*dto.Currency, error) {
+	currency, err := p.currencyStorage.GetCompanyCurrency(ctx, companyID,
+		constant.Currency(strings.ToUpper(code)))
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrNoRecordFound) {
+			err = errors.ErrInvalidUserInput.New("currency %s is not supported", code)
+			p.log.Warn(ctx, "unsupported currency", zap.Error(err))
+		}
+		return nil, err
+	}
+	if !currency.Enabled {
+		err := errors.ErrInvalidUserInput.New("currency %s is not enabled for this company", currency.Code)
+		p.log.Warn(ctx, "currency not enabled for company", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	return currency, nil
+}
+
+// settle works out what the company is paid for an amount. When it settles
+// in another currency, the amount is converted at the provider's mid rate
+// less the company's spread, rounded down to the settlement currency's minor
+// units, and the rate is kept with the intent.
+func (p *paymentIntent) settle(ctx context.Context, companyID uuid.UUID,
+	presentment dto.Currency, amount decimal.Decimal) (*dto.Settlement, error) {
+	settings, err := p.currencyStorage.GetCompanyFXSettings(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.SettlementCurrency == "" || settings.SettlementCurrency == presentment.Code {
+		return &dto.Settlement{Currency: presentment.Code, Amount: amount}, nil
+	}
+	if p.fxProvider == nil {
+		err := errors.ErrInternalServerError.New("exchange rates are not configured")
+		p.log.Error(ctx, "no fx provider for cross-currency settlement", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	settlement, err := p.currencyStorage.GetCompanyCurrency(ctx, companyID, settings.SettlementCurrency)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := p.fxProvider.Rate(ctx, string(presentment.Code), string(settlement.Code))
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to get exchange rate")
+		p.log.Error(ctx, "unable to get exchange rate", zap.Error(err),
+			zap.String("base", string(presentment.Code)), zap.String("quote", string(settlement.Code)))
+		return nil, err
+	}
+	applied := fx.ApplySpread(rate.Mid, settings.SpreadBps)
+
+	return &dto.Settlement{
+		Currency: settlement.Code,
+		Amount:   fx.Convert(amount, applied, int32(settlement.MinorUnits), fx.RoundDown),
+		FX: &dto.FXSnapshot{
+			MidRate:   rate.Mid,
+			SpreadBps: settings.SpreadBps,
+			Rate:      applied,
+			Source:    rate.Source,
+			AsOf:      rate.AsOf,
+		},
+	}, nil
+}
+
+// GetPaymentIntentDetail returns one of the company's payment intents. An
+// intent of another company or of the other mode is reported as not found.
+func (p *paymentIntent) GetPaymentIntentDetail(ctx context.Context, id, companyID string,
+	livemode bool) (*dto.PaymentIntentDetail, error) {
 	pID, err := uuid.Parse(id)
 	if err != nil {
 		err = errors.ErrInternalServerError.Wrap(err, "unable to parse payment intent id")
@@ -128,7 +321,19 @@ func (p *paymentIntent) GetPaymentIntentDetail(ctx context.Context,
 		return nil, err
 	}
 
-	return p.paymentIntentStorage.GetPaymentIntentByID(ctx, pID)
+	paymentIntent, err := p.paymentIntentStorage.GetPaymentIntentByID(ctx, pID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentIntent.Company.ID.String() != companyID || paymentIntent.Livemode != livemode {
+		err := errors.ErrNoRecordFound.New("payment intent not found")
+		p.log.Warn(ctx, "payment intent outside the caller's company or mode", zap.Error(err),
+			zap.String("payment-intent-id", id), zap.String("company-id", companyID))
+		return nil, err
+	}
+	paymentIntent.Risk = paymentIntent.Risk.ForMerchant()
+
+	return paymentIntent, nil
 }
 
 func (p *paymentIntent) StartWorker(ctx context.Context) {
@@ -212,14 +417,19 @@ func (p *paymentIntent) processPayment(ctx context.Context, d amqp091.Delivery)
 			return nil
 		}
 
-		// 3. Simulate processing
-		p.log.Info(ctx, "processing payment", zap.String("id", paymentIntentID))
-		time.Sleep(2 * time.Second)
+		// 3. Test intents never reach a real processor
+		var status constant.Status
+		if !pi.Livemode {
+			status = simulateCharge(pi.Amount)
+		} else {
+			p.log.Info(ctx, "processing payment", zap.String("id", paymentIntentID))
+			time.Sleep(2 * time.Second)
 
-		// 4. Randomly succeed or fail
-		status := constant.Success
-		if time.Now().Unix()%2 == 0 {
-			status = constant.Failed
+			// 4. Randomly succeed or fail
+			status = constant.Success
+			if time.Now().Unix()%2 == 0 {
+				status = constant.Failed
+			}
 		}
 
 		// 5. Update status
@@ -231,7 +441,29 @@ func (p *paymentIntent) processPayment(ctx context.Context, d amqp091.Delivery)
 			return err
 		}
 
-		p.log.Info(ctx, "payment processed", zap.String("id", paymentIntentID), zap.String("status", string(status)))
+		// 6. Credit the company and, for test intents, open the dispute the
+		// simulated processor raises
+		if status == constant.Success {
+			if err := tx.CreditPayment(ctx, pi); err != nil {
+				return err
+			}
+			if !pi.Livemode && simulateDispute(pi.Amount) {
+				if _, err := tx.OpenDispute(ctx, dto.CreateDispute{
+					CompanyID:       pi.CompanyID,
+					PaymentIntentID: pi.ID,
+					Livemode:        pi.Livemode,
+					Amount:          pi.Amount,
+					Currency:        constant.Currency(pi.Currency),
+					Reason:          constant.DisputeFraudulent,
+					EvidenceDueBy:   time.Now().Add(dto.DefaultDisputeEvidenceWindow),
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		p.log.Info(ctx, "payment processed", zap.String("id", paymentIntentID),
+			zap.String("status", string(status)), zap.Bool("livemode", pi.Livemode))
 		return nil
 	})
 
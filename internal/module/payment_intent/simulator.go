@@ -0,0 +1,32 @@
+package paymentintent
+
+import (
+	"pg/internal/constant"
+
+	"github.com/shopspring/decimal"
+)
+
+// simulatedFailureCents is the fractional part that makes a test payment
+// fail, so that merchants can exercise their failure handling.
+var simulatedFailureCents = decimal.RequireFromString("0.13")
+
+// simulatedDisputeCents is the fractional part that makes a test payment
+// succeed and then be disputed, so that merchants can exercise disputes.
+var simulatedDisputeCents = decimal.RequireFromString("0.14")
+
+// simulateCharge settles a test payment intent. Unlike the live processor
+// the outcome only depends on the amount: amounts ending in .13 fail and
+// every other amount succeeds.
+func simulateCharge(amount decimal.Decimal) constant.Status {
+	if amount.Sub(amount.Truncate(0)).Equal(simulatedFailureCents) {
+		return constant.Failed
+	}
+
+	return constant.Success
+}
+
+// simulateDispute reports whether the simulated processor disputes a test
+// payment intent once it succeeded: amounts ending in .14 are disputed.
+func simulateDispute(amount decimal.Decimal) bool {
+	return amount.Sub(amount.Truncate(0)).Equal(simulatedDisputeCents)
+}
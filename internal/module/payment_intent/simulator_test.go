@@ -0,0 +1,28 @@
+package paymentintent
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSimulateDispute(t *testing.T) {
+	cases := []struct {
+		amount string
+		want   bool
+	}{
+		{"100.14", true},
+		{"0.14", true},
+		{"100.140", true},
+		{"100.13", false},
+		{"100.41", false},
+		{"100.1", false},
+		{"14", false},
+		{"100.145", false},
+	}
+	for _, c := range cases {
+		if got := simulateDispute(decimal.RequireFromString(c.amount)); got != c.want {
+			t.Errorf("simulateDispute(%s) = %v, want %v", c.amount, got, c.want)
+		}
+	}
+}
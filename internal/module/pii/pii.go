@@ -0,0 +1,170 @@
+package pii
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type Options struct {
+	// DataKeyMaxAge rotates the active data key once it is this old. Zero
+	// leaves rotation to operators.
+	DataKeyMaxAge time.Duration
+	// ResealInterval is how often the worker rewraps data keys and reseals
+	// customers sealed with a retired key, hourly by default.
+	ResealInterval time.Duration
+	// BatchSize is how many customers are resealed per query, 500 by default.
+	BatchSize int
+}
+
+type pii struct {
+	log             hlog.Logger
+	piiStorage      storage.PII
+	operatorStorage storage.Operator
+	auditLog        module.Audit
+	options         Options
+}
+
+func New(piiStorage storage.PII,
+	operatorStorage storage.Operator,
+	auditLog module.Audit,
+	log hlog.Logger,
+	options Options) module.PII {
+	if options.ResealInterval <= 0 {
+		options.ResealInterval = time.Hour
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = 500
+	}
+	return &pii{
+		log:             log,
+		piiStorage:      piiStorage,
+		operatorStorage: operatorStorage,
+		auditLog:        auditLog,
+		options:         options,
+	}
+}
+
+func (p *pii) ListDataKeys(ctx context.Context) ([]dto.PIIDataKey, error) {
+	return p.piiStorage.ListDataKeys(ctx)
+}
+
+// RotateDataKey makes a new data key active. Customers sealed with the
+// previous key are resealed by the worker.
+func (p *pii) RotateDataKey(ctx context.Context, operatorID string) (*dto.PIIDataKey, error) {
+	id, err := uuid.Parse(operatorID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid operator id")
+		p.log.Error(ctx, "invalid operator id", zap.Error(err))
+		return nil, err
+	}
+	operator, err := p.operatorStorage.GetOperatorByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := p.piiStorage.RotateDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	event := dto.OperatorAuditEvent(*operator, uuid.Nil, constant.AuditPIIDataKeyRotated)
+	event.After = map[string]any{"data_key_id": key.ID}
+	p.auditLog.Record(ctx, event)
+
+	return key, nil
+}
+
+// Reseal moves every data key to the current master key, rotates the
+// active data key when it is too old, and reseals the customers and MFA
+// secrets that are not sealed with the active key, plaintext rows included.
+func (p *pii) Reseal(ctx context.Context) error {
+	rewrapped, err := p.piiStorage.RewrapDataKeys(ctx)
+	if err != nil {
+		return err
+	}
+	if rewrapped > 0 {
+		p.log.Info(ctx, "rewrapped data keys", zap.Int("count", rewrapped))
+	}
+	if err := p.rotateExpired(ctx); err != nil {
+		return err
+	}
+
+	total := 0
+	for {
+		count, err := p.piiStorage.ResealCustomers(ctx, p.options.BatchSize)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			break
+		}
+		total += count
+	}
+	if total > 0 {
+		p.log.Info(ctx, "resealed customers", zap.Int("count", total))
+	}
+
+	total = 0
+	for {
+		count, err := p.piiStorage.ResealMFASecrets(ctx, p.options.BatchSize)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			break
+		}
+		total += count
+	}
+	if total > 0 {
+		p.log.Info(ctx, "resealed mfa secrets", zap.Int("count", total))
+	}
+
+	return nil
+}
+
+func (p *pii) rotateExpired(ctx context.Context) error {
+	if p.options.DataKeyMaxAge <= 0 {
+		return nil
+	}
+	keys, err := p.piiStorage.ListDataKeys(ctx)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if !key.Active || time.Since(key.CreatedAt) < p.options.DataKeyMaxAge {
+			continue
+		}
+		rotated, err := p.piiStorage.RotateDataKey(ctx)
+		if err != nil {
+			return err
+		}
+		p.log.Info(ctx, "rotated data key", zap.String("retired", key.ID.String()),
+			zap.String("active", rotated.ID.String()))
+	}
+
+	return nil
+}
+
+// StartWorker runs Reseal every ResealInterval until ctx is done.
+func (p *pii) StartWorker(ctx context.Context) {
+	ticker := time.NewTicker(p.options.ResealInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Reseal(ctx); err != nil {
+				p.log.Error(ctx, "unable to reseal personal data", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package risk
+
+import (
+	"context"
+	"pg/initiator/platform/amqp"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultPerPage = 50
+
+type risk struct {
+	log                  hlog.Logger
+	riskStorage          storage.Risk
+	paymentIntentStorage storage.PaymentIntent
+	companyStorage       storage.Company
+	operatorStorage      storage.Operator
+	auditLog             module.Audit
+	amqpClient           amqp.Client
+}
+
+func New(riskStorage storage.Risk,
+	paymentIntentStorage storage.PaymentIntent,
+	companyStorage storage.Company,
+	operatorStorage storage.Operator,
+	auditLog module.Audit,
+	amqpClient amqp.Client,
+	log hlog.Logger) module.Risk {
+	return &risk{
+		log:                  log,
+		riskStorage:          riskStorage,
+		paymentIntentStorage: paymentIntentStorage,
+		companyStorage:       companyStorage,
+		operatorStorage:      operatorStorage,
+		auditLog:             auditLog,
+		amqpClient:           amqpClient,
+	}
+}
+
+func (r *risk) ListRules(ctx context.Context, companyID string) ([]dto.RiskRule, error) {
+	company, err := r.getCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.riskStorage.ListRiskRules(ctx, company.ID)
+}
+
+// CreateRule adds a rule to the company's risk rules. Payment intents
+// created afterwards are scored with it.
+func (r *risk) CreateRule(ctx context.Context, operatorID, companyID string,
+	param dto.SetRiskRule) (*dto.RiskRule, error) {
+	operator, company, err := r.prepareRule(ctx, operatorID, companyID, &param)
+	if err != nil {
+		return nil, err
+	}
+	param.CompanyID, param.UpdatedBy = company.ID, operator.ID
+	rule, err := r.riskStorage.CreateRiskRule(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	event := dto.OperatorAuditEvent(*operator, company.ID, constant.AuditRiskRuleCreated)
+	event.After = ruleFields(*rule)
+	r.auditLog.Record(ctx, event)
+
+	return rule, nil
+}
+
+// UpdateRule replaces one of the company's risk rules.
+func (r *risk) UpdateRule(ctx context.Context, operatorID, companyID, ruleID string,
+	param dto.SetRiskRule) (*dto.RiskRule, error) {
+	operator, company, err := r.prepareRule(ctx, operatorID, companyID, &param)
+	if err != nil {
+		return nil, err
+	}
+	id, err := r.parseID(ctx, ruleID, "risk rule")
+	if err != nil {
+		return nil, err
+	}
+	before, err := r.riskStorage.GetRiskRule(ctx, company.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	param.ID, param.CompanyID, param.UpdatedBy = id, company.ID, operator.ID
+	after, err := r.riskStorage.UpdateRiskRule(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	event := dto.OperatorAuditEvent(*operator, company.ID, constant.AuditRiskRuleUpdated)
+	event.Before = ruleFields(*before)
+	event.After = ruleFields(*after)
+	r.auditLog.Record(ctx, event)
+
+	return after, nil
+}
+
+func (r *risk) DeleteRule(ctx context.Context, operatorID, companyID, ruleID string) error {
+	operator, err := r.getOperator(ctx, operatorID)
+	if err != nil {
+		return err
+	}
+	company, err := r.getCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	id, err := r.parseID(ctx, ruleID, "risk rule")
+	if err != nil {
+		return err
+	}
+	before, err := r.riskStorage.GetRiskRule(ctx, company.ID, id)
+	if err != nil {
+		return err
+	}
+	if err := r.riskStorage.DeleteRiskRule(ctx, company.ID, id); err != nil {
+		return err
+	}
+
+	event := dto.OperatorAuditEvent(*operator, company.ID, constant.AuditRiskRuleDeleted)
+	event.Before = ruleFields(*before)
+	r.auditLog.Record(ctx, event)
+
+	return nil
+}
+
+func (r *risk) GetSettings(ctx context.Context, companyID string) (*dto.RiskSettings, error) {
+	company, err := r.getCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.riskStorage.GetRiskSettings(ctx, company.ID)
+}
+
+// SetSettings sets the scores at which the company's payment intents are
+// held for review and blocked.
+func (r *risk) SetSettings(ctx context.Context, operatorID, companyID string,
+	param dto.SetRiskSettings) (*dto.RiskSettings, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		r.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	operator, err := r.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	company, err := r.getCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	before, err := r.riskStorage.GetRiskSettings(ctx, company.ID)
+	if err != nil {
+		return nil, err
+	}
+	param.CompanyID, param.UpdatedBy = company.ID, operator.ID
+	after, err := r.riskStorage.SetRiskSettings(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	event := dto.OperatorAuditEvent(*operator, company.ID, constant.AuditRiskSettingsUpdated)
+	event.Before = settingsFields(*before)
+	event.After = settingsFields(*after)
+	r.auditLog.Record(ctx, event)
+
+	return after, nil
+}
+
+// ListReviews returns the payment intents held for review, oldest first,
+// of every company or of one.
+func (r *risk) ListReviews(ctx context.Context,
+	filter dto.RiskReviewFilter) ([]dto.RiskReview, int, error) {
+	if err := filter.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid filter")
+		r.log.Warn(ctx, "invalid risk review filter", zap.Error(err))
+		return nil, 0, err
+	}
+	query := dto.RiskReviewQuery{}
+	if filter.CompanyID != "" {
+		id, err := r.parseID(ctx, filter.CompanyID, "company")
+		if err != nil {
+			return nil, 0, err
+		}
+		query.CompanyID = id
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage <= 0 {
+		filter.PerPage = defaultPerPage
+	}
+	query.Limit, query.Offset = filter.PerPage, (filter.Page-1)*filter.PerPage
+
+	return r.riskStorage.ListRiskReviews(ctx, query)
+}
+
+// ReviewPaymentIntent approves a payment intent held for review, queueing
+// it to be processed, or rejects it, blocking it.
+func (r *risk) ReviewPaymentIntent(ctx context.Context, operatorID, paymentIntentID string,
+	param dto.RiskReviewDecision) (*dto.PaymentIntentDetail, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		r.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	operator, err := r.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := r.parseID(ctx, paymentIntentID, "payment intent")
+	if err != nil {
+		return nil, err
+	}
+	paymentIntent, err := r.paymentIntentStorage.GetPaymentIntentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if paymentIntent.Status != constant.Review {
+		err := errors.ErrInvalidUserInput.New("payment intent is %s, not held for review",
+			paymentIntent.Status)
+		r.log.Warn(ctx, "payment intent is not held for review", zap.Error(err),
+			zap.String("payment-intent-id", paymentIntentID))
+		return nil, err
+	}
+
+	status, action := constant.Pending, constant.AuditRiskReviewApproved
+	if param.Decision == constant.RiskReject {
+		status, action = constant.Blocked, constant.AuditRiskReviewRejected
+	}
+	note := strings.TrimSpace(param.Note)
+	if err := r.riskStorage.ReviewPaymentIntent(ctx, dto.ReviewPaymentIntent{
+		PaymentIntentID: paymentIntent.ID,
+		Status:          status,
+		ReviewedBy:      operator.ID,
+		Note:            note,
+	}, r.amqpClient); err != nil {
+		return nil, err
+	}
+	reviewed, err := r.paymentIntentStorage.GetPaymentIntentByID(ctx, paymentIntent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := dto.OperatorAuditEvent(*operator, paymentIntent.Company.ID, action)
+	event.Metadata = map[string]any{
+		"payment_intent_id": paymentIntent.ID,
+		"note":              note,
+	}
+	event.Before = map[string]any{"status": paymentIntent.Status}
+	event.After = map[string]any{"status": reviewed.Status}
+	r.auditLog.Record(ctx, event)
+	r.log.Info(ctx, "payment intent reviewed",
+		zap.String("payment-intent-id", paymentIntent.ID.String()),
+		zap.String("operator-id", operator.ID.String()),
+		zap.String("decision", string(param.Decision)))
+
+	return reviewed, nil
+}
+
+// prepareRule validates a rule and resolves the operator and company it is
+// set by and for. Rules are enabled unless they say otherwise.
+func (r *risk) prepareRule(ctx context.Context, operatorID, companyID string,
+	param *dto.SetRiskRule) (*dto.Operator, *dto.Company, error) {
+	param.Name = strings.TrimSpace(param.Name)
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		r.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, nil, err
+	}
+	if param.Enabled == nil {
+		enabled := true
+		param.Enabled = &enabled
+	}
+	operator, err := r.getOperator(ctx, operatorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	company, err := r.getCompany(ctx, companyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return operator, company, nil
+}
+
+func (r *risk) getCompany(ctx context.Context, companyID string) (*dto.Company, error) {
+	id, err := r.parseID(ctx, companyID, "company")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.companyStorage.GetCompanyByID(ctx, id)
+}
+
+func (r *risk) getOperator(ctx context.Context, operatorID string) (*dto.Operator, error) {
+	id, err := uuid.Parse(operatorID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid operator id")
+		r.log.Error(ctx, "invalid operator id", zap.Error(err))
+		return nil, err
+	}
+
+	return r.operatorStorage.GetOperatorByID(ctx, id)
+}
+
+func (r *risk) parseID(ctx context.Context, value, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid %s id", name)
+		r.log.Warn(ctx, "invalid "+name+" id", zap.Error(err), zap.String("id", value))
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+func ruleFields(rule dto.RiskRule) map[string]any {
+	return map[string]any{
+		"id":         rule.ID,
+		"name":       rule.Name,
+		"expression": rule.Expression,
+		"score":      rule.Score,
+		"enabled":    rule.Enabled,
+	}
+}
+
+func settingsFields(settings dto.RiskSettings) map[string]any {
+	return map[string]any{
+		"review_score": settings.ReviewScore,
+		"block_score":  settings.BlockScore,
+	}
+}
@@ -0,0 +1,314 @@
+package team
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hcrypto"
+	"pg/platform/hlog"
+	"pg/platform/notifier"
+	"pg/platform/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Options struct {
+	// InvitationURL is the dashboard page that accepts an invitation. The
+	// invitation token is appended as the "token" query parameter.
+	InvitationURL string
+}
+
+type team struct {
+	log            hlog.Logger
+	teamStorage    storage.Team
+	companyStorage storage.Company
+	auditLog       module.Audit
+	maker          hcrypto.Maker
+	notifier       notifier.Notifier
+	options        Options
+}
+
+func New(teamStorage storage.Team,
+	companyStorage storage.Company,
+	auditLog module.Audit,
+	log hlog.Logger,
+	maker hcrypto.Maker,
+	notifier notifier.Notifier,
+	options Options) module.Team {
+	return &team{
+		log:            log,
+		teamStorage:    teamStorage,
+		companyStorage: companyStorage,
+		auditLog:       auditLog,
+		maker:          maker,
+		notifier:       notifier,
+		options:        options,
+	}
+}
+
+func (t *team) InviteUser(ctx context.Context, userID string,
+	param dto.InviteUser) (*dto.UserInvitation, error) {
+	param.Email = strings.ToLower(strings.TrimSpace(param.Email))
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		t.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	inviter, err := t.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := t.companyStorage.PhoneOrEmailExists(ctx, param.Email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		err := errors.ErrInvalidUserInput.New("a user with this email already exists")
+		t.log.Warn(ctx, "invited email already registered", zap.Error(err))
+		return nil, err
+	}
+	company, err := t.companyStorage.GetCompanyByID(ctx, inviter.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, tokenID, err := t.maker.CreatePasetoToken(hcrypto.UserData{
+		UserID:   company.ID.String(),
+		Email:    param.Email,
+		Provider: constant.Normal,
+	}, constant.InviteTokenType)
+	if err != nil {
+		err = errors.ErrInternalServerError.Wrap(err, "unable to generate invitation token")
+		t.log.Error(ctx, "unable to generate invitation token", zap.Error(err))
+		return nil, err
+	}
+	invitation, err := t.teamStorage.CreateInvitation(ctx, dto.CreateUserInvitation{
+		CompanyID: company.ID,
+		Email:     param.Email,
+		Role:      param.Role,
+		TokenID:   tokenID,
+		InvitedBy: inviter.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.notifier.Send(ctx, notifier.Message{
+		Channel: notifier.ChannelEmail,
+		To:      param.Email,
+		Subject: fmt.Sprintf("You have been invited to join %s", company.Name),
+		Body: fmt.Sprintf("%s invited you to join %s as %s. Accept the invitation here: %s",
+			inviter.FirstName, company.Name, param.Role, t.invitationLink(token)),
+	}); err != nil {
+		err = errors.ErrUnableToSendMail.Wrap(err, "unable to send invitation")
+		t.log.Error(ctx, "unable to send invitation", zap.Error(err),
+			zap.String("invitation-id", invitation.ID.String()))
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*inviter, constant.AuditTeamInvitationCreated)
+	event.Metadata = map[string]any{
+		"invitation_id": invitation.ID,
+		"email":         invitation.Email,
+		"role":          invitation.Role,
+	}
+	t.auditLog.Record(ctx, event)
+
+	return invitation, nil
+}
+
+func (t *team) AcceptInvitation(ctx context.Context,
+	param dto.AcceptInvitation) (*dto.User, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		t.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	payload, err := t.maker.VerifyPasetoToken(param.Token)
+	if err != nil {
+		err = errors.ErrInvalidAccessToken.Wrap(err, "invalid invitation token")
+		t.log.Warn(ctx, "invalid invitation token", zap.Error(err))
+		return nil, err
+	}
+	if payload.TokenType != constant.InviteTokenType {
+		err := errors.ErrInvalidAccessToken.New("invalid invitation token")
+		t.log.Warn(ctx, "token is not an invitation token", zap.Error(err),
+			zap.String("token-type", string(payload.TokenType)))
+		return nil, err
+	}
+	invitation, err := t.teamStorage.GetInvitationByTokenID(ctx, payload.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.Status != constant.Pending {
+		err := errors.ErrInvalidUserInput.New("invitation is no longer valid")
+		t.log.Warn(ctx, "invitation is not pending", zap.Error(err),
+			zap.String("invitation-id", invitation.ID.String()),
+			zap.String("status", string(invitation.Status)))
+		return nil, err
+	}
+
+	phone, err := utils.ParsePhoneNumber(param.Phone)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "failed to parse phone number")
+		t.log.Error(ctx, "failed to parse phone number",
+			zap.Error(err), zap.String("phone", param.Phone))
+		return nil, err
+	}
+	for _, value := range []string{invitation.Email, *phone} {
+		exists, err := t.companyStorage.PhoneOrEmailExists(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			err := errors.ErrInvalidUserInput.New("a user with this email or phone already exists")
+			t.log.Warn(ctx, "invited user already registered", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(param.Password), bcrypt.DefaultCost)
+	if err != nil {
+		err = errors.ErrUnableToHashPassword.Wrap(err,
+			"Unable to generate password hash")
+		t.log.Error(ctx, "unable to generate password hash", zap.Error(err))
+		return nil, err
+	}
+
+	user, err := t.teamStorage.AcceptInvitation(ctx, invitation.ID, dto.CreateUser{
+		CompanyID: invitation.CompanyID,
+		FirstName: param.FirstName,
+		Email:     invitation.Email,
+		Phone:     *phone,
+		Password:  string(hashedPassword),
+		Role:      invitation.Role,
+		Status:    constant.Active,
+		// The invitation link was delivered to this address.
+		EmailVerifiedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*user, constant.AuditTeamInvitationAccepted)
+	event.Metadata = map[string]any{"invitation_id": invitation.ID}
+	t.auditLog.Record(ctx, event)
+
+	return user, nil
+}
+
+func (t *team) ListMembers(ctx context.Context, userID string) ([]dto.User, error) {
+	user, err := t.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.teamStorage.ListMembers(ctx, user.CompanyID)
+}
+
+func (t *team) UpdateMemberRole(ctx context.Context, userID, memberID string,
+	param dto.UpdateMemberRole) (*dto.User, error) {
+	if err := param.Validate(); err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid input")
+		t.log.Warn(ctx, "invalid input", zap.Error(err))
+		return nil, err
+	}
+	actor, member, err := t.getManageableMember(ctx, userID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := t.teamStorage.UpdateMemberRole(ctx, actor.CompanyID, member.ID, param.Role)
+	if err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*actor, constant.AuditTeamMemberRoleChanged)
+	event.Metadata = map[string]any{"member_id": member.ID}
+	event.Before = map[string]any{"role": member.Role}
+	event.After = map[string]any{"role": user.Role}
+	t.auditLog.Record(ctx, event)
+
+	return user, nil
+}
+
+func (t *team) DeactivateMember(ctx context.Context,
+	userID, memberID string) (*dto.User, error) {
+	actor, member, err := t.getManageableMember(ctx, userID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := t.teamStorage.UpdateMemberStatus(ctx, actor.CompanyID, member.ID, constant.Inactive)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.companyStorage.ResetActiveToken(ctx, member.ID); err != nil {
+		return nil, err
+	}
+	event := dto.UserAuditEvent(*actor, constant.AuditTeamMemberStatusChanged)
+	event.Metadata = map[string]any{"member_id": member.ID}
+	event.Before = map[string]any{"status": member.Status}
+	event.After = map[string]any{"status": user.Status}
+	t.auditLog.Record(ctx, event)
+
+	return user, nil
+}
+
+// getManageableMember loads the acting user and the member they want to
+// change, making sure both belong to the same company and that the member
+// is neither the actor nor the company owner.
+func (t *team) getManageableMember(ctx context.Context,
+	userID, memberID string) (*dto.User, *dto.User, error) {
+	actor, err := t.getUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	member, err := t.getUser(ctx, memberID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if member.CompanyID != actor.CompanyID {
+		err := errors.ErrNoRecordFound.New("member not found")
+		t.log.Warn(ctx, "member belongs to another company", zap.Error(err),
+			zap.String("member-id", memberID))
+		return nil, nil, err
+	}
+	if member.ID == actor.ID {
+		err := errors.ErrInvalidUserInput.New("you cannot change your own membership")
+		t.log.Warn(ctx, "user tried to change own membership", zap.Error(err))
+		return nil, nil, err
+	}
+	if constant.Role(member.Role) == constant.Owner {
+		err := errors.ErrAcessError.New("the company owner cannot be changed")
+		t.log.Warn(ctx, "user tried to change the company owner", zap.Error(err))
+		return nil, nil, err
+	}
+
+	return actor, member, nil
+}
+
+func (t *team) getUser(ctx context.Context, userID string) (*dto.User, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "Invalid user id")
+		t.log.Error(ctx, "Invalid user id", zap.Error(err))
+		return nil, err
+	}
+
+	return t.companyStorage.GetUserByID(ctx, id)
+}
+
+func (t *team) invitationLink(token string) string {
+	if t.options.InvitationURL == "" {
+		return token
+	}
+
+	return t.options.InvitationURL + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"pg/internal/constant"
+	"pg/internal/constant/model/dto"
+	"pg/internal/module"
+	"pg/internal/storage"
+	"pg/platform/hcrypto"
+	"pg/platform/hlog"
+	"pg/platform/httpclient"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxBackoff caps the wait between two delivery attempts of an event.
+const maxBackoff = 6 * time.Hour
+
+type Options struct {
+	// Interval is how often the worker looks for due events, every 10
+	// seconds by default.
+	Interval time.Duration
+	// BatchSize is how many events are delivered per interval, 100 by
+	// default.
+	BatchSize int
+	// MaxAttempts is how many times an event is tried before it is given
+	// up, 8 by default.
+	MaxAttempts int
+	// Lease keeps a claimed event from being claimed by another worker
+	// while it is delivered, 1 minute by default.
+	Lease time.Duration
+}
+
+type webhook struct {
+	log            hlog.Logger
+	webhookStorage storage.Webhook
+	companyStorage storage.Company
+	httpClient     httpclient.HTTPClient
+	options        Options
+}
+
+// body is what a company's callback_url receives for an event.
+type body struct {
+	ID        uuid.UUID                 `json:"id"`
+	Type      constant.WebhookEventType `json:"type"`
+	Livemode  bool                      `json:"livemode"`
+	CreatedAt time.Time                 `json:"created_at"`
+	Data      json.RawMessage           `json:"data"`
+}
+
+func New(webhookStorage storage.Webhook,
+	companyStorage storage.Company,
+	httpClient httpclient.HTTPClient,
+	log hlog.Logger,
+	options Options) module.Webhook {
+	if options.Interval <= 0 {
+		options.Interval = 10 * time.Second
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = 100
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = 8
+	}
+	if options.Lease <= 0 {
+		options.Lease = time.Minute
+	}
+	return &webhook{
+		log:            log,
+		webhookStorage: webhookStorage,
+		companyStorage: companyStorage,
+		httpClient:     httpClient,
+		options:        options,
+	}
+}
+
+// StartWorker delivers due events every Interval until ctx is done.
+func (w *webhook) StartWorker(ctx context.Context) {
+	ticker := time.NewTicker(w.options.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			events, err := w.webhookStorage.ClaimDueEvents(ctx, w.options.BatchSize, w.options.Lease)
+			if err != nil {
+				w.log.Error(ctx, "unable to claim webhook events", zap.Error(err))
+				continue
+			}
+			for _, event := range events {
+				w.deliver(ctx, event)
+			}
+		}
+	}
+}
+
+// deliver posts an event to the company's callback_url. Any answer other
+// than 2xx is a failure, retried with an exponential backoff.
+func (w *webhook) deliver(ctx context.Context, event dto.WebhookEvent) {
+	if err := w.post(ctx, event); err != nil {
+		attempts := event.Attempts + 1
+		w.log.Warn(ctx, "unable to deliver webhook event", zap.Error(err),
+			zap.String("webhook-event-id", event.ID.String()),
+			zap.String("type", string(event.Type)),
+			zap.Int("attempts", attempts))
+		if err := w.webhookStorage.MarkFailed(ctx, event.ID, err.Error(),
+			time.Now().Add(backoff(attempts)), w.options.MaxAttempts); err != nil {
+			w.log.Error(ctx, "unable to record webhook failure", zap.Error(err),
+				zap.String("webhook-event-id", event.ID.String()))
+		}
+		return
+	}
+
+	// An event delivered but not marked is delivered again once its lease
+	// ends; receivers deduplicate by X-PG-Event-ID.
+	if err := w.webhookStorage.MarkDelivered(ctx, event.ID); err != nil {
+		w.log.Error(ctx, "unable to mark webhook event delivered", zap.Error(err),
+			zap.String("webhook-event-id", event.ID.String()))
+	}
+}
+
+// post sends an event signed with the company's newest active HMAC key of
+// the event's mode. The receiver checks it like a PG-HMAC request:
+//
+//	X-PG-Timestamp: <unix seconds>
+//	X-PG-Signature: keyId=<key id>,signature=<hex hmac-sha256>
+//
+// where the signature covers hcrypto.WebhookSigningString of the
+// timestamp, the X-PG-Event-ID header and the raw body. Receivers should
+// also refuse timestamps more than a few minutes old. A company without an
+// active key cannot be sent events; they are retried until it creates one.
+func (w *webhook) post(ctx context.Context, event dto.WebhookEvent) error {
+	company, err := w.companyStorage.GetCompanyByID(ctx, event.CompanyID)
+	if err != nil {
+		return err
+	}
+	if company.CallBackURL == "" {
+		return fmt.Errorf("company has no callback url")
+	}
+	key, err := w.companyStorage.GetLatestHMACKey(ctx, event.CompanyID, event.Livemode)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(body{
+		ID:        event.ID,
+		Type:      event.Type,
+		Livemode:  event.Livemode,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := hcrypto.SignHMAC(key.Secret,
+		hcrypto.WebhookSigningString(timestamp, event.ID.String(), payload))
+
+	resp, err := w.httpClient.DoRequest(ctx, http.MethodPost, company.CallBackURL, "",
+		func(r *http.Request) {
+			r.Header.Set(constant.WebhookEventIDHeader, event.ID.String())
+			r.Header.Set(constant.WebhookEventTypeHeader, string(event.Type))
+			r.Header.Set(constant.HMACTimestampHeader, timestamp)
+			r.Header.Set(constant.WebhookSignatureHeader,
+				fmt.Sprintf("keyId=%s,signature=%s", key.KeyID, signature))
+		},
+		payload, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("callback url answered %s", resp.Status)
+	}
+
+	return nil
+}
+
+// backoff is how long to wait after the given number of failed attempts:
+// one minute, then doubling up to maxBackoff.
+func backoff(attempts int) time.Duration {
+	wait := time.Minute
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxBackoff)
+}
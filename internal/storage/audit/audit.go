@@ -0,0 +1,185 @@
+package audit
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+
+	"github.com/jackc/pgtype"
+	"go.uber.org/zap"
+)
+
+type auditPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewAuditPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Audit {
+	return &auditPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (a *auditPersistance) CreateAuditEvent(ctx context.Context,
+	param dto.CreateAuditEvent) (*dto.AuditEvent, error) {
+	if param.Identifier != "" {
+		withIdentifier := make(map[string]any, len(param.Metadata)+1)
+		for key, value := range param.Metadata {
+			withIdentifier[key] = value
+		}
+		withIdentifier["identifier_hash"] = hex.EncodeToString(
+			a.persistenceQueries.AuditIdentifierIndex(param.Identifier))
+		param.Metadata = withIdentifier
+	}
+	metadata, err := toJSONB(param.Metadata)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid audit metadata")
+		a.logger.Error(ctx, "invalid audit metadata", zap.Error(err))
+		return nil, err
+	}
+	before, err := toJSONB(param.Before)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid audit before state")
+		a.logger.Error(ctx, "invalid audit before state", zap.Error(err))
+		return nil, err
+	}
+	after, err := toJSONB(param.After)
+	if err != nil {
+		err = errors.ErrInvalidUserInput.Wrap(err, "invalid audit after state")
+		a.logger.Error(ctx, "invalid audit after state", zap.Error(err))
+		return nil, err
+	}
+
+	event, err := a.persistenceQueries.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		CompanyID: sql.UUIDOrNull(param.CompanyID),
+		ActorType: string(param.ActorType),
+		ActorID:   sql.UUIDOrNull(param.ActorID),
+		Action:    string(param.Action),
+		IpAddress: param.IPAddress,
+		RequestID: param.RequestID,
+		Metadata:  metadata,
+		Before:    before,
+		After:     after,
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create audit event")
+		a.logger.Error(ctx, "unable to create audit event",
+			zap.Error(err), zap.String("action", string(param.Action)))
+		return nil, err
+	}
+
+	return toAuditEvent(event), nil
+}
+
+func (a *auditPersistance) ListAuditEvents(ctx context.Context,
+	query dto.AuditEventQuery) ([]dto.AuditEvent, int, error) {
+	filter := db.CountAuditEventsParams{
+		CompanyID: sql.UUIDOrNull(query.CompanyID),
+		Action:    sql.StringOrNull(query.Action),
+		ActorID:   sql.UUIDOrNull(query.ActorID),
+		FromTime:  sql.TimeOrNull(query.From),
+		ToTime:    sql.TimeOrNull(query.To),
+	}
+	total, err := a.persistenceQueries.CountAuditEvents(ctx, filter)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to count audit events")
+		a.logger.Error(ctx, "unable to count audit events", zap.Error(err))
+		return nil, 0, err
+	}
+	events, err := a.persistenceQueries.ListAuditEvents(ctx, db.ListAuditEventsParams{
+		CompanyID:  filter.CompanyID,
+		Action:     filter.Action,
+		ActorID:    filter.ActorID,
+		FromTime:   filter.FromTime,
+		ToTime:     filter.ToTime,
+		PageLimit:  int32(query.Limit),
+		PageOffset: int32(query.Offset),
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list audit events")
+		a.logger.Error(ctx, "unable to list audit events", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]dto.AuditEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, *toAuditEvent(event))
+	}
+	return result, int(total), nil
+}
+
+func (a *auditPersistance) ListAuditEventsBefore(ctx context.Context,
+	query dto.AuditEventQuery, last *dto.AuditEvent) ([]dto.AuditEvent, error) {
+	params := db.ListAuditEventsBeforeParams{
+		CompanyID: sql.UUIDOrNull(query.CompanyID),
+		Action:    sql.StringOrNull(query.Action),
+		ActorID:   sql.UUIDOrNull(query.ActorID),
+		FromTime:  sql.TimeOrNull(query.From),
+		ToTime:    sql.TimeOrNull(query.To),
+		PageLimit: int32(query.Limit),
+	}
+	if last != nil {
+		params.BeforeCreatedAt = sql.TimeOrNull(last.CreatedAt)
+		params.BeforeID = sql.UUIDOrNull(last.ID)
+	}
+	events, err := a.persistenceQueries.ListAuditEventsBefore(ctx, params)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list audit events")
+		a.logger.Error(ctx, "unable to list audit events", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]dto.AuditEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, *toAuditEvent(event))
+	}
+	return result, nil
+}
+
+func toJSONB(value map[string]any) (pgtype.JSONB, error) {
+	if len(value) == 0 {
+		return pgtype.JSONB{Status: pgtype.Null}, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return pgtype.JSONB{}, err
+	}
+	return pgtype.JSONB{Bytes: raw, Status: pgtype.Present}, nil
+}
+
+func fromJSONB(value pgtype.JSONB) map[string]any {
+	if value.Status != pgtype.Present {
+		return nil
+	}
+	result := map[string]any{}
+	if err := json.Unmarshal(value.Bytes, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+func toAuditEvent(event db.AuditEvent) *dto.AuditEvent {
+	return &dto.AuditEvent{
+		ID:        event.ID,
+		CompanyID: event.CompanyID.UUID,
+		ActorType: constant.AuditActorType(event.ActorType),
+		ActorID:   event.ActorID.UUID,
+		Action:    constant.AuditAction(event.Action),
+		IPAddress: event.IpAddress,
+		RequestID: event.RequestID,
+		Metadata:  fromJSONB(event.Metadata),
+		Before:    fromJSONB(event.Before),
+		After:     fromJSONB(event.After),
+		CreatedAt: event.CreatedAt,
+	}
+}
@@ -0,0 +1,292 @@
+package blocklist
+
+import (
+	"context"
+	"net"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+	"go.uber.org/zap"
+)
+
+type blocklistPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewBlocklistPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Blocklist {
+	return &blocklistPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (b *blocklistPersistance) CreateBlocklistEntry(ctx context.Context,
+	param dto.CreateBlocklistEntry) (*dto.BlocklistEntry, error) {
+	entry, err := b.persistenceQueries.CreateBlocklistEntry(ctx, db.CreateBlocklistEntryParams{
+		CompanyID:         sql.UUIDOrNull(param.CompanyID),
+		Type:              string(param.Type),
+		Value:             param.Value,
+		Network:           toCIDR(param.Network),
+		Reason:            param.Reason,
+		CreatedByUser:     sql.UUIDOrNull(param.CreatedByUser),
+		CreatedByOperator: sql.UUIDOrNull(param.CreatedByOperator),
+	})
+	if err != nil {
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "value is already on the blocklist")
+			b.logger.Warn(ctx, "duplicate blocklist entry", zap.Error(err))
+			return nil, err
+		}
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create blocklist entry")
+		b.logger.Error(ctx, "unable to create blocklist entry", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()))
+		return nil, err
+	}
+
+	return toBlocklistEntry(entry), nil
+}
+
+func (b *blocklistPersistance) GetBlocklistEntry(ctx context.Context,
+	id uuid.UUID) (*dto.BlocklistEntry, error) {
+	entry, err := b.persistenceQueries.GetBlocklistEntry(ctx, id)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "blocklist entry not found")
+			b.logger.Warn(ctx, "blocklist entry not found", zap.Error(err),
+				zap.String("blocklist-entry-id", id.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get blocklist entry")
+		b.logger.Error(ctx, "unable to get blocklist entry", zap.Error(err),
+			zap.String("blocklist-entry-id", id.String()))
+		return nil, err
+	}
+
+	return toBlocklistEntry(entry), nil
+}
+
+func (b *blocklistPersistance) ListBlocklistEntries(ctx context.Context,
+	query dto.BlocklistQuery) ([]dto.BlocklistEntry, int, error) {
+	companyID := sql.UUIDOrNull(query.CompanyID)
+	entryType := sql.StringOrNull(string(query.Type))
+	entries, err := b.persistenceQueries.ListBlocklistEntries(ctx, db.ListBlocklistEntriesParams{
+		CompanyID:    companyID,
+		PlatformOnly: query.PlatformOnly,
+		Type:         entryType,
+		PageLimit:    int32(query.Limit),
+		PageOffset:   int32(query.Offset),
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list blocklist entries")
+		b.logger.Error(ctx, "unable to list blocklist entries", zap.Error(err))
+		return nil, 0, err
+	}
+	total, err := b.persistenceQueries.CountBlocklistEntries(ctx, db.CountBlocklistEntriesParams{
+		CompanyID:    companyID,
+		PlatformOnly: query.PlatformOnly,
+		Type:         entryType,
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to count blocklist entries")
+		b.logger.Error(ctx, "unable to count blocklist entries", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]dto.BlocklistEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, *toBlocklistEntry(entry))
+	}
+	return result, int(total), nil
+}
+
+func (b *blocklistPersistance) UpdateBlocklistEntry(ctx context.Context,
+	param dto.UpdateBlocklistEntry) (*dto.BlocklistEntry, error) {
+	entry, err := b.persistenceQueries.UpdateBlocklistEntry(ctx, db.UpdateBlocklistEntryParams{
+		ID:      param.ID,
+		Type:    string(param.Type),
+		Value:   param.Value,
+		Network: toCIDR(param.Network),
+		Reason:  param.Reason,
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "blocklist entry not found")
+			b.logger.Warn(ctx, "blocklist entry not found", zap.Error(err),
+				zap.String("blocklist-entry-id", param.ID.String()))
+			return nil, err
+		}
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "value is already on the blocklist")
+			b.logger.Warn(ctx, "duplicate blocklist entry", zap.Error(err))
+			return nil, err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update blocklist entry")
+		b.logger.Error(ctx, "unable to update blocklist entry", zap.Error(err),
+			zap.String("blocklist-entry-id", param.ID.String()))
+		return nil, err
+	}
+
+	return toBlocklistEntry(entry), nil
+}
+
+func (b *blocklistPersistance) DeleteBlocklistEntry(ctx context.Context, id uuid.UUID) error {
+	deleted, err := b.persistenceQueries.DeleteBlocklistEntry(ctx, id)
+	if err != nil {
+		err = errors.ErrDBDelError.Wrap(err, "unable to delete blocklist entry")
+		b.logger.Error(ctx, "unable to delete blocklist entry", zap.Error(err),
+			zap.String("blocklist-entry-id", id.String()))
+		return err
+	}
+	if deleted == 0 {
+		err := errors.ErrNoRecordFound.New("blocklist entry not found")
+		b.logger.Warn(ctx, "blocklist entry not found", zap.Error(err),
+			zap.String("blocklist-entry-id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+// MatchBlocklist returns the entries of the company and of every company
+// the customer is on, the company's own first.
+func (b *blocklistPersistance) MatchBlocklist(ctx context.Context,
+	check dto.BlocklistCheck) ([]dto.BlocklistEntry, error) {
+	ipAddress := pgtype.Inet{Status: pgtype.Null}
+	if ip := net.ParseIP(check.IPAddress); ip != nil {
+		bits := 128
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 32
+		}
+		ipAddress = pgtype.Inet{
+			IPNet:  &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)},
+			Status: pgtype.Present,
+		}
+	}
+	entries, err := b.persistenceQueries.MatchBlocklist(ctx, db.MatchBlocklistParams{
+		CompanyID:    sql.UUIDOrNull(check.CompanyID),
+		PhoneNumber:  sql.StringOrNull(check.PhoneNumber),
+		EmailDomains: check.EmailDomains(),
+		IpAddress:    ipAddress,
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to check blocklist")
+		b.logger.Error(ctx, "unable to check blocklist", zap.Error(err),
+			zap.String("company-id", check.CompanyID.String()))
+		return nil, err
+	}
+
+	result := make([]dto.BlocklistEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, *toBlocklistEntry(entry))
+	}
+	return result, nil
+}
+
+// RecordBlocklistHit stores a refused payment intent and counts it on the
+// entry that refused it. The payer's phone number is only kept as its blind
+// index, so that the hit is anonymized when the customer is erased.
+func (b *blocklistPersistance) RecordBlocklistHit(ctx context.Context,
+	param dto.CreateBlocklistHit) error {
+	if err := b.persistenceQueries.RecordBlocklistHit(ctx, db.RecordBlocklistHitParams{
+		EntryID:          sql.UUIDOrNull(param.EntryID),
+		CompanyID:        param.CompanyID,
+		Type:             string(param.Type),
+		Value:            param.Value,
+		Amount:           param.Amount,
+		Currency:         string(param.Currency),
+		Livemode:         param.Livemode,
+		PhoneNumberIndex: b.persistenceQueries.CustomerPhoneIndex(param.CompanyID, param.PhoneNumber),
+	}); err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to record blocklist hit")
+		b.logger.Error(ctx, "unable to record blocklist hit", zap.Error(err),
+			zap.String("blocklist-entry-id", param.EntryID.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (b *blocklistPersistance) ListBlocklistHits(ctx context.Context,
+	query dto.BlocklistHitQuery) ([]dto.BlocklistHit, int, error) {
+	companyID, entryID := sql.UUIDOrNull(query.CompanyID), sql.UUIDOrNull(query.EntryID)
+	hits, err := b.persistenceQueries.ListBlocklistHits(ctx, db.ListBlocklistHitsParams{
+		CompanyID:  companyID,
+		EntryID:    entryID,
+		PageLimit:  int32(query.Limit),
+		PageOffset: int32(query.Offset),
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list blocklist hits")
+		b.logger.Error(ctx, "unable to list blocklist hits", zap.Error(err))
+		return nil, 0, err
+	}
+	total, err := b.persistenceQueries.CountBlocklistHits(ctx, db.CountBlocklistHitsParams{
+		CompanyID: companyID,
+		EntryID:   entryID,
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to count blocklist hits")
+		b.logger.Error(ctx, "unable to count blocklist hits", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]dto.BlocklistHit, 0, len(hits))
+	for _, hit := range hits {
+		item := dto.BlocklistHit{
+			ID:          hit.ID,
+			CompanyID:   hit.CompanyID,
+			CompanyName: hit.CompanyName,
+			Type:        constant.BlocklistType(hit.Type),
+			Value:       hit.Value,
+			Amount:      hit.Amount,
+			Currency:    constant.Currency(hit.Currency),
+			Livemode:    hit.Livemode,
+			CreatedAt:   hit.CreatedAt,
+		}
+		if hit.EntryID.Valid {
+			entryID := hit.EntryID.UUID
+			item.EntryID = &entryID
+		}
+		result = append(result, item)
+	}
+	return result, int(total), nil
+}
+
+func toCIDR(network *net.IPNet) pgtype.CIDR {
+	if network == nil {
+		return pgtype.CIDR{Status: pgtype.Null}
+	}
+	return pgtype.CIDR{IPNet: network, Status: pgtype.Present}
+}
+
+func toBlocklistEntry(entry db.BlocklistEntry) *dto.BlocklistEntry {
+	result := &dto.BlocklistEntry{
+		ID:        entry.ID,
+		Type:      constant.BlocklistType(entry.Type),
+		Value:     entry.Value,
+		Reason:    entry.Reason,
+		HitCount:  int(entry.HitCount),
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+	if entry.CompanyID.Valid {
+		companyID := entry.CompanyID.UUID
+		result.CompanyID = &companyID
+	}
+	if entry.LastHitAt.Valid {
+		lastHitAt := entry.LastHitAt.Time
+		result.LastHitAt = &lastHitAt
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+package company
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (c *companyPersistance) ListCompanies(ctx context.Context,
+	query dto.CompanyQuery) ([]dto.Company, int, error) {
+	companies, err := c.persistenceQueries.ListCompanies(ctx, db.ListCompaniesParams{
+		Status:     sql.StringOrNull(query.Status),
+		Search:     sql.StringOrNull(query.Search),
+		PageLimit:  int32(query.Limit),
+		PageOffset: int32(query.Offset),
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list companies")
+		c.logger.Error(ctx, "unable to list companies", zap.Error(err))
+		return nil, 0, err
+	}
+	total, err := c.persistenceQueries.CountCompanies(ctx, db.CountCompaniesParams{
+		Status: sql.StringOrNull(query.Status),
+		Search: sql.StringOrNull(query.Search),
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to count companies")
+		c.logger.Error(ctx, "unable to count companies", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]dto.Company, 0, len(companies))
+	for _, company := range companies {
+		result = append(result, dto.Company{
+			ID:                 company.ID,
+			Name:               company.Name,
+			RegistrationNumber: company.RegistrationNumber,
+			AddressStreet:      company.AddressStreet.String,
+			AddressCity:        company.AddressCity.String,
+			AddressState:       company.AddressState.String,
+			AddressPostalCode:  company.AddressPostalCode.String,
+			AddressCountry:     company.AddressCountry.String,
+			PrimaryPhone:       company.PrimaryPhone.String,
+			SecondaryPhone:     company.SecondaryPhone.String,
+			Email:              company.Email.String,
+			Status:             company.Status,
+			Website:            company.Website.String,
+			CallBackURL:        company.CallbackUrl.String,
+			ReturnURL:          company.ReturnUrl.String,
+			MFARequired:        company.MfaRequired,
+			CreatedAt:          company.CreatedAt,
+			UpdatedAt:          company.UpdatedAt,
+		})
+	}
+
+	return result, int(total), nil
+}
+
+func (c *companyPersistance) SuspendCompany(ctx context.Context, id uuid.UUID) error {
+	suspended, err := c.persistenceQueries.SuspendCompany(ctx, id)
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to suspend company")
+		c.logger.Error(ctx, "unable to suspend company", zap.Error(err),
+			zap.String("company-id", id.String()))
+		return err
+	}
+	if suspended == 0 {
+		err := errors.ErrInvalidUserInput.New("company is already suspended")
+		c.logger.Warn(ctx, "company is already suspended", zap.Error(err),
+			zap.String("company-id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (c *companyPersistance) ReactivateCompany(ctx context.Context,
+	id uuid.UUID) (constant.Status, error) {
+	status, err := c.persistenceQueries.ReactivateCompany(ctx, id)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "company is not suspended")
+			c.logger.Warn(ctx, "company is not suspended", zap.Error(err),
+				zap.String("company-id", id.String()))
+			return "", err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to reactivate company")
+		c.logger.Error(ctx, "unable to reactivate company", zap.Error(err),
+			zap.String("company-id", id.String()))
+		return "", err
+	}
+
+	return constant.Status(status), nil
+}
@@ -68,6 +68,7 @@ func (c *companyPersistance) AddCompany(ctx context.Context,
 		Website:            company.Website.String,
 		CallBackURL:        company.CallbackUrl.String,
 		ReturnURL:          company.ReturnUrl.String,
+		MFARequired:        company.MfaRequired,
 		CreatedAt:          company.CreatedAt,
 		UpdatedAt:          company.UpdatedAt,
 	}, nil
@@ -97,6 +98,58 @@ func (c *companyPersistance) GetCompanyByID(ctx context.Context, id uuid.UUID) (
 		Website:            company.Website.String,
 		CallBackURL:        company.CallbackUrl.String,
 		ReturnURL:          company.ReturnUrl.String,
+		MFARequired:        company.MfaRequired,
+		CreatedAt:          company.CreatedAt,
+		UpdatedAt:          company.UpdatedAt,
+	}, nil
+}
+
+func (c *companyPersistance) UpdateCompanyProfile(ctx context.Context,
+	id uuid.UUID, arg dto.UpdateCompany) (*dto.Company, error) {
+	company, err := c.persistenceQueries.UpdateCompanyProfile(ctx,
+		db.UpdateCompanyProfileParams{
+			ID:                id,
+			AddressStreet:     sql.StringOrNullPntr(arg.AddressStreet),
+			AddressCity:       sql.StringOrNullPntr(arg.AddressCity),
+			AddressState:      sql.StringOrNullPntr(arg.AddressState),
+			AddressPostalCode: sql.StringOrNullPntr(arg.AddressPostalCode),
+			AddressCountry:    sql.StringOrNullPntr(arg.AddressCountry),
+			PrimaryPhone:      sql.StringOrNullPntr(arg.PrimaryPhone),
+			SecondaryPhone:    sql.StringOrNullPntr(arg.SecondaryPhone),
+			Website:           sql.StringOrNullPntr(arg.Website),
+			CallbackUrl:       sql.StringOrNullPntr(arg.CallBackURL),
+			ReturnUrl:         sql.StringOrNullPntr(arg.ReturnURL),
+		})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "company not found")
+			c.logger.Warn(ctx, "company not found",
+				zap.Error(err), zap.String("id", id.String()))
+			return nil, err
+		}
+		err := errors.ErrUnableToUpdate.Wrap(err, "Unable to update Company")
+		c.logger.Error(ctx, "Unable to update Company",
+			zap.Error(err), zap.String("id", id.String()))
+		return nil, err
+	}
+
+	return &dto.Company{
+		ID:                 company.ID,
+		Name:               company.Name,
+		RegistrationNumber: company.RegistrationNumber,
+		AddressStreet:      company.AddressStreet.String,
+		AddressCity:        company.AddressCity.String,
+		AddressState:       company.AddressState.String,
+		AddressPostalCode:  company.AddressPostalCode.String,
+		AddressCountry:     company.AddressCountry.String,
+		PrimaryPhone:       company.PrimaryPhone.String,
+		SecondaryPhone:     company.SecondaryPhone.String,
+		Email:              company.Email.String,
+		Status:             company.Status,
+		Website:            company.Website.String,
+		CallBackURL:        company.CallbackUrl.String,
+		ReturnURL:          company.ReturnUrl.String,
+		MFARequired:        company.MfaRequired,
 		CreatedAt:          company.CreatedAt,
 		UpdatedAt:          company.UpdatedAt,
 	}, nil
@@ -108,6 +161,7 @@ func (c *companyPersistance) CreateCompanyToken(ctx context.Context,
 		db.CreateCompanyTokenParams{
 			CompanyID: arg.CompanyID,
 			TokenID:   arg.TokenID,
+			Livemode:  arg.Livemode,
 		})
 	if err != nil {
 		err := errors.ErrUnableToCreate.Wrap(err, "Unable to create Company Token")
@@ -120,14 +174,18 @@ func (c *companyPersistance) CreateCompanyToken(ctx context.Context,
 		CompanyID: token.CompanyID,
 		TokenID:   token.TokenID,
 		Status:    token.Status,
+		Livemode:  token.Livemode,
 		CreatedAt: token.CreatedAt,
 		UpdatedAt: token.UpdatedAt,
 		DeletedAt: token.DeletedAt.Time,
 	}, nil
 }
 func (c *companyPersistance) InactiveToken(ctx context.Context,
-	companyID uuid.UUID) error {
-	err := c.persistenceQueries.Queries.InActiveCompanyToken(ctx, companyID)
+	companyID uuid.UUID, livemode bool) error {
+	err := c.persistenceQueries.Queries.InActiveCompanyToken(ctx, db.InActiveCompanyTokenParams{
+		CompanyID: companyID,
+		Livemode:  livemode,
+	})
 	if err != nil {
 		err := errors.ErrUnableToUpdate.Wrap(err, "Unable to inactive Token")
 		c.logger.Error(ctx, "Unable to inactive Token",
@@ -142,12 +200,13 @@ func (c *companyPersistance) GenerateCompanyCredentials(ctx context.Context,
 		dto.CreateCompanyToken{
 			CompanyID: arg.CompanyID,
 			TokenID:   arg.TokenID,
+			Livemode:  arg.Livemode,
 		})
 }
 
-func (c *companyPersistance) GetActiveCompanyTokenByID(ctx context.Context,
-	id uuid.UUID) (*dto.CompanyToken, error) {
-	token, err := c.persistenceQueries.GetActiveCompanyTokenByID(ctx, id)
+func (c *companyPersistance) GetActiveCompanyTokenByTokenID(ctx context.Context,
+	tokenID uuid.UUID) (*dto.CompanyToken, error) {
+	token, err := c.persistenceQueries.GetActiveCompanyTokenByTokenID(ctx, tokenID)
 	if err != nil {
 		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
 			err := errors.ErrNoRecordFound.Wrap(err, "active company token not found")
@@ -164,6 +223,7 @@ func (c *companyPersistance) GetActiveCompanyTokenByID(ctx context.Context,
 		TokenID:   token.TokenID,
 		CompanyID: token.CompanyID,
 		Status:    token.Status,
+		Livemode:  token.Livemode,
 		CreatedAt: token.CreatedAt,
 		UpdatedAt: token.UpdatedAt,
 	}, nil
@@ -0,0 +1,160 @@
+package company
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (c *companyPersistance) CreateHMACKey(ctx context.Context,
+	param dto.CreateHMACKey) (*dto.HMACKey, error) {
+	key, err := c.persistenceQueries.CreateHMACKey(ctx, db.CreateHMACKeyParams{
+		KeyID:     param.KeyID,
+		CompanyID: param.CompanyID,
+		Secret:    param.Secret,
+		Livemode:  param.Livemode,
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create hmac key")
+		c.logger.Error(ctx, "unable to create hmac key",
+			zap.Error(err), zap.String("company-id", param.CompanyID.String()))
+		return nil, err
+	}
+
+	return toHMACKey(key), nil
+}
+
+func (c *companyPersistance) ListHMACKeys(ctx context.Context,
+	companyID uuid.UUID) ([]dto.HMACKey, error) {
+	keys, err := c.persistenceQueries.ListHMACKeys(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list hmac keys")
+		c.logger.Error(ctx, "unable to list hmac keys",
+			zap.Error(err), zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	result := make([]dto.HMACKey, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *toHMACKey(key))
+	}
+	return result, nil
+}
+
+func (c *companyPersistance) GetActiveHMACKey(ctx context.Context,
+	keyID string) (*dto.HMACKey, error) {
+	key, err := c.persistenceQueries.GetActiveHMACKeyByKeyID(ctx, keyID)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "hmac key not found")
+			c.logger.Warn(ctx, "hmac key not found",
+				zap.Error(err), zap.String("key-id", keyID))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get hmac key")
+		c.logger.Error(ctx, "unable to get hmac key",
+			zap.Error(err), zap.String("key-id", keyID))
+		return nil, err
+	}
+
+	return toHMACKey(key), nil
+}
+
+func (c *companyPersistance) GetLatestHMACKey(ctx context.Context,
+	companyID uuid.UUID, livemode bool) (*dto.HMACKey, error) {
+	key, err := c.persistenceQueries.GetLatestActiveHMACKey(ctx, db.GetLatestActiveHMACKeyParams{
+		CompanyID: companyID,
+		Livemode:  livemode,
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "company has no active hmac key")
+			c.logger.Warn(ctx, "no active hmac key",
+				zap.Error(err), zap.String("company-id", companyID.String()),
+				zap.Bool("livemode", livemode))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get hmac key")
+		c.logger.Error(ctx, "unable to get latest hmac key",
+			zap.Error(err), zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	return toHMACKey(key), nil
+}
+
+func (c *companyPersistance) RevokeHMACKey(ctx context.Context,
+	companyID, id uuid.UUID) (*dto.HMACKey, error) {
+	key, err := c.persistenceQueries.RevokeHMACKey(ctx, db.RevokeHMACKeyParams{
+		ID:        id,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "active hmac key not found")
+			c.logger.Warn(ctx, "active hmac key not found",
+				zap.Error(err), zap.String("id", id.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to revoke hmac key")
+		c.logger.Error(ctx, "unable to revoke hmac key",
+			zap.Error(err), zap.String("id", id.String()))
+		return nil, err
+	}
+
+	return toHMACKey(key), nil
+}
+
+func (c *companyPersistance) TouchHMACKey(ctx context.Context, id uuid.UUID) error {
+	if err := c.persistenceQueries.TouchHMACKey(ctx, id); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to record hmac key use")
+		c.logger.Error(ctx, "unable to record hmac key use",
+			zap.Error(err), zap.String("id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+func toHMACKey(key db.CompanyHmacKey) *dto.HMACKey {
+	return &dto.HMACKey{
+		ID:         key.ID,
+		KeyID:      key.KeyID,
+		CompanyID:  key.CompanyID,
+		Secret:     key.Secret,
+		Status:     key.Status,
+		Livemode:   key.Livemode,
+		LastUsedAt: key.LastUsedAt.Time,
+		CreatedAt:  key.CreatedAt,
+		RevokedAt:  key.RevokedAt.Time,
+	}
+}
+
+func (c *companyPersistance) UseHMACNonce(ctx context.Context, keyID uuid.UUID,
+	nonce string, expiresAt time.Time) (bool, error) {
+	// Expired nonces of the key are swept first, which keeps the table to
+	// the nonces of the current clock-skew window.
+	if err := c.persistenceQueries.DeleteExpiredHMACNonces(ctx, keyID); err != nil {
+		c.logger.Warn(ctx, "unable to delete expired hmac nonces",
+			zap.Error(err), zap.String("hmac-key-id", keyID.String()))
+	}
+	recorded, err := c.persistenceQueries.UseHMACNonce(ctx, db.UseHMACNonceParams{
+		HmacKeyID: keyID,
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to record hmac nonce")
+		c.logger.Error(ctx, "unable to record hmac nonce",
+			zap.Error(err), zap.String("hmac-key-id", keyID.String()))
+		return false, err
+	}
+
+	return recorded == 1, nil
+}
@@ -0,0 +1,122 @@
+package company
+
+import (
+	"context"
+	"net"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+	"go.uber.org/zap"
+)
+
+func (c *companyPersistance) CreateIPAllowlistEntry(ctx context.Context,
+	param dto.CreateIPAllowlistEntry) (*dto.IPAllowlistEntry, error) {
+	entry, err := c.persistenceQueries.CreateIPAllowlistEntry(ctx, db.CreateIPAllowlistEntryParams{
+		CompanyID:   param.CompanyID,
+		Cidr:        toCIDR(param.Network),
+		Description: param.Description,
+		CreatedBy:   sql.UUIDOrNull(param.CreatedBy),
+	})
+	if err != nil {
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "network is already on the allowlist")
+			c.logger.Warn(ctx, "duplicate allowlist entry", zap.Error(err))
+			return nil, err
+		}
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create allowlist entry")
+		c.logger.Error(ctx, "unable to create allowlist entry",
+			zap.Error(err), zap.String("company-id", param.CompanyID.String()))
+		return nil, err
+	}
+
+	return toIPAllowlistEntry(db.ListIPAllowlistRow(entry)), nil
+}
+
+func (c *companyPersistance) ListIPAllowlist(ctx context.Context,
+	companyID uuid.UUID) ([]dto.IPAllowlistEntry, error) {
+	entries, err := c.persistenceQueries.ListIPAllowlist(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list allowlist")
+		c.logger.Error(ctx, "unable to list allowlist",
+			zap.Error(err), zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	result := make([]dto.IPAllowlistEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, *toIPAllowlistEntry(entry))
+	}
+	return result, nil
+}
+
+func (c *companyPersistance) UpdateIPAllowlistEntry(ctx context.Context,
+	param dto.UpdateIPAllowlistEntry) (*dto.IPAllowlistEntry, error) {
+	entry, err := c.persistenceQueries.UpdateIPAllowlistEntry(ctx, db.UpdateIPAllowlistEntryParams{
+		ID:          param.ID,
+		CompanyID:   param.CompanyID,
+		Cidr:        toCIDR(param.Network),
+		Description: param.Description,
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "allowlist entry not found")
+			c.logger.Warn(ctx, "allowlist entry not found",
+				zap.Error(err), zap.String("id", param.ID.String()))
+			return nil, err
+		}
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "network is already on the allowlist")
+			c.logger.Warn(ctx, "duplicate allowlist entry", zap.Error(err))
+			return nil, err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update allowlist entry")
+		c.logger.Error(ctx, "unable to update allowlist entry",
+			zap.Error(err), zap.String("id", param.ID.String()))
+		return nil, err
+	}
+
+	return toIPAllowlistEntry(db.ListIPAllowlistRow(entry)), nil
+}
+
+func (c *companyPersistance) DeleteIPAllowlistEntry(ctx context.Context,
+	companyID, id uuid.UUID) error {
+	deleted, err := c.persistenceQueries.DeleteIPAllowlistEntry(ctx, db.DeleteIPAllowlistEntryParams{
+		ID:        id,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		err = errors.ErrDBDelError.Wrap(err, "unable to delete allowlist entry")
+		c.logger.Error(ctx, "unable to delete allowlist entry",
+			zap.Error(err), zap.String("id", id.String()))
+		return err
+	}
+	if deleted == 0 {
+		err := errors.ErrNoRecordFound.New("allowlist entry not found")
+		c.logger.Warn(ctx, "allowlist entry not found",
+			zap.Error(err), zap.String("id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+func toCIDR(network *net.IPNet) pgtype.CIDR {
+	return pgtype.CIDR{IPNet: network, Status: pgtype.Present}
+}
+
+func toIPAllowlistEntry(entry db.ListIPAllowlistRow) *dto.IPAllowlistEntry {
+	return &dto.IPAllowlistEntry{
+		ID:          entry.ID,
+		CompanyID:   entry.CompanyID,
+		CIDR:        entry.Cidr,
+		Description: entry.Description,
+		CreatedBy:   entry.CreatedBy.UUID,
+		CreatedAt:   entry.CreatedAt,
+		UpdatedAt:   entry.UpdatedAt,
+	}
+}
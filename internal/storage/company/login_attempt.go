@@ -0,0 +1,73 @@
+package company
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+
+	"go.uber.org/zap"
+)
+
+func (c *companyPersistance) ReserveLoginAttempt(ctx context.Context,
+	throttle dto.LoginThrottle) (int, error) {
+	attempts, err := c.persistenceQueries.ReserveLoginAttempt(ctx, db.ReserveLoginAttemptParams{
+		ThrottleKey:      throttle.Key,
+		Since:            throttle.Since,
+		MaxAttempts:      int32(throttle.MaxAttempts),
+		DelayAfter:       int32(throttle.DelayAfter),
+		DelayBaseSeconds: throttle.DelayBase.Seconds(),
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			return 0, errors.ErrTooManyRequests.Wrap(err,
+				"too many failed sign-in attempts, try again later")
+		}
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to record login attempt")
+		c.logger.Error(ctx, "unable to record login attempt", zap.Error(err))
+		return 0, err
+	}
+
+	return int(attempts), nil
+}
+
+func (c *companyPersistance) GetLoginThrottle(ctx context.Context,
+	key string) (*dto.LoginFailureStats, error) {
+	throttle, err := c.persistenceQueries.GetLoginThrottle(ctx, key)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			return &dto.LoginFailureStats{}, nil
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get login attempts")
+		c.logger.Error(ctx, "unable to get login attempts", zap.Error(err))
+		return nil, err
+	}
+
+	return &dto.LoginFailureStats{
+		Failures:     int(throttle.Attempts),
+		LastFailedAt: throttle.LastAttemptAt,
+	}, nil
+}
+
+// ReleaseLoginAttempt uncounts an attempt that turned out to succeed.
+func (c *companyPersistance) ReleaseLoginAttempt(ctx context.Context, key string) error {
+	if err := c.persistenceQueries.ReleaseLoginAttempt(ctx, key); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to release login attempt")
+		c.logger.Error(ctx, "unable to release login attempt", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (c *companyPersistance) ClearLoginAttempts(ctx context.Context,
+	identifiers ...string) error {
+	if err := c.persistenceQueries.ClearLoginThrottles(ctx, identifiers); err != nil {
+		err = errors.ErrDBDelError.Wrap(err, "unable to clear login attempts")
+		c.logger.Error(ctx, "unable to clear login attempts", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package company
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/db"
+	"pg/platform/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (c *companyPersistance) SetMFASecret(ctx context.Context,
+	userID uuid.UUID, secret string) error {
+	sealed, err := c.persistenceQueries.SealUserMFASecret(ctx, userID, secret)
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to seal mfa secret")
+		c.logger.Error(ctx, "unable to seal mfa secret",
+			zap.Error(err), zap.String("user-id", userID.String()))
+		return err
+	}
+	if err := c.persistenceQueries.SetUserMFASecret(ctx, db.SetUserMFASecretParams{
+		ID:                  userID,
+		MfaDataKeyID:        sealed.DataKeyID,
+		MfaSecretCiphertext: sealed.Ciphertext,
+	}); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to store mfa secret")
+		c.logger.Error(ctx, "unable to store mfa secret",
+			zap.Error(err), zap.String("user-id", userID.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (c *companyPersistance) EnableMFA(ctx context.Context,
+	userID uuid.UUID, recoveryCodeHashes []string) error {
+	return c.persistenceQueries.ReplaceUserRecoveryCodesTx(ctx, userID, recoveryCodeHashes, true)
+}
+
+func (c *companyPersistance) ReplaceRecoveryCodes(ctx context.Context,
+	userID uuid.UUID, recoveryCodeHashes []string) error {
+	return c.persistenceQueries.ReplaceUserRecoveryCodesTx(ctx, userID, recoveryCodeHashes, false)
+}
+
+func (c *companyPersistance) DisableMFA(ctx context.Context, userID uuid.UUID) error {
+	return c.persistenceQueries.DisableUserMFATx(ctx, userID)
+}
+
+// UseMFAStep records step as the last accepted TOTP step. It reports false
+// when a code from this or a later step was already used.
+func (c *companyPersistance) UseMFAStep(ctx context.Context,
+	userID uuid.UUID, step int64) (bool, error) {
+	updated, err := c.persistenceQueries.UseUserMFAStep(ctx, db.UseUserMFAStepParams{
+		ID:          userID,
+		MfaLastStep: sql.Int64OrNullPntr(&step),
+	})
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to record mfa code")
+		c.logger.Error(ctx, "unable to record mfa code",
+			zap.Error(err), zap.String("user-id", userID.String()))
+		return false, err
+	}
+
+	return updated > 0, nil
+}
+
+// UseToken records a single-use token until expiresAt and reports false if
+// it was already used.
+func (c *companyPersistance) UseToken(ctx context.Context, tokenID uuid.UUID,
+	tokenType string, expiresAt time.Time) (bool, error) {
+	if err := c.persistenceQueries.DeleteExpiredUsedTokens(ctx); err != nil {
+		c.logger.Warn(ctx, "unable to delete expired used tokens", zap.Error(err))
+	}
+	recorded, err := c.persistenceQueries.UseToken(ctx, db.UseTokenParams{
+		TokenID:   tokenID,
+		TokenType: tokenType,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to record used token")
+		c.logger.Error(ctx, "unable to record used token", zap.Error(err),
+			zap.String("token-id", tokenID.String()), zap.String("token-type", tokenType))
+		return false, err
+	}
+
+	return recorded == 1, nil
+}
+
+// UseRecoveryCode marks the recovery code as used. It reports false when the
+// code does not exist or was already used.
+func (c *companyPersistance) UseRecoveryCode(ctx context.Context,
+	userID uuid.UUID, codeHash string) (bool, error) {
+	updated, err := c.persistenceQueries.UseUserRecoveryCode(ctx, db.UseUserRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to use recovery code")
+		c.logger.Error(ctx, "unable to use recovery code",
+			zap.Error(err), zap.String("user-id", userID.String()))
+		return false, err
+	}
+
+	return updated > 0, nil
+}
+
+func (c *companyPersistance) SetCompanyMFARequired(ctx context.Context,
+	companyID uuid.UUID, required bool) error {
+	if err := c.persistenceQueries.SetCompanyMFARequired(ctx, db.SetCompanyMFARequiredParams{
+		ID:          companyID,
+		MfaRequired: required,
+	}); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update company mfa policy")
+		c.logger.Error(ctx, "unable to update company mfa policy",
+			zap.Error(err), zap.String("company-id", companyID.String()))
+		return err
+	}
+
+	return nil
+}
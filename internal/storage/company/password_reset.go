@@ -0,0 +1,58 @@
+package company
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (c *companyPersistance) CreatePasswordReset(ctx context.Context,
+	userID, tokenID uuid.UUID) (*dto.PasswordReset, error) {
+	reset, err := c.persistenceQueries.CreatePasswordResetTx(ctx, userID, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPasswordReset(reset), nil
+}
+
+func (c *companyPersistance) GetPasswordResetByTokenID(ctx context.Context,
+	tokenID uuid.UUID) (*dto.PasswordReset, error) {
+	reset, err := c.persistenceQueries.GetPasswordResetByTokenID(ctx, tokenID)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "password reset not found")
+			c.logger.Error(ctx, "password reset not found",
+				zap.Error(err), zap.String("token-id", tokenID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get password reset")
+		c.logger.Error(ctx, "unable to get password reset",
+			zap.Error(err), zap.String("token-id", tokenID.String()))
+		return nil, err
+	}
+
+	return toPasswordReset(reset), nil
+}
+
+func (c *companyPersistance) ResetPassword(ctx context.Context,
+	resetID, userID uuid.UUID, hashedPassword string) error {
+	return c.persistenceQueries.ResetPasswordTx(ctx, resetID, userID, hashedPassword)
+}
+
+func toPasswordReset(reset db.PasswordReset) *dto.PasswordReset {
+	return &dto.PasswordReset{
+		ID:        reset.ID,
+		UserID:    reset.UserID,
+		TokenID:   reset.TokenID,
+		Status:    reset.Status,
+		UsedAt:    reset.UsedAt.Time,
+		CreatedAt: reset.CreatedAt,
+		UpdatedAt: reset.UpdatedAt,
+	}
+}
@@ -14,11 +14,14 @@ import (
 
 func (c *companyPersistance) CreateUser(ctx context.Context, param dto.CreateUser) (*dto.User, error) {
 	user, err := c.persistenceQueries.CreateUser(ctx, db.CreateUserParams{
-		CompanyID: param.CompanyID,
-		FirstName: sql.StringOrNull(param.FirstName),
-		Email:     param.Email,
-		Phone:     param.Phone,
-		Password:  param.Password,
+		CompanyID:       param.CompanyID,
+		FirstName:       sql.StringOrNull(param.FirstName),
+		Email:           param.Email,
+		Phone:           param.Phone,
+		Password:        param.Password,
+		Role:            string(param.Role),
+		Status:          string(param.Status),
+		EmailVerifiedAt: sql.TimeOrNull(param.EmailVerifiedAt),
 	})
 	if err != nil {
 		err = errors.ErrUnableToCreate.Wrap(err, "Unable to create user")
@@ -28,13 +31,16 @@ func (c *companyPersistance) CreateUser(ctx context.Context, param dto.CreateUse
 	}
 
 	return &dto.User{
-		ID:        user.ID,
-		CompanyID: user.CompanyID,
-		FirstName: user.FirstName.String,
-		Email:     user.Email,
-		Phone:     user.Phone,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:              user.ID,
+		CompanyID:       user.CompanyID,
+		FirstName:       user.FirstName.String,
+		Email:           user.Email,
+		Phone:           user.Phone,
+		Role:            user.Role,
+		Status:          user.Status,
+		EmailVerifiedAt: user.EmailVerifiedAt.Time,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
 	}, nil
 }
 func (c *companyPersistance) GetUserByID(ctx context.Context, id uuid.UUID) (*dto.User, error) {
@@ -51,6 +57,10 @@ func (c *companyPersistance) GetUserByID(ctx context.Context, id uuid.UUID) (*dt
 			zap.Error(err), zap.String("user-id", id.String()))
 		return nil, err
 	}
+	mfaSecret, err := c.openMFASecret(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 
 	return &dto.User{
 		ID:                user.ID,
@@ -61,11 +71,17 @@ func (c *companyPersistance) GetUserByID(ctx context.Context, id uuid.UUID) (*dt
 		Phone:             user.Phone,
 		FirstName:         user.FirstName.String,
 		LastName:          user.LastName.String,
-		Role:              user.Role.String,
+		Role:              user.Role,
 		Status:            user.Status,
 		TimezoneID:        user.TimezoneID.String,
 		Bio:               user.Bio.String,
 		ProfilePictureUrl: user.ProfilePictureUrl.String,
+		EmailVerifiedAt:   user.EmailVerifiedAt.Time,
+		PhoneVerifiedAt:   user.PhoneVerifiedAt.Time,
+		MFAEnabledAt:      user.MfaEnabledAt.Time,
+		MFASecret:         mfaSecret,
+		MFALastStep:       user.MfaLastStep.Int64,
+		SessionsRevokedAt: user.SessionsRevokedAt.Time,
 		CreatedAt:         user.CreatedAt,
 		UpdatedAt:         user.UpdatedAt,
 	}, nil
@@ -74,11 +90,21 @@ func (c *companyPersistance) GetUserByID(ctx context.Context, id uuid.UUID) (*dt
 func (c *companyPersistance) GetUserByPhoneOrEmail(ctx context.Context, phone string) (*dto.User, error) {
 	user, err := c.persistenceQueries.GetUserByPhoneOrEmail(ctx, phone)
 	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "user not found")
+			c.logger.Warn(ctx, "user not found",
+				zap.Error(err), zap.String("phone-or-email", phone))
+			return nil, err
+		}
 		err := errors.ErrUnableToGet.Wrap(err, "unable to get user")
 		c.logger.Error(ctx, "unable to get user",
 			zap.Error(err), zap.String("phone-or-email", phone))
 		return nil, err
 	}
+	mfaSecret, err := c.openMFASecret(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 
 	return &dto.User{
 		ID:                user.ID,
@@ -89,16 +115,32 @@ func (c *companyPersistance) GetUserByPhoneOrEmail(ctx context.Context, phone st
 		Phone:             user.Phone,
 		FirstName:         user.FirstName.String,
 		LastName:          user.LastName.String,
-		Role:              user.Role.String,
+		Role:              user.Role,
 		Status:            user.Status,
 		TimezoneID:        user.TimezoneID.String,
 		Bio:               user.Bio.String,
 		ProfilePictureUrl: user.ProfilePictureUrl.String,
+		EmailVerifiedAt:   user.EmailVerifiedAt.Time,
+		PhoneVerifiedAt:   user.PhoneVerifiedAt.Time,
+		MFAEnabledAt:      user.MfaEnabledAt.Time,
+		MFASecret:         mfaSecret,
+		MFALastStep:       user.MfaLastStep.Int64,
+		SessionsRevokedAt: user.SessionsRevokedAt.Time,
 		CreatedAt:         user.CreatedAt,
 		UpdatedAt:         user.UpdatedAt,
 	}, nil
 }
 
+func (c *companyPersistance) PhoneOrEmailExists(ctx context.Context, phoneOrEmail string) (bool, error) {
+	exists, err := c.persistenceQueries.PhoneOrEmailExists(ctx, phoneOrEmail)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to check user existence")
+		c.logger.Error(ctx, "unable to check user existence", zap.Error(err))
+		return false, err
+	}
+	return exists, nil
+}
+
 func (c *companyPersistance) CreateUserToken(ctx context.Context, param dto.UserToken) (*dto.UserToken, error) {
 	userToken, err := c.persistenceQueries.CreateUserToken(ctx, db.CreateUserTokenParams{
 		TokenID: param.TokenID,
@@ -149,3 +191,17 @@ func (c *companyPersistance) ResetActiveToken(ctx context.Context, id uuid.UUID)
 	}
 	return nil
 }
+
+// openMFASecret returns the user's TOTP secret, opening it when it is sealed.
+func (c *companyPersistance) openMFASecret(ctx context.Context, user db.User) (string, error) {
+	secret, err := c.persistenceQueries.OpenUserMFASecret(ctx, user.ID,
+		user.MfaDataKeyID, user.MfaSecret, user.MfaSecretCiphertext)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to open mfa secret")
+		c.logger.Error(ctx, "unable to open mfa secret",
+			zap.Error(err), zap.String("user-id", user.ID.String()))
+		return "", err
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,131 @@
+package company
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (c *companyPersistance) CreateUserVerification(ctx context.Context,
+	param dto.CreateUserVerification) (*dto.UserVerification, error) {
+	verification, err := c.persistenceQueries.CreateUserVerificationTx(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserVerification(verification), nil
+}
+
+func (c *companyPersistance) GetUserVerificationByTokenID(ctx context.Context,
+	tokenID uuid.UUID) (*dto.UserVerification, error) {
+	verification, err := c.persistenceQueries.GetUserVerificationByTokenID(ctx,
+		uuid.NullUUID{UUID: tokenID, Valid: true})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "verification not found")
+			c.logger.Warn(ctx, "verification not found",
+				zap.Error(err), zap.String("token-id", tokenID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get verification")
+		c.logger.Error(ctx, "unable to get verification",
+			zap.Error(err), zap.String("token-id", tokenID.String()))
+		return nil, err
+	}
+
+	return toUserVerification(verification), nil
+}
+
+func (c *companyPersistance) GetPendingUserVerification(ctx context.Context,
+	userID uuid.UUID, channel string) (*dto.UserVerification, error) {
+	verification, err := c.persistenceQueries.GetPendingUserVerification(ctx,
+		db.GetPendingUserVerificationParams{
+			UserID:  userID,
+			Channel: channel,
+		})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "verification not found")
+			c.logger.Warn(ctx, "pending verification not found",
+				zap.Error(err), zap.String("user-id", userID.String()),
+				zap.String("channel", channel))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get verification")
+		c.logger.Error(ctx, "unable to get verification",
+			zap.Error(err), zap.String("user-id", userID.String()),
+			zap.String("channel", channel))
+		return nil, err
+	}
+
+	return toUserVerification(verification), nil
+}
+
+// IncrementVerificationAttempts counts an attempt at a pending code. It
+// fails with errors.ErrInvalidUserInput once the code was tried
+// maxAttempts times, so concurrent guesses cannot exceed the limit.
+func (c *companyPersistance) IncrementVerificationAttempts(ctx context.Context,
+	id uuid.UUID, maxAttempts int) (int32, error) {
+	attempts, err := c.persistenceQueries.IncrementUserVerificationAttempts(ctx,
+		db.IncrementUserVerificationAttemptsParams{
+			ID:          id,
+			MaxAttempts: int32(maxAttempts),
+		})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "verification code exhausted")
+			c.logger.Warn(ctx, "verification code exhausted",
+				zap.Error(err), zap.String("verification-id", id.String()))
+			return 0, err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to record verification attempt")
+		c.logger.Error(ctx, "unable to record verification attempt",
+			zap.Error(err), zap.String("verification-id", id.String()))
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+func (c *companyPersistance) CompleteUserVerification(ctx context.Context,
+	verification dto.UserVerification) (*dto.User, error) {
+	user, err := c.persistenceQueries.CompleteUserVerificationTx(ctx, verification)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.User{
+		ID:              user.ID,
+		CompanyID:       user.CompanyID,
+		Email:           user.Email,
+		Phone:           user.Phone,
+		FirstName:       user.FirstName.String,
+		Role:            user.Role,
+		Status:          user.Status,
+		EmailVerifiedAt: user.EmailVerifiedAt.Time,
+		PhoneVerifiedAt: user.PhoneVerifiedAt.Time,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}, nil
+}
+
+func toUserVerification(verification db.UserVerification) *dto.UserVerification {
+	return &dto.UserVerification{
+		ID:         verification.ID,
+		UserID:     verification.UserID,
+		Channel:    verification.Channel,
+		TokenID:    verification.TokenID.UUID,
+		CodeHash:   verification.CodeHash.String,
+		Attempts:   verification.Attempts,
+		Status:     verification.Status,
+		ExpiresAt:  verification.ExpiresAt,
+		VerifiedAt: verification.VerifiedAt.Time,
+		CreatedAt:  verification.CreatedAt,
+		UpdatedAt:  verification.UpdatedAt,
+	}
+}
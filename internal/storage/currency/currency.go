@@ -0,0 +1,148 @@
+package currency
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type currencyPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewCurrencyPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Currency {
+	return &currencyPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (c *currencyPersistance) ListCompanyCurrencies(ctx context.Context,
+	companyID uuid.UUID) ([]dto.Currency, error) {
+	currencies, err := c.persistenceQueries.ListCompanyCurrencies(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list currencies")
+		c.logger.Error(ctx, "unable to list currencies", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	result := make([]dto.Currency, 0, len(currencies))
+	for _, currency := range currencies {
+		result = append(result, toCurrency(db.GetCompanyCurrencyRow(currency)))
+	}
+	return result, nil
+}
+
+func (c *currencyPersistance) GetCompanyCurrency(ctx context.Context, companyID uuid.UUID,
+	code constant.Currency) (*dto.Currency, error) {
+	currency, err := c.persistenceQueries.GetCompanyCurrency(ctx, db.GetCompanyCurrencyParams{
+		CompanyID: companyID,
+		Code:      string(code),
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err = errors.ErrNoRecordFound.Wrap(err, "currency not found")
+			c.logger.Warn(ctx, "currency not found", zap.Error(err),
+				zap.String("currency", string(code)))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get currency")
+		c.logger.Error(ctx, "unable to get currency", zap.Error(err),
+			zap.String("currency", string(code)))
+		return nil, err
+	}
+
+	result := toCurrency(currency)
+	return &result, nil
+}
+
+func (c *currencyPersistance) SetCompanyCurrency(ctx context.Context,
+	param dto.SetCompanyCurrency) error {
+	err := c.persistenceQueries.SetCompanyCurrency(ctx, db.SetCompanyCurrencyParams{
+		CompanyID:    param.CompanyID,
+		CurrencyCode: string(param.Code),
+		Enabled:      *param.Enabled,
+		UpdatedBy:    sql.UUIDOrNull(param.UpdatedBy),
+	})
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update company currency")
+		c.logger.Error(ctx, "unable to update company currency", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()),
+			zap.String("currency", string(param.Code)))
+		return err
+	}
+
+	return nil
+}
+
+// GetCompanyFXSettings returns how the company is paid out. A company
+// nobody configured settles in the presentment currency without a spread.
+func (c *currencyPersistance) GetCompanyFXSettings(ctx context.Context,
+	companyID uuid.UUID) (*dto.CompanyFXSettings, error) {
+	settings, err := c.persistenceQueries.GetCompanyFXSettings(ctx, companyID)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			return &dto.CompanyFXSettings{CompanyID: companyID}, nil
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get company fx settings")
+		c.logger.Error(ctx, "unable to get company fx settings", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	result := toFXSettings(settings)
+	return &result, nil
+}
+
+func (c *currencyPersistance) SetCompanyFXSettings(ctx context.Context,
+	param dto.SetCompanyFXSettings) (*dto.CompanyFXSettings, error) {
+	settings, err := c.persistenceQueries.SetCompanyFXSettings(ctx, db.SetCompanyFXSettingsParams{
+		CompanyID:          param.CompanyID,
+		SettlementCurrency: sql.StringOrNull(param.SettlementCurrency),
+		SpreadBps:          int32(*param.SpreadBps),
+		UpdatedBy:          sql.UUIDOrNull(param.UpdatedBy),
+	})
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update company fx settings")
+		c.logger.Error(ctx, "unable to update company fx settings", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()))
+		return nil, err
+	}
+
+	result := toFXSettings(settings)
+	return &result, nil
+}
+
+func toFXSettings(settings db.CompanyFxSetting) dto.CompanyFXSettings {
+	return dto.CompanyFXSettings{
+		CompanyID:          settings.CompanyID,
+		SettlementCurrency: constant.Currency(settings.SettlementCurrency.String),
+		SpreadBps:          int(settings.SpreadBps),
+		UpdatedAt:          settings.UpdatedAt,
+	}
+}
+
+func toCurrency(currency db.GetCompanyCurrencyRow) dto.Currency {
+	return dto.Currency{
+		Code:        constant.Currency(currency.Code),
+		NumericCode: currency.NumericCode,
+		Name:        currency.Name,
+		MinorUnits:  int(currency.MinorUnits),
+		MinAmount:   currency.MinAmount,
+		MaxAmount:   currency.MaxAmount,
+		Enabled:     currency.Enabled,
+	}
+}
@@ -0,0 +1,348 @@
+package customer
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type customerPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewCustomerPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Customer {
+	return &customerPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (c *customerPersistance) CreateCustomer(ctx context.Context,
+	param dto.CreateCustomer) (*dto.Customer, error) {
+	// The id is chosen here because the sealed fields are bound to it.
+	id := uuid.New()
+	sealed, err := c.persistenceQueries.SealCustomer(ctx, param.CompanyID, id,
+		persistencedb.CustomerPII{
+			FullName:    param.FullName,
+			PhoneNumber: param.PhoneNumber,
+			Email:       param.Email,
+		})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to seal customer data")
+		c.logger.Error(ctx, "unable to seal customer data", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()))
+		return nil, err
+	}
+	customer, err := c.persistenceQueries.InsertCustomer(ctx, db.InsertCustomerParams{
+		ID:                    id,
+		CompanyID:             param.CompanyID,
+		Livemode:              param.Livemode,
+		DataKeyID:             sealed.DataKeyID,
+		FullNameCiphertext:    sealed.FullName,
+		PhoneNumberCiphertext: sealed.PhoneNumber,
+		EmailCiphertext:       sealed.Email,
+		PhoneNumberIndex:      sealed.PhoneNumberIndex,
+	})
+	if err != nil {
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "a customer with this phone number already exists")
+			c.logger.Warn(ctx, "duplicate customer phone number", zap.Error(err),
+				zap.String("company-id", param.CompanyID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create customer")
+		c.logger.Error(ctx, "unable to create customer", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()))
+		return nil, err
+	}
+
+	return c.open(ctx, customer)
+}
+
+func (c *customerPersistance) GetCustomer(ctx context.Context, companyID uuid.UUID,
+	livemode bool, id uuid.UUID) (*dto.Customer, error) {
+	customer, err := c.persistenceQueries.GetCustomerByID(ctx, db.GetCustomerByIDParams{
+		ID:        id,
+		CompanyID: companyID,
+		Livemode:  livemode,
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "customer not found")
+			c.logger.Warn(ctx, "customer not found", zap.Error(err),
+				zap.String("customer-id", id.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get customer")
+		c.logger.Error(ctx, "unable to get customer", zap.Error(err),
+			zap.String("customer-id", id.String()))
+		return nil, err
+	}
+
+	return c.open(ctx, customer)
+}
+
+func (c *customerPersistance) ListCustomers(ctx context.Context,
+	query dto.CustomerQuery) ([]dto.Customer, int, error) {
+	total, err := c.persistenceQueries.CountCustomers(ctx, db.CountCustomersParams{
+		CompanyID: query.CompanyID,
+		Livemode:  query.Livemode,
+		PhoneNumberIndex: c.persistenceQueries.CustomerPhoneIndex(query.CompanyID,
+			query.PhoneNumber),
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to count customers")
+		c.logger.Error(ctx, "unable to count customers", zap.Error(err))
+		return nil, 0, err
+	}
+	customers, err := c.persistenceQueries.ListCustomers(ctx, db.ListCustomersParams{
+		CompanyID: query.CompanyID,
+		Livemode:  query.Livemode,
+		PhoneNumberIndex: c.persistenceQueries.CustomerPhoneIndex(query.CompanyID,
+			query.PhoneNumber),
+		PageLimit:  int32(query.Limit),
+		PageOffset: int32(query.Offset),
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list customers")
+		c.logger.Error(ctx, "unable to list customers", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]dto.Customer, 0, len(customers))
+	for _, customer := range customers {
+		opened, err := c.open(ctx, customer)
+		if err != nil {
+			return nil, 0, err
+		}
+		result = append(result, *opened)
+	}
+	return result, int(total), nil
+}
+
+// UpdateCustomer reseals every field, not only the changed ones, so the
+// row never mixes data keys.
+func (c *customerPersistance) UpdateCustomer(ctx context.Context, companyID uuid.UUID,
+	livemode bool, id uuid.UUID, param dto.UpdateCustomer) (*dto.Customer, error) {
+	var customer db.Customer
+	err := c.persistenceQueries.WithTransaction(ctx, func(tx persistencedb.PersistenceDB) error {
+		current, err := tx.GetCustomerByIDForUpdate(ctx, db.GetCustomerByIDForUpdateParams{
+			ID:        id,
+			CompanyID: companyID,
+			Livemode:  livemode,
+		})
+		if err != nil {
+			return err
+		}
+		opened, err := tx.OpenCustomer(ctx, current)
+		if err != nil {
+			return err
+		}
+		pii := persistencedb.CustomerPII{
+			FullName:    opened.FullName,
+			PhoneNumber: opened.PhoneNumber,
+			Email:       opened.Email,
+		}
+		if param.FullName != nil {
+			pii.FullName = *param.FullName
+		}
+		if param.PhoneNumber != nil {
+			pii.PhoneNumber = *param.PhoneNumber
+		}
+		if param.Email != nil {
+			pii.Email = *param.Email
+		}
+		sealed, err := tx.SealCustomer(ctx, companyID, id, pii)
+		if err != nil {
+			return err
+		}
+		customer, err = tx.UpdateCustomer(ctx, db.UpdateCustomerParams{
+			DataKeyID:             sealed.DataKeyID,
+			FullNameCiphertext:    sealed.FullName,
+			PhoneNumberCiphertext: sealed.PhoneNumber,
+			EmailCiphertext:       sealed.Email,
+			PhoneNumberIndex:      sealed.PhoneNumberIndex,
+			ID:                    id,
+			CompanyID:             companyID,
+			Livemode:              livemode,
+		})
+		return err
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "customer not found")
+			c.logger.Warn(ctx, "customer not found", zap.Error(err),
+				zap.String("customer-id", id.String()))
+			return nil, err
+		}
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "a customer with this phone number already exists")
+			c.logger.Warn(ctx, "duplicate customer phone number", zap.Error(err),
+				zap.String("customer-id", id.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update customer")
+		c.logger.Error(ctx, "unable to update customer", zap.Error(err),
+			zap.String("customer-id", id.String()))
+		return nil, err
+	}
+
+	return c.open(ctx, customer)
+}
+
+func (c *customerPersistance) DeleteCustomer(ctx context.Context, companyID uuid.UUID,
+	livemode bool, id uuid.UUID) error {
+	rows, err := c.persistenceQueries.SoftDeleteCustomer(ctx, db.SoftDeleteCustomerParams{
+		ID:        id,
+		CompanyID: companyID,
+		Livemode:  livemode,
+	})
+	if err != nil {
+		err = errors.ErrDBDelError.Wrap(err, "unable to delete customer")
+		c.logger.Error(ctx, "unable to delete customer", zap.Error(err),
+			zap.String("customer-id", id.String()))
+		return err
+	}
+	if rows == 0 {
+		err := errors.ErrNoRecordFound.New("customer not found")
+		c.logger.Warn(ctx, "customer not found", zap.Error(err),
+			zap.String("customer-id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (c *customerPersistance) EraseCustomer(ctx context.Context,
+	param dto.EraseCustomer) (*dto.CustomerErasure, error) {
+	erased, err := c.persistenceQueries.EraseCustomerTx(ctx, db.EraseCustomerParams{
+		ID:             param.ID,
+		CompanyID:      param.CompanyID,
+		Livemode:       param.Livemode,
+		InactiveBefore: sql.TimeOrNull(param.InactiveBefore),
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "customer not found")
+			c.logger.Warn(ctx, "customer not found or already erased", zap.Error(err),
+				zap.String("customer-id", param.ID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to erase customer")
+		c.logger.Error(ctx, "unable to erase customer", zap.Error(err),
+			zap.String("customer-id", param.ID.String()))
+		return nil, err
+	}
+
+	return &dto.CustomerErasure{
+		CustomerID:             erased.ID,
+		CompanyID:              erased.CompanyID,
+		Livemode:               erased.Livemode,
+		ErasedFields:           []string{"full_name", "phone_number", "email"},
+		RetainedPaymentIntents: int(erased.PaymentIntents),
+		ErasedAt:               erased.ErasedAt.Time,
+	}, nil
+}
+
+func (c *customerPersistance) ListInactiveCustomers(ctx context.Context,
+	inactiveBefore time.Time, limit int) ([]dto.EraseCustomer, error) {
+	customers, err := c.persistenceQueries.ListInactiveCustomers(ctx,
+		db.ListInactiveCustomersParams{
+			InactiveBefore: inactiveBefore,
+			BatchSize:      int32(limit),
+		})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list inactive customers")
+		c.logger.Error(ctx, "unable to list inactive customers", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]dto.EraseCustomer, 0, len(customers))
+	for _, customer := range customers {
+		result = append(result, dto.EraseCustomer{
+			ID:             customer.ID,
+			CompanyID:      customer.CompanyID,
+			Livemode:       customer.Livemode,
+			InactiveBefore: inactiveBefore,
+		})
+	}
+	return result, nil
+}
+
+func (c *customerPersistance) ListCustomerPaymentIntents(ctx context.Context,
+	companyID uuid.UUID, livemode bool, customerID uuid.UUID,
+	limit, offset int) ([]dto.PaymentIntent, int, error) {
+	total, err := c.persistenceQueries.CountCustomerPaymentIntents(ctx,
+		db.CountCustomerPaymentIntentsParams{
+			CustomerID: customerID,
+			CompanyID:  companyID,
+			Livemode:   livemode,
+		})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to count customer payment intents")
+		c.logger.Error(ctx, "unable to count customer payment intents", zap.Error(err))
+		return nil, 0, err
+	}
+	paymentIntents, err := c.persistenceQueries.ListCustomerPaymentIntents(ctx,
+		db.ListCustomerPaymentIntentsParams{
+			CustomerID: customerID,
+			CompanyID:  companyID,
+			Livemode:   livemode,
+			PageLimit:  int32(limit),
+			PageOffset: int32(offset),
+		})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list customer payment intents")
+		c.logger.Error(ctx, "unable to list customer payment intents", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]dto.PaymentIntent, 0, len(paymentIntents))
+	for _, pi := range paymentIntents {
+		result = append(result, dto.PaymentIntent{
+			ID:          pi.ID,
+			CompanyID:   pi.CompanyID,
+			CustomerID:  pi.CustomerID,
+			PaymentType: constant.PaymentType(pi.PaymentType),
+			Amount:      pi.Amount,
+			Status:      constant.Status(pi.Status),
+			Currency:    constant.Currency(pi.Currency),
+			CallBackURL: pi.CallbackUrl,
+			ReturnURL:   pi.ReturnUrl,
+			Description: pi.Description.String,
+			BillRefNO:   pi.BillRefNo.String,
+			Livemode:    pi.Livemode,
+			Settlement:  persistencedb.PaymentIntentSettlement(pi),
+			ExpireAt:    pi.ExpireAt.Time,
+			CreatedAt:   pi.CreatedAt,
+			UpdatedAt:   pi.UpdatedAt,
+		})
+	}
+	return result, int(total), nil
+}
+
+func (c *customerPersistance) open(ctx context.Context,
+	customer db.Customer) (*dto.Customer, error) {
+	opened, err := c.persistenceQueries.OpenCustomer(ctx, customer)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to open customer data")
+		c.logger.Error(ctx, "unable to open customer data", zap.Error(err),
+			zap.String("customer-id", customer.ID.String()))
+		return nil, err
+	}
+
+	return opened, nil
+}
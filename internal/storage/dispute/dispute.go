@@ -0,0 +1,263 @@
+package dispute
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type disputePersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewDisputePersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Dispute {
+	return &disputePersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (d *disputePersistance) CreateDispute(ctx context.Context,
+	param dto.CreateDispute) (*dto.Dispute, error) {
+	dispute, err := d.persistenceQueries.CreateDisputeTx(ctx, param)
+	if err != nil {
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "payment intent is already disputed")
+			d.logger.Warn(ctx, "duplicate dispute", zap.Error(err),
+				zap.String("payment-intent-id", param.PaymentIntentID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create dispute")
+		d.logger.Error(ctx, "unable to create dispute", zap.Error(err),
+			zap.String("payment-intent-id", param.PaymentIntentID.String()))
+		return nil, err
+	}
+
+	result := persistencedb.ToDispute(*dispute)
+	return &result, nil
+}
+
+// GetDispute returns a dispute with its evidence files and, once lost, the
+// ledger entries reversing it.
+func (d *disputePersistance) GetDispute(ctx context.Context,
+	id uuid.UUID) (*dto.Dispute, error) {
+	dispute, err := d.persistenceQueries.GetDispute(ctx, id)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "dispute not found")
+			d.logger.Warn(ctx, "dispute not found", zap.Error(err),
+				zap.String("dispute-id", id.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get dispute")
+		d.logger.Error(ctx, "unable to get dispute", zap.Error(err),
+			zap.String("dispute-id", id.String()))
+		return nil, err
+	}
+	files, err := d.persistenceQueries.ListDisputeEvidenceFiles(ctx, id)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list dispute evidence files")
+		d.logger.Error(ctx, "unable to list dispute evidence files", zap.Error(err),
+			zap.String("dispute-id", id.String()))
+		return nil, err
+	}
+	entries, err := d.persistenceQueries.ListDisputeLedgerEntries(ctx, sql.UUIDOrNull(id))
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list dispute reversals")
+		d.logger.Error(ctx, "unable to list dispute reversals", zap.Error(err),
+			zap.String("dispute-id", id.String()))
+		return nil, err
+	}
+
+	result := persistencedb.ToDispute(dispute)
+	result.Files = make([]dto.DisputeEvidenceFile, 0, len(files))
+	for _, file := range files {
+		result.Files = append(result.Files, toDisputeEvidenceFile(file))
+	}
+	for _, entry := range entries {
+		result.Reversals = append(result.Reversals, toLedgerEntry(entry))
+	}
+	return &result, nil
+}
+
+func (d *disputePersistance) ListDisputes(ctx context.Context,
+	query dto.DisputeQuery) ([]dto.Dispute, int, error) {
+	companyID := sql.UUIDOrNull(query.CompanyID)
+	status := sql.StringOrNull(string(query.Status))
+	disputes, err := d.persistenceQueries.ListDisputes(ctx, db.ListDisputesParams{
+		CompanyID:  companyID,
+		Status:     status,
+		PageLimit:  int32(query.Limit),
+		PageOffset: int32(query.Offset),
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list disputes")
+		d.logger.Error(ctx, "unable to list disputes", zap.Error(err))
+		return nil, 0, err
+	}
+	total, err := d.persistenceQueries.CountDisputes(ctx, db.CountDisputesParams{
+		CompanyID: companyID,
+		Status:    status,
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to count disputes")
+		d.logger.Error(ctx, "unable to count disputes", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]dto.Dispute, 0, len(disputes))
+	for _, dispute := range disputes {
+		result = append(result, persistencedb.ToDispute(dispute))
+	}
+	return result, int(total), nil
+}
+
+// UpdateDisputeEvidence replaces the evidence text. It returns
+// errors.ErrNoRecordFound when the dispute no longer takes evidence.
+func (d *disputePersistance) UpdateDisputeEvidence(ctx context.Context,
+	id uuid.UUID, text string) (*dto.Dispute, error) {
+	dispute, err := d.persistenceQueries.UpdateDisputeEvidence(ctx, db.UpdateDisputeEvidenceParams{
+		ID:           id,
+		EvidenceText: sql.StringOrNull(text),
+	})
+	if err != nil {
+		return nil, d.closedError(ctx, err, id, "unable to update dispute evidence")
+	}
+
+	result := persistencedb.ToDispute(dispute)
+	return &result, nil
+}
+
+// SubmitDispute sends the merchant's evidence for review. It returns
+// errors.ErrNoRecordFound when the dispute no longer takes evidence.
+func (d *disputePersistance) SubmitDispute(ctx context.Context,
+	id, userID uuid.UUID) (*dto.Dispute, error) {
+	dispute, err := d.persistenceQueries.SubmitDispute(ctx, db.SubmitDisputeParams{
+		ID:          id,
+		SubmittedBy: sql.UUIDOrNull(userID),
+	})
+	if err != nil {
+		return nil, d.closedError(ctx, err, id, "unable to submit dispute")
+	}
+
+	result := persistencedb.ToDispute(dispute)
+	return &result, nil
+}
+
+// ResolveDispute closes a dispute. It returns errors.ErrNoRecordFound when
+// the dispute cannot be resolved.
+func (d *disputePersistance) ResolveDispute(ctx context.Context,
+	id, operatorID uuid.UUID, param dto.ResolveDispute) (*dto.Dispute, error) {
+	dispute, err := d.persistenceQueries.ResolveDisputeTx(ctx, id, operatorID, param)
+	if err != nil {
+		return nil, d.closedError(ctx, err, id, "unable to resolve dispute")
+	}
+
+	result := persistencedb.ToDispute(*dispute)
+	return &result, nil
+}
+
+func (d *disputePersistance) CreateDisputeEvidenceFile(ctx context.Context,
+	param dto.CreateDisputeEvidenceFile) (*dto.DisputeEvidenceFile, error) {
+	file, err := d.persistenceQueries.CreateDisputeEvidenceFile(ctx, db.CreateDisputeEvidenceFileParams{
+		ID:          param.ID,
+		DisputeID:   param.DisputeID,
+		FileName:    param.FileName,
+		ContentType: param.ContentType,
+		SizeBytes:   param.SizeBytes,
+		Sha256:      param.SHA256,
+		StorageKey:  param.StorageKey,
+		UploadedBy:  param.UploadedBy,
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create dispute evidence file")
+		d.logger.Error(ctx, "unable to create dispute evidence file", zap.Error(err),
+			zap.String("dispute-id", param.DisputeID.String()))
+		return nil, err
+	}
+
+	result := toDisputeEvidenceFile(file)
+	return &result, nil
+}
+
+func (d *disputePersistance) GetDisputeEvidenceFile(ctx context.Context,
+	disputeID, fileID uuid.UUID) (*dto.DisputeEvidenceFile, error) {
+	file, err := d.persistenceQueries.GetDisputeEvidenceFile(ctx, db.GetDisputeEvidenceFileParams{
+		ID:        fileID,
+		DisputeID: disputeID,
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "evidence file not found")
+			d.logger.Warn(ctx, "dispute evidence file not found", zap.Error(err),
+				zap.String("file-id", fileID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get evidence file")
+		d.logger.Error(ctx, "unable to get dispute evidence file", zap.Error(err),
+			zap.String("file-id", fileID.String()))
+		return nil, err
+	}
+
+	result := toDisputeEvidenceFile(file)
+	return &result, nil
+}
+
+// closedError reports a conditional dispute update that matched no row as
+// errors.ErrNoRecordFound, for the module to explain.
+func (d *disputePersistance) closedError(ctx context.Context, err error,
+	id uuid.UUID, message string) error {
+	if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+		err := errors.ErrNoRecordFound.Wrap(err, "dispute not found in a status that allows this")
+		d.logger.Warn(ctx, "dispute not in the expected status", zap.Error(err),
+			zap.String("dispute-id", id.String()))
+		return err
+	}
+	err = errors.ErrUnableToUpdate.Wrap(err, "unable to update dispute")
+	d.logger.Error(ctx, message, zap.Error(err), zap.String("dispute-id", id.String()))
+	return err
+}
+
+func toDisputeEvidenceFile(file db.DisputeEvidenceFile) dto.DisputeEvidenceFile {
+	return dto.DisputeEvidenceFile{
+		ID:          file.ID,
+		DisputeID:   file.DisputeID,
+		FileName:    file.FileName,
+		ContentType: file.ContentType,
+		SizeBytes:   file.SizeBytes,
+		SHA256:      file.Sha256,
+		StorageKey:  file.StorageKey,
+		UploadedBy:  file.UploadedBy,
+		CreatedAt:   file.CreatedAt,
+	}
+}
+
+func toLedgerEntry(entry db.LedgerEntry) dto.LedgerEntry {
+	result := dto.LedgerEntry{
+		ID:              entry.ID,
+		CompanyID:       entry.CompanyID,
+		PaymentIntentID: entry.PaymentIntentID,
+		Type:            constant.LedgerEntryType(entry.Type),
+		Amount:          entry.Amount,
+		Currency:        constant.Currency(entry.Currency),
+		Livemode:        entry.Livemode,
+		CreatedAt:       entry.CreatedAt,
+	}
+	if entry.DisputeID.Valid {
+		disputeID := entry.DisputeID.UUID
+		result.DisputeID = &disputeID
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package kyc
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type kycPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewKYCPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.KYC {
+	return &kycPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (k *kycPersistance) CreateKYCDocument(ctx context.Context,
+	param dto.CreateKYCDocument) (*dto.KYCDocument, error) {
+	document, err := k.persistenceQueries.CreateKYCDocument(ctx, db.CreateKYCDocumentParams{
+		ID:           param.ID,
+		CompanyID:    param.CompanyID,
+		DocumentType: string(param.DocumentType),
+		FileName:     param.FileName,
+		ContentType:  param.ContentType,
+		SizeBytes:    param.SizeBytes,
+		Sha256:       param.SHA256,
+		StorageKey:   param.StorageKey,
+		UploadedBy:   param.UploadedBy,
+	})
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to save kyc document")
+		k.logger.Error(ctx, "unable to save kyc document", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()))
+		return nil, err
+	}
+
+	return toKYCDocument(document), nil
+}
+
+func (k *kycPersistance) ListKYCDocuments(ctx context.Context,
+	companyID uuid.UUID) ([]dto.KYCDocument, error) {
+	documents, err := k.persistenceQueries.ListKYCDocuments(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list kyc documents")
+		k.logger.Error(ctx, "unable to list kyc documents", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	result := make([]dto.KYCDocument, 0, len(documents))
+	for _, document := range documents {
+		result = append(result, *toKYCDocument(document))
+	}
+	return result, nil
+}
+
+func (k *kycPersistance) GetKYCDocumentByID(ctx context.Context,
+	id uuid.UUID) (*dto.KYCDocument, error) {
+	document, err := k.persistenceQueries.GetKYCDocumentByID(ctx, id)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "kyc document not found")
+			k.logger.Warn(ctx, "kyc document not found", zap.Error(err),
+				zap.String("document-id", id.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get kyc document")
+		k.logger.Error(ctx, "unable to get kyc document", zap.Error(err),
+			zap.String("document-id", id.String()))
+		return nil, err
+	}
+
+	return toKYCDocument(document), nil
+}
+
+func (k *kycPersistance) DeleteKYCDocument(ctx context.Context, companyID, id uuid.UUID) error {
+	if err := k.persistenceQueries.DeleteKYCDocument(ctx, db.DeleteKYCDocumentParams{
+		ID:        id,
+		CompanyID: companyID,
+	}); err != nil {
+		err = errors.ErrDBDelError.Wrap(err, "unable to delete kyc document")
+		k.logger.Error(ctx, "unable to delete kyc document", zap.Error(err),
+			zap.String("document-id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (k *kycPersistance) GetCompanyKYC(ctx context.Context,
+	companyID uuid.UUID) (*dto.KYCStatus, error) {
+	company, err := k.persistenceQueries.GetCompanyKYC(ctx, companyID)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "company not found")
+			k.logger.Warn(ctx, "company not found", zap.Error(err),
+				zap.String("company-id", companyID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get company kyc status")
+		k.logger.Error(ctx, "unable to get company kyc status", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	return &dto.KYCStatus{
+		CompanyID:   company.ID,
+		Status:      constant.Status(company.Status),
+		SubmittedAt: company.KycSubmittedAt.Time,
+		ReviewedAt:  company.KycReviewedAt.Time,
+		ReviewedBy:  company.KycReviewedBy.UUID,
+		ReviewNote:  company.KycReviewNote.String,
+	}, nil
+}
+
+func (k *kycPersistance) SubmitCompanyKYC(ctx context.Context, companyID uuid.UUID) (bool, error) {
+	rows, err := k.persistenceQueries.SubmitCompanyKYC(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to submit kyc")
+		k.logger.Error(ctx, "unable to submit kyc", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (k *kycPersistance) ReviewCompanyKYC(ctx context.Context, companyID, operatorID uuid.UUID,
+	status constant.Status, note string) (bool, error) {
+	rows, err := k.persistenceQueries.ReviewCompanyKYC(ctx, db.ReviewCompanyKYCParams{
+		ID:         companyID,
+		Status:     string(status),
+		ReviewedBy: sql.UUIDOrNull(operatorID),
+		ReviewNote: sql.StringOrNull(note),
+	})
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to review kyc")
+		k.logger.Error(ctx, "unable to review kyc", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func toKYCDocument(document db.KycDocument) *dto.KYCDocument {
+	return &dto.KYCDocument{
+		ID:           document.ID,
+		CompanyID:    document.CompanyID,
+		DocumentType: constant.KYCDocumentType(document.DocumentType),
+		FileName:     document.FileName,
+		ContentType:  document.ContentType,
+		SizeBytes:    document.SizeBytes,
+		SHA256:       document.Sha256,
+		StorageKey:   document.StorageKey,
+		UploadedBy:   document.UploadedBy,
+		CreatedAt:    document.CreatedAt,
+	}
+}
@@ -0,0 +1,119 @@
+package limit
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type limitPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewLimitPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Limit {
+	return &limitPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (l *limitPersistance) ListCompanyLimits(ctx context.Context,
+	companyID uuid.UUID) ([]dto.CompanyLimit, error) {
+	limits, err := l.persistenceQueries.ListCompanyLimits(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list company limits")
+		l.logger.Error(ctx, "unable to list company limits", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	result := make([]dto.CompanyLimit, 0, len(limits))
+	for _, limit := range limits {
+		result = append(result, toLimit(limit))
+	}
+	return result, nil
+}
+
+func (l *limitPersistance) SetCompanyLimit(ctx context.Context,
+	param dto.SetCompanyLimit) (*dto.CompanyLimit, error) {
+	limit, err := l.persistenceQueries.SetCompanyLimit(ctx, db.SetCompanyLimitParams{
+		CompanyID:             param.CompanyID,
+		CurrencyCode:          string(param.Currency),
+		MinAmount:             param.MinAmount,
+		MaxAmount:             param.MaxAmount,
+		DailyTotal:            param.DailyTotal,
+		MonthlyTotal:          param.MonthlyTotal,
+		CustomerMaxPayments:   sql.Int32OrNullpntr(intPtrTo32(param.CustomerMaxPayments)),
+		CustomerWindowMinutes: int32(param.CustomerWindowMinutes),
+		UpdatedBy:             sql.UUIDOrNull(param.UpdatedBy),
+	})
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to set company limit")
+		l.logger.Error(ctx, "unable to set company limit", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()),
+			zap.String("currency", string(param.Currency)))
+		return nil, err
+	}
+
+	result := toLimit(limit)
+	return &result, nil
+}
+
+func (l *limitPersistance) DeleteCompanyLimit(ctx context.Context, companyID uuid.UUID,
+	currency constant.Currency) error {
+	rows, err := l.persistenceQueries.DeleteCompanyLimit(ctx, db.DeleteCompanyLimitParams{
+		CompanyID:    companyID,
+		CurrencyCode: string(currency),
+	})
+	if err != nil {
+		err = errors.ErrDBDelError.Wrap(err, "unable to delete company limit")
+		l.logger.Error(ctx, "unable to delete company limit", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return err
+	}
+	if rows == 0 {
+		err := errors.ErrNoRecordFound.New("company has no limits in %s", currency)
+		l.logger.Warn(ctx, "company limit not found", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return err
+	}
+
+	return nil
+}
+
+func toLimit(limit db.CompanyLimit) dto.CompanyLimit {
+	result := dto.CompanyLimit{
+		CompanyID:             limit.CompanyID,
+		Currency:              constant.Currency(limit.CurrencyCode),
+		MinAmount:             limit.MinAmount,
+		MaxAmount:             limit.MaxAmount,
+		DailyTotal:            limit.DailyTotal,
+		MonthlyTotal:          limit.MonthlyTotal,
+		CustomerWindowMinutes: int(limit.CustomerWindowMinutes),
+		UpdatedAt:             limit.UpdatedAt,
+	}
+	if limit.CustomerMaxPayments.Valid {
+		payments := int(limit.CustomerMaxPayments.Int32)
+		result.CustomerMaxPayments = &payments
+	}
+	return result
+}
+
+func intPtrTo32(n *int) *int32 {
+	if n == nil {
+		return nil
+	}
+	v := int32(*n)
+	return &v
+}
@@ -0,0 +1,123 @@
+package operator
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type operatorPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewOperatorPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Operator {
+	return &operatorPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (o *operatorPersistance) CreateOperator(ctx context.Context,
+	param dto.CreateOperator) (*dto.Operator, error) {
+	operator, err := o.persistenceQueries.CreateOperator(ctx, db.CreateOperatorParams{
+		FullName:  param.FullName,
+		Email:     param.Email,
+		Password:  param.Password,
+		CreatedBy: sql.UUIDOrNull(param.CreatedBy),
+	})
+	if err != nil {
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "an operator with this email already exists")
+			o.logger.Warn(ctx, "duplicate operator email", zap.Error(err))
+			return nil, err
+		}
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create operator")
+		o.logger.Error(ctx, "unable to create operator", zap.Error(err))
+		return nil, err
+	}
+
+	return toOperator(operator), nil
+}
+
+func (o *operatorPersistance) GetOperatorByID(ctx context.Context,
+	id uuid.UUID) (*dto.Operator, error) {
+	operator, err := o.persistenceQueries.GetOperatorByID(ctx, id)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "operator not found")
+			o.logger.Warn(ctx, "operator not found",
+				zap.Error(err), zap.String("operator-id", id.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get operator")
+		o.logger.Error(ctx, "unable to get operator",
+			zap.Error(err), zap.String("operator-id", id.String()))
+		return nil, err
+	}
+
+	return toOperator(operator), nil
+}
+
+func (o *operatorPersistance) GetOperatorByEmail(ctx context.Context,
+	email string) (*dto.Operator, error) {
+	operator, err := o.persistenceQueries.GetOperatorByEmail(ctx, email)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "operator not found")
+			o.logger.Warn(ctx, "operator not found", zap.Error(err))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get operator")
+		o.logger.Error(ctx, "unable to get operator", zap.Error(err))
+		return nil, err
+	}
+
+	return toOperator(operator), nil
+}
+
+func (o *operatorPersistance) CountOperators(ctx context.Context) (int, error) {
+	count, err := o.persistenceQueries.CountOperators(ctx)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to count operators")
+		o.logger.Error(ctx, "unable to count operators", zap.Error(err))
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (o *operatorPersistance) TouchOperatorLogin(ctx context.Context, id uuid.UUID) error {
+	if err := o.persistenceQueries.TouchOperatorLogin(ctx, id); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update operator login time")
+		o.logger.Error(ctx, "unable to update operator login time",
+			zap.Error(err), zap.String("operator-id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+func toOperator(operator db.Operator) *dto.Operator {
+	return &dto.Operator{
+		ID:          operator.ID,
+		FullName:    operator.FullName,
+		Email:       operator.Email,
+		Password:    operator.Password,
+		Status:      operator.Status,
+		CreatedBy:   operator.CreatedBy.UUID,
+		LastLoginAt: operator.LastLoginAt.Time,
+		CreatedAt:   operator.CreatedAt,
+		UpdatedAt:   operator.UpdatedAt,
+	}
+}
@@ -0,0 +1,111 @@
+package paymentintent
+
+import (
+	"context"
+	"pg/initiator/platform/amqp"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+
+	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
+	"go.uber.org/zap"
+)
+
+func (p *paymentIntentPersistance) CreatePaymentConfirmation(ctx context.Context,
+	param dto.CreatePaymentConfirmation) (*dto.PaymentConfirmation, error) {
+	confirmation, err := p.persistenceQueries.CreatePaymentConfirmationTx(ctx, param)
+	if err != nil {
+		if errorx.IsOfType(err, errors.ErrTooManyRequests) {
+			p.logger.Warn(ctx, "payment confirmation resent too often", zap.Error(err),
+				zap.String("payment-intent-id", param.PaymentIntentID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create payment confirmation")
+		p.logger.Error(ctx, "unable to create payment confirmation", zap.Error(err),
+			zap.String("payment-intent-id", param.PaymentIntentID.String()))
+		return nil, err
+	}
+
+	return toPaymentConfirmation(*confirmation), nil
+}
+
+func (p *paymentIntentPersistance) GetPendingPaymentConfirmation(ctx context.Context,
+	paymentIntentID uuid.UUID) (*dto.PaymentConfirmation, error) {
+	confirmation, err := p.persistenceQueries.GetPendingPaymentConfirmation(ctx, paymentIntentID)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "payment confirmation not found")
+			p.logger.Warn(ctx, "pending payment confirmation not found", zap.Error(err),
+				zap.String("payment-intent-id", paymentIntentID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get payment confirmation")
+		p.logger.Error(ctx, "unable to get payment confirmation", zap.Error(err),
+			zap.String("payment-intent-id", paymentIntentID.String()))
+		return nil, err
+	}
+
+	return toPaymentConfirmation(confirmation), nil
+}
+
+// IncrementConfirmationAttempts counts an attempt at a pending code. It
+// fails with errors.ErrInvalidUserInput once the code was tried
+// maxAttempts times, so concurrent guesses cannot exceed the limit.
+func (p *paymentIntentPersistance) IncrementConfirmationAttempts(ctx context.Context,
+	id uuid.UUID, maxAttempts int) (int32, error) {
+	attempts, err := p.persistenceQueries.IncrementPaymentConfirmationAttempts(ctx,
+		db.IncrementPaymentConfirmationAttemptsParams{
+			ID:          id,
+			MaxAttempts: int32(maxAttempts),
+		})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "confirmation code expired, request a new one")
+			p.logger.Warn(ctx, "confirmation code exhausted", zap.Error(err),
+				zap.String("payment-confirmation-id", id.String()))
+			return 0, err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to record confirmation attempt")
+		p.logger.Error(ctx, "unable to record confirmation attempt", zap.Error(err),
+			zap.String("payment-confirmation-id", id.String()))
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+// ConfirmPaymentIntent uses up the code and queues its payment intent to be
+// processed.
+func (p *paymentIntentPersistance) ConfirmPaymentIntent(ctx context.Context,
+	confirmation dto.PaymentConfirmation, client amqp.Client) (*dto.PaymentIntent, error) {
+	pi, err := p.persistenceQueries.ConfirmPaymentIntentTx(ctx, confirmation, client)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "invalid or expired confirmation code")
+			p.logger.Warn(ctx, "payment intent no longer awaiting confirmation", zap.Error(err),
+				zap.String("payment-intent-id", confirmation.PaymentIntentID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to confirm payment intent")
+		p.logger.Error(ctx, "unable to confirm payment intent", zap.Error(err),
+			zap.String("payment-intent-id", confirmation.PaymentIntentID.String()))
+		return nil, err
+	}
+
+	return p.toPaymentIntent(ctx, *pi)
+}
+
+func toPaymentConfirmation(confirmation db.PaymentIntentConfirmation) *dto.PaymentConfirmation {
+	return &dto.PaymentConfirmation{
+		ID:              confirmation.ID,
+		PaymentIntentID: confirmation.PaymentIntentID,
+		CodeHash:        confirmation.CodeHash,
+		Attempts:        confirmation.Attempts,
+		Status:          confirmation.Status,
+		ExpiresAt:       confirmation.ExpiresAt,
+		ConfirmedAt:     confirmation.ConfirmedAt.Time,
+		CreatedAt:       confirmation.CreatedAt,
+	}
+}
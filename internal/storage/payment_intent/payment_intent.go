@@ -6,6 +6,7 @@ import (
 	"pg/initiator/platform/amqp"
 	"pg/internal/constant"
 	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
 	"pg/internal/constant/model/db"
 	"pg/internal/constant/model/dto"
 	persistencedb "pg/internal/constant/persistenceDB"
@@ -13,6 +14,7 @@ import (
 	"pg/platform/hlog"
 
 	"github.com/google/uuid"
+	"github.com/joomcode/errorx"
 	"go.uber.org/zap"
 )
 
@@ -33,6 +35,11 @@ func (p *paymentIntentPersistance) CreatePaymentIntent(ctx context.Context,
 	param dto.CreatePaymentIntent, client amqp.Client) (*dto.PaymentIntent, error) {
 	pi, err := p.persistenceQueries.CreatePaymentIntentTx(ctx, param, client)
 	if err != nil {
+		if errorx.IsOfType(err, errors.ErrLimitExceeded) {
+			p.logger.Warn(ctx, "payment intent refused by a transaction limit",
+				zap.Error(err), zap.String("company-id", param.CompanyID.String()))
+			return nil, err
+		}
 		err = errors.ErrUnableToCreate.Wrap(err, "unable to create payment intent")
 		p.logger.Error(ctx,
 			"unable to create payment intent",
@@ -40,33 +47,7 @@ func (p *paymentIntentPersistance) CreatePaymentIntent(ctx context.Context,
 		return nil, err
 	}
 
-	extraMap := make(map[string]any)
-	if pi.Extra.Bytes != nil {
-		if err := json.Unmarshal(pi.Extra.Bytes, &extraMap); err != nil {
-			err = errors.ErrBadRequest.Wrap(err, "unable to unmarshal extra fields")
-			p.logger.Error(ctx,
-				"error unmarshalling extra fields",
-				zap.Error(err), zap.String("extra", string(pi.Extra.Bytes)))
-			return nil, err
-		}
-	}
-
-	return &dto.PaymentIntent{
-		ID:          pi.ID,
-		CompanyID:   pi.CompanyID,
-		CustomerID:  pi.CustomerID,
-		PaymentType: constant.PaymentType(pi.PaymentType),
-		Amount:      pi.Amount,
-		Status:      constant.Status(pi.Status),
-		Currency:    constant.Currency(pi.Currency),
-		CallBackURL: pi.CallbackUrl,
-		ReturnURL:   pi.ReturnUrl,
-		Extra:       extraMap,
-		BillRefNO:   pi.BillRefNo.String,
-		ExpireAt:    pi.ExpireAt.Time,
-		CreatedAt:   pi.CreatedAt,
-		UpdatedAt:   pi.UpdatedAt,
-	}, nil
+	return p.toPaymentIntent(ctx, *pi)
 }
 
 func (p *paymentIntentPersistance) GetPaymentIntentByID(ctx context.Context,
@@ -86,11 +67,11 @@ func (p *paymentIntentPersistance) GetPaymentIntentByID(ctx context.Context,
 			zap.Error(err), zap.String("extra", string(pi.Extra.Bytes)))
 		return nil, err
 	}
-	customer := dto.Customer{}
-	if err := json.Unmarshal(pi.Customer.Bytes, &customer); err != nil {
-		err = errors.ErrBadRequest.Wrap(err, "unable to unmarshal customer data")
-		p.logger.Error(ctx, "unable to unmarshal customer data",
-			zap.Error(err), zap.String("customer", string(pi.Customer.Bytes)))
+	customer, err := p.persistenceQueries.OpenPaymentIntentCustomer(ctx, pi)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to open customer data")
+		p.logger.Error(ctx, "unable to open customer data",
+			zap.Error(err), zap.String("customer-id", pi.CustomerID.String()))
 		return nil, err
 	}
 	company := dto.Company{}
@@ -100,6 +81,21 @@ func (p *paymentIntentPersistance) GetPaymentIntentByID(ctx context.Context,
 			zap.Error(err), zap.String("company", string(pi.Company.Bytes)))
 		return nil, err
 	}
+	risk, err := persistencedb.PaymentIntentRisk(db.PaymentIntent{
+		RiskScore:   pi.RiskScore,
+		RiskOutcome: pi.RiskOutcome,
+		RiskRules:   pi.RiskRules,
+		RiskSignals: pi.RiskSignals,
+		ReviewedBy:  pi.ReviewedBy,
+		ReviewedAt:  pi.ReviewedAt,
+		ReviewNote:  pi.ReviewNote,
+	})
+	if err != nil {
+		err = errors.ErrBadRequest.Wrap(err, "unable to unmarshal risk assessment")
+		p.logger.Error(ctx, "unable to unmarshal risk assessment",
+			zap.Error(err), zap.String("payment-intent-id", pi.ID.String()))
+		return nil, err
+	}
 
 	return &dto.PaymentIntentDetail{
 		ID:          pi.ID,
@@ -112,21 +108,43 @@ func (p *paymentIntentPersistance) GetPaymentIntentByID(ctx context.Context,
 		Description: pi.Description.String,
 		Extra:       extra,
 		BillRefNO:   pi.BillRefNo.String,
-		ExpireAt:    pi.ExpireAt.Time,
-		CreatedAt:   pi.CreatedAt,
-		UpdatedAt:   pi.UpdatedAt,
-		Customer:    customer,
-		Company:     company,
+		Livemode:    pi.Livemode,
+		Settlement: persistencedb.PaymentIntentSettlement(db.PaymentIntent{
+			Amount:             pi.Amount,
+			Currency:           pi.Currency,
+			SettlementCurrency: pi.SettlementCurrency,
+			SettlementAmount:   pi.SettlementAmount,
+			FxMidRate:          pi.FxMidRate,
+			FxSpreadBps:        pi.FxSpreadBps,
+			FxRate:             pi.FxRate,
+			FxRateSource:       pi.FxRateSource,
+			FxRateAsOf:         pi.FxRateAsOf,
+		}),
+		Risk:      risk,
+		ExpireAt:  pi.ExpireAt.Time,
+		CreatedAt: pi.CreatedAt,
+		UpdatedAt: pi.UpdatedAt,
+		Customer:  *customer,
+		Company:   company,
 	}, nil
 }
 
-func (p *paymentIntentPersistance) UpdatePaymentIntentStatus(ctx context.Context,
-	id uuid.UUID, status string) error {
-	_, err := p.persistenceQueries.UpdatePaymentIntentStatus(ctx, db.UpdatePaymentIntentStatusParams{
-		ID:     id,
-		Status: status,
-	})
+func (p *paymentIntentPersistance) CorrectPaymentIntentStatus(ctx context.Context,
+	id uuid.UUID, expected, status constant.Status) error {
+	err := p.persistenceQueries.CorrectPaymentIntentStatusTx(ctx, id, expected, status)
 	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "payment intent is no longer %s, reload it and retry",
+				expected)
+			p.logger.Warn(ctx, "payment intent status changed before the correction",
+				zap.Error(err), zap.String("payment-intent-id", id.String()))
+			return err
+		}
+		if errorx.IsOfType(err, errors.ErrInvalidUserInput) {
+			p.logger.Warn(ctx, "unable to correct a disputed payment intent",
+				zap.Error(err), zap.String("payment-intent-id", id.String()))
+			return err
+		}
 		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update payment intent status")
 		p.logger.Error(ctx, "unable to update payment intent status",
 			zap.Error(err), zap.String("payment-intent-id", id.String()))
@@ -135,6 +153,7 @@ func (p *paymentIntentPersistance) UpdatePaymentIntentStatus(ctx context.Context
 
 	return nil
 }
+
 func (p *paymentIntentPersistance) GetPaymentIntentByIDForUpdate(ctx context.Context,
 	id uuid.UUID) (*dto.PaymentIntent, error) {
 	pi, err := p.persistenceQueries.GetPaymentIntentByIDForUpdate(ctx, id)
@@ -168,6 +187,52 @@ func (p *paymentIntentPersistance) GetPaymentIntentByIDForUpdate(ctx context.Con
 		ReturnURL:   pi.ReturnUrl,
 		Extra:       extraMap,
 		BillRefNO:   pi.BillRefNo.String,
+		Livemode:    pi.Livemode,
+		Settlement:  persistencedb.PaymentIntentSettlement(pi),
+		ExpireAt:    pi.ExpireAt.Time,
+		CreatedAt:   pi.CreatedAt,
+		UpdatedAt:   pi.UpdatedAt,
+	}, nil
+}
+
+// toPaymentIntent converts a payment intent row, reading its extra fields
+// and risk assessment.
+func (p *paymentIntentPersistance) toPaymentIntent(ctx context.Context,
+	pi db.PaymentIntent) (*dto.PaymentIntent, error) {
+	extraMap := make(map[string]any)
+	if pi.Extra.Bytes != nil {
+		if err := json.Unmarshal(pi.Extra.Bytes, &extraMap); err != nil {
+			err = errors.ErrBadRequest.Wrap(err, "unable to unmarshal extra fields")
+			p.logger.Error(ctx,
+				"error unmarshalling extra fields",
+				zap.Error(err), zap.String("extra", string(pi.Extra.Bytes)))
+			return nil, err
+		}
+	}
+
+	risk, err := persistencedb.PaymentIntentRisk(pi)
+	if err != nil {
+		err = errors.ErrBadRequest.Wrap(err, "unable to unmarshal risk assessment")
+		p.logger.Error(ctx, "error unmarshalling risk assessment",
+			zap.Error(err), zap.String("payment-intent-id", pi.ID.String()))
+		return nil, err
+	}
+
+	return &dto.PaymentIntent{
+		ID:          pi.ID,
+		CompanyID:   pi.CompanyID,
+		CustomerID:  pi.CustomerID,
+		PaymentType: constant.PaymentType(pi.PaymentType),
+		Amount:      pi.Amount,
+		Status:      constant.Status(pi.Status),
+		Currency:    constant.Currency(pi.Currency),
+		CallBackURL: pi.CallbackUrl,
+		ReturnURL:   pi.ReturnUrl,
+		Extra:       extraMap,
+		BillRefNO:   pi.BillRefNo.String,
+		Livemode:    pi.Livemode,
+		Settlement:  persistencedb.PaymentIntentSettlement(pi),
+		Risk:        risk,
 		ExpireAt:    pi.ExpireAt.Time,
 		CreatedAt:   pi.CreatedAt,
 		UpdatedAt:   pi.UpdatedAt,
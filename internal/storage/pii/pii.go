@@ -0,0 +1,97 @@
+package pii
+
+import (
+	"context"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+
+	"go.uber.org/zap"
+)
+
+type piiPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewPIIPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.PII {
+	return &piiPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (p *piiPersistance) ListDataKeys(ctx context.Context) ([]dto.PIIDataKey, error) {
+	keys, err := p.persistenceQueries.ListPIIDataKeys(ctx)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list data keys")
+		p.logger.Error(ctx, "unable to list data keys", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]dto.PIIDataKey, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, dto.PIIDataKey{
+			ID:          key.ID,
+			MasterKeyID: key.MasterKeyID,
+			Active:      key.Active,
+			Customers:   int(key.Customers),
+			CreatedAt:   key.CreatedAt,
+			RetiredAt:   key.RetiredAt.Time,
+		})
+	}
+	return result, nil
+}
+
+func (p *piiPersistance) RotateDataKey(ctx context.Context) (*dto.PIIDataKey, error) {
+	key, err := p.persistenceQueries.RotatePIIDataKey(ctx)
+	if err != nil {
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to rotate data key")
+		p.logger.Error(ctx, "unable to rotate data key", zap.Error(err))
+		return nil, err
+	}
+
+	return &dto.PIIDataKey{
+		ID:          key.ID,
+		MasterKeyID: key.MasterKeyID,
+		Active:      key.Active,
+		CreatedAt:   key.CreatedAt,
+	}, nil
+}
+
+func (p *piiPersistance) RewrapDataKeys(ctx context.Context) (int, error) {
+	count, err := p.persistenceQueries.RewrapPIIDataKeys(ctx)
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to rewrap data keys")
+		p.logger.Error(ctx, "unable to rewrap data keys", zap.Error(err),
+			zap.Int("rewrapped", count))
+		return count, err
+	}
+
+	return count, nil
+}
+
+func (p *piiPersistance) ResealCustomers(ctx context.Context, batchSize int) (int, error) {
+	count, err := p.persistenceQueries.ResealCustomers(ctx, batchSize)
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to reseal customers")
+		p.logger.Error(ctx, "unable to reseal customers", zap.Error(err))
+		return count, err
+	}
+
+	return count, nil
+}
+
+func (p *piiPersistance) ResealMFASecrets(ctx context.Context, batchSize int) (int, error) {
+	count, err := p.persistenceQueries.ResealUserMFASecrets(ctx, batchSize)
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to reseal mfa secrets")
+		p.logger.Error(ctx, "unable to reseal mfa secrets", zap.Error(err))
+		return count, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,275 @@
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"pg/initiator/platform/amqp"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type riskPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewRiskPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Risk {
+	return &riskPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (r *riskPersistance) ListRiskRules(ctx context.Context,
+	companyID uuid.UUID) ([]dto.RiskRule, error) {
+	rules, err := r.persistenceQueries.ListRiskRules(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list risk rules")
+		r.logger.Error(ctx, "unable to list risk rules", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	result := make([]dto.RiskRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, toRiskRule(rule))
+	}
+	return result, nil
+}
+
+func (r *riskPersistance) GetRiskRule(ctx context.Context,
+	companyID, id uuid.UUID) (*dto.RiskRule, error) {
+	rule, err := r.persistenceQueries.GetRiskRule(ctx, db.GetRiskRuleParams{
+		ID:        id,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "risk rule not found")
+			r.logger.Warn(ctx, "risk rule not found", zap.Error(err),
+				zap.String("risk-rule-id", id.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get risk rule")
+		r.logger.Error(ctx, "unable to get risk rule", zap.Error(err),
+			zap.String("risk-rule-id", id.String()))
+		return nil, err
+	}
+
+	result := toRiskRule(rule)
+	return &result, nil
+}
+
+func (r *riskPersistance) CreateRiskRule(ctx context.Context,
+	param dto.SetRiskRule) (*dto.RiskRule, error) {
+	rule, err := r.persistenceQueries.CreateRiskRule(ctx, db.CreateRiskRuleParams{
+		CompanyID:  param.CompanyID,
+		Name:       param.Name,
+		Expression: param.Expression,
+		Score:      int32(*param.Score),
+		Enabled:    *param.Enabled,
+		UpdatedBy:  sql.UUIDOrNull(param.UpdatedBy),
+	})
+	if err != nil {
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "a risk rule with this name already exists")
+			r.logger.Warn(ctx, "duplicate risk rule", zap.Error(err))
+			return nil, err
+		}
+		err = errors.ErrUnableToCreate.Wrap(err, "unable to create risk rule")
+		r.logger.Error(ctx, "unable to create risk rule", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()))
+		return nil, err
+	}
+
+	result := toRiskRule(rule)
+	return &result, nil
+}
+
+func (r *riskPersistance) UpdateRiskRule(ctx context.Context,
+	param dto.SetRiskRule) (*dto.RiskRule, error) {
+	rule, err := r.persistenceQueries.UpdateRiskRule(ctx, db.UpdateRiskRuleParams{
+		ID:         param.ID,
+		CompanyID:  param.CompanyID,
+		Name:       param.Name,
+		Expression: param.Expression,
+		Score:      int32(*param.Score),
+		Enabled:    *param.Enabled,
+		UpdatedBy:  sql.UUIDOrNull(param.UpdatedBy),
+	})
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "risk rule not found")
+			r.logger.Warn(ctx, "risk rule not found", zap.Error(err),
+				zap.String("risk-rule-id", param.ID.String()))
+			return nil, err
+		}
+		if sqlcerr.IsDuplicate(err) {
+			err := errors.ErrInvalidUserInput.Wrap(err, "a risk rule with this name already exists")
+			r.logger.Warn(ctx, "duplicate risk rule", zap.Error(err))
+			return nil, err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update risk rule")
+		r.logger.Error(ctx, "unable to update risk rule", zap.Error(err),
+			zap.String("risk-rule-id", param.ID.String()))
+		return nil, err
+	}
+
+	result := toRiskRule(rule)
+	return &result, nil
+}
+
+func (r *riskPersistance) DeleteRiskRule(ctx context.Context, companyID, id uuid.UUID) error {
+	deleted, err := r.persistenceQueries.DeleteRiskRule(ctx, db.DeleteRiskRuleParams{
+		ID:        id,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		err = errors.ErrDBDelError.Wrap(err, "unable to delete risk rule")
+		r.logger.Error(ctx, "unable to delete risk rule", zap.Error(err),
+			zap.String("risk-rule-id", id.String()))
+		return err
+	}
+	if deleted == 0 {
+		err := errors.ErrNoRecordFound.New("risk rule not found")
+		r.logger.Warn(ctx, "risk rule not found", zap.Error(err),
+			zap.String("risk-rule-id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *riskPersistance) GetRiskSettings(ctx context.Context,
+	companyID uuid.UUID) (*dto.RiskSettings, error) {
+	settings, err := r.persistenceQueries.CompanyRiskSettings(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get risk settings")
+		r.logger.Error(ctx, "unable to get risk settings", zap.Error(err),
+			zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *riskPersistance) SetRiskSettings(ctx context.Context,
+	param dto.SetRiskSettings) (*dto.RiskSettings, error) {
+	settings, err := r.persistenceQueries.SetCompanyRiskSettings(ctx, db.SetCompanyRiskSettingsParams{
+		CompanyID:   param.CompanyID,
+		ReviewScore: int32(param.ReviewScore),
+		BlockScore:  int32(param.BlockScore),
+		UpdatedBy:   sql.UUIDOrNull(param.UpdatedBy),
+	})
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to set risk settings")
+		r.logger.Error(ctx, "unable to set risk settings", zap.Error(err),
+			zap.String("company-id", param.CompanyID.String()))
+		return nil, err
+	}
+
+	return &dto.RiskSettings{
+		CompanyID:   settings.CompanyID,
+		ReviewScore: int(settings.ReviewScore),
+		BlockScore:  int(settings.BlockScore),
+		UpdatedAt:   settings.UpdatedAt,
+	}, nil
+}
+
+func (r *riskPersistance) ListRiskReviews(ctx context.Context,
+	query dto.RiskReviewQuery) ([]dto.RiskReview, int, error) {
+	companyID := sql.UUIDOrNull(query.CompanyID)
+	reviews, err := r.persistenceQueries.ListPaymentIntentsForReview(ctx,
+		db.ListPaymentIntentsForReviewParams{
+			CompanyID:  companyID,
+			PageLimit:  int32(query.Limit),
+			PageOffset: int32(query.Offset),
+		})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list payment intents for review")
+		r.logger.Error(ctx, "unable to list payment intents for review", zap.Error(err))
+		return nil, 0, err
+	}
+	total, err := r.persistenceQueries.CountPaymentIntentsForReview(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to count payment intents for review")
+		r.logger.Error(ctx, "unable to count payment intents for review", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]dto.RiskReview, 0, len(reviews))
+	for _, review := range reviews {
+		risk := dto.RiskAssessment{
+			Score:   int(review.RiskScore.Int32),
+			Outcome: constant.RiskReview,
+		}
+		if err := json.Unmarshal(review.RiskRules.Bytes, &risk.Rules); err != nil {
+			err = errors.ErrBadRequest.Wrap(err, "unable to unmarshal risk rules")
+			r.logger.Error(ctx, "unable to unmarshal risk rules", zap.Error(err),
+				zap.String("payment-intent-id", review.ID.String()))
+			return nil, 0, err
+		}
+		if err := json.Unmarshal(review.RiskSignals.Bytes, &risk.Signals); err != nil {
+			err = errors.ErrBadRequest.Wrap(err, "unable to unmarshal risk signals")
+			r.logger.Error(ctx, "unable to unmarshal risk signals", zap.Error(err),
+				zap.String("payment-intent-id", review.ID.String()))
+			return nil, 0, err
+		}
+		result = append(result, dto.RiskReview{
+			PaymentIntentID: review.ID,
+			CompanyID:       review.CompanyID,
+			CompanyName:     review.CompanyName,
+			CustomerID:      review.CustomerID,
+			Amount:          review.Amount,
+			Currency:        constant.Currency(review.Currency),
+			Livemode:        review.Livemode,
+			Risk:            risk,
+			CreatedAt:       review.CreatedAt,
+		})
+	}
+
+	return result, int(total), nil
+}
+
+func (r *riskPersistance) ReviewPaymentIntent(ctx context.Context,
+	param dto.ReviewPaymentIntent, client amqp.Client) error {
+	_, err := r.persistenceQueries.ReviewPaymentIntentTx(ctx, param, client)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrInvalidUserInput.New("payment intent is not held for review")
+			r.logger.Warn(ctx, "payment intent is not held for review", zap.Error(err),
+				zap.String("payment-intent-id", param.PaymentIntentID.String()))
+			return err
+		}
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to review payment intent")
+		r.logger.Error(ctx, "unable to review payment intent", zap.Error(err),
+			zap.String("payment-intent-id", param.PaymentIntentID.String()))
+		return err
+	}
+
+	return nil
+}
+
+func toRiskRule(rule db.RiskRule) dto.RiskRule {
+	return dto.RiskRule{
+		ID:         rule.ID,
+		CompanyID:  rule.CompanyID,
+		Name:       rule.Name,
+		Expression: rule.Expression,
+		Score:      int(rule.Score),
+		Enabled:    rule.Enabled,
+		CreatedAt:  rule.CreatedAt,
+		UpdatedAt:  rule.UpdatedAt,
+	}
+}
@@ -3,7 +3,9 @@ package storage
 import (
 	"context"
 	"pg/initiator/platform/amqp"
+	"pg/internal/constant"
 	"pg/internal/constant/model/dto"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,12 +15,21 @@ type Company interface {
 		arg dto.CreateCompany) (*dto.Company, error)
 	GetCompanyByID(ctx context.Context,
 		id uuid.UUID) (*dto.Company, error)
+	UpdateCompanyProfile(ctx context.Context,
+		id uuid.UUID, arg dto.UpdateCompany) (*dto.Company, error)
+	ListCompanies(ctx context.Context,
+		query dto.CompanyQuery) ([]dto.Company, int, error)
+	// SuspendCompany suspends a company in any other status and keeps
+	// that status for ReactivateCompany.
+	SuspendCompany(ctx context.Context, id uuid.UUID) error
+	// ReactivateCompany moves a suspended company back to the status it was
+	// suspended from, ACTIVE for companies suspended before it was kept,
+	// and returns that status.
+	ReactivateCompany(ctx context.Context, id uuid.UUID) (constant.Status, error)
 	CreateCompanyToken(ctx context.Context,
 		arg dto.CreateCompanyToken) (*dto.CompanyToken, error)
 	InactiveToken(ctx context.Context,
-		companyID uuid.UUID) error
-	CreateCustomer(ctx context.Context,
-		arg dto.CreateCustomer) (*dto.Customer, error)
+		companyID uuid.UUID, livemode bool) error
 	GenerateCompanyCredentials(ctx context.Context,
 		arg dto.CreateCompanyToken) error
 	CreateUser(ctx context.Context,
@@ -27,12 +38,118 @@ type Company interface {
 		id uuid.UUID) (*dto.User, error)
 	GetUserByPhoneOrEmail(ctx context.Context,
 		phone string) (*dto.User, error)
+	PhoneOrEmailExists(ctx context.Context,
+		phoneOrEmail string) (bool, error)
 	CreateUserToken(ctx context.Context,
 		param dto.UserToken) (*dto.UserToken, error)
 	GetActiveUserTokenByUserID(ctx context.Context,
 		id uuid.UUID) (*dto.UserToken, error)
 	ResetActiveToken(ctx context.Context, id uuid.UUID) error
-	GetActiveCompanyTokenByID(ctx context.Context, id uuid.UUID) (*dto.CompanyToken, error)
+	GetActiveCompanyTokenByTokenID(ctx context.Context,
+		tokenID uuid.UUID) (*dto.CompanyToken, error)
+	CreatePasswordReset(ctx context.Context,
+		userID, tokenID uuid.UUID) (*dto.PasswordReset, error)
+	GetPasswordResetByTokenID(ctx context.Context,
+		tokenID uuid.UUID) (*dto.PasswordReset, error)
+	ResetPassword(ctx context.Context,
+		resetID, userID uuid.UUID, hashedPassword string) error
+	CreateUserVerification(ctx context.Context,
+		param dto.CreateUserVerification) (*dto.UserVerification, error)
+	GetUserVerificationByTokenID(ctx context.Context,
+		tokenID uuid.UUID) (*dto.UserVerification, error)
+	GetPendingUserVerification(ctx context.Context,
+		userID uuid.UUID, channel string) (*dto.UserVerification, error)
+	IncrementVerificationAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int32, error)
+	CompleteUserVerification(ctx context.Context,
+		verification dto.UserVerification) (*dto.User, error)
+	SetMFASecret(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableMFA(ctx context.Context, userID uuid.UUID, recoveryCodeHashes []string) error
+	ReplaceRecoveryCodes(ctx context.Context,
+		userID uuid.UUID, recoveryCodeHashes []string) error
+	DisableMFA(ctx context.Context, userID uuid.UUID) error
+	UseMFAStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	// UseToken records a single-use token, such as an MFA challenge, until
+	// expiresAt and reports false if it was already used.
+	UseToken(ctx context.Context, tokenID uuid.UUID, tokenType string,
+		expiresAt time.Time) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	SetCompanyMFARequired(ctx context.Context, companyID uuid.UUID, required bool) error
+	// ReserveLoginAttempt counts an attempt under the throttle's key and
+	// returns the attempts counted so far. It fails with
+	// errors.ErrTooManyRequests, counting nothing, while the key is locked
+	// out or inside its progressive delay.
+	ReserveLoginAttempt(ctx context.Context, throttle dto.LoginThrottle) (int, error)
+	GetLoginThrottle(ctx context.Context, key string) (*dto.LoginFailureStats, error)
+	ReleaseLoginAttempt(ctx context.Context, key string) error
+	ClearLoginAttempts(ctx context.Context, identifiers ...string) error
+	CreateHMACKey(ctx context.Context,
+		param dto.CreateHMACKey) (*dto.HMACKey, error)
+	ListHMACKeys(ctx context.Context,
+		companyID uuid.UUID) ([]dto.HMACKey, error)
+	GetActiveHMACKey(ctx context.Context,
+		keyID string) (*dto.HMACKey, error)
+	// GetLatestHMACKey returns the company's newest active key of the
+	// mode, which signs its webhook events.
+	GetLatestHMACKey(ctx context.Context,
+		companyID uuid.UUID, livemode bool) (*dto.HMACKey, error)
+	RevokeHMACKey(ctx context.Context,
+		companyID, id uuid.UUID) (*dto.HMACKey, error)
+	TouchHMACKey(ctx context.Context, id uuid.UUID) error
+	// UseHMACNonce records a nonce of a key until expiresAt and reports
+	// false if it is already recorded. The nonces are shared by every
+	// instance of the service.
+	UseHMACNonce(ctx context.Context, keyID uuid.UUID, nonce string,
+		expiresAt time.Time) (bool, error)
+	CreateIPAllowlistEntry(ctx context.Context,
+		param dto.CreateIPAllowlistEntry) (*dto.IPAllowlistEntry, error)
+	ListIPAllowlist(ctx context.Context,
+		companyID uuid.UUID) ([]dto.IPAllowlistEntry, error)
+	UpdateIPAllowlistEntry(ctx context.Context,
+		param dto.UpdateIPAllowlistEntry) (*dto.IPAllowlistEntry, error)
+	DeleteIPAllowlistEntry(ctx context.Context, companyID, id uuid.UUID) error
+}
+
+// Customer methods are scoped to one company and mode; customers of
+// another company or mode are not found.
+type Customer interface {
+	CreateCustomer(ctx context.Context, param dto.CreateCustomer) (*dto.Customer, error)
+	GetCustomer(ctx context.Context, companyID uuid.UUID, livemode bool,
+		id uuid.UUID) (*dto.Customer, error)
+	ListCustomers(ctx context.Context, query dto.CustomerQuery) ([]dto.Customer, int, error)
+	UpdateCustomer(ctx context.Context, companyID uuid.UUID, livemode bool,
+		id uuid.UUID, param dto.UpdateCustomer) (*dto.Customer, error)
+	DeleteCustomer(ctx context.Context, companyID uuid.UUID, livemode bool, id uuid.UUID) error
+	// EraseCustomer clears the customer's personal data, including the
+	// blocklist hits of its phone number. It returns the erasure without a
+	// certificate id or reason.
+	EraseCustomer(ctx context.Context, param dto.EraseCustomer) (*dto.CustomerErasure, error)
+	ListInactiveCustomers(ctx context.Context, inactiveBefore time.Time,
+		limit int) ([]dto.EraseCustomer, error)
+	ListCustomerPaymentIntents(ctx context.Context, companyID uuid.UUID, livemode bool,
+		customerID uuid.UUID, limit, offset int) ([]dto.PaymentIntent, int, error)
+}
+
+type KYC interface {
+	CreateKYCDocument(ctx context.Context,
+		param dto.CreateKYCDocument) (*dto.KYCDocument, error)
+	ListKYCDocuments(ctx context.Context, companyID uuid.UUID) ([]dto.KYCDocument, error)
+	GetKYCDocumentByID(ctx context.Context, id uuid.UUID) (*dto.KYCDocument, error)
+	DeleteKYCDocument(ctx context.Context, companyID, id uuid.UUID) error
+	GetCompanyKYC(ctx context.Context, companyID uuid.UUID) (*dto.KYCStatus, error)
+	// SubmitCompanyKYC and ReviewCompanyKYC report false when the company
+	// was not in a status the transition starts from.
+	SubmitCompanyKYC(ctx context.Context, companyID uuid.UUID) (bool, error)
+	ReviewCompanyKYC(ctx context.Context, companyID, operatorID uuid.UUID,
+		status constant.Status, note string) (bool, error)
+}
+
+type Operator interface {
+	CreateOperator(ctx context.Context,
+		param dto.CreateOperator) (*dto.Operator, error)
+	GetOperatorByID(ctx context.Context, id uuid.UUID) (*dto.Operator, error)
+	GetOperatorByEmail(ctx context.Context, email string) (*dto.Operator, error)
+	CountOperators(ctx context.Context) (int, error)
+	TouchOperatorLogin(ctx context.Context, id uuid.UUID) error
 }
 
 type PaymentIntent interface {
@@ -40,8 +157,113 @@ type PaymentIntent interface {
 		param dto.CreatePaymentIntent, client amqp.Client) (*dto.PaymentIntent, error)
 	GetPaymentIntentByID(ctx context.Context,
 		id uuid.UUID) (*dto.PaymentIntentDetail, error)
-	UpdatePaymentIntentStatus(ctx context.Context,
-		id uuid.UUID, status string) error
+	// CorrectPaymentIntentStatus moves a payment intent from expected to
+	// status and books the difference in the ledger. An intent no longer in
+	// expected, or disputed, is refused.
+	CorrectPaymentIntentStatus(ctx context.Context,
+		id uuid.UUID, expected, status constant.Status) error
 	GetPaymentIntentByIDForUpdate(ctx context.Context,
 		id uuid.UUID) (*dto.PaymentIntent, error)
+	CreatePaymentConfirmation(ctx context.Context,
+		param dto.CreatePaymentConfirmation) (*dto.PaymentConfirmation, error)
+	GetPendingPaymentConfirmation(ctx context.Context,
+		paymentIntentID uuid.UUID) (*dto.PaymentConfirmation, error)
+	IncrementConfirmationAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int32, error)
+	ConfirmPaymentIntent(ctx context.Context,
+		confirmation dto.PaymentConfirmation, client amqp.Client) (*dto.PaymentIntent, error)
+}
+
+type Team interface {
+	CreateInvitation(ctx context.Context,
+		param dto.CreateUserInvitation) (*dto.UserInvitation, error)
+	GetInvitationByTokenID(ctx context.Context,
+		tokenID uuid.UUID) (*dto.UserInvitation, error)
+	AcceptInvitation(ctx context.Context,
+		invitationID uuid.UUID, param dto.CreateUser) (*dto.User, error)
+	ListMembers(ctx context.Context,
+		companyID uuid.UUID) ([]dto.User, error)
+	UpdateMemberRole(ctx context.Context,
+		companyID, userID uuid.UUID, role constant.Role) (*dto.User, error)
+	UpdateMemberStatus(ctx context.Context,
+		companyID, userID uuid.UUID, status constant.Status) (*dto.User, error)
+}
+
+type Audit interface {
+	CreateAuditEvent(ctx context.Context,
+		param dto.CreateAuditEvent) (*dto.AuditEvent, error)
+	ListAuditEvents(ctx context.Context,
+		query dto.AuditEventQuery) ([]dto.AuditEvent, int, error)
+	// ListAuditEventsBefore returns up to query.Limit events newest first,
+	// continuing after last when it is set. query.Offset is ignored.
+	ListAuditEventsBefore(ctx context.Context,
+		query dto.AuditEventQuery, last *dto.AuditEvent) ([]dto.AuditEvent, error)
+}
+
+type PII interface {
+	ListDataKeys(ctx context.Context) ([]dto.PIIDataKey, error)
+	RotateDataKey(ctx context.Context) (*dto.PIIDataKey, error)
+	RewrapDataKeys(ctx context.Context) (int, error)
+	ResealCustomers(ctx context.Context, batchSize int) (int, error)
+	ResealMFASecrets(ctx context.Context, batchSize int) (int, error)
+}
+
+// Currency reads the currency registry as seen by one company.
+type Currency interface {
+	ListCompanyCurrencies(ctx context.Context, companyID uuid.UUID) ([]dto.Currency, error)
+	GetCompanyCurrency(ctx context.Context, companyID uuid.UUID,
+		code constant.Currency) (*dto.Currency, error)
+	SetCompanyCurrency(ctx context.Context, param dto.SetCompanyCurrency) error
+	GetCompanyFXSettings(ctx context.Context, companyID uuid.UUID) (*dto.CompanyFXSettings, error)
+	SetCompanyFXSettings(ctx context.Context,
+		param dto.SetCompanyFXSettings) (*dto.CompanyFXSettings, error)
+}
+
+type Limit interface {
+	ListCompanyLimits(ctx context.Context, companyID uuid.UUID) ([]dto.CompanyLimit, error)
+	SetCompanyLimit(ctx context.Context, param dto.SetCompanyLimit) (*dto.CompanyLimit, error)
+	DeleteCompanyLimit(ctx context.Context, companyID uuid.UUID, currency constant.Currency) error
+}
+
+type Risk interface {
+	ListRiskRules(ctx context.Context, companyID uuid.UUID) ([]dto.RiskRule, error)
+	GetRiskRule(ctx context.Context, companyID, id uuid.UUID) (*dto.RiskRule, error)
+	CreateRiskRule(ctx context.Context, param dto.SetRiskRule) (*dto.RiskRule, error)
+	UpdateRiskRule(ctx context.Context, param dto.SetRiskRule) (*dto.RiskRule, error)
+	DeleteRiskRule(ctx context.Context, companyID, id uuid.UUID) error
+	GetRiskSettings(ctx context.Context, companyID uuid.UUID) (*dto.RiskSettings, error)
+	SetRiskSettings(ctx context.Context, param dto.SetRiskSettings) (*dto.RiskSettings, error)
+	ListRiskReviews(ctx context.Context, query dto.RiskReviewQuery) ([]dto.RiskReview, int, error)
+	ReviewPaymentIntent(ctx context.Context, param dto.ReviewPaymentIntent, client amqp.Client) error
+}
+
+type Blocklist interface {
+	CreateBlocklistEntry(ctx context.Context, param dto.CreateBlocklistEntry) (*dto.BlocklistEntry, error)
+	GetBlocklistEntry(ctx context.Context, id uuid.UUID) (*dto.BlocklistEntry, error)
+	ListBlocklistEntries(ctx context.Context, query dto.BlocklistQuery) ([]dto.BlocklistEntry, int, error)
+	UpdateBlocklistEntry(ctx context.Context, param dto.UpdateBlocklistEntry) (*dto.BlocklistEntry, error)
+	DeleteBlocklistEntry(ctx context.Context, id uuid.UUID) error
+	MatchBlocklist(ctx context.Context, check dto.BlocklistCheck) ([]dto.BlocklistEntry, error)
+	RecordBlocklistHit(ctx context.Context, param dto.CreateBlocklistHit) error
+	ListBlocklistHits(ctx context.Context, query dto.BlocklistHitQuery) ([]dto.BlocklistHit, int, error)
+}
+
+type Dispute interface {
+	CreateDispute(ctx context.Context, param dto.CreateDispute) (*dto.Dispute, error)
+	GetDispute(ctx context.Context, id uuid.UUID) (*dto.Dispute, error)
+	ListDisputes(ctx context.Context, query dto.DisputeQuery) ([]dto.Dispute, int, error)
+	UpdateDisputeEvidence(ctx context.Context, id uuid.UUID, text string) (*dto.Dispute, error)
+	SubmitDispute(ctx context.Context, id, userID uuid.UUID) (*dto.Dispute, error)
+	ResolveDispute(ctx context.Context, id, operatorID uuid.UUID,
+		param dto.ResolveDispute) (*dto.Dispute, error)
+	CreateDisputeEvidenceFile(ctx context.Context,
+		param dto.CreateDisputeEvidenceFile) (*dto.DisputeEvidenceFile, error)
+	GetDisputeEvidenceFile(ctx context.Context,
+		disputeID, fileID uuid.UUID) (*dto.DisputeEvidenceFile, error)
+}
+
+type Webhook interface {
+	ClaimDueEvents(ctx context.Context, batchSize int, lease time.Duration) ([]dto.WebhookEvent, error)
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string,
+		nextAttemptAt time.Time, maxAttempts int) error
 }
@@ -0,0 +1,158 @@
+package team
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/errors/sqlcerr"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type teamPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewTeamPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Team {
+	return &teamPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+func (t *teamPersistance) CreateInvitation(ctx context.Context,
+	param dto.CreateUserInvitation) (*dto.UserInvitation, error) {
+	invitation, err := t.persistenceQueries.CreateUserInvitationTx(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvitation(invitation), nil
+}
+
+func (t *teamPersistance) GetInvitationByTokenID(ctx context.Context,
+	tokenID uuid.UUID) (*dto.UserInvitation, error) {
+	invitation, err := t.persistenceQueries.GetUserInvitationByTokenID(ctx, tokenID)
+	if err != nil {
+		if sqlcerr.Is(err, sqlcerr.ErrNoRows) {
+			err := errors.ErrNoRecordFound.Wrap(err, "invitation not found")
+			t.logger.Error(ctx, "invitation not found",
+				zap.Error(err), zap.String("token-id", tokenID.String()))
+			return nil, err
+		}
+		err = errors.ErrUnableToGet.Wrap(err, "unable to get invitation")
+		t.logger.Error(ctx, "unable to get invitation",
+			zap.Error(err), zap.String("token-id", tokenID.String()))
+		return nil, err
+	}
+
+	return toInvitation(invitation), nil
+}
+
+func (t *teamPersistance) AcceptInvitation(ctx context.Context,
+	invitationID uuid.UUID, param dto.CreateUser) (*dto.User, error) {
+	user, err := t.persistenceQueries.AcceptUserInvitationTx(ctx, invitationID, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUser(user), nil
+}
+
+func (t *teamPersistance) ListMembers(ctx context.Context,
+	companyID uuid.UUID) ([]dto.User, error) {
+	users, err := t.persistenceQueries.ListCompanyUsers(ctx, companyID)
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to list company users")
+		t.logger.Error(ctx, "unable to list company users",
+			zap.Error(err), zap.String("company-id", companyID.String()))
+		return nil, err
+	}
+
+	members := make([]dto.User, 0, len(users))
+	for _, user := range users {
+		members = append(members, *toUser(user))
+	}
+	return members, nil
+}
+
+func (t *teamPersistance) UpdateMemberRole(ctx context.Context,
+	companyID, userID uuid.UUID, role constant.Role) (*dto.User, error) {
+	user, err := t.persistenceQueries.UpdateUserRole(ctx, db.UpdateUserRoleParams{
+		ID:        userID,
+		CompanyID: companyID,
+		Role:      string(role),
+	})
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update user role")
+		t.logger.Error(ctx, "unable to update user role",
+			zap.Error(err), zap.String("user-id", userID.String()))
+		return nil, err
+	}
+
+	return toUser(user), nil
+}
+
+func (t *teamPersistance) UpdateMemberStatus(ctx context.Context,
+	companyID, userID uuid.UUID, status constant.Status) (*dto.User, error) {
+	user, err := t.persistenceQueries.UpdateUserStatus(ctx, db.UpdateUserStatusParams{
+		ID:        userID,
+		CompanyID: companyID,
+		Status:    string(status),
+	})
+	if err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to update user status")
+		t.logger.Error(ctx, "unable to update user status",
+			zap.Error(err), zap.String("user-id", userID.String()))
+		return nil, err
+	}
+
+	return toUser(user), nil
+}
+
+func toInvitation(invitation db.UserInvitation) *dto.UserInvitation {
+	return &dto.UserInvitation{
+		ID:         invitation.ID,
+		CompanyID:  invitation.CompanyID,
+		Email:      invitation.Email,
+		Role:       constant.Role(invitation.Role),
+		TokenID:    invitation.TokenID,
+		InvitedBy:  invitation.InvitedBy,
+		Status:     constant.Status(invitation.Status),
+		AcceptedAt: invitation.AcceptedAt.Time,
+		CreatedAt:  invitation.CreatedAt,
+		UpdatedAt:  invitation.UpdatedAt,
+	}
+}
+
+// toUser maps a user row without its password hash.
+func toUser(user db.User) *dto.User {
+	return &dto.User{
+		ID:                user.ID,
+		CompanyID:         user.CompanyID,
+		Username:          user.Username.String,
+		Email:             user.Email,
+		Phone:             user.Phone,
+		FirstName:         user.FirstName.String,
+		LastName:          user.LastName.String,
+		Role:              user.Role,
+		Status:            user.Status,
+		TimezoneID:        user.TimezoneID.String,
+		Bio:               user.Bio.String,
+		ProfilePictureUrl: user.ProfilePictureUrl.String,
+		EmailVerifiedAt:   user.EmailVerifiedAt.Time,
+		PhoneVerifiedAt:   user.PhoneVerifiedAt.Time,
+		MFAEnabledAt:      user.MfaEnabledAt.Time,
+		SessionsRevokedAt: user.SessionsRevokedAt.Time,
+		CreatedAt:         user.CreatedAt,
+		UpdatedAt:         user.UpdatedAt,
+	}
+}
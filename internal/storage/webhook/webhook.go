@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"context"
+	"pg/internal/constant"
+	"pg/internal/constant/errors"
+	"pg/internal/constant/model/db"
+	"pg/internal/constant/model/dto"
+	persistencedb "pg/internal/constant/persistenceDB"
+	"pg/internal/storage"
+	"pg/platform/hlog"
+	"pg/platform/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type webhookPersistance struct {
+	persistenceQueries persistencedb.PersistenceDB
+	logger             hlog.Logger
+}
+
+func NewWebhookPersistance(persistenceQueries persistencedb.PersistenceDB,
+	logger hlog.Logger) storage.Webhook {
+	return &webhookPersistance{
+		persistenceQueries: persistenceQueries,
+		logger:             logger,
+	}
+}
+
+// ClaimDueEvents returns up to batchSize events due for delivery and keeps
+// them from being claimed again for lease.
+func (w *webhookPersistance) ClaimDueEvents(ctx context.Context, batchSize int,
+	lease time.Duration) ([]dto.WebhookEvent, error) {
+	events, err := w.persistenceQueries.ClaimDueWebhookEvents(ctx, db.ClaimDueWebhookEventsParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		BatchSize:    int32(batchSize),
+	})
+	if err != nil {
+		err = errors.ErrUnableToGet.Wrap(err, "unable to claim webhook events")
+		w.logger.Error(ctx, "unable to claim webhook events", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]dto.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, dto.WebhookEvent{
+			ID:        event.ID,
+			CompanyID: event.CompanyID,
+			Type:      constant.WebhookEventType(event.Type),
+			Livemode:  event.Livemode,
+			Payload:   event.Payload.Bytes,
+			Attempts:  int(event.Attempts),
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (w *webhookPersistance) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	if err := w.persistenceQueries.MarkWebhookEventDelivered(ctx, id); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to mark webhook event delivered")
+		w.logger.Error(ctx, "unable to mark webhook event delivered", zap.Error(err),
+			zap.String("webhook-event-id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed delivery. The event is retried at
+// nextAttemptAt, or given up once it has failed maxAttempts times.
+func (w *webhookPersistance) MarkFailed(ctx context.Context, id uuid.UUID,
+	reason string, nextAttemptAt time.Time, maxAttempts int) error {
+	if err := w.persistenceQueries.MarkWebhookEventFailed(ctx, db.MarkWebhookEventFailedParams{
+		ID:            id,
+		LastError:     sql.StringOrNull(reason),
+		NextAttemptAt: nextAttemptAt,
+		MaxAttempts:   int32(maxAttempts),
+	}); err != nil {
+		err = errors.ErrUnableToUpdate.Wrap(err, "unable to record webhook failure")
+		w.logger.Error(ctx, "unable to record webhook failure", zap.Error(err),
+			zap.String("webhook-event-id", id.String()))
+		return err
+	}
+
+	return nil
+}
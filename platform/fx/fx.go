@@ -0,0 +1,174 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrRateNotFound is returned when the provider has no rate for a pair.
+	ErrRateNotFound = errors.New("fx rate not found")
+	// ErrRateStale is returned when the provider's rate is older than it
+	// accepts.
+	ErrRateStale = errors.New("fx rate is stale")
+)
+
+// RatePlaces is how many decimals a rate is kept to once a spread is
+// applied or it is inverted.
+const RatePlaces = 8
+
+// Rate is the mid-market price of one unit of Base in Quote.
+type Rate struct {
+	Base   string          `json:"base"`
+	Quote  string          `json:"quote"`
+	Mid    decimal.Decimal `json:"rate"`
+	Source string          `json:"source,omitempty"`
+	AsOf   time.Time       `json:"as_of"`
+}
+
+// Provider quotes exchange rates. Implementations are expected to be safe
+// for concurrent use.
+type Provider interface {
+	Rate(ctx context.Context, base, quote string) (Rate, error)
+}
+
+// RoundingMode names how an amount is brought to a number of decimals.
+// Every conversion states its mode rather than relying on a default.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds ties to the even neighbour.
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds ties away from zero.
+	RoundHalfUp
+	// RoundDown truncates toward zero.
+	RoundDown
+)
+
+// Round rounds amount to places decimals with mode.
+func Round(amount decimal.Decimal, places int32, mode RoundingMode) decimal.Decimal {
+	switch mode {
+	case RoundHalfUp:
+		return amount.Round(places)
+	case RoundDown:
+		return amount.Truncate(places)
+	default:
+		return amount.RoundBank(places)
+	}
+}
+
+// ApplySpread lowers a mid rate by spreadBps basis points, rounded half to
+// even to RatePlaces decimals.
+func ApplySpread(mid decimal.Decimal, spreadBps int) decimal.Decimal {
+	factor := decimal.NewFromInt(10000 - int64(spreadBps)).Div(decimal.NewFromInt(10000))
+	return Round(mid.Mul(factor), RatePlaces, RoundHalfEven)
+}
+
+// Convert multiplies amount by rate and rounds the result to places
+// decimals with mode.
+func Convert(amount, rate decimal.Decimal, places int32, mode RoundingMode) decimal.Decimal {
+	return Round(amount.Mul(rate), places, mode)
+}
+
+// ratesFile is the layout of the file read by NewFile. Rates without their
+// own source or as_of take the file's.
+type ratesFile struct {
+	Source string    `json:"source"`
+	AsOf   time.Time `json:"as_of"`
+	Rates  []Rate    `json:"rates"`
+}
+
+type fileProvider struct {
+	path    string
+	maxAge  time.Duration
+	mu      sync.Mutex
+	modTime time.Time
+	rates   map[string]Rate
+}
+
+// NewFile returns a Provider that reads rates from a JSON file, rereading
+// it whenever it changes. It stands in for a market data feed. A pair is
+// also quoted in reverse by inverting its rate. Rates older than maxAge are
+// refused; zero accepts rates of any age.
+func NewFile(path string, maxAge time.Duration) (Provider, error) {
+	f := &fileProvider{path: path, maxAge: maxAge}
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *fileProvider) Rate(_ context.Context, base, quote string) (Rate, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.load(); err != nil {
+		return Rate{}, err
+	}
+
+	rate, ok := f.rates[base+"/"+quote]
+	if !ok {
+		inverse, found := f.rates[quote+"/"+base]
+		if !found {
+			return Rate{}, fmt.Errorf("%w: %s/%s", ErrRateNotFound, base, quote)
+		}
+		rate = Rate{
+			Base:   base,
+			Quote:  quote,
+			Mid:    decimal.NewFromInt(1).DivRound(inverse.Mid, RatePlaces),
+			Source: inverse.Source,
+			AsOf:   inverse.AsOf,
+		}
+	}
+	if f.maxAge > 0 && time.Since(rate.AsOf) > f.maxAge {
+		return Rate{}, fmt.Errorf("%w: %s/%s as of %s", ErrRateStale, base, quote,
+			rate.AsOf.Format(time.RFC3339))
+	}
+
+	return rate, nil
+}
+
+// load rereads the file if it changed since it was last read.
+func (f *fileProvider) load() error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	if f.rates != nil && info.ModTime().Equal(f.modTime) {
+		return nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	file := ratesFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("invalid fx rates file: %w", err)
+	}
+
+	rates := make(map[string]Rate, len(file.Rates))
+	for _, rate := range file.Rates {
+		rate.Base, rate.Quote = strings.ToUpper(rate.Base), strings.ToUpper(rate.Quote)
+		if !rate.Mid.IsPositive() {
+			return fmt.Errorf("invalid fx rate for %s/%s", rate.Base, rate.Quote)
+		}
+		if rate.Source == "" {
+			rate.Source = file.Source
+		}
+		if rate.AsOf.IsZero() {
+			rate.AsOf = file.AsOf
+		}
+		rates[rate.Base+"/"+rate.Quote] = rate
+	}
+	f.rates, f.modTime = rates, info.ModTime()
+
+	return nil
+}
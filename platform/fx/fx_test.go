@@ -0,0 +1,83 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		amount string
+		mode   RoundingMode
+		want   string
+	}{
+		{"2.345", RoundHalfEven, "2.34"},
+		{"2.355", RoundHalfEven, "2.36"},
+		{"2.345", RoundHalfUp, "2.35"},
+		{"-2.345", RoundHalfUp, "-2.35"},
+		{"2.349", RoundDown, "2.34"},
+		{"-2.349", RoundDown, "-2.34"},
+	}
+	for _, c := range cases {
+		got := Round(decimal.RequireFromString(c.amount), 2, c.mode)
+		if !got.Equal(decimal.RequireFromString(c.want)) {
+			t.Errorf("Round(%s, %d) = %s, want %s", c.amount, c.mode, got, c.want)
+		}
+	}
+}
+
+func TestConvertWithSpread(t *testing.T) {
+	rate := ApplySpread(decimal.RequireFromString("128.4567"), 150)
+	if !rate.Equal(decimal.RequireFromString("126.52984950")) {
+		t.Fatalf("rate with spread = %s", rate)
+	}
+	settled := Convert(decimal.RequireFromString("19.99"), rate, 2, RoundDown)
+	if !settled.Equal(decimal.RequireFromString("2529.33")) {
+		t.Fatalf("settled = %s", settled)
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	asOf := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+	body := `{"source":"test","as_of":"` + asOf + `","rates":[{"base":"USD","quote":"ETB","rate":"125"}]}`
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := NewFile(path, 2*time.Hour)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	rate, err := provider.Rate(context.Background(), "usd", "etb")
+	if err != nil {
+		t.Fatalf("rate: %v", err)
+	}
+	if !rate.Mid.Equal(decimal.NewFromInt(125)) || rate.Source != "test" {
+		t.Fatalf("rate = %+v", rate)
+	}
+	inverse, err := provider.Rate(context.Background(), "ETB", "USD")
+	if err != nil {
+		t.Fatalf("inverse: %v", err)
+	}
+	if !inverse.Mid.Equal(decimal.RequireFromString("0.008")) {
+		t.Fatalf("inverse = %s", inverse.Mid)
+	}
+	if _, err := provider.Rate(context.Background(), "EUR", "ETB"); !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("missing pair: %v", err)
+	}
+
+	stale, err := NewFile(path, time.Minute)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err := stale.Rate(context.Background(), "USD", "ETB"); !errors.Is(err, ErrRateStale) {
+		t.Fatalf("stale rate: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package hcrypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/aead/chacha20poly1305"
+)
+
+// FieldKeys configures the keys that protect personal data stored in the
+// database. Values are sealed with data keys, and data keys are stored
+// wrapped by a master key, so rotating the master key only rewraps the data
+// keys and rotating a data key only re-encrypts the rows that use it.
+type FieldKeys struct {
+	// MasterKey wraps every new data key.
+	MasterKey string
+	// MasterKeyID identifies MasterKey on wrapped data keys. Defaults to
+	// DefaultKeyID.
+	MasterKeyID string
+	// PreviousMasterKeys maps key ids to master keys that were rotated out.
+	// Data keys they wrapped stay readable until they are rewrapped.
+	PreviousMasterKeys map[string]string
+	// BlindIndexKey keys the HMAC of values that must stay searchable. It
+	// cannot be rotated without rebuilding every index.
+	BlindIndexKey string
+}
+
+// FieldKeyring wraps data keys and computes blind indexes.
+type FieldKeyring struct {
+	masters       *keyring
+	blindIndexKey []byte
+}
+
+func NewFieldKeyring(config FieldKeys) (*FieldKeyring, error) {
+	primaryID := config.MasterKeyID
+	if primaryID == "" {
+		primaryID = DefaultKeyID
+	}
+	masters, err := buildKeyring(primaryID, primaryID, config.MasterKey,
+		config.PreviousMasterKeys, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.BlindIndexKey) < chacha20poly1305.KeySize {
+		return nil, fmt.Errorf("blind index key must be at least %d characters",
+			chacha20poly1305.KeySize)
+	}
+
+	return &FieldKeyring{
+		masters:       masters,
+		blindIndexKey: []byte(config.BlindIndexKey),
+	}, nil
+}
+
+// MasterKeyID is the id of the master key new data keys are wrapped with.
+func (f *FieldKeyring) MasterKeyID() string {
+	return f.masters.primaryID
+}
+
+// NewDataKey returns a random data key.
+func (f *FieldKeyring) NewDataKey() ([]byte, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// WrapDataKey seals a data key with the primary master key. The data key id
+// is authenticated, so a wrapped key cannot be moved to another row.
+func (f *FieldKeyring) WrapDataKey(dataKeyID string, key []byte) (string, []byte, error) {
+	wrapped, err := Seal(f.masters.keys[f.masters.primaryID], key, []byte(dataKeyID))
+	if err != nil {
+		return "", nil, err
+	}
+	return f.masters.primaryID, wrapped, nil
+}
+
+// UnwrapDataKey opens a data key wrapped by the given master key.
+func (f *FieldKeyring) UnwrapDataKey(masterKeyID, dataKeyID string, wrapped []byte) ([]byte, error) {
+	master, ok := f.masters.keys[masterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown master key %q", masterKeyID)
+	}
+	return Open(master, wrapped, []byte(dataKeyID))
+}
+
+// BlindIndex is a keyed hash of value within scope. Equal values in the
+// same scope hash equally, so the index supports equality lookups and
+// unique constraints without revealing the value.
+func (f *FieldKeyring) BlindIndex(scope, value string) []byte {
+	mac := hmac.New(sha256.New, f.blindIndexKey)
+	mac.Write([]byte(scope))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// Seal encrypts plaintext with XChaCha20-Poly1305 under a random nonce and
+// returns the nonce followed by the ciphertext.
+func Seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewXCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Open decrypts a value produced by Seal with the same additional data.
+func Open(key, sealed, additionalData []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewXCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("sealed value is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
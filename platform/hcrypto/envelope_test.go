@@ -0,0 +1,87 @@
+package hcrypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+const indexKey = "blind-index-key-0123456789abcdef"
+
+func TestSealOpen(t *testing.T) {
+	sealed, err := Seal([]byte(newKey), []byte("+251911234567"), []byte("aad"))
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	plain, err := Open([]byte(newKey), sealed, []byte("aad"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if string(plain) != "+251911234567" {
+		t.Fatalf("opened %q", plain)
+	}
+	if _, err := Open([]byte(newKey), sealed, []byte("other")); err == nil {
+		t.Fatal("opened with different additional data")
+	}
+	if _, err := Open([]byte(oldKey), sealed, []byte("aad")); err == nil {
+		t.Fatal("opened with the wrong key")
+	}
+	again, err := Seal([]byte(newKey), []byte("+251911234567"), []byte("aad"))
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	if bytes.Equal(sealed, again) {
+		t.Fatal("sealing twice gave the same ciphertext")
+	}
+}
+
+func TestDataKeySurvivesMasterRotation(t *testing.T) {
+	before, err := NewFieldKeyring(FieldKeys{MasterKey: oldKey, MasterKeyID: "m1",
+		BlindIndexKey: indexKey})
+	if err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+	dataKey, err := before.NewDataKey()
+	if err != nil {
+		t.Fatalf("data key: %v", err)
+	}
+	masterID, wrapped, err := before.WrapDataKey("dk1", dataKey)
+	if err != nil {
+		t.Fatalf("wrap: %v", err)
+	}
+
+	after, err := NewFieldKeyring(FieldKeys{MasterKey: newKey, MasterKeyID: "m2",
+		PreviousMasterKeys: map[string]string{"m1": oldKey}, BlindIndexKey: indexKey})
+	if err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+	unwrapped, err := after.UnwrapDataKey(masterID, "dk1", wrapped)
+	if err != nil {
+		t.Fatalf("unwrap: %v", err)
+	}
+	if !bytes.Equal(unwrapped, dataKey) {
+		t.Fatal("unwrapped a different data key")
+	}
+	if _, err := after.UnwrapDataKey(masterID, "dk2", wrapped); err == nil {
+		t.Fatal("unwrapped under another data key id")
+	}
+	if got, _, _ := after.WrapDataKey("dk1", dataKey); got != "m2" {
+		t.Fatalf("rewrapped with %q, want m2", got)
+	}
+}
+
+func TestBlindIndex(t *testing.T) {
+	ring, err := NewFieldKeyring(FieldKeys{MasterKey: newKey, BlindIndexKey: indexKey})
+	if err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+	a := ring.BlindIndex("company-a", "+251911234567")
+	if !bytes.Equal(a, ring.BlindIndex("company-a", "+251911234567")) {
+		t.Fatal("blind index is not deterministic")
+	}
+	if bytes.Equal(a, ring.BlindIndex("company-b", "+251911234567")) {
+		t.Fatal("blind index is the same across scopes")
+	}
+	if _, err := NewFieldKeyring(FieldKeys{MasterKey: newKey, BlindIndexKey: "short"}); err == nil {
+		t.Fatal("accepted a short blind index key")
+	}
+}
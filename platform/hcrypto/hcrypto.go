@@ -5,12 +5,11 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"errors"
-	"fmt"
 	"pg/internal/constant"
 	"pg/platform/hlog"
+	"strings"
 	"time"
 
-	"github.com/aead/chacha20poly1305"
 	"github.com/google/uuid"
 	"github.com/o1egl/paseto"
 	"go.uber.org/zap"
@@ -21,12 +20,16 @@ type Maker interface {
 		tokenType constant.TokenType) (string, uuid.UUID, error)
 	VerifyPasetoToken(token string) (*Payload, error)
 	GenerateRandomKey() string
+	// PublicKeys returns the keys that verify v4.public tokens.
+	PublicKeys() []PublicKey
 	// VerifyJwtToken(signingMethod jwt.SigningMethod, token string) (bool, *jwt.RegisteredClaims)
 }
 type tokenMaker struct {
 	Logger             hlog.Logger
 	paseto             *paseto.V2
-	symmetricKey       []byte
+	keyring            *keyring
+	signingKeyring     *signingKeyring
+	publicTokenTypes   map[constant.TokenType]bool
 	Issuer             string
 	Footer             string
 	KeyLength          int
@@ -34,6 +37,11 @@ type tokenMaker struct {
 	AccessExpires      time.Duration
 	RefreshExpires     time.Duration
 	SecretTokenExpires time.Duration
+	InviteExpires      time.Duration
+	ResetExpires       time.Duration
+	VerifyExpires      time.Duration
+	MFAExpires         time.Duration
+	UnlockExpires      time.Duration
 }
 type UserData struct {
 	UserID    string                 `json:"user_id"`
@@ -45,6 +53,7 @@ type Payload struct {
 	Issuer    string                 `json:"issuer"`
 	Audience  string                 `json:"audience"`
 	TokenID   uuid.UUID              `json:"token_id"`
+	TokenType constant.TokenType     `json:"token_type"`
 	UserID    string                 `json:"user_id"`
 	Email     string                 `json:"email"`
 	IsNewUser bool                   `json:"is_new_user"`
@@ -55,15 +64,24 @@ type Payload struct {
 
 func PasetoInit(tokenconfig TokenKey,
 	logger hlog.Logger) Maker {
-	if len(tokenconfig.SymmetricKey) != chacha20poly1305.KeySize {
-		err := fmt.Errorf("invalid key size: must be exactly %d characters",
-			chacha20poly1305.KeySize)
-		logger.Fatal(context.Background(), "Invalid key size", zap.Error(err))
+	ring, err := newKeyring(tokenconfig)
+	if err != nil {
+		logger.Fatal(context.Background(), "Invalid token keys", zap.Error(err))
+	}
+	signingRing, err := newSigningKeyring(tokenconfig)
+	if err != nil {
+		logger.Fatal(context.Background(), "Invalid token signing keys", zap.Error(err))
+	}
+	publicTokenTypes := make(map[constant.TokenType]bool, len(tokenconfig.PublicTokenTypes))
+	for _, tokenType := range tokenconfig.PublicTokenTypes {
+		publicTokenTypes[tokenType] = true
 	}
 	maker := &tokenMaker{
 		Logger:             logger,
 		paseto:             paseto.NewV2(),
-		symmetricKey:       []byte(tokenconfig.SymmetricKey),
+		keyring:            ring,
+		signingKeyring:     signingRing,
+		publicTokenTypes:   publicTokenTypes,
 		Issuer:             tokenconfig.Issuer,
 		Footer:             tokenconfig.Footer,
 		KeyLength:          tokenconfig.KeyLength,
@@ -71,6 +89,26 @@ func PasetoInit(tokenconfig TokenKey,
 		AccessExpires:      tokenconfig.AccessExpires,
 		RefreshExpires:     tokenconfig.RefreshExpires,
 		SecretTokenExpires: tokenconfig.SecretTokenExpires,
+		InviteExpires:      tokenconfig.InviteExpires,
+		ResetExpires:       tokenconfig.ResetExpires,
+		VerifyExpires:      tokenconfig.VerifyExpires,
+		MFAExpires:         tokenconfig.MFAExpires,
+		UnlockExpires:      tokenconfig.UnlockExpires,
+	}
+	if maker.InviteExpires <= 0 {
+		maker.InviteExpires = 7 * 24 * time.Hour
+	}
+	if maker.ResetExpires <= 0 {
+		maker.ResetExpires = 15 * time.Minute
+	}
+	if maker.VerifyExpires <= 0 {
+		maker.VerifyExpires = 24 * time.Hour
+	}
+	if maker.MFAExpires <= 0 {
+		maker.MFAExpires = 5 * time.Minute
+	}
+	if maker.UnlockExpires <= 0 {
+		maker.UnlockExpires = time.Hour
 	}
 
 	return maker
@@ -86,6 +124,7 @@ func (maker *tokenMaker) CreatePasetoToken(data UserData,
 		Audience:  maker.Audience,
 		Issuer:    maker.Issuer,
 		TokenID:   tokenID,
+		TokenType: tokenType,
 		UserID:    data.UserID,
 		Email:     data.Email,
 		IsNewUser: data.IsNewUser,
@@ -93,12 +132,30 @@ func (maker *tokenMaker) CreatePasetoToken(data UserData,
 		IssuedAt:  time.Now(),
 		ExpiresAt: time.Now().Add(maker.AccessExpires),
 	}
-	if tokenType == constant.RefreshToken {
+	switch tokenType {
+	case constant.RefreshToken:
 		payload.ExpiresAt = time.Now().Add(maker.RefreshExpires)
-	} else if tokenType == constant.SecretToken {
+	case constant.SecretToken:
 		payload.ExpiresAt = time.Now().Add(maker.SecretTokenExpires)
+	case constant.InviteTokenType:
+		payload.ExpiresAt = time.Now().Add(maker.InviteExpires)
+	case constant.ResetPassword:
+		payload.ExpiresAt = time.Now().Add(maker.ResetExpires)
+	case constant.VerificationTokenType:
+		payload.ExpiresAt = time.Now().Add(maker.VerifyExpires)
+	case constant.MFAChallengeToken:
+		payload.ExpiresAt = time.Now().Add(maker.MFAExpires)
+	case constant.UnlockAccountToken:
+		payload.ExpiresAt = time.Now().Add(maker.UnlockExpires)
+	}
+	var pay string
+	if maker.publicTokenTypes[tokenType] {
+		pay, err = signV4Public(maker.signingKeyring.privateKey, payload,
+			tokenFooter{KeyID: maker.signingKeyring.primaryID})
+	} else {
+		footer, key := maker.keyring.primary()
+		pay, err = maker.paseto.Encrypt(key, payload, footer)
 	}
-	pay, err := maker.paseto.Encrypt(maker.symmetricKey, payload, maker.Footer)
 	if err != nil {
 		return "",
 			uuid.Nil, errors.New("failed to create token")
@@ -109,15 +166,52 @@ func (maker *tokenMaker) CreatePasetoToken(data UserData,
 func (maker *tokenMaker) VerifyPasetoToken(token string) (*Payload, error) {
 	payload := &Payload{}
 
-	err := maker.paseto.Decrypt(token, maker.symmetricKey, payload, nil)
-	if err != nil {
+	var footer string
+	if err := paseto.ParseFooter(token, &footer); err != nil {
 		return nil, errors.New("token is invalid")
 	}
+	if strings.HasPrefix(token, v4PublicHeader) {
+		if err := maker.verifyPublic(token, footer, payload); err != nil {
+			return nil, err
+		}
+	} else {
+		keyID, key, ok := maker.keyring.lookup(footer)
+		if !ok {
+			maker.Logger.Warn(context.Background(), "token key is unknown or retired",
+				zap.String("kid", keyID))
+			return nil, errors.New("token is invalid")
+		}
+		err := maker.paseto.Decrypt(token, key, payload, nil)
+		if err != nil {
+			return nil, errors.New("token is invalid")
+		}
+	}
 	if payload.ExpiresAt.Before(time.Now()) {
 		return nil, errors.New("token has expired")
 	}
 	return payload, nil
 }
+func (maker *tokenMaker) verifyPublic(token, footer string, payload *Payload) error {
+	if maker.signingKeyring == nil {
+		return errors.New("token is invalid")
+	}
+	keyID, key, ok := maker.signingKeyring.lookup(footer)
+	if !ok {
+		maker.Logger.Warn(context.Background(), "token signing key is unknown or retired",
+			zap.String("kid", keyID))
+		return errors.New("token is invalid")
+	}
+	if err := verifyV4Public(token, key, payload); err != nil {
+		return errors.New("token is invalid")
+	}
+	return nil
+}
+func (maker *tokenMaker) PublicKeys() []PublicKey {
+	if maker.signingKeyring == nil {
+		return []PublicKey{}
+	}
+	return maker.signingKeyring.publicKeys()
+}
 func (maker *tokenMaker) GenerateRandomKey() string {
 	uuidkey := uuid.New().String()
 	key := maker.Issuer + "-" + uuidkey + "-" + maker.Footer
@@ -0,0 +1,160 @@
+package hcrypto
+
+import (
+	"pg/internal/constant"
+	"pg/platform/hlog"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/o1egl/paseto"
+	"go.uber.org/zap"
+)
+
+const (
+	oldKey = "0123456789abcdef0123456789abcdef"
+	newKey = "fedcba9876543210fedcba9876543210"
+)
+
+func newTestMaker(t *testing.T, config TokenKey) Maker {
+	t.Helper()
+	config.Issuer = "pg"
+	config.Footer = "pg"
+	config.KeyLength = 12
+	config.Audience = "pg"
+	config.AccessExpires = time.Hour
+	config.RefreshExpires = time.Hour
+	config.SecretTokenExpires = time.Hour
+
+	// An empty DSN gives a client that drops every event.
+	client, err := sentry.NewClient(sentry.ClientOptions{})
+	if err != nil {
+		t.Fatalf("create sentry client: %v", err)
+	}
+
+	return PasetoInit(config, hlog.New(zap.NewNop(), hlog.Options{}, client))
+}
+
+func issue(t *testing.T, maker Maker) string {
+	t.Helper()
+	token, _, err := maker.CreatePasetoToken(UserData{UserID: "user"}, constant.AccessToken)
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+	return token
+}
+
+func TestTokenFooterCarriesPrimaryKeyID(t *testing.T) {
+	maker := newTestMaker(t, TokenKey{SymmetricKey: newKey, KeyID: "k2"})
+	token := issue(t, maker)
+
+	footer := tokenFooter{}
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		t.Fatalf("parse footer: %v", err)
+	}
+	if footer.KeyID != "k2" {
+		t.Fatalf("footer kid = %q, want %q", footer.KeyID, "k2")
+	}
+}
+
+func TestRotatedKeyStillVerifies(t *testing.T) {
+	before := newTestMaker(t, TokenKey{SymmetricKey: oldKey, KeyID: "k1"})
+	token := issue(t, before)
+
+	after := newTestMaker(t, TokenKey{
+		SymmetricKey: newKey,
+		KeyID:        "k2",
+		PreviousKeys: map[string]string{"k1": oldKey},
+	})
+	payload, err := after.VerifyPasetoToken(token)
+	if err != nil {
+		t.Fatalf("verify token from previous key: %v", err)
+	}
+	if payload.UserID != "user" {
+		t.Fatalf("user id = %q, want %q", payload.UserID, "user")
+	}
+
+	fresh := issue(t, after)
+	if _, err := before.VerifyPasetoToken(fresh); err == nil {
+		t.Fatal("token from the new primary key verified with the old keyring only")
+	}
+}
+
+func TestRetiredKeyIsRejected(t *testing.T) {
+	before := newTestMaker(t, TokenKey{SymmetricKey: oldKey, KeyID: "k1"})
+	token := issue(t, before)
+
+	after := newTestMaker(t, TokenKey{
+		SymmetricKey:  newKey,
+		KeyID:         "k2",
+		PreviousKeys:  map[string]string{"k1": oldKey},
+		RetiredKeyIDs: []string{"k1"},
+	})
+	if _, err := after.VerifyPasetoToken(token); err == nil {
+		t.Fatal("token from a retired key verified")
+	}
+}
+
+func TestUnknownKeyIDIsRejected(t *testing.T) {
+	other := newTestMaker(t, TokenKey{SymmetricKey: oldKey, KeyID: "k9"})
+	token := issue(t, other)
+
+	maker := newTestMaker(t, TokenKey{SymmetricKey: oldKey, KeyID: "k1"})
+	if _, err := maker.VerifyPasetoToken(token); err == nil {
+		t.Fatal("token with an unknown key id verified")
+	}
+}
+
+func TestLegacyTokenWithoutKeyID(t *testing.T) {
+	// Tokens issued before rotation carried the configured footer string.
+	legacy, err := paseto.NewV2().Encrypt([]byte(oldKey), Payload{
+		UserID:    "user",
+		TokenType: constant.AccessToken,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, "pg")
+	if err != nil {
+		t.Fatalf("create legacy token: %v", err)
+	}
+
+	unrotated := newTestMaker(t, TokenKey{SymmetricKey: oldKey})
+	if _, err := unrotated.VerifyPasetoToken(legacy); err != nil {
+		t.Fatalf("verify legacy token before rotation: %v", err)
+	}
+
+	rotated := newTestMaker(t, TokenKey{
+		SymmetricKey: newKey,
+		KeyID:        "k2",
+		PreviousKeys: map[string]string{"k1": oldKey},
+		LegacyKeyID:  "k1",
+	})
+	if _, err := rotated.VerifyPasetoToken(legacy); err != nil {
+		t.Fatalf("verify legacy token after rotation: %v", err)
+	}
+
+	retired := newTestMaker(t, TokenKey{
+		SymmetricKey:  newKey,
+		KeyID:         "k2",
+		PreviousKeys:  map[string]string{"k1": oldKey},
+		RetiredKeyIDs: []string{"k1"},
+		LegacyKeyID:   "k1",
+	})
+	if _, err := retired.VerifyPasetoToken(legacy); err == nil {
+		t.Fatal("legacy token verified after its key was retired")
+	}
+}
+
+func TestParseKeyList(t *testing.T) {
+	keys, err := ParseKeyList(" k1:" + oldKey + ", k2:" + newKey + " ,")
+	if err != nil {
+		t.Fatalf("parse key list: %v", err)
+	}
+	if len(keys) != 2 || keys["k1"] != oldKey || keys["k2"] != newKey {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+
+	for _, value := range []string{"k1", ":" + oldKey, "k1:" + oldKey + ",k1:" + newKey} {
+		if _, err := ParseKeyList(value); err == nil {
+			t.Fatalf("ParseKeyList(%q) succeeded, want error", value)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package hcrypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// HMACSigningString is the canonical string a PG-HMAC signature covers:
+// method, request URI (path and query), unix timestamp, nonce and the
+// hex SHA-256 of the raw body, joined by newlines.
+func HMACSigningString(method, requestURI, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		requestURI,
+		timestamp,
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+}
+
+// WebhookSigningString is the canonical string the signature of a webhook
+// event covers: unix timestamp, event id and the hex SHA-256 of the raw
+// body, joined by newlines.
+func WebhookSigningString(timestamp, eventID string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	return strings.Join([]string{
+		timestamp,
+		eventID,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+}
+
+// SignHMAC returns the hex HMAC-SHA256 of the signing string.
+func SignHMAC(secret, signingString string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingString))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyHMAC compares signature with the expected one in constant time.
+func VerifyHMAC(secret, signingString, signature string) bool {
+	expected, err := hex.DecodeString(SignHMAC(secret, signingString))
+	if err != nil {
+		return false
+	}
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, given)
+}
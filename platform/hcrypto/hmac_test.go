@@ -0,0 +1,50 @@
+package hcrypto
+
+import "testing"
+
+func TestHMACSignature(t *testing.T) {
+	signingString := HMACSigningString("post", "/api/payment-intents?x=1",
+		"1760000000", "nonce-1", []byte(`{"amount":100}`))
+	want := "POST\n/api/payment-intents?x=1\n1760000000\nnonce-1\n" +
+		"4d4bbe59c6aad22442cde199a6a8a5f034405fcd78fb5a81c24ef249de1c45f1"
+	if signingString != want {
+		t.Fatalf("signing string = %q, want %q", signingString, want)
+	}
+
+	signature := SignHMAC("secret", signingString)
+	if !VerifyHMAC("secret", signingString, signature) {
+		t.Fatal("valid signature rejected")
+	}
+	if VerifyHMAC("other-secret", signingString, signature) {
+		t.Fatal("signature verified with the wrong secret")
+	}
+	tampered := HMACSigningString("POST", "/api/payment-intents?x=1",
+		"1760000000", "nonce-1", []byte(`{"amount":999}`))
+	if VerifyHMAC("secret", tampered, signature) {
+		t.Fatal("signature verified for a different body")
+	}
+	if VerifyHMAC("secret", signingString, "not-hex") {
+		t.Fatal("malformed signature verified")
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	body := []byte(`{"amount":100}`)
+	signingString := WebhookSigningString("1760000000", "evt-1", body)
+	want := "1760000000\nevt-1\n" +
+		"4d4bbe59c6aad22442cde199a6a8a5f034405fcd78fb5a81c24ef249de1c45f1"
+	if signingString != want {
+		t.Fatalf("signing string = %q, want %q", signingString, want)
+	}
+
+	signature := SignHMAC("secret", signingString)
+	if !VerifyHMAC("secret", signingString, signature) {
+		t.Fatal("valid signature rejected")
+	}
+	if VerifyHMAC("secret", WebhookSigningString("1760000001", "evt-1", body), signature) {
+		t.Fatal("signature verified for a different timestamp")
+	}
+	if VerifyHMAC("secret", WebhookSigningString("1760000000", "evt-2", body), signature) {
+		t.Fatal("signature verified for a different event")
+	}
+}
@@ -0,0 +1,127 @@
+package hcrypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aead/chacha20poly1305"
+)
+
+// DefaultKeyID names the primary key when no key id is configured.
+const DefaultKeyID = "k1"
+
+// DefaultPublicKeyID names the primary signing key when no key id is
+// configured. It differs from DefaultKeyID so that both keyrings can be
+// rotated and retired on their own.
+const DefaultPublicKeyID = "p1"
+
+// tokenFooter is the unencrypted but authenticated footer of every token.
+// It tells the verifier which key the token was encrypted with.
+type tokenFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// keyring holds the symmetric keys accepted for decryption. New tokens are
+// always encrypted with the primary key.
+type keyring struct {
+	primaryID string
+	// legacyID is the key used for tokens issued before key ids were added
+	// to the footer.
+	legacyID string
+	keys     map[string][]byte
+}
+
+func newKeyring(config TokenKey) (*keyring, error) {
+	primaryID := config.KeyID
+	if primaryID == "" {
+		primaryID = DefaultKeyID
+	}
+	legacyID := config.LegacyKeyID
+	if legacyID == "" {
+		legacyID = primaryID
+	}
+
+	return buildKeyring(primaryID, legacyID, config.SymmetricKey,
+		config.PreviousKeys, config.RetiredKeyIDs)
+}
+
+func buildKeyring(primaryID, legacyID, primaryKey string,
+	previousKeys map[string]string, retiredKeyIDs []string) (*keyring, error) {
+	retired := make(map[string]bool, len(retiredKeyIDs))
+	for _, id := range retiredKeyIDs {
+		retired[id] = true
+	}
+	if retired[primaryID] {
+		return nil, fmt.Errorf("primary key %q cannot be retired", primaryID)
+	}
+
+	ring := &keyring{
+		primaryID: primaryID,
+		legacyID:  legacyID,
+		keys:      map[string][]byte{},
+	}
+	if err := ring.add(primaryID, primaryKey); err != nil {
+		return nil, err
+	}
+	for id, key := range previousKeys {
+		if id == primaryID {
+			return nil, fmt.Errorf("previous key %q reuses the primary key id", id)
+		}
+		if retired[id] {
+			continue
+		}
+		if err := ring.add(id, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return ring, nil
+}
+
+func (k *keyring) add(id, key string) error {
+	if len(key) != chacha20poly1305.KeySize {
+		return fmt.Errorf("invalid size for key %q: must be exactly %d characters",
+			id, chacha20poly1305.KeySize)
+	}
+	k.keys[id] = []byte(key)
+	return nil
+}
+
+func (k *keyring) primary() (tokenFooter, []byte) {
+	return tokenFooter{KeyID: k.primaryID}, k.keys[k.primaryID]
+}
+
+// lookup returns the key for a token footer. Footers that are not a key id
+// document belong to tokens issued before rotation and use the legacy key.
+func (k *keyring) lookup(rawFooter string) (string, []byte, bool) {
+	id := k.legacyID
+	footer := tokenFooter{}
+	if err := json.Unmarshal([]byte(rawFooter), &footer); err == nil && footer.KeyID != "" {
+		id = footer.KeyID
+	}
+	key, ok := k.keys[id]
+	return id, key, ok
+}
+
+// ParseKeyList parses keys configured as "kid:key,kid:key". Key ids may not
+// contain ':' and keys may not contain ','.
+func ParseKeyList(value string) (map[string]string, error) {
+	keys := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, key, ok := strings.Cut(entry, ":")
+		if !ok || id == "" || key == "" {
+			return nil, fmt.Errorf("invalid key entry %q: expected kid:key", entry)
+		}
+		if _, exists := keys[id]; exists {
+			return nil, fmt.Errorf("duplicate key id %q", id)
+		}
+		keys[id] = key
+	}
+
+	return keys, nil
+}
@@ -2,13 +2,43 @@ package hcrypto
 
 import (
 	"errors"
+	"pg/internal/constant"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
 type TokenKey struct {
-	SymmetricKey       string `json:"symmetric_key"`
+	// SymmetricKey is the primary key; every new token is encrypted with it.
+	SymmetricKey string `json:"symmetric_key"`
+	// KeyID identifies SymmetricKey in token footers. Defaults to DefaultKeyID.
+	KeyID string `json:"key_id"`
+	// PreviousKeys maps key ids to keys that were rotated out. Tokens
+	// encrypted with them are still accepted until the id is retired.
+	PreviousKeys map[string]string `json:"-"`
+	// RetiredKeyIDs lists symmetric keys whose tokens are no longer
+	// accepted.
+	RetiredKeyIDs []string `json:"retired_key_ids"`
+	// LegacyKeyID is the key used for tokens issued before key ids were
+	// written to the footer. Defaults to KeyID.
+	LegacyKeyID string `json:"legacy_key_id"`
+	// PublicSigningKey is the base64 Ed25519 seed that signs v4.public
+	// tokens. Leave it empty to issue every token as v2.local.
+	PublicSigningKey string `json:"-"`
+	// PublicKeyID identifies PublicSigningKey in token footers and in the
+	// published key set. Defaults to DefaultPublicKeyID.
+	PublicKeyID string `json:"public_key_id"`
+	// PreviousPublicKeys maps key ids to base64 Ed25519 public keys that were
+	// rotated out. They stay published until retired.
+	PreviousPublicKeys map[string]string `json:"-"`
+	// RetiredPublicKeyIDs lists signing keys whose tokens are no longer
+	// accepted. It is separate from RetiredKeyIDs because the two keyrings
+	// name their keys independently.
+	RetiredPublicKeyIDs []string `json:"retired_public_key_ids"`
+	// PublicTokenTypes are issued as v4.public; all other token types stay
+	// v2.local.
+	PublicTokenTypes []constant.TokenType `json:"public_token_types"`
+
 	Issuer             string `json:"issuer"`
 	Footer             string `json:"footer"`
 	KeyLength          int    `json:"key_length"`
@@ -16,6 +46,11 @@ type TokenKey struct {
 	AccessExpires      time.Duration
 	RefreshExpires     time.Duration
 	SecretTokenExpires time.Duration
+	InviteExpires      time.Duration
+	ResetExpires       time.Duration
+	VerifyExpires      time.Duration
+	MFAExpires         time.Duration
+	UnlockExpires      time.Duration
 }
 
 func (m TokenKey) Validate() error {
@@ -0,0 +1,197 @@
+package hcrypto
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// v4PublicHeader prefixes every v4.public token. v4.public tokens are signed
+// with Ed25519 but not encrypted: anyone holding the token can read its
+// payload, and anyone holding the public key can verify it.
+const v4PublicHeader = "v4.public."
+
+var (
+	errIncorrectTokenHeader = errors.New("incorrect token header")
+	errInvalidSignature     = errors.New("invalid token signature")
+	tokenEncoding           = base64.RawURLEncoding
+)
+
+// PublicKey is a v4.public verification key as published to other services.
+type PublicKey struct {
+	KeyID     string `json:"kid"`
+	Version   string `json:"version"`
+	Purpose   string `json:"purpose"`
+	PublicKey string `json:"public_key"`
+}
+
+// signingKeyring holds the Ed25519 keys for v4.public tokens. New tokens are
+// always signed with the primary key; previous public keys keep verifying
+// tokens signed before a rotation.
+type signingKeyring struct {
+	primaryID  string
+	privateKey ed25519.PrivateKey
+	keys       map[string]ed25519.PublicKey
+}
+
+func newSigningKeyring(config TokenKey) (*signingKeyring, error) {
+	if config.PublicSigningKey == "" {
+		if len(config.PublicTokenTypes) > 0 {
+			return nil, errors.New("public token types are configured without a signing key")
+		}
+		return nil, nil
+	}
+	primaryID := config.PublicKeyID
+	if primaryID == "" {
+		primaryID = DefaultPublicKeyID
+	}
+	retired := make(map[string]bool, len(config.RetiredPublicKeyIDs))
+	for _, id := range config.RetiredPublicKeyIDs {
+		retired[id] = true
+	}
+	if retired[primaryID] {
+		return nil, fmt.Errorf("primary signing key %q cannot be retired", primaryID)
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(config.PublicSigningKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid signing key %q: must be a base64 encoded %d byte seed",
+			primaryID, ed25519.SeedSize)
+	}
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	ring := &signingKeyring{
+		primaryID:  primaryID,
+		privateKey: privateKey,
+		keys: map[string]ed25519.PublicKey{
+			primaryID: privateKey.Public().(ed25519.PublicKey),
+		},
+	}
+	for id, key := range config.PreviousPublicKeys {
+		if id == primaryID {
+			return nil, fmt.Errorf("previous public key %q reuses the primary key id", id)
+		}
+		if retired[id] {
+			continue
+		}
+		publicKey, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(publicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid public key %q: must be a base64 encoded %d byte key",
+				id, ed25519.PublicKeySize)
+		}
+		ring.keys[id] = publicKey
+	}
+
+	return ring, nil
+}
+
+func (k *signingKeyring) publicKeys() []PublicKey {
+	keys := make([]PublicKey, 0, len(k.keys))
+	keys = append(keys, k.publicKey(k.primaryID))
+	for id := range k.keys {
+		if id != k.primaryID {
+			keys = append(keys, k.publicKey(id))
+		}
+	}
+	return keys
+}
+
+func (k *signingKeyring) publicKey(id string) PublicKey {
+	return PublicKey{
+		KeyID:     id,
+		Version:   "v4",
+		Purpose:   "public",
+		PublicKey: base64.StdEncoding.EncodeToString(k.keys[id]),
+	}
+}
+
+// lookup returns the public key named by a token footer. Unlike v2.local
+// there are no legacy v4.public tokens, so the footer must carry a key id.
+func (k *signingKeyring) lookup(rawFooter string) (string, ed25519.PublicKey, bool) {
+	footer := tokenFooter{}
+	if err := json.Unmarshal([]byte(rawFooter), &footer); err != nil || footer.KeyID == "" {
+		return "", nil, false
+	}
+	key, ok := k.keys[footer.KeyID]
+	return footer.KeyID, key, ok
+}
+
+// signV4Public builds a v4.public token as defined by the PASETO v4
+// specification, with no implicit assertion.
+func signV4Public(privateKey ed25519.PrivateKey, payload, footer any) (string, error) {
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	rawFooter, err := json.Marshal(footer)
+	if err != nil {
+		return "", err
+	}
+	signature := ed25519.Sign(privateKey,
+		preAuthEncode([]byte(v4PublicHeader), message, rawFooter, nil))
+
+	token := v4PublicHeader + tokenEncoding.EncodeToString(append(message, signature...))
+	if len(rawFooter) > 0 {
+		token += "." + tokenEncoding.EncodeToString(rawFooter)
+	}
+	return token, nil
+}
+
+// verifyV4Public checks the signature of a v4.public token and decodes its
+// payload.
+func verifyV4Public(token string, publicKey ed25519.PublicKey, payload any) error {
+	if !strings.HasPrefix(token, v4PublicHeader) {
+		return errIncorrectTokenHeader
+	}
+	parts := strings.Split(token[len(v4PublicHeader):], ".")
+	var rawFooter []byte
+	switch len(parts) {
+	case 1:
+	case 2:
+		footer, err := tokenEncoding.DecodeString(parts[1])
+		if err != nil {
+			return err
+		}
+		rawFooter = footer
+	default:
+		return errIncorrectTokenHeader
+	}
+	body, err := tokenEncoding.DecodeString(parts[0])
+	if err != nil {
+		return err
+	}
+	if len(body) < ed25519.SignatureSize {
+		return errInvalidSignature
+	}
+	message := body[:len(body)-ed25519.SignatureSize]
+	signature := body[len(body)-ed25519.SignatureSize:]
+	if !ed25519.Verify(publicKey,
+		preAuthEncode([]byte(v4PublicHeader), message, rawFooter, nil), signature) {
+		return errInvalidSignature
+	}
+
+	return json.Unmarshal(message, payload)
+}
+
+// preAuthEncode is PASETO's PAE: the piece count followed by each piece
+// prefixed with its length, all lengths as little-endian uint64 with the
+// most significant bit cleared.
+func preAuthEncode(pieces ...[]byte) []byte {
+	encoded := make([]byte, 8)
+	binary.LittleEndian.PutUint64(encoded, uint64(len(pieces))&^(1<<63))
+	for _, piece := range pieces {
+		length := make([]byte, 8)
+		binary.LittleEndian.PutUint64(length, uint64(len(piece))&^(1<<63))
+		encoded = append(encoded, length...)
+		encoded = append(encoded, piece...)
+	}
+	return encoded
+}
+
+// PublicKeySet is the document served at /.well-known/paseto-keys.
+type PublicKeySet struct {
+	Keys []PublicKey `json:"keys"`
+}
@@ -0,0 +1,193 @@
+package hcrypto
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"pg/internal/constant"
+	"strings"
+	"testing"
+)
+
+const (
+	oldSigningSeed = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+	newSigningSeed = "HxwdHhobGBkUFRYXEBESEwwNDg8ICQoLBAUGBwABAgM="
+)
+
+func publicKeyOf(t *testing.T, seed string) string {
+	t.Helper()
+	raw, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil {
+		t.Fatalf("decode seed: %v", err)
+	}
+	public := ed25519.NewKeyFromSeed(raw).Public().(ed25519.PublicKey)
+	return base64.StdEncoding.EncodeToString(public)
+}
+
+func TestV4PublicSpecVector(t *testing.T) {
+	// Test vector 4-S-2 from the PASETO v4 specification.
+	seed, _ := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774")
+	want := "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9" +
+		"v3Jt8mx_TdM2ceTGoqwrh4yDFn0XsHvvV_D0DtwQxVrJEBMl0F2caAdgnpKlt4p7xBnx1HcO-SPo8FPp214HDw" +
+		".eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9"
+
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	token, err := signV4Public(privateKey,
+		json.RawMessage(`{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`),
+		json.RawMessage(`{"kid":"zVhMiPBP9fRf2snEcT7gFTioeA9COcNy9DfgL1W60haN"}`))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if token != want {
+		t.Fatalf("token = %s, want %s", token, want)
+	}
+
+	payload := map[string]string{}
+	if err := verifyV4Public(want, privateKey.Public().(ed25519.PublicKey), &payload); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if payload["data"] != "this is a signed message" {
+		t.Fatalf("unexpected payload: %v", payload)
+	}
+}
+
+func TestPublicTokenTypesAreSigned(t *testing.T) {
+	maker := newTestMaker(t, TokenKey{
+		SymmetricKey:     oldKey,
+		PublicSigningKey: oldSigningSeed,
+		PublicKeyID:      "p1",
+		PublicTokenTypes: []constant.TokenType{constant.SecretToken},
+	})
+
+	secret, _, err := maker.CreatePasetoToken(UserData{UserID: "company"}, constant.SecretToken)
+	if err != nil {
+		t.Fatalf("create secret token: %v", err)
+	}
+	if !strings.HasPrefix(secret, "v4.public.") {
+		t.Fatalf("secret token = %q, want a v4.public token", secret)
+	}
+	payload, err := maker.VerifyPasetoToken(secret)
+	if err != nil {
+		t.Fatalf("verify secret token: %v", err)
+	}
+	if payload.UserID != "company" || payload.TokenType != constant.SecretToken {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+
+	if access := issue(t, maker); !strings.HasPrefix(access, "v2.local.") {
+		t.Fatalf("access token = %q, want a v2.local token", access)
+	}
+}
+
+func TestPublicTokenVerifiesWithPublishedKey(t *testing.T) {
+	maker := newTestMaker(t, TokenKey{
+		SymmetricKey:     oldKey,
+		PublicSigningKey: oldSigningSeed,
+		PublicKeyID:      "p1",
+		PublicTokenTypes: []constant.TokenType{constant.SecretToken},
+	})
+	token, _, err := maker.CreatePasetoToken(UserData{UserID: "company"}, constant.SecretToken)
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	keys := maker.PublicKeys()
+	if len(keys) != 1 || keys[0].KeyID != "p1" || keys[0].PublicKey != publicKeyOf(t, oldSigningSeed) {
+		t.Fatalf("unexpected published keys: %+v", keys)
+	}
+	public, _ := base64.StdEncoding.DecodeString(keys[0].PublicKey)
+	if err := verifyV4Public(token, public, &Payload{}); err != nil {
+		t.Fatalf("verify with published key: %v", err)
+	}
+}
+
+func TestRotatedSigningKey(t *testing.T) {
+	config := TokenKey{
+		SymmetricKey:     oldKey,
+		PublicSigningKey: oldSigningSeed,
+		PublicKeyID:      "p1",
+		PublicTokenTypes: []constant.TokenType{constant.SecretToken},
+	}
+	before := newTestMaker(t, config)
+	token, _, err := before.CreatePasetoToken(UserData{UserID: "company"}, constant.SecretToken)
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	config.PublicSigningKey = newSigningSeed
+	config.PublicKeyID = "p2"
+	config.PreviousPublicKeys = map[string]string{"p1": publicKeyOf(t, oldSigningSeed)}
+	after := newTestMaker(t, config)
+	if _, err := after.VerifyPasetoToken(token); err != nil {
+		t.Fatalf("verify token from previous signing key: %v", err)
+	}
+	if keys := after.PublicKeys(); len(keys) != 2 || keys[0].KeyID != "p2" {
+		t.Fatalf("unexpected published keys: %+v", keys)
+	}
+
+	config.RetiredKeyIDs = []string{"p1"}
+	if _, err := newTestMaker(t, config).VerifyPasetoToken(token); err != nil {
+		t.Fatalf("retiring a symmetric key id retired a signing key: %v", err)
+	}
+
+	config.RetiredPublicKeyIDs = []string{"p1"}
+	retired := newTestMaker(t, config)
+	if _, err := retired.VerifyPasetoToken(token); err == nil {
+		t.Fatal("token from a retired signing key verified")
+	}
+}
+
+func TestKeyringsRetireSeparately(t *testing.T) {
+	before := newTestMaker(t, TokenKey{SymmetricKey: oldKey})
+	local, _, err := before.CreatePasetoToken(UserData{UserID: "user"}, constant.AccessToken)
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	// Retiring the default symmetric key must leave the signing key, which
+	// also uses its default id, in service.
+	after := newTestMaker(t, TokenKey{
+		SymmetricKey:     newKey,
+		KeyID:            "k2",
+		PreviousKeys:     map[string]string{DefaultKeyID: oldKey},
+		RetiredKeyIDs:    []string{DefaultKeyID},
+		PublicSigningKey: oldSigningSeed,
+		PublicTokenTypes: []constant.TokenType{constant.SecretToken},
+	})
+	if _, err := after.VerifyPasetoToken(local); err == nil {
+		t.Fatal("token from a retired symmetric key verified")
+	}
+	public, _, err := after.CreatePasetoToken(UserData{UserID: "company"}, constant.SecretToken)
+	if err != nil {
+		t.Fatalf("create public token: %v", err)
+	}
+	if _, err := after.VerifyPasetoToken(public); err != nil {
+		t.Fatalf("verify public token: %v", err)
+	}
+}
+
+func TestTamperedPublicTokenIsRejected(t *testing.T) {
+	maker := newTestMaker(t, TokenKey{
+		SymmetricKey:     oldKey,
+		PublicSigningKey: oldSigningSeed,
+		PublicTokenTypes: []constant.TokenType{constant.SecretToken},
+	})
+	token, _, err := maker.CreatePasetoToken(UserData{UserID: "company"}, constant.SecretToken)
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	body, footer, _ := strings.Cut(strings.TrimPrefix(token, "v4.public."), ".")
+	raw, _ := base64.RawURLEncoding.DecodeString(body)
+	raw[10] ^= 1
+	tampered := "v4.public." + base64.RawURLEncoding.EncodeToString(raw) + "." + footer
+	if _, err := maker.VerifyPasetoToken(tampered); err == nil {
+		t.Fatal("tampered token verified")
+	}
+
+	unsigned := newTestMaker(t, TokenKey{SymmetricKey: oldKey})
+	if _, err := unsigned.VerifyPasetoToken(token); err == nil {
+		t.Fatal("public token verified without a signing keyring")
+	}
+}
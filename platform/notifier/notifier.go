@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"context"
+	"pg/platform/hlog"
+
+	"go.uber.org/zap"
+)
+
+type Channel string
+
+const (
+	ChannelEmail Channel = "EMAIL"
+	ChannelSMS   Channel = "SMS"
+)
+
+// Message is a single notification addressed to one recipient.
+type Message struct {
+	Channel Channel
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages to users. Implementations are expected to
+// be safe for concurrent use.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type logNotifier struct {
+	log hlog.Logger
+}
+
+// NewLogNotifier returns a Notifier that only writes messages to the log.
+// It is meant for local development and testing; the full message body,
+// including any tokens or codes, ends up in the log output.
+func NewLogNotifier(log hlog.Logger) Notifier {
+	return &logNotifier{
+		log: log,
+	}
+}
+
+func (l *logNotifier) Send(ctx context.Context, msg Message) error {
+	l.log.Info(ctx, "notification",
+		zap.String("channel", string(msg.Channel)),
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body))
+	return nil
+}
@@ -0,0 +1,564 @@
+// Package riskexpr is the small expression language risk rules are written
+// in. An expression is a condition over named signals, for example
+//
+//	amount_ratio >= 5 and new_customer
+//	phone_country not in ["ET", "KE"] or customer_payments_1h > 3
+//
+// It has numbers, strings in single or double quotes, true and false; the
+// operators + - * /, == != < <= > >=, in and not in a list of literals, and,
+// or and not; and parentheses. Numbers are decimals, so amounts compare
+// exactly. Expressions are type checked against the signals they may use
+// when they are compiled, so a rule that compiles cannot fail on a typo.
+package riskexpr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrDivisionByZero is returned by Eval when an expression divides by zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// Kind is the type of a signal or a sub-expression.
+type Kind int
+
+const (
+	Number Kind = iota + 1
+	String
+	Bool
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Number:
+		return "number"
+	case String:
+		return "string"
+	case Bool:
+		return "bool"
+	default:
+		return "unknown"
+	}
+}
+
+// Vars declares the signals an expression may use and their kinds.
+type Vars map[string]Kind
+
+// Expr is a compiled expression. It is safe for concurrent use.
+type Expr struct {
+	src  string
+	root node
+}
+
+// Compile parses src and checks that it is a condition over vars.
+func Compile(src string, vars Vars) (*Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, vars: vars}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+	if root.kind() != Bool {
+		return nil, fmt.Errorf("expression is a %s, not a condition", root.kind())
+	}
+
+	return &Expr{src: src, root: root}, nil
+}
+
+// String returns the source the expression was compiled from.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Eval reports whether the expression holds for env. Numbers in env may be
+// decimals or any Go integer or float type. A signal missing from env is
+// an error.
+func (e *Expr) Eval(env map[string]any) (bool, error) {
+	v, err := e.root.eval(env)
+	if err != nil {
+		return false, err
+	}
+	return v.b, nil
+}
+
+type value struct {
+	num decimal.Decimal
+	str string
+	b   bool
+}
+
+func toValue(name string, kind Kind, raw any) (value, error) {
+	switch kind {
+	case Number:
+		switch n := raw.(type) {
+		case decimal.Decimal:
+			return value{num: n}, nil
+		case int:
+			return value{num: decimal.NewFromInt(int64(n))}, nil
+		case int32:
+			return value{num: decimal.NewFromInt32(n)}, nil
+		case int64:
+			return value{num: decimal.NewFromInt(n)}, nil
+		case float64:
+			return value{num: decimal.NewFromFloat(n)}, nil
+		}
+	case String:
+		if s, ok := raw.(string); ok {
+			return value{str: s}, nil
+		}
+	case Bool:
+		if b, ok := raw.(bool); ok {
+			return value{b: b}, nil
+		}
+	}
+	return value{}, fmt.Errorf("signal %s is %T, want %s", name, raw, kind)
+}
+
+type node interface {
+	kind() Kind
+	eval(env map[string]any) (value, error)
+}
+
+type literal struct {
+	k Kind
+	v value
+}
+
+func (l literal) kind() Kind                         { return l.k }
+func (l literal) eval(map[string]any) (value, error) { return l.v, nil }
+
+type ident struct {
+	name string
+	k    Kind
+}
+
+func (i ident) kind() Kind { return i.k }
+
+func (i ident) eval(env map[string]any) (value, error) {
+	raw, ok := env[i.name]
+	if !ok {
+		return value{}, fmt.Errorf("signal %s is not set", i.name)
+	}
+	return toValue(i.name, i.k, raw)
+}
+
+type unary struct {
+	op string
+	x  node
+}
+
+func (u unary) kind() Kind { return u.x.kind() }
+
+func (u unary) eval(env map[string]any) (value, error) {
+	x, err := u.x.eval(env)
+	if err != nil {
+		return value{}, err
+	}
+	if u.op == "not" {
+		return value{b: !x.b}, nil
+	}
+	return value{num: x.num.Neg()}, nil
+}
+
+type binary struct {
+	op   string
+	l, r node
+}
+
+func (b binary) kind() Kind {
+	switch b.op {
+	case "+", "-", "*", "/":
+		return Number
+	default:
+		return Bool
+	}
+}
+
+func (b binary) eval(env map[string]any) (value, error) {
+	l, err := b.l.eval(env)
+	if err != nil {
+		return value{}, err
+	}
+	// and and or only evaluate their right side when they need it.
+	switch {
+	case b.op == "and" && !l.b:
+		return value{b: false}, nil
+	case b.op == "or" && l.b:
+		return value{b: true}, nil
+	}
+	r, err := b.r.eval(env)
+	if err != nil {
+		return value{}, err
+	}
+
+	switch b.op {
+	case "and", "or":
+		return value{b: r.b}, nil
+	case "+":
+		return value{num: l.num.Add(r.num)}, nil
+	case "-":
+		return value{num: l.num.Sub(r.num)}, nil
+	case "*":
+		return value{num: l.num.Mul(r.num)}, nil
+	case "/":
+		if r.num.IsZero() {
+			return value{}, ErrDivisionByZero
+		}
+		return value{num: l.num.Div(r.num)}, nil
+	case "==":
+		return value{b: equal(b.l.kind(), l, r)}, nil
+	case "!=":
+		return value{b: !equal(b.l.kind(), l, r)}, nil
+	case "<":
+		return value{b: l.num.LessThan(r.num)}, nil
+	case "<=":
+		return value{b: l.num.LessThanOrEqual(r.num)}, nil
+	case ">":
+		return value{b: l.num.GreaterThan(r.num)}, nil
+	default:
+		return value{b: l.num.GreaterThanOrEqual(r.num)}, nil
+	}
+}
+
+type membership struct {
+	x      node
+	list   []value
+	negate bool
+}
+
+func (m membership) kind() Kind { return Bool }
+
+func (m membership) eval(env map[string]any) (value, error) {
+	x, err := m.x.eval(env)
+	if err != nil {
+		return value{}, err
+	}
+	for _, v := range m.list {
+		if equal(m.x.kind(), x, v) {
+			return value{b: !m.negate}, nil
+		}
+	}
+	return value{b: m.negate}, nil
+}
+
+// equal compares strings case-insensitively, since country and currency
+// codes are written either way.
+func equal(k Kind, a, b value) bool {
+	switch k {
+	case Number:
+		return a.num.Equal(b.num)
+	case String:
+		return strings.EqualFold(a.str, b.str)
+	default:
+		return a.b == b.b
+	}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, string(runes[start:i]), start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, string(runes[start:i]), start})
+		case c == '"' || c == '\'':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != c {
+				i++
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated string at %d", start)
+			}
+			tokens = append(tokens, token{tokString, string(runes[start+1 : i]), start})
+			i++
+		default:
+			start := i
+			two := ""
+			if i+1 < len(runes) {
+				two = string(runes[i : i+2])
+			}
+			switch two {
+			case "==", "!=", "<=", ">=":
+				tokens = append(tokens, token{tokOp, two, start})
+				i += 2
+				continue
+			}
+			if !strings.ContainsRune("+-*/<>()[],", c) {
+				return nil, fmt.Errorf("unexpected %q at %d", c, start)
+			}
+			tokens = append(tokens, token{tokOp, string(c), start})
+			i++
+		}
+	}
+
+	return append(tokens, token{tokEOF, "end of expression", len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	vars   Vars
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the operator or keyword text.
+func (p *parser) accept(text string) bool {
+	t := p.peek()
+	if (t.kind == tokOp || t.kind == tokIdent) && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		t := p.peek()
+		return fmt.Errorf("expected %q at %d, got %q", text, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *parser) or() (node, error) {
+	return p.logical("or", p.and)
+}
+
+func (p *parser) and() (node, error) {
+	return p.logical("and", p.not)
+}
+
+func (p *parser) logical(op string, operand func() (node, error)) (node, error) {
+	l, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().text == op && p.peek().kind == tokIdent {
+		pos := p.next().pos
+		r, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if l.kind() != Bool || r.kind() != Bool {
+			return nil, fmt.Errorf("%s at %d needs conditions on both sides", op, pos)
+		}
+		l = binary{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) not() (node, error) {
+	t := p.peek()
+	if t.kind == tokIdent && t.text == "not" {
+		p.next()
+		x, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		if x.kind() != Bool {
+			return nil, fmt.Errorf("not at %d needs a condition", t.pos)
+		}
+		return unary{op: "not", x: x}, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (node, error) {
+	l, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	switch {
+	case t.kind == tokIdent && (t.text == "in" || t.text == "not"):
+		p.next()
+		negate := t.text == "not"
+		if negate {
+			if err := p.expect("in"); err != nil {
+				return nil, err
+			}
+		}
+		return p.membership(l, negate, t.pos)
+	case t.kind == tokOp && (t.text == "==" || t.text == "!="):
+		p.next()
+		r, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if l.kind() != r.kind() {
+			return nil, fmt.Errorf("%s at %d compares a %s with a %s", t.text, t.pos, l.kind(), r.kind())
+		}
+		return binary{op: t.text, l: l, r: r}, nil
+	case t.kind == tokOp && (t.text == "<" || t.text == "<=" || t.text == ">" || t.text == ">="):
+		p.next()
+		r, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if l.kind() != Number || r.kind() != Number {
+			return nil, fmt.Errorf("%s at %d needs numbers on both sides", t.text, t.pos)
+		}
+		return binary{op: t.text, l: l, r: r}, nil
+	}
+	return l, nil
+}
+
+func (p *parser) membership(x node, negate bool, pos int) (node, error) {
+	if x.kind() == Bool {
+		return nil, fmt.Errorf("in at %d needs a number or a string", pos)
+	}
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	m := membership{x: x, negate: negate}
+	for !p.accept("]") {
+		if len(m.list) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		item, err := p.primary()
+		if err != nil {
+			return nil, err
+		}
+		lit, ok := item.(literal)
+		if !ok || lit.k != x.kind() {
+			return nil, fmt.Errorf("list at %d may only hold %s literals", pos, x.kind())
+		}
+		m.list = append(m.list, lit.v)
+	}
+	return m, nil
+}
+
+func (p *parser) sum() (node, error) {
+	return p.arithmetic(p.product, "+", "-")
+}
+
+func (p *parser) product() (node, error) {
+	return p.arithmetic(p.negation, "*", "/")
+}
+
+func (p *parser) arithmetic(operand func() (node, error), ops ...string) (node, error) {
+	l, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokOp || (t.text != ops[0] && t.text != ops[1]) {
+			return l, nil
+		}
+		p.next()
+		r, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if l.kind() != Number || r.kind() != Number {
+			return nil, fmt.Errorf("%s at %d needs numbers on both sides", t.text, t.pos)
+		}
+		l = binary{op: t.text, l: l, r: r}
+	}
+}
+
+func (p *parser) negation() (node, error) {
+	t := p.peek()
+	if t.kind == tokOp && t.text == "-" {
+		p.next()
+		x, err := p.negation()
+		if err != nil {
+			return nil, err
+		}
+		if x.kind() != Number {
+			return nil, fmt.Errorf("- at %d needs a number", t.pos)
+		}
+		return unary{op: "-", x: x}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		n, err := decimal.NewFromString(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at %d", t.text, t.pos)
+		}
+		return literal{k: Number, v: value{num: n}}, nil
+	case tokString:
+		return literal{k: String, v: value{str: t.text}}, nil
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			return literal{k: Bool, v: value{b: t.text == "true"}}, nil
+		case "and", "or", "not", "in":
+			return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+		}
+		k, ok := p.vars[t.text]
+		if !ok {
+			return nil, fmt.Errorf("unknown signal %q at %d", t.text, t.pos)
+		}
+		return ident{name: t.text, k: k}, nil
+	case tokOp:
+		if t.text == "(" {
+			x, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+}
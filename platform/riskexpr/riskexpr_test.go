@@ -0,0 +1,104 @@
+package riskexpr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+var testVars = Vars{
+	"amount":        Number,
+	"amount_ratio":  Number,
+	"new_customer":  Bool,
+	"phone_country": String,
+	"payments_1h":   Number,
+}
+
+func TestEval(t *testing.T) {
+	env := map[string]any{
+		"amount":        decimal.RequireFromString("1500.50"),
+		"amount_ratio":  decimal.RequireFromString("6.2"),
+		"new_customer":  true,
+		"phone_country": "ET",
+		"payments_1h":   2,
+	}
+	cases := []struct {
+		src  string
+		want bool
+	}{
+		{"amount_ratio >= 5 and new_customer", true},
+		{"amount_ratio >= 5 and not new_customer", false},
+		{"amount > 1000 or payments_1h > 3", true},
+		{"amount == 1500.5", true},
+		{"amount - 500.50 * 2 <= 500", true},
+		{"-amount < 0", true},
+		{"(payments_1h + 1) * 2 == 6", true},
+		{"phone_country in ['et', \"KE\"]", true},
+		{"phone_country not in ['ET']", false},
+		{"payments_1h in [1, 3]", false},
+		{"new_customer == true and phone_country != 'KE'", true},
+		{"false or (true and amount / 2 > 750)", true},
+	}
+	for _, c := range cases {
+		expr, err := Compile(c.src, testVars)
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", c.src, err)
+		}
+		got, err := expr.Eval(env)
+		if err != nil {
+			t.Fatalf("Eval(%q): %v", c.src, err)
+		}
+		if got != c.want {
+			t.Errorf("Eval(%q) = %v, want %v", c.src, got, c.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"amount",
+		"amount >",
+		"amount > 'ten'",
+		"new_customer > 1",
+		"unknown_signal",
+		"phone_country in [1]",
+		"phone_country in [amount]",
+		"(amount > 1",
+		"amount > 1 amount",
+		"'open",
+		"amount # 1",
+		"not amount",
+		"new_customer and amount",
+	} {
+		if _, err := Compile(src, testVars); err == nil {
+			t.Errorf("Compile(%q) succeeded", src)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	expr, err := Compile("amount / payments_1h > 1 or new_customer", testVars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]any{"amount": 10, "payments_1h": 0, "new_customer": false}
+	if _, err := expr.Eval(env); !errors.Is(err, ErrDivisionByZero) {
+		t.Fatalf("division by zero: %v", err)
+	}
+	if _, err := expr.Eval(map[string]any{"amount": 10}); err == nil {
+		t.Fatal("missing signal evaluated")
+	}
+	if _, err := expr.Eval(map[string]any{"amount": "10", "payments_1h": 1}); err == nil {
+		t.Fatal("string evaluated as a number")
+	}
+
+	short, err := Compile("new_customer and payments_1h / 0 > 1", testVars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := short.Eval(map[string]any{"new_customer": false}); err != nil || got {
+		t.Fatalf("and did not short-circuit: %v, %v", got, err)
+	}
+}